@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// materializedDefaults are the defaults applied to every existing object of a kind. Each one
+// must keep the behavior objects had before the field was added.
+var materializedDefaults = map[string][]string{
+	"npuclusterpolicies.npu.ai":        {"spec.conflictPolicy"},
+	"npuclusterpolicytemplates.npu.ai": {"spec.template.conflictPolicy"},
+}
+
+// TestSchemaDefaults checks that CRD upgrades cannot switch on features of existing objects.
+// The API server applies a default whenever the enclosing object is present, so defaults are
+// only allowed below optional sections, where they take effect once the section is set.
+func TestSchemaDefaults(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "config", "crd", "bases", "*.yaml"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no CRDs found: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(data, &crd); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, version := range crd.Spec.Versions {
+			spec, ok := version.Schema.OpenAPIV3Schema.Properties["spec"]
+			if !ok {
+				continue
+			}
+			checkDefaults(t, crd.Name, "spec", spec, true)
+		}
+	}
+}
+
+// -- checkDefaults walks a schema, where present tells whether the object always exists
+func checkDefaults(t *testing.T, crd, path string, schema apiextensionsv1.JSONSchemaProps, present bool) {
+	for name, prop := range schema.Properties {
+		child := path + "." + name
+		if prop.Default != nil {
+			switch {
+			case prop.Type == "object" || prop.Type == "array":
+				t.Errorf("%s: %s defaults a whole section", crd, child)
+			case present && !slices.Contains(materializedDefaults[crd], child):
+				t.Errorf("%s: %s would be defaulted on existing objects", crd, child)
+			}
+		}
+		checkDefaults(t, crd, child, prop, present && slices.Contains(schema.Required, name))
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		checkDefaults(t, crd, path+"[]", *schema.Items.Schema, false)
+	}
+}
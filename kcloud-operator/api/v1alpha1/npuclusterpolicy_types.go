@@ -17,50 +17,1435 @@ limitations under the License.
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.clusterAPI)",message="clusterAPI requires enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.partitioning) || !has(self.config) || !has(self.config.defaultPe) || self.config.defaultPe == 'Single'",message="partitioning requires config.defaultPe Single"
 type FuriosaSpec struct {
-	Enabled           bool   `json:"enabled"`
-	DevicePluginImage string `json:"devicePluginImage"`
+	Enabled bool `json:"enabled"`
+
+	// DevicePluginImage is the device plugin image.
+	// Deprecated: use devicePlugin.image and devicePlugin.version.
+	// +optional
+	DevicePluginImage string `json:"devicePluginImage,omitempty"`
 	ConfigMapName     string `json:"configMapName,omitempty"`
+
+	// Env is merged into the environment of the device plugin container, replacing variables
+	// of the same name, e.g. RUST_LOG=debug.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ExtraVolumes are added to the device plugin pods, e.g. host paths of custom driver
+	// installs or secrets holding certificates.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are added to the device plugin container, mounting extraVolumes.
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Partitioning advertises the cards as partitions of processing elements (PEs), so
+	// workloads can request a fraction of a card. Whole cards are advertised without it.
+	// +optional
+	Partitioning *FuriosaPartitioning `json:"partitioning,omitempty"`
+
+	// Config is rendered into the config.yaml of the device plugin, in the ConfigMap named
+	// configMapName.
+	// +optional
+	Config *FuriosaPluginConfig `json:"config,omitempty"`
+
+	VendorComponents `json:",inline"`
+
+	VendorPodSpec `json:",inline"`
+
+	// ClusterAPI propagates the Furiosa node labels and taints into Cluster API machine templates.
+	// +optional
+	ClusterAPI *ClusterAPISpec `json:"clusterAPI,omitempty"`
+}
+
+// FuriosaPartitioning configures the device plugin to advertise partitions of a card.
+type FuriosaPartitioning struct {
+	// PEsPerPartition is the number of PEs of each partition. Partitions are advertised as
+	// furiosa.ai/npu-<n>pe, e.g. furiosa.ai/npu-2pe, instead of furiosa.ai/npu.
+	// +kubebuilder:validation:Enum=1;2;4
+	PEsPerPartition int32 `json:"pesPerPartition"`
+}
+
+// FuriosaPEMode is how the processing elements (PEs) of a card are advertised.
+// +kubebuilder:validation:Enum=Fusion;Single
+type FuriosaPEMode string
+
+const (
+	// FuriosaPEFusion fuses the PEs of a card, which is advertised whole.
+	FuriosaPEFusion FuriosaPEMode = "Fusion"
+	// FuriosaPESingle advertises the PEs of a card one by one, or grouped by partitioning.
+	FuriosaPESingle FuriosaPEMode = "Single"
+)
+
+// FuriosaPluginConfig is the configuration of the Furiosa device plugin.
+type FuriosaPluginConfig struct {
+	// DefaultPE is the mode the PEs of the cards are advertised in. It defaults to Fusion, or
+	// to Single with partitioning, which requires it.
+	// +optional
+	DefaultPE FuriosaPEMode `json:"defaultPe,omitempty"`
+
+	// Interval is the number of seconds between the health checks of the devices.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	Interval int32 `json:"interval,omitempty"`
+
+	// DisabledDevices are not advertised on any node. Devices are named by index, e.g. "3", or
+	// by ID as listed in the status of the NPUNodes. Devices of a single node are disabled
+	// through spec.disabledDevices of its NPUNode instead.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	DisabledDevices []string `json:"disabledDevices,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.clusterAPI)",message="clusterAPI requires enabled"
 type NvidiaSpec struct {
-	Enabled           bool   `json:"enabled"`
-	DevicePluginImage string `json:"devicePluginImage"`
+	Enabled bool `json:"enabled"`
+
+	// DevicePluginImage is the device plugin image.
+	// Deprecated: use devicePlugin.image and devicePlugin.version.
+	// +optional
+	DevicePluginImage string `json:"devicePluginImage,omitempty"`
+
+	// Env is merged into the environment of the device plugin container, replacing variables
+	// of the same name, e.g. MIG_STRATEGY=mixed or DP_DISABLE_HEALTHCHECKS=xids.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ExtraVolumes are added to the device plugin pods, e.g. host paths of custom driver
+	// installs or secrets holding certificates.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are added to the device plugin container, mounting extraVolumes.
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// AutoMIG repartitions idle MIG-capable GPUs by the MIG profiles pending pods request.
+	// +optional
+	AutoMIG *AutoMIGSpec `json:"autoMIG,omitempty"`
+
+	// DevicePluginConfig is the configuration file of the device plugin, rendered into the
+	// ConfigMap nvidia-device-plugin-config and passed with --config-file.
+	// +optional
+	DevicePluginConfig *NvidiaDevicePluginConfig `json:"devicePluginConfig,omitempty"`
+
+	VendorComponents `json:",inline"`
+
+	VendorPodSpec `json:",inline"`
+
+	// ClusterAPI propagates the NVIDIA node labels and taints into Cluster API machine templates.
+	// +optional
+	ClusterAPI *ClusterAPISpec `json:"clusterAPI,omitempty"`
+}
+
+// NvidiaDevicePluginConfig is the configuration file of the NVIDIA device plugin, given as is or
+// by its sharing settings.
+// +kubebuilder:validation:XValidation:rule="has(self.raw) != has(self.sharing)",message="exactly one of raw and sharing is required"
+type NvidiaDevicePluginConfig struct {
+	// Raw is the configuration file in the format of the device plugin, e.g. version: v1 with
+	// flags and sharing.
+	// +optional
+	Raw string `json:"raw,omitempty"`
+
+	// Sharing shares the GPUs between pods.
+	// +optional
+	Sharing *NvidiaSharing `json:"sharing,omitempty"`
+}
+
+// NvidiaSharing advertises each GPU as several replicas, so pods can share it.
+// +kubebuilder:validation:XValidation:rule="has(self.timeSlicing) != has(self.mps)",message="exactly one of timeSlicing and mps is required"
+type NvidiaSharing struct {
+	// TimeSlicing interleaves the pods sharing a GPU in time, without isolating their memory.
+	// +optional
+	TimeSlicing *NvidiaReplicas `json:"timeSlicing,omitempty"`
+
+	// MPS runs the pods sharing a GPU concurrently through the CUDA Multi-Process Service,
+	// each limited to its share of the memory and compute. It requires the MPS control
+	// daemon of the device plugin on the nodes.
+	// +optional
+	MPS *NvidiaReplicas `json:"mps,omitempty"`
+}
+
+// NvidiaReplicas are the resources advertised as replicas.
+type NvidiaReplicas struct {
+	// RenameByDefault advertises the replicas of resources without rename as <name>.shared,
+	// e.g. nvidia.com/gpu.shared.
+	// +optional
+	RenameByDefault bool `json:"renameByDefault,omitempty"`
+
+	// FailRequestsGreaterThanOne fails pods requesting more than one replica, which would not
+	// get a larger share of the GPU.
+	// +optional
+	FailRequestsGreaterThanOne bool `json:"failRequestsGreaterThanOne,omitempty"`
+
+	// Resources are the replicated resources.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Resources []NvidiaReplicatedResource `json:"resources"`
+}
+
+// NvidiaReplicatedResource is a resource whose devices are advertised as replicas.
+type NvidiaReplicatedResource struct {
+	// Name is the resource, nvidia.com/gpu or a MIG resource, e.g. nvidia.com/mig-1g.10gb.
+	// +kubebuilder:validation:Pattern=`^nvidia\.com/`
+	Name string `json:"name"`
+
+	// Replicas is the number of replicas of each device.
+	// +kubebuilder:validation:Minimum=2
+	Replicas int32 `json:"replicas"`
+
+	// Rename advertises the replicas under this resource name instead.
+	// +optional
+	Rename string `json:"rename,omitempty"`
+}
+
+// VendorPodSpec places the pods of every component of a vendor.
+type VendorPodSpec struct {
+	// NodeSelector of the component pods. It replaces the default label of the vendor,
+	// nvidia.com/gpu.present=true or furiosa=true, e.g. for nodes labeled by another scheme.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity of the component pods, e.g. to target or avoid some pools of mixed clusters.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations of the component pods, e.g. of taints such as nvidia.com/gpu=present:NoSchedule
+	// on the accelerator nodes. Without them the device plugin tolerates every taint; the
+	// defaulting webhook sets them to tolerate the taint of the vendor resource.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// ImagePullSecrets of the component pods, added to spec.imagePullSecrets, e.g. for the
+	// images of the vendor mirrored to a private registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ImagePullPolicy of the component containers, e.g. Never on air-gapped clusters or Always
+	// on development clusters. By default the NVIDIA components use IfNotPresent and the
+	// Furiosa device plugin Always. Ignored at edge sites, see spec.edge.pullPolicy.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// RuntimeClassName of the component pods, e.g. nvidia when the NVIDIA container runtime is
+	// configured by a RuntimeClass instead of as the default runtime of the nodes. The driver
+	// pods, which do not need the runtime of the vendor, keep the default runtime.
+	// +optional
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+}
+
+// VendorComponents are the components of a vendor stack. Each one is deployed as its
+// own DaemonSet with its own Ready condition, so it can be enabled, disabled and
+// upgraded without touching the others. Components only run while the vendor is enabled.
+type VendorComponents struct {
+	// DevicePlugin advertises the accelerators to the kubelet. It is enabled unless
+	// explicitly disabled.
+	// +optional
+	DevicePlugin ComponentSpec `json:"devicePlugin,omitempty"`
+
+	// Exporter exposes accelerator metrics.
+	// +optional
+	Exporter ComponentSpec `json:"exporter,omitempty"`
+
+	// Validator checks that the accelerators are usable on each node.
+	// +optional
+	Validator ComponentSpec `json:"validator,omitempty"`
+
+	// Driver installs the kernel driver on each node.
+	// +optional
+	Driver ComponentSpec `json:"driver,omitempty"`
+
+	// GFD labels nodes with the discovered accelerator features.
+	// +optional
+	GFD ComponentSpec `json:"gfd,omitempty"`
+}
+
+// ComponentSpec configures one component of a vendor stack.
+type ComponentSpec struct {
+	// Enabled deploys the component. Defaults to false, except for the device plugin.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Image is the image repository, e.g. nvcr.io/nvidia/k8s-device-plugin.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Version is the image tag, appended to Image. With a catalog it selects the
+	// catalog entry instead.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Hooks are Jobs run before and after image upgrades of the component.
+	// +optional
+	Hooks *UpgradeHooks `json:"hooks,omitempty"`
+
+	// Resources are the CPU and memory requests and limits of the component container.
+	// Without them the pods are best-effort and evicted first under node pressure.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Labels are added to the DaemonSet and pods of the component, overriding commonLabels.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the DaemonSet and pods of the component, overriding
+	// commonAnnotations.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Args are appended to the arguments of the component container, e.g.
+	// --fail-on-init-error=false or --pass-device-specs for the NVIDIA device plugin.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// ImagePullPolicy of the component containers. It overrides the pull policy of the vendor.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the component container. The fields it sets override those set by the
+	// operator, e.g. privileged for the drivers and validators or allowPrivilegeEscalation,
+	// the others keep their defaults, e.g. readOnlyRootFilesystem or runAsUser.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// PodSecurityContext of the component pods, e.g. runAsNonRoot or a seccompProfile.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// UpdateStrategy of the component DaemonSet, e.g. a RollingUpdate with a maxUnavailable of
+	// 10% to upgrade large clusters faster, or OnDelete to replace the pods by hand. Rollouts
+	// of OnDelete components only complete once every pod was replaced. Ignored for drivers
+	// upgraded by the WorkloadAware strategy and for device plugins upgraded blue/green, whose
+	// pods the operator replaces itself.
+	// +kubebuilder:validation:XValidation:rule="!has(self.rollingUpdate) || !has(self.type) || self.type == 'RollingUpdate'",message="rollingUpdate requires type RollingUpdate"
+	// +optional
+	UpdateStrategy *appsv1.DaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// UpgradeHooks are site-specific steps of a component upgrade, e.g. flushing MPS clients
+// before the device plugin restarts or running a vendor health check afterwards. Hooks
+// only run on upgrades, not when the component is first deployed. The Jobs run in
+// the component namespace (spec.namespace) with COMPONENT, FROM_IMAGE and TO_IMAGE set.
+type UpgradeHooks struct {
+	// PreUpgrade runs before the component is updated. The update waits for it to finish.
+	// +optional
+	PreUpgrade *UpgradeHook `json:"preUpgrade,omitempty"`
+
+	// PostUpgrade runs once the updated component is rolled out. The rollout completes,
+	// and its image becomes the last known good image, once it finished.
+	// +optional
+	PostUpgrade *UpgradeHook `json:"postUpgrade,omitempty"`
 }
 
-// NPUClusterPolicySpec defines the desired state of NPUClusterPolicy.
+// HookFailurePolicy is what happens to an upgrade whose hook failed.
+// +kubebuilder:validation:Enum=Abort;Ignore
+type HookFailurePolicy string
+
+const (
+	// HookFailureAbort stops the upgrade before the update for pre-upgrade hooks, and keeps
+	// the previous last known good image for post-upgrade hooks.
+	HookFailureAbort HookFailurePolicy = "Abort"
+	// HookFailureIgnore reports the failure and continues the upgrade.
+	HookFailureIgnore HookFailurePolicy = "Ignore"
+)
+
+// UpgradeHook is a Job run as a step of a component upgrade.
+type UpgradeHook struct {
+	// Image of the hook container.
+	Image string `json:"image"`
+
+	// Command of the hook container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args of the hook container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Timeout after which the hook fails. Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailurePolicy of the hook. Defaults to Abort.
+	// +optional
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// ClusterAPISpec selects the Cluster API MachineDeployments that provision accelerator
+// nodes for a vendor. The vendor node labels (plus NodeLabels) and NodeTaints are written
+// into the KubeadmConfigTemplate of each selected MachineDeployment and into its
+// cluster-autoscaler capacity annotations, so new machines join the cluster pre-labeled.
+type ClusterAPISpec struct {
+	Enabled bool `json:"enabled"`
+
+	// Namespace of the MachineDeployments. Defaults to the namespace of the policy.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// MachineDeploymentSelector selects the MachineDeployments to update.
+	MachineDeploymentSelector *metav1.LabelSelector `json:"machineDeploymentSelector"`
+
+	// NodeLabels are additional labels applied on top of the vendor node labels.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are registered by kubeadm when a new machine joins the cluster.
+	// +optional
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+}
+
+// Defaults of the policies created without them. The defaulting webhook sets them, and
+// the operator falls back to DefaultComponentNamespace while spec.namespace is unset.
+const (
+	// DefaultComponentNamespace is where the components are deployed unless spec.namespace is set.
+	DefaultComponentNamespace = "kube-system"
+	// DefaultFuriosaConfigMapName is the ConfigMap of the Furiosa device plugin configuration.
+	DefaultFuriosaConfigMapName = "furiosa-device-plugin-config"
+
+	DefaultNvidiaDevicePluginImage    = "nvcr.io/nvidia/k8s-device-plugin"
+	DefaultNvidiaDevicePluginVersion  = "v0.17.1"
+	DefaultFuriosaDevicePluginImage   = "ghcr.io/furiosa-ai/k8s-device-plugin"
+	DefaultFuriosaDevicePluginVersion = "0.10.1"
+)
+
+// NPUClusterPolicySpec defines the desired state of NPUClusterPolicy. Optional sections
+// are off while absent and carry no defaults of their own, so a CRD upgrade adding a
+// section never enables it on existing policies.
 type NPUClusterPolicySpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	Nvidia  NvidiaSpec  `json:"nvidia"`
 	Furiosa FuriosaSpec `json:"furiosa"`
+
+	// Namespace the components are deployed to. Defaults to kube-system and cannot be changed.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CreateNamespace creates the namespace of the components when it does not exist. The
+	// namespace is left in place when the policy is deleted.
+	// +optional
+	CreateNamespace bool `json:"createNamespace,omitempty"`
+
+	// ImagePullSecrets are Secrets of the component namespace used to pull the images of every
+	// pod the operator runs, e.g. from a private Harbor registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PriorityClassName of the component pods, so device plugins and drivers survive node
+	// pressure instead of being evicted as best-effort pods. Defaults to system-node-critical,
+	// which outside kube-system must be allowed by a ResourceQuota of the namespace.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// CreatePriorityClass creates the class named by priorityClassName when it does not exist,
+	// with the highest value allowed for classes other than the system ones. The class is left
+	// in place when the policy is deleted.
+	// +optional
+	CreatePriorityClass bool `json:"createPriorityClass,omitempty"`
+
+	// CommonLabels are added to every object the operator creates for the policy and to the
+	// pods of its DaemonSets, Deployments and Jobs, e.g. for cost allocation. Labels the
+	// operator sets itself, such as app.kubernetes.io/name, are kept.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added like commonLabels.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// Paused stops the operator from creating, updating or deleting any object of the policy,
+	// e.g. while debugging a node, so manual changes are kept. The status is still reported.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// DryRun renders the DaemonSets and ConfigMaps of the policy into the ConfigMap
+	// <policy name>-dry-run in the policy namespace instead of applying them, so changes can
+	// be reviewed before they are enforced. Objects already deployed are left as they are.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// AdoptExisting takes over device plugin DaemonSets installed by other means, e.g. the
+	// upstream Helm chart, that register the same resources on the same nodes. A DaemonSet of the
+	// same name and selector is relabeled and reconciled to the policy, any other is deleted and
+	// replaced, so its installer must no longer manage it. Without it such a plugin holds back
+	// the operator's plugin, which is reported by the PluginConflict condition.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// SelfHealing controls how managed DaemonSets deleted out-of-band are recreated.
+	// +optional
+	SelfHealing SelfHealingSpec `json:"selfHealing,omitempty"`
+
+	// SafeMode freezes a component whose pods crash loop shortly after the operator changed it.
+	// +optional
+	SafeMode SafeModeSpec `json:"safeMode,omitempty"`
+
+	// ExtraManifests reference ConfigMaps in the policy namespace holding raw YAML
+	// manifests, e.g. extra Services or PodMonitors, that are applied along with the
+	// policy and garbage-collected with it. Objects must be namespaced; they are
+	// created in the policy namespace. The manager must be granted RBAC for their kinds.
+	// +optional
+	ExtraManifests []ExtraManifestRef `json:"extraManifests,omitempty"`
+
+	// Catalog is the name of the NPUComponentCatalog that component images are resolved
+	// from. Components then select a version only and must not set an image.
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+
+	// Thermal responds to sustained thermal throttling reported by the exporters.
+	// +optional
+	Thermal *ThermalSpec `json:"thermal,omitempty"`
+
+	// Edge keeps components running while the image registry is intermittently unreachable.
+	// +optional
+	Edge *EdgeSpec `json:"edge,omitempty"`
+
+	// PrePull pulls new component images onto the target nodes before a component is updated.
+	// +optional
+	PrePull *PrePullSpec `json:"prePull,omitempty"`
+
+	// PluginUpgrade selects how device plugin image changes are rolled out.
+	// +optional
+	PluginUpgrade *PluginUpgradeSpec `json:"pluginUpgrade,omitempty"`
+
+	// DriverUpgrade selects how driver image changes are rolled out across the nodes.
+	// +optional
+	DriverUpgrade *DriverUpgradeSpec `json:"driverUpgrade,omitempty"`
+
+	// Scheduler deploys a secondary scheduler that scores nodes by accelerator fragmentation
+	// and temperature. Workloads opt in by setting spec.schedulerName.
+	// +optional
+	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+
+	// DevicePreferences are the device selection preferences of the device plugins per node
+	// pool. Nodes outside every pool use the defaults.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	DevicePreferences []DevicePreferencePool `json:"devicePreferences,omitempty"`
+
+	// Patches are RFC 6902 JSON patches applied to the rendered objects before they are
+	// applied, for customizations the structured fields cannot express. An object whose
+	// patch fails is left unchanged; failures are reported by the PatchesApplied condition.
+	// +optional
+	Patches []ObjectPatch `json:"patches,omitempty"`
+
+	// ConflictPolicy resolves fields of managed objects that another field manager, e.g.
+	// kubectl edit, changed. Force takes the fields back, Fail stops updating the object and
+	// Ignore leaves the object to the other manager. Conflicts are reported by events, the
+	// FieldConflict condition and the Ready condition of the component.
+	// +kubebuilder:default=Force
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// MetricsTLS serves the metrics of the exporters over TLS.
+	// +optional
+	MetricsTLS *MetricsTLSSpec `json:"metricsTLS,omitempty"`
+}
+
+// MetricsTLSSpec configures TLS for the metrics endpoints of the exporters. Without an
+// issuer the operator maintains a self-signed CA and rotates the serving certificate
+// before it expires; with an issuer cert-manager issues it.
+// +kubebuilder:validation:XValidation:rule="self.enabled || (!has(self.issuerRef) && !(has(self.serviceMonitor) && self.serviceMonitor))",message="issuerRef and serviceMonitor require enabled"
+type MetricsTLSSpec struct {
+	// Enabled serves the exporter metrics over HTTPS only.
+	Enabled bool `json:"enabled"`
+
+	// IssuerRef selects the cert-manager issuer of the serving certificate.
+	// +optional
+	IssuerRef *CertificateIssuerRef `json:"issuerRef,omitempty"`
+
+	// ServiceMonitor creates a Service and a Prometheus Operator ServiceMonitor per exporter
+	// that scrapes it over HTTPS, verifying the serving certificate.
+	// +optional
+	ServiceMonitor bool `json:"serviceMonitor,omitempty"`
+}
+
+// CertificateIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type CertificateIssuerRef struct {
+	// Name of the issuer. An Issuer must be in the component namespace (spec.namespace).
+	Name string `json:"name"`
+
+	// Kind of the issuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, for external issuers.
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// ConflictPolicy is the resolution of server-side apply field conflicts.
+// +kubebuilder:validation:Enum=Force;Fail;Ignore
+type ConflictPolicy string
+
+const (
+	ConflictPolicyForce  ConflictPolicy = "Force"
+	ConflictPolicyFail   ConflictPolicy = "Fail"
+	ConflictPolicyIgnore ConflictPolicy = "Ignore"
+)
+
+// ObjectPatch patches one object rendered into the component namespace (spec.namespace).
+type ObjectPatch struct {
+	// Kind of the object.
+	// +kubebuilder:validation:Enum=DaemonSet;ConfigMap
+	Kind string `json:"kind"`
+
+	// Name of the object, e.g. nvidia-device-plugin.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Operations of the patch, applied in order.
+	// +kubebuilder:validation:MinItems=1
+	Operations []JSONPatchOperation `json:"operations"`
+}
+
+// JSONPatchOperation is one RFC 6902 operation.
+// +kubebuilder:validation:XValidation:rule="!(self.op in ['add', 'replace', 'test']) || has(self.value)",message="value is required by add, replace and test"
+// +kubebuilder:validation:XValidation:rule="!(self.op in ['move', 'copy']) || has(self.from)",message="from is required by move and copy"
+type JSONPatchOperation struct {
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+
+	// Path is the JSON pointer of the target location, e.g. /spec/template/spec/hostNetwork.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// From is the JSON pointer of the source location of move and copy.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// Value of add, replace and test.
+	// +optional
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+}
+
+// DevicePreferencePool sets the device selection preferences of a node pool. The
+// preferences are rendered into the <vendor>-device-plugin-preferences ConfigMaps, one key
+// per pool, and the nodes of a pool are labeled npu.ai/device-preferences=<pool>, so the
+// device plugins pick the preferences of their node.
+type DevicePreferencePool struct {
+	// Name of the pool.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the pool. A node matching several pools uses the first.
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// PreferSameNUMANode prefers devices attached to a single NUMA node. Defaults to true.
+	// +optional
+	PreferSameNUMANode *bool `json:"preferSameNUMANode,omitempty"`
+
+	// PreferNVLink prefers sets of NVIDIA GPUs connected by NVLink. Defaults to true.
+	// +optional
+	PreferNVLink *bool `json:"preferNVLink,omitempty"`
+}
+
+// SchedulerSpec configures the accelerator-aware secondary scheduler. It runs kube-scheduler
+// with a profile that packs accelerator requests onto the nodes already using the most
+// accelerators, so whole nodes stay free for multi-device jobs, and that avoids nodes above
+// a thermal threshold. The accelerator resources are taken from the NPUNodes and the hot
+// nodes from status.thermal, which are marked with the npu.ai/thermal-pressure taint.
+type SchedulerSpec struct {
+	// Image of kube-scheduler, without tag.
+	// +kubebuilder:default="registry.k8s.io/kube-scheduler"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Version of kube-scheduler. It should match the version of the control plane.
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// SchedulerName that workloads set to be scheduled by this scheduler.
+	// +kubebuilder:default=npu-scheduler
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// BinPackingWeight is the score weight of packing accelerator requests together.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=5
+	// +optional
+	BinPackingWeight int32 `json:"binPackingWeight,omitempty"`
+
+	// TemperatureWeight is the score weight of avoiding nodes above a thermal threshold.
+	// It requires spec.thermal; zero disables temperature scoring.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TemperatureWeight int32 `json:"temperatureWeight,omitempty"`
+}
+
+// PluginUpgradeStrategy is the upgrade strategy of the device plugins.
+// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen
+type PluginUpgradeStrategy string
+
+const (
+	// PluginUpgradeRollingUpdate updates the device plugin DaemonSet in place.
+	PluginUpgradeRollingUpdate PluginUpgradeStrategy = "RollingUpdate"
+	// PluginUpgradeBlueGreen validates the new plugin next to the current one first.
+	PluginUpgradeBlueGreen PluginUpgradeStrategy = "BlueGreen"
+)
+
+// DriverUpgradeStrategy is the upgrade strategy of the drivers.
+// +kubebuilder:validation:Enum=RollingUpdate;WorkloadAware
+type DriverUpgradeStrategy string
+
+const (
+	// DriverUpgradeRollingUpdate lets the driver DaemonSet replace its pods in any order.
+	DriverUpgradeRollingUpdate DriverUpgradeStrategy = "RollingUpdate"
+	// DriverUpgradeWorkloadAware replaces the driver pods node by node, least loaded nodes first.
+	DriverUpgradeWorkloadAware DriverUpgradeStrategy = "WorkloadAware"
+)
+
+// DriverUpgradeSpec configures driver upgrades. With WorkloadAware, the driver DaemonSets use
+// the OnDelete update strategy and the operator replaces the outdated driver pods itself,
+// ordering the nodes by the accelerators requested by their pods: idle nodes first, and
+// among equally loaded nodes those whose pods run the longest last.
+// +kubebuilder:validation:XValidation:rule="(has(self.strategy) && self.strategy == 'WorkloadAware') || (!has(self.maxParallel) && !has(self.waitForCompletion))",message="maxParallel and waitForCompletion require the WorkloadAware strategy"
+type DriverUpgradeSpec struct {
+	// Strategy of the upgrade. Defaults to RollingUpdate.
+	// +optional
+	Strategy DriverUpgradeStrategy `json:"strategy,omitempty"`
+
+	// MaxParallel is the number of nodes whose driver is replaced at the same time. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxParallel *int32 `json:"maxParallel,omitempty"`
+
+	// WaitForCompletion holds back nodes running accelerator pods until the pods complete,
+	// for at most this long after the upgrade started. Unset upgrades busy nodes last
+	// without waiting for their pods.
+	// +optional
+	WaitForCompletion *metav1.Duration `json:"waitForCompletion,omitempty"`
+}
+
+// AutoMIGSpec configures the repartitioning of MIG-capable GPUs by demand. When pods requesting
+// a MIG resource, e.g. nvidia.com/mig-1g.10gb, cannot be scheduled, the operator sets the
+// nvidia.com/mig.config label of an idle node to a layout providing the resource, which the
+// NVIDIA MIG manager applies. Nodes running GPU pods are never repartitioned, and only one
+// node is repartitioned at a time.
+type AutoMIGSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// NodeSelector selects the nodes that may be repartitioned, e.g. an A100 pool. Defaults
+	// to the nodes labeled nvidia.com/mig.capable=true.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Layouts the nodes may be repartitioned to.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Layouts []MIGLayout `json:"layouts"`
+
+	// MaintenanceWindows restrict repartitioning to these times. Nodes are repartitioned at
+	// any time without them.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Cooldown is the minimum time between two repartitions of the same node. Defaults to 30m.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
 }
 
+// MIGLayout is a MIG configuration of the MIG manager and the resources it advertises.
+// +kubebuilder:validation:XValidation:rule="!has(self.minNodes) || !has(self.maxNodes) || self.minNodes <= self.maxNodes",message="minNodes must not exceed maxNodes"
+type MIGLayout struct {
+	// Name of the configuration in the config file of the MIG manager, e.g. all-1g.10gb.
+	Name string `json:"name"`
+
+	// Resources a node advertises with the layout, e.g. nvidia.com/mig-1g.10gb: 56 for
+	// eight A100 80GB with seven 1g.10gb instances each.
+	// +kubebuilder:validation:MinProperties=1
+	Resources map[corev1.ResourceName]int64 `json:"resources"`
+
+	// MinNodes keeps at least this many nodes on the layout: they are not repartitioned to
+	// another layout.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinNodes *int32 `json:"minNodes,omitempty"`
+
+	// MaxNodes is the most nodes repartitioned to the layout. Unlimited when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of time, in UTC, in which disruptive changes are allowed.
+type MaintenanceWindow struct {
+	// Days of the week the window opens on. Every day when empty.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, as HH:MM in UTC, e.g. 02:00.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration of the window, e.g. 4h. It may extend into the next day.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type Weekday string
+
+// PluginUpgradeSpec configures device plugin upgrades. With BlueGreen, the new image first
+// runs as a separate green DaemonSet whose pods get the RESOURCE_NAME_SUFFIX environment
+// variable, which the plugin image must append to its resource and socket names. Once
+// every node advertises the suffixed resource, the plugin DaemonSet is switched over with
+// a surge rollout, so a new plugin registers before the old one stops, and the green
+// DaemonSet is removed.
+// +kubebuilder:validation:XValidation:rule="(has(self.strategy) && self.strategy == 'BlueGreen') || (!has(self.resourceSuffix) && !has(self.validationTimeout))",message="resourceSuffix and validationTimeout require the BlueGreen strategy"
+type PluginUpgradeSpec struct {
+	// Strategy of the upgrade. Defaults to RollingUpdate.
+	// +optional
+	Strategy PluginUpgradeStrategy `json:"strategy,omitempty"`
+
+	// ResourceSuffix is appended to the resource names of the green plugin. Defaults to -green.
+	// +optional
+	ResourceSuffix string `json:"resourceSuffix,omitempty"`
+
+	// ValidationTimeout after which a green plugin that is not advertised on every node is
+	// abandoned. Defaults to 10m.
+	// +optional
+	ValidationTimeout *metav1.Duration `json:"validationTimeout,omitempty"`
+}
+
+// PrePullSpec configures the pre-pull stage of component upgrades. A temporary DaemonSet
+// pulling the new images runs on the nodes of the component, and the component is only
+// updated once it is ready on every node or the timeout expired, so plugin pods are not
+// down while large images download. The images must provide sh.
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.timeout)",message="timeout requires enabled"
+type PrePullSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// Timeout after which the update starts even if some nodes did not pull the images yet.
+	// Defaults to 30m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// EdgeSpec configures disconnected operation at edge sites. Components are pulled from
+// the node image cache when present, image changes are deferred while component pods
+// fail to pull images, and that state is reported by the Disconnected condition instead
+// of failing the components.
+type EdgeSpec struct {
+	// PullPolicy of the component containers. Never requires images to be pre-pulled.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=IfNotPresent;Never
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// PrePull runs a Job on every accelerator node that pulls the images of its components
+	// while the registry is reachable, so restarted pods start from the cache. The images
+	// must provide sh.
+	// +optional
+	PrePull bool `json:"prePull,omitempty"`
+}
+
+// ThermalAction is a response to sustained thermal throttling of a node.
+// +kubebuilder:validation:Enum=PowerCap;Cordon;Notify
+type ThermalAction string
+
+const (
+	// ThermalActionPowerCap requests a lower power limit through the npu.ai/power-limit-watts
+	// node annotation, applied by the vendor driver component.
+	ThermalActionPowerCap ThermalAction = "PowerCap"
+	// ThermalActionCordon marks the node unschedulable.
+	ThermalActionCordon ThermalAction = "Cordon"
+	// ThermalActionNotify emits events on the node and the policy, and a CloudEvent.
+	ThermalActionNotify ThermalAction = "Notify"
+)
+
+// ThermalSpec configures the responses to sustained thermal throttling. The operator
+// scrapes the enabled exporters and considers a node hot while any sample of a threshold
+// metric exceeds its value. Responses are reverted once the node cools down.
+// +kubebuilder:validation:XValidation:rule="!has(self.actions) || !self.actions.exists(a, a == 'PowerCap') || has(self.powerLimitWatts)",message="powerLimitWatts is required by the PowerCap action"
+type ThermalSpec struct {
+	// Thresholds are the exporter metrics checked on every node.
+	// +kubebuilder:validation:MinItems=1
+	Thresholds []MetricThreshold `json:"thresholds"`
+
+	// For is how long a node must stay hot before the actions are taken. Defaults to 5m.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// Actions taken once a node stayed hot for the duration. Defaults to Notify.
+	// +optional
+	Actions []ThermalAction `json:"actions,omitempty"`
+
+	// PowerLimitWatts is the per-device power limit requested by the PowerCap action.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PowerLimitWatts *int32 `json:"powerLimitWatts,omitempty"`
+}
+
+// MetricThreshold is exceeded when any sample of the metric is above Value.
+type MetricThreshold struct {
+	// Metric is the name of the exporter metric, e.g. DCGM_FI_DEV_GPU_TEMP.
+	Metric string `json:"metric"`
+
+	// Value is the threshold, e.g. 85.
+	Value resource.Quantity `json:"value"`
+}
+
+// ExtraManifestRef selects a ConfigMap with manifests to apply.
+type ExtraManifestRef struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the manifests in the ConfigMap. All keys are applied when empty.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// SafeModeSpec configures crash loop detection after operator-applied changes.
+// A frozen component is neither created nor updated until the policy is annotated
+// with npu.ai/acknowledge-safe-mode=<component name or "true" for all>.
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.window)",message="window requires enabled"
+type SafeModeSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// Window after an operator-applied change in which a CrashLoopBackOff triggers safe mode.
+	// Defaults to 10m.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// SelfHealingSpec rate limits the recreation of managed DaemonSets and stops it
+// after repeated unexpected deletions, so a misbehaving cleanup script is noticed.
+type SelfHealingSpec struct {
+	// MinInterval is the minimum time between two recreations. Defaults to 30s.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+
+	// MaxUnexpectedDeletions is the number of out-of-band deletions after which the operator
+	// stops recreating components until the policy is annotated with npu.ai/approve-recreate=true.
+	// Zero disables the limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnexpectedDeletions int32 `json:"maxUnexpectedDeletions,omitempty"`
+}
+
+// PolicyPhase summarizes the conditions of a policy.
+// +kubebuilder:validation:Enum=Ready;Progressing;Degraded
+type PolicyPhase string
+
+const (
+	// PolicyReady means every enabled component is deployed and rolled out.
+	PolicyReady PolicyPhase = "Ready"
+	// PolicyProgressing means a component is still being rolled out.
+	PolicyProgressing PolicyPhase = "Progressing"
+	// PolicyDegraded means a component failed and needs attention.
+	PolicyDegraded PolicyPhase = "Degraded"
+)
+
 // NPUClusterPolicyStatus defines the observed state of NPUClusterPolicy.
 type NPUClusterPolicyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
-	Phase string `json:"phase,omitempty"`
+	// Phase summarizes the Ready, Progressing and Degraded conditions.
+	// +optional
+	Phase PolicyPhase `json:"phase,omitempty"`
+
+	// NvidiaReady counts the nodes running a ready NVIDIA device plugin out of the nodes that
+	// should, e.g. 3/4. Unset while NVIDIA is disabled or its DaemonSet was not observed yet.
+	// +optional
+	NvidiaReady string `json:"nvidiaReady,omitempty"`
+
+	// FuriosaReady counts the nodes running a ready Furiosa device plugin like nvidiaReady.
+	// +optional
+	FuriosaReady string `json:"furiosaReady,omitempty"`
+
+	// Conditions describe the observed state of the policy and its vendor components.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// MetricsCertificate reports the serving certificate of the exporter metrics.
+	// +optional
+	MetricsCertificate *MetricsCertificateStatus `json:"metricsCertificate,omitempty"`
+
+	// SelfHealing records out-of-band deletions of managed DaemonSets.
+	// +optional
+	SelfHealing *SelfHealingStatus `json:"selfHealing,omitempty"`
+
+	// Components reports the observed state of each managed component.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// ExtraManifests lists the objects applied from spec.extraManifests, so objects
+	// dropped from the manifests are pruned.
+	// +optional
+	ExtraManifests []ManifestObjectReference `json:"extraManifests,omitempty"`
+
+	// Thermal lists the nodes above a thermal threshold.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	Thermal []NodeThermalStatus `json:"thermal,omitempty"`
+
+	// Edge reports the disconnected operation state.
+	// +optional
+	Edge *EdgeStatus `json:"edge,omitempty"`
+
+	// LastExport reports the last export requested with the npu.ai/export annotation.
+	// +optional
+	LastExport *ExportStatus `json:"lastExport,omitempty"`
+
+	// LastReconcile summarizes the last reconcile that processed a new generation, changed
+	// objects or failed, so it tells whether the latest edit was fully applied.
+	// +optional
+	LastReconcile *ReconcileSummary `json:"lastReconcile,omitempty"`
+
+	// Inventory counts the nodes running each combination of driver, firmware and device
+	// plugin versions, and how far each component is from its target image, so the progress
+	// of an upgrade across the fleet can be read from the policy.
+	// +optional
+	Inventory *FleetInventory `json:"inventory,omitempty"`
+
+	// MIGRepartitions lists the last repartitions of spec.nvidia.autoMIG, oldest first.
+	// +optional
+	MIGRepartitions []MIGRepartition `json:"migRepartitions,omitempty"`
+}
+
+// MIGRepartitionPhase is the phase of a MIG repartition.
+type MIGRepartitionPhase string
+
+const (
+	// MIGRepartitionInProgress means the layout label is set and the MIG manager applies it.
+	MIGRepartitionInProgress MIGRepartitionPhase = "InProgress"
+	// MIGRepartitionSucceeded means the MIG manager reported the layout as applied.
+	MIGRepartitionSucceeded MIGRepartitionPhase = "Succeeded"
+	// MIGRepartitionFailed means the MIG manager failed to apply the layout, or the node is gone.
+	MIGRepartitionFailed MIGRepartitionPhase = "Failed"
+)
+
+// MIGRepartition is one step of auto-MIG: a node moved from a layout to another.
+type MIGRepartition struct {
+	// Node is the name of the repartitioned node.
+	Node string `json:"node"`
+
+	// From is the layout of the node before, empty when it had none.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// To is the layout the node is repartitioned to.
+	To string `json:"to"`
+
+	// Reason is the demand the repartition responds to, e.g. "12 pending pods request
+	// 12 nvidia.com/mig-1g.10gb".
+	Reason string `json:"reason"`
+
+	// Phase of the repartition.
+	Phase MIGRepartitionPhase `json:"phase"`
+
+	// StartTime is when the layout label was set.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the repartition succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// FleetInventory summarizes the versions running on the accelerator nodes of the enabled vendors.
+type FleetInventory struct {
+	// Nodes is the number of accelerator nodes of the enabled vendors.
+	Nodes int32 `json:"nodes"`
+
+	// Versions counts the nodes of each vendor and combination of versions, the most common
+	// first. Only the 50 most common combinations are listed.
+	// +optional
+	Versions []VersionCount `json:"versions,omitempty"`
+
+	// Drift reports for each component how many of its nodes run another image than the one
+	// last applied.
+	// +listType=map
+	// +listMapKey=component
+	// +optional
+	Drift []ComponentDrift `json:"drift,omitempty"`
+
+	// Unmanaged lists the nodes excluded by the npu.ai/unmanaged annotation, sorted. Only the
+	// first 50 are listed.
+	// +optional
+	Unmanaged []string `json:"unmanaged,omitempty"`
+}
+
+// VersionCount is the number of nodes of a vendor running a combination of versions.
+// Versions unknown on a node are empty.
+type VersionCount struct {
+	Vendor string `json:"vendor"`
+	// DriverVersion as reported by the feature discovery labels of the node.
+	// +optional
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// FirmwareVersion as reported by the npu.ai/firmware-version.<vendor> label of the node.
+	// +optional
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// PluginVersion is the image tag of the device plugin running on the node.
+	// +optional
+	PluginVersion string `json:"pluginVersion,omitempty"`
+	Nodes         int32  `json:"nodes"`
+}
+
+// ComponentDrift is how far the pods of a component are from its target image.
+type ComponentDrift struct {
+	Component string `json:"component"`
+	// Target is the image last applied to the component.
+	Target string `json:"target"`
+	// Nodes running a pod of the component.
+	Nodes int32 `json:"nodes"`
+	// OnTarget is the number of those nodes running the target image.
+	OnTarget int32 `json:"onTarget"`
+	// DriftPercent is the share of the nodes not running the target image, e.g. "12.5".
+	DriftPercent string `json:"driftPercent"`
+}
+
+// ReconcileSummary is the outcome of a reconcile of the policy.
+type ReconcileSummary struct {
+	// ObservedGeneration is the generation of the policy the reconcile processed.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Time the reconcile started.
+	Time metav1.Time `json:"time"`
+
+	// Duration of the reconcile.
+	Duration metav1.Duration `json:"duration"`
+
+	// Created is the number of objects the reconcile created.
+	// +optional
+	Created int32 `json:"created,omitempty"`
+
+	// Updated is the number of objects the reconcile changed.
+	// +optional
+	Updated int32 `json:"updated,omitempty"`
+
+	// Unchanged is the number of objects the reconcile applied without change.
+	// +optional
+	Unchanged int32 `json:"unchanged,omitempty"`
+
+	// Errors that failed the reconcile. It is retried with backoff.
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ExportStatus is the result of an export of the policy.
+type ExportStatus struct {
+	// Time of the export.
+	Time metav1.Time `json:"time"`
+
+	// Location the bundle was written to.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Error is set when the export failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// EdgeStatus is the observed disconnected operation state.
+type EdgeStatus struct {
+	// LastConnectedTime is when no component pod was last seen failing to pull its image.
+	// +optional
+	LastConnectedTime *metav1.Time `json:"lastConnectedTime,omitempty"`
+
+	// PrePullNodes is the number of nodes that pre-pull the current images.
+	// +optional
+	PrePullNodes int32 `json:"prePullNodes,omitempty"`
+
+	// PrePulledNodes is the number of nodes that hold the current images.
+	// +optional
+	PrePulledNodes int32 `json:"prePulledNodes,omitempty"`
+}
+
+// NodeThermalStatus tracks a node above a thermal threshold.
+type NodeThermalStatus struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+
+	// Since is when the node was first seen above the threshold.
+	Since metav1.Time `json:"since"`
+
+	// Metric is the threshold metric that was exceeded.
+	Metric string `json:"metric"`
+
+	// Value is the last sample above the threshold.
+	Value string `json:"value"`
+
+	// Responded is set once the actions were taken, so they are reverted on cool down.
+	// +optional
+	Responded bool `json:"responded,omitempty"`
+}
+
+// ManifestObjectReference identifies an object applied in the policy namespace.
+type ManifestObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// ComponentStatus is the observed state of one managed component.
+type ComponentStatus struct {
+	// Name of the component, e.g. nvidia-device-plugin.
+	Name string `json:"name"`
+
+	// LastChangeTime is when the operator last created or changed the component.
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+
+	// SafeMode is set when the component crash looped shortly after an operator change.
+	// +optional
+	SafeMode bool `json:"safeMode,omitempty"`
+
+	// Image is the image last applied to the component.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// LastKnownGoodImage is the image of the last rollout that completed validation.
+	// +optional
+	LastKnownGoodImage string `json:"lastKnownGoodImage,omitempty"`
+
+	// PrePull is set while the next image is pre-pulled before the component is updated.
+	// +optional
+	PrePull *PrePullStatus `json:"prePull,omitempty"`
+
+	// RolledOutImage is the image every pod of the component runs, once a rollout completed.
+	// +optional
+	RolledOutImage string `json:"rolledOutImage,omitempty"`
+
+	// Pods reports the pod counts of the DaemonSet of the component.
+	// +optional
+	Pods *ComponentPods `json:"pods,omitempty"`
+
+	// NotReadySince is when the DaemonSet of the component stopped being ready and up to
+	// date on every node. Unset while it is.
+	// +optional
+	NotReadySince *metav1.Time `json:"notReadySince,omitempty"`
+
+	// LastRollout reports the timing of the last image rollout of the component.
+	// +optional
+	LastRollout *RolloutStatus `json:"lastRollout,omitempty"`
+
+	// BlueGreen tracks a blue/green upgrade of a device plugin.
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// DriverUpgrade tracks a workload-aware upgrade of a driver.
+	// +optional
+	DriverUpgrade *DriverUpgradeStatus `json:"driverUpgrade,omitempty"`
+
+	// UpgradeHook reports the last upgrade hook run for the component.
+	// +optional
+	UpgradeHook *UpgradeHookStatus `json:"upgradeHook,omitempty"`
+}
+
+// MetricsCertificateStatus reports the serving certificate of the exporter metrics.
+type MetricsCertificateStatus struct {
+	// Issuer of the certificate, SelfSigned or the cert-manager issuer.
+	Issuer string `json:"issuer"`
+
+	// NotAfter is when the certificate expires. Unset while cert-manager issues it.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RotationTime is when the operator last issued the self-signed certificate.
+	// +optional
+	RotationTime *metav1.Time `json:"rotationTime,omitempty"`
+}
+
+// ComponentPods reports the pod counts of the DaemonSet of a component.
+type ComponentPods struct {
+	// Desired is the number of nodes that should run the component.
+	Desired int32 `json:"desired"`
+	// Ready is the number of nodes running a ready pod of the component.
+	Ready int32 `json:"ready"`
+	// Available is the number of nodes running an available pod of the component.
+	Available int32 `json:"available"`
+	// Updated is the number of nodes running the current pod template.
+	Updated int32 `json:"updated"`
+}
+
+// UpgradeHookStatus reports an upgrade hook Job.
+type UpgradeHookStatus struct {
+	// Phase of the upgrade the hook runs in, pre-upgrade or post-upgrade.
+	Phase string `json:"phase"`
+
+	// Job running the hook in the component namespace (spec.namespace).
+	Job string `json:"job"`
+
+	// Image the component is upgraded to.
+	Image string `json:"image"`
+
+	// Result of the hook: Running, Succeeded or Failed.
+	Result string `json:"result"`
+}
+
+// BlueGreenPhase is the phase of a blue/green device plugin upgrade.
+type BlueGreenPhase string
+
+const (
+	// BlueGreenValidating waits for every node to advertise the green plugin.
+	BlueGreenValidating BlueGreenPhase = "Validating"
+	// BlueGreenSwitchingOver waits for the plugin DaemonSet to run the new image.
+	BlueGreenSwitchingOver BlueGreenPhase = "SwitchingOver"
+	// BlueGreenFailed is set when the green plugin was not validated in time. The
+	// upgrade is retried once the image changes again.
+	BlueGreenFailed BlueGreenPhase = "Failed"
+)
+
+// DriverUpgradeStatus is the progress of a workload-aware driver upgrade.
+type DriverUpgradeStatus struct {
+	// Image the driver is upgraded to.
+	Image string `json:"image"`
+
+	// StartTime is when the first outdated driver pod was found.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Upgrading lists the nodes whose driver is being replaced.
+	// +optional
+	Upgrading []string `json:"upgrading,omitempty"`
+
+	// Waiting lists the nodes held back until their accelerator pods complete.
+	// +optional
+	Waiting []string `json:"waiting,omitempty"`
+
+	// Remaining is the number of nodes still running the previous driver.
+	// +optional
+	Remaining int32 `json:"remaining,omitempty"`
+}
+
+// BlueGreenStatus tracks a blue/green upgrade of a device plugin.
+type BlueGreenStatus struct {
+	// Image of the green plugin.
+	Image string `json:"image"`
+
+	// Phase of the upgrade.
+	Phase BlueGreenPhase `json:"phase"`
+
+	// StartTime is when the green plugin was created.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// RolloutStatus is the timing of one image rollout of a component, broken down into
+// phases: pre-pull of the image, apply of the DaemonSet, until the DaemonSet is ready on
+// every node, and until the validator of the vendor is ready afterwards.
+type RolloutStatus struct {
+	// Image rolled out.
+	Image string `json:"image"`
+
+	// PreviousImage is the image the component ran before, empty for its first deployment.
+	// +optional
+	PreviousImage string `json:"previousImage,omitempty"`
+
+	// StartTime is when the pre-pull, or the apply without pre-pull, started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the rollout was validated.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// PrePull is how long the image was pre-pulled.
+	// +optional
+	PrePull *metav1.Duration `json:"prePull,omitempty"`
+
+	// Apply is how long applying the DaemonSet took.
+	// +optional
+	Apply *metav1.Duration `json:"apply,omitempty"`
+
+	// Ready is how long the DaemonSet took to be ready on every node after the apply.
+	// +optional
+	Ready *metav1.Duration `json:"ready,omitempty"`
+
+	// Validation is how long the validator took to be ready after the DaemonSet.
+	// +optional
+	Validation *metav1.Duration `json:"validation,omitempty"`
+
+	// Total is the duration of the whole rollout.
+	// +optional
+	Total *metav1.Duration `json:"total,omitempty"`
+}
+
+// PrePullStatus tracks the pre-pull of the next image of a component.
+type PrePullStatus struct {
+	// Image being pre-pulled.
+	Image string `json:"image"`
+
+	// StartTime is when the pre-pull started.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// SelfHealingStatus is the deletion trail used to rate limit and gate recreation.
+type SelfHealingStatus struct {
+	// UnexpectedDeletions counts out-of-band deletions since the last approval.
+	UnexpectedDeletions int32 `json:"unexpectedDeletions,omitempty"`
+	// LastDeletionTime is when a managed DaemonSet was last deleted out-of-band.
+	LastDeletionTime *metav1.Time `json:"lastDeletionTime,omitempty"`
+	// LastRecreationTime is when a deleted DaemonSet was last recreated.
+	LastRecreationTime *metav1.Time `json:"lastRecreationTime,omitempty"`
+	// ApprovalRequired is set once MaxUnexpectedDeletions is reached; no components
+	// are created or updated until the policy is annotated with npu.ai/approve-recreate=true.
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Nvidia Ready",type=string,JSONPath=`.status.nvidiaReady`
+// +kubebuilder:printcolumn:name="Furiosa Ready",type=string,JSONPath=`.status.furiosaReady`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // NPUClusterPolicy is the Schema for the npuclusterpolicies API.
 type NPUClusterPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// +kubebuilder:validation:XValidation:rule="(has(self.namespace) ? self.namespace : 'kube-system') == (has(oldSelf.namespace) ? oldSelf.namespace : 'kube-system')",message="namespace is immutable"
 	Spec   NPUClusterPolicySpec   `json:"spec,omitempty"`
 	Status NPUClusterPolicyStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the policy.
+func (p *NPUClusterPolicy) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+// SetConditions replaces the status conditions of the policy.
+func (p *NPUClusterPolicy) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // NPUClusterPolicyList contains a list of NPUClusterPolicy.
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NPUClusterPolicyTemplateSpec is a policy spec shared by many clusters. String fields
+// of the template may contain ${name} placeholders, which are replaced with the values
+// of an NPUPolicyParameterSet when the template is rendered.
+type NPUClusterPolicyTemplateSpec struct {
+	// Parameters declares the placeholders of the template.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+
+	// Template is the policy spec to render.
+	Template NPUClusterPolicySpec `json:"template"`
+}
+
+// TemplateParameter declares a placeholder of a template.
+type TemplateParameter struct {
+	// Name of the placeholder, referenced as ${name}.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Description tells parameter set authors what the value is for.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default is used when a parameter set has no value. Parameters without a
+	// default are required.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// NPUClusterPolicyTemplateStatus defines the observed state of NPUClusterPolicyTemplate.
+type NPUClusterPolicyTemplateStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// NPUClusterPolicyTemplate is the Schema for the npuclusterpolicytemplates API.
+type NPUClusterPolicyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUClusterPolicyTemplateSpec   `json:"spec,omitempty"`
+	Status NPUClusterPolicyTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUClusterPolicyTemplateList contains a list of NPUClusterPolicyTemplate.
+type NPUClusterPolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUClusterPolicyTemplate `json:"items"`
+}
+
+// NPUPolicyParameterSetSpec renders a template into a concrete NPUClusterPolicy in
+// the namespace of the parameter set.
+type NPUPolicyParameterSetSpec struct {
+	// TemplateRef is the name of the NPUClusterPolicyTemplate.
+	TemplateRef string `json:"templateRef"`
+
+	// PolicyName is the name of the rendered policy. Defaults to the name of the parameter set.
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
+
+	// Values of the template parameters.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+
+	// Patch is a JSON merge patch applied to the rendered spec, for variations that
+	// placeholders cannot express, e.g. toggling a vendor.
+	// +optional
+	Patch *apiextensionsv1.JSON `json:"patch,omitempty"`
+}
+
+// NPUPolicyParameterSetStatus defines the observed state of NPUPolicyParameterSet.
+type NPUPolicyParameterSetStatus struct {
+	// ObservedGeneration is the generation of the parameter set last rendered.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TemplateGeneration is the generation of the template last rendered.
+	// +optional
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// NPUPolicyParameterSet is the Schema for the npupolicyparametersets API.
+type NPUPolicyParameterSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUPolicyParameterSetSpec   `json:"spec,omitempty"`
+	Status NPUPolicyParameterSetStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the parameter set.
+func (p *NPUPolicyParameterSet) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+// SetConditions replaces the status conditions of the parameter set.
+func (p *NPUPolicyParameterSet) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// NPUPolicyParameterSetList contains a list of NPUPolicyParameterSet.
+type NPUPolicyParameterSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUPolicyParameterSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUClusterPolicyTemplate{}, &NPUClusterPolicyTemplateList{},
+		&NPUPolicyParameterSet{}, &NPUPolicyParameterSetList{})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NPUComponentCatalogSpec maps component versions to image references. Policies that
+// reference a catalog only pick versions; the images are resolved from the catalog.
+type NPUComponentCatalogSpec struct {
+	// Components lists the released versions of each component.
+	// +listType=map
+	// +listMapKey=name
+	Components []CatalogComponent `json:"components,omitempty"`
+}
+
+// CatalogComponent lists the versions of one component.
+type CatalogComponent struct {
+	// Name of the component, e.g. nvidia-device-plugin.
+	Name string `json:"name"`
+
+	// Image is the image repository of the component.
+	Image string `json:"image"`
+
+	// +listType=map
+	// +listMapKey=version
+	Versions []CatalogVersion `json:"versions"`
+}
+
+// CatalogVersion is one released version of a component.
+type CatalogVersion struct {
+	// Version as referenced by policies. It is also the image tag unless Digest is set.
+	Version string `json:"version"`
+
+	// Digest pins the image, e.g. sha256:4f5c...; it is used instead of the tag when set.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Image overrides the component image repository for this version.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// NPUComponentCatalogStatus defines the observed state of NPUComponentCatalog.
+type NPUComponentCatalogStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// NPUComponentCatalog is the Schema for the npucomponentcatalogs API.
+type NPUComponentCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUComponentCatalogSpec   `json:"spec,omitempty"`
+	Status NPUComponentCatalogStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUComponentCatalogList contains a list of NPUComponentCatalog.
+type NPUComponentCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUComponentCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUComponentCatalog{}, &NPUComponentCatalogList{})
+}
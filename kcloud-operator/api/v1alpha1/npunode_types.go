@@ -0,0 +1,287 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NPUNodeSpec holds the per-node settings.
+type NPUNodeSpec struct {
+	// DisabledDevices are taken out of rotation by the device plugin of the node, so a flaky
+	// device can be excluded without cordoning the node. Devices are named by index, e.g. "3",
+	// or by ID as listed in status.devices. On nodes with accelerators of several vendors,
+	// devices named by index are prefixed with their vendor, e.g. "furiosa:3". Pods already
+	// using a device keep it. When burn-in is enabled on the operator, devices passing it are
+	// removed from the list.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	DisabledDevices []string `json:"disabledDevices,omitempty"`
+}
+
+// RemediationStep is a step of the allocatable remediation sequence.
+type RemediationStep string
+
+const (
+	RemediationRestartPlugin RemediationStep = "RestartPlugin"
+	RemediationCheckDriver   RemediationStep = "CheckDriver"
+	RemediationReboot        RemediationStep = "Reboot"
+	RemediationExhausted     RemediationStep = "Exhausted"
+)
+
+// RemediationState is the remediation in progress on a node.
+type RemediationState struct {
+	// Resource whose allocatable dropped to zero, e.g. nvidia.com/gpu.
+	Resource string `json:"resource"`
+	// Step last taken.
+	Step RemediationStep `json:"step"`
+	// StartedTime is when the drop was detected.
+	StartedTime metav1.Time `json:"startedTime"`
+	// StepTime is when the last step was taken.
+	StepTime metav1.Time `json:"stepTime"`
+}
+
+// RemediationRecord is one entry of the remediation history.
+type RemediationRecord struct {
+	Time     metav1.Time     `json:"time"`
+	Resource string          `json:"resource"`
+	Step     RemediationStep `json:"step,omitempty"`
+	// Result is Recovered, Deferred, Failed or empty while the step is in progress.
+	// +optional
+	Result  string `json:"result,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ExcludedDevice is a device taken out of rotation through spec.disabledDevices.
+type ExcludedDevice struct {
+	// Device as named in spec.disabledDevices.
+	Device string `json:"device"`
+	// ExcludedTime is when the device was found disabled.
+	ExcludedTime metav1.Time `json:"excludedTime"`
+	// BurnInJob is the burn-in Job running against the device, if any.
+	// +optional
+	BurnInJob string `json:"burnInJob,omitempty"`
+	// LastBurnInTime is when the last burn-in of the device started.
+	// +optional
+	LastBurnInTime *metav1.Time `json:"lastBurnInTime,omitempty"`
+}
+
+// BurnInRecord is one entry of the burn-in history.
+type BurnInRecord struct {
+	Time   metav1.Time `json:"time"`
+	Device string      `json:"device"`
+	Job    string      `json:"job,omitempty"`
+	// Result is Passed when the device was re-enabled, or Failed.
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+// DiscoveredDevice is an accelerator found on the node by the node agent.
+type DiscoveredDevice struct {
+	// ID of the device, e.g. its PCI address or UUID.
+	ID string `json:"id"`
+	// Vendor of the device, e.g. nvidia.
+	Vendor string `json:"vendor"`
+	// Model of the device as named by the discovery backend.
+	// +optional
+	Model string `json:"model,omitempty"`
+	// NUMANode the device is attached to, if known.
+	// +optional
+	NUMANode *int32 `json:"numaNode,omitempty"`
+}
+
+// VendorSummary summarizes the accelerators of one vendor on a node.
+type VendorSummary struct {
+	// Vendor of the accelerators, e.g. nvidia.
+	Vendor string `json:"vendor"`
+	// Model of the accelerators, as reported by the feature discovery labels of the node or
+	// found by the node agent.
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Count is the number of accelerators of the vendor.
+	// +optional
+	Count int64 `json:"count,omitempty"`
+	// DriverVersion of the accelerators of the vendor.
+	// +optional
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// FirmwareVersion of the accelerators of the vendor, as reported by the
+	// npu.ai/firmware-version.<vendor> label of the node.
+	// +optional
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+}
+
+// NodeHealth summarizes whether the accelerators of a node are usable.
+// +kubebuilder:validation:Enum=Healthy;Degraded;Remediating
+type NodeHealth string
+
+const (
+	// NodeHealthy means every accelerator is allocatable.
+	NodeHealthy NodeHealth = "Healthy"
+	// NodeDegraded means some accelerators are not allocatable.
+	NodeDegraded NodeHealth = "Degraded"
+	// NodeRemediating means the operator is remediating the node.
+	NodeRemediating NodeHealth = "Remediating"
+)
+
+// NPUNodeStatus defines the observed state of NPUNode.
+type NPUNodeStatus struct {
+	// Model of the accelerators, as reported by the feature discovery labels of the node. On
+	// nodes with accelerators of several vendors, the model of the first one in vendors.
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// Count is the number of accelerators of the node, of every vendor.
+	// +optional
+	Count int64 `json:"count,omitempty"`
+
+	// Allocated is the number of accelerators requested by the pods running on the node.
+	// +optional
+	Allocated int64 `json:"allocated,omitempty"`
+
+	// DriverVersion of the accelerators, as reported by the feature discovery labels of the node.
+	// On nodes with accelerators of several vendors, the version of the first one in vendors.
+	// +optional
+	DriverVersion string `json:"driverVersion,omitempty"`
+
+	// Vendors summarizes the accelerators of each vendor of the node, sorted by vendor. A node
+	// may carry accelerators of several vendors, e.g. a workstation with an NVIDIA GPU and a
+	// Furiosa card, and then runs the components of both.
+	// +listType=map
+	// +listMapKey=vendor
+	// +optional
+	Vendors []VendorSummary `json:"vendors,omitempty"`
+
+	// Health of the accelerators.
+	// +optional
+	Health NodeHealth `json:"health,omitempty"`
+
+	// Unmanaged is set while the node carries the npu.ai/unmanaged=true annotation, which
+	// excludes it from every action of the operator.
+	// +optional
+	Unmanaged bool `json:"unmanaged,omitempty"`
+
+	// Rack of the node, as read from the rack label configured on the operator.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+
+	// PowerZone of the node, as read from the power zone label configured on the operator.
+	// +optional
+	PowerZone string `json:"powerZone,omitempty"`
+
+	// Devices are the accelerators found by the node agent.
+	// +optional
+	Devices []DiscoveredDevice `json:"devices,omitempty"`
+
+	// DiscoveredBy is the discovery backend that found the devices: pci, smi or cloud.
+	// +optional
+	DiscoveredBy string `json:"discoveredBy,omitempty"`
+
+	// Capacity of the accelerator resources of the node.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// Allocatable accelerator resources of the node.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+
+	// Remediation is the remediation in progress, if any.
+	// +optional
+	Remediation *RemediationState `json:"remediation,omitempty"`
+
+	// RemediationHistory lists the most recent remediation steps, oldest first.
+	// +optional
+	RemediationHistory []RemediationRecord `json:"remediationHistory,omitempty"`
+
+	// ExcludedDevices are the devices of spec.disabledDevices with their burn-in progress.
+	// +optional
+	ExcludedDevices []ExcludedDevice `json:"excludedDevices,omitempty"`
+
+	// BurnInHistory lists the most recent burn-ins of excluded devices, oldest first.
+	// +optional
+	BurnInHistory []BurnInRecord `json:"burnInHistory,omitempty"`
+
+	// LastRevalidationTime is when validation of the node was last requested through
+	// the npu.ai/revalidate annotation.
+	// +optional
+	LastRevalidationTime *metav1.Time `json:"lastRevalidationTime,omitempty"`
+
+	// NodeRemovedTime is when the node was found removed from the cluster. The NPUNode is
+	// deleted once the retention period of the operator expired.
+	// +optional
+	NodeRemovedTime *metav1.Time `json:"nodeRemovedTime,omitempty"`
+
+	// Conditions of the node. Ready is True while every accelerator is allocatable, Degraded
+	// and Remediating detail why it is not.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.status.model`
+// +kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.status.count`
+// +kubebuilder:printcolumn:name="Allocated",type=integer,JSONPath=`.status.allocated`
+// +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.status.driverVersion`
+// +kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.health`
+// +kubebuilder:printcolumn:name="Unmanaged",type=boolean,JSONPath=`.status.unmanaged`,priority=1
+// +kubebuilder:printcolumn:name="Rack",type=string,JSONPath=`.status.rack`,priority=1
+// +kubebuilder:printcolumn:name="Power Zone",type=string,JSONPath=`.status.powerZone`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:selectablefield:JSONPath=`.status.model`
+// +kubebuilder:selectablefield:JSONPath=`.status.health`
+
+// NPUNode is the Schema for the npunodes API. The operator keeps one NPUNode per
+// accelerator node, named after the node, and labels it with npu.ai/vendor and
+// npu.ai/model, so e.g. kubectl get npunodes -l npu.ai/model=A100 lists the A100 nodes.
+// Each vendor of the node is also labeled as npu.ai/vendor.<vendor>=true, which selects
+// the nodes carrying accelerators of several vendors as well.
+// Nodes in a failure domain are also labeled with npu.ai/rack and npu.ai/power-zone.
+type NPUNode struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUNodeSpec   `json:"spec,omitempty"`
+	Status NPUNodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUNodeList contains a list of NPUNode.
+type NPUNodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUNode `json:"items"`
+}
+
+// GetConditions returns the status conditions of the node.
+func (n *NPUNode) GetConditions() []metav1.Condition {
+	return n.Status.Conditions
+}
+
+// SetConditions replaces the status conditions of the node.
+func (n *NPUNode) SetConditions(conditions []metav1.Condition) {
+	n.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUNode{}, &NPUNodeList{})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NPUQuotaGrantSpec defines the extra accelerator quota granted to the namespace of the grant.
+type NPUQuotaGrantSpec struct {
+	// Resource granted, e.g. nvidia.com/gpu. The grant raises requests.<resource> of the quota.
+	Resource string `json:"resource"`
+
+	// Amount of extra quota.
+	Amount resource.Quantity `json:"amount"`
+
+	// Duration of the grant from its creation. The quota reverts once it elapsed.
+	Duration metav1.Duration `json:"duration"`
+
+	// QuotaName is the ResourceQuota raised. Defaults to the ResourceQuota of the namespace
+	// that limits the resource.
+	// +optional
+	QuotaName string `json:"quotaName,omitempty"`
+
+	// Reason of the grant, recorded in its events.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// QuotaGrantPhase is the phase of an NPUQuotaGrant.
+// +kubebuilder:validation:Enum=Active;Expired;Unapplied
+type QuotaGrantPhase string
+
+const (
+	// QuotaGrantActive means the extra quota is applied.
+	QuotaGrantActive QuotaGrantPhase = "Active"
+	// QuotaGrantExpired means the grant elapsed and the quota reverted.
+	QuotaGrantExpired QuotaGrantPhase = "Expired"
+	// QuotaGrantUnapplied means no ResourceQuota of the namespace limits the resource.
+	QuotaGrantUnapplied QuotaGrantPhase = "Unapplied"
+)
+
+// NPUQuotaGrantStatus reports the lifecycle of the grant.
+type NPUQuotaGrantStatus struct {
+	// Phase of the grant.
+	// +optional
+	Phase QuotaGrantPhase `json:"phase,omitempty"`
+
+	// QuotaName is the ResourceQuota the grant applies to.
+	// +optional
+	QuotaName string `json:"quotaName,omitempty"`
+
+	// ExpiryTime is when the quota reverts.
+	// +optional
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Resource",type=string,JSONPath=`.spec.resource`
+// +kubebuilder:printcolumn:name="Amount",type=string,JSONPath=`.spec.amount`
+// +kubebuilder:printcolumn:name="Quota",type=string,JSONPath=`.status.quotaName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiryTime`
+
+// NPUQuotaGrant is the Schema for the npuquotagrants API. It grants its namespace extra
+// accelerator quota for a limited time: the operator raises the ResourceQuota of the
+// namespace by the amount and lowers it again once the grant expires or is deleted, so
+// one-off requests do not turn into permanent quota. Expired grants are kept as a record.
+type NPUQuotaGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUQuotaGrantSpec   `json:"spec,omitempty"`
+	Status NPUQuotaGrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUQuotaGrantList contains a list of NPUQuotaGrant.
+type NPUQuotaGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUQuotaGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUQuotaGrant{}, &NPUQuotaGrantList{})
+}
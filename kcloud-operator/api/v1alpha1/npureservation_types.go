@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NPUReservationSpec defines the accelerators held for a team.
+type NPUReservationSpec struct {
+	// Resource reserved, e.g. nvidia.com/gpu.
+	Resource string `json:"resource"`
+
+	// Count of accelerators reserved.
+	// +kubebuilder:validation:Minimum=1
+	Count int64 `json:"count"`
+
+	// Model restricts the pool to nodes whose NPUNode carries this npu.ai/model label, e.g. A100.
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// NodeSelector restricts the pool to nodes with these labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Namespaces the accelerators are reserved for. Pods of other namespaces are not admitted
+	// when they would leave fewer free accelerators in the pool than are still held, unless the
+	// scheduler can make room by preempting pods of lower priority of other namespaces.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// Start of the reservation, e.g. ahead of a launch. Defaults to its creation.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End of the reservation. Without it the reservation holds until it is deleted.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// ReservationPhase is the phase of an NPUReservation.
+// +kubebuilder:validation:Enum=Pending;Active;Expired
+type ReservationPhase string
+
+const (
+	// ReservationPending means the reservation has not started yet.
+	ReservationPending ReservationPhase = "Pending"
+	// ReservationActive means the reservation is enforced.
+	ReservationActive ReservationPhase = "Active"
+	// ReservationExpired means the reservation ended.
+	ReservationExpired ReservationPhase = "Expired"
+)
+
+// FailureDomainCapacity is the part of a reservation pool in one rack and power zone.
+type FailureDomainCapacity struct {
+	// Rack of the nodes.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// PowerZone of the nodes.
+	// +optional
+	PowerZone string `json:"powerZone,omitempty"`
+	// Nodes of the pool in the failure domain.
+	Nodes int32 `json:"nodes"`
+	// Capacity is the number of allocatable accelerators of the nodes.
+	Capacity int64 `json:"capacity"`
+	// Allocated is the number of accelerators of the nodes requested by running pods.
+	Allocated int64 `json:"allocated"`
+}
+
+// NPUReservationStatus reports the capacity of the pool and how much of the reservation is held.
+type NPUReservationStatus struct {
+	// Phase of the reservation.
+	// +optional
+	Phase ReservationPhase `json:"phase,omitempty"`
+
+	// Nodes in the pool.
+	// +optional
+	Nodes int32 `json:"nodes,omitempty"`
+
+	// Capacity is the number of allocatable accelerators in the pool.
+	// +optional
+	Capacity int64 `json:"capacity,omitempty"`
+
+	// Allocated is the number of accelerators of the pool requested by running pods.
+	// +optional
+	Allocated int64 `json:"allocated,omitempty"`
+
+	// Used is the number of accelerators of the pool requested by pods of the reserved namespaces.
+	// +optional
+	Used int64 `json:"used,omitempty"`
+
+	// Held is the number of reserved accelerators not used yet, and kept free from other namespaces.
+	// +optional
+	Held int64 `json:"held,omitempty"`
+
+	// FailureDomains breaks the pool down by rack and power zone. Nodes without failure
+	// domain labels are counted in an entry with both empty.
+	// +optional
+	FailureDomains []FailureDomainCapacity `json:"failureDomains,omitempty"`
+
+	// Conditions of the reservation.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Resource",type=string,JSONPath=`.spec.resource`
+// +kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.spec.model`
+// +kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.spec.count`
+// +kubebuilder:printcolumn:name="Used",type=integer,JSONPath=`.status.used`
+// +kubebuilder:printcolumn:name="Held",type=integer,JSONPath=`.status.held`
+// +kubebuilder:printcolumn:name="Capacity",type=integer,JSONPath=`.status.capacity`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="End",type=date,JSONPath=`.spec.end`
+
+// NPUReservation is the Schema for the npureservations API. It reserves accelerators of a
+// pool for the namespaces of a team, e.g. ahead of a launch, so batch jobs of other
+// namespaces cannot starve it. The reservation is enforced by the pod admission webhook.
+type NPUReservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUReservationSpec   `json:"spec,omitempty"`
+	Status NPUReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUReservationList contains a list of NPUReservation.
+type NPUReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUReservation `json:"items"`
+}
+
+// GetConditions returns the status conditions of the reservation.
+func (r *NPUReservation) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+// SetConditions replaces the status conditions of the reservation.
+func (r *NPUReservation) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUReservation{}, &NPUReservationList{})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FuriosaResource is the resource of a whole Furiosa card.
+const FuriosaResource = "furiosa.ai/npu"
+
+// FuriosaPartitionResource returns the resource of a Furiosa partition of the given PEs.
+func FuriosaPartitionResource(pes int32) string {
+	return fmt.Sprintf("%s-%dpe", FuriosaResource, pes)
+}
+
+// FuriosaWorkload requests Furiosa processing elements (PEs) rather than whole cards.
+type FuriosaWorkload struct {
+	// PEs requested by each accelerator container of the pod.
+	// +kubebuilder:validation:Minimum=1
+	PEs int32 `json:"pes"`
+}
+
+// WorkloadPlacement constrains where the pods of a group run relative to each other, as
+// distributed training commonly needs.
+type WorkloadPlacement struct {
+	// SameModel runs the pods of a group on nodes of a single accelerator model, so no
+	// replica is held back by a slower device.
+	// +optional
+	SameModel bool `json:"sameModel,omitempty"`
+
+	// SpreadAcross is the node label of the failure domains the pods of a group are spread
+	// evenly across, e.g. topology.kubernetes.io/zone.
+	// +optional
+	SpreadAcross string `json:"spreadAcross,omitempty"`
+
+	// GroupLabel is the pod label whose value groups the pods, e.g. job-name. Without it
+	// the pods of the profile in its namespace form a single group.
+	// +optional
+	GroupLabel string `json:"groupLabel,omitempty"`
+}
+
+// ProfileReference names the parent of a profile.
+type ProfileReference struct {
+	// Name of the parent profile.
+	Name string `json:"name"`
+	// Namespace of the parent profile, e.g. of the base profiles provided by the platform team.
+	// Defaults to the namespace of the profile.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NPUWorkloadProfileSpec defines the accelerators requested by the pods of the profile.
+// +kubebuilder:validation:XValidation:rule="!has(self.placement) || !self.placement.sameModel || has(self.furiosa) || has(self.parent)",message="placement.sameModel requires accelerator requests"
+type NPUWorkloadProfileSpec struct {
+	// Parent is the profile this one inherits from. Fields set on the profile override those
+	// of its parent: furiosa as a whole, and each field of placement on its own, so a profile
+	// only holds its differences from a base profile. sameModel can only be turned on.
+	// Parents may have parents of their own, up to 8 levels.
+	// +optional
+	Parent *ProfileReference `json:"parent,omitempty"`
+
+	// Furiosa requests Furiosa PEs, which are mapped to the partitions advertised in the cluster.
+	// +optional
+	Furiosa *FuriosaWorkload `json:"furiosa,omitempty"`
+
+	// Placement of the pods of the profile relative to each other.
+	// +optional
+	Placement *WorkloadPlacement `json:"placement,omitempty"`
+}
+
+// NPUWorkloadProfileStatus reports how the requests of the profile are mapped to resources.
+type NPUWorkloadProfileStatus struct {
+	// Resources requested by each accelerator container of the pods of the profile.
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+
+	// Affinity rendered from the placement, added to the pods of the profile.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints rendered from the placement, added to the pods of the profile.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// InheritedFrom lists the parents the requests are inherited from as namespace/name,
+	// nearest first.
+	// +optional
+	InheritedFrom []string `json:"inheritedFrom,omitempty"`
+
+	// Conditions of the profile. Resolved is True once the requests map to advertised resources.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Resolved",type=string,JSONPath=`.status.conditions[?(@.type=="Resolved")].status`
+// +kubebuilder:printcolumn:name="Parent",type=string,JSONPath=`.spec.parent.name`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NPUWorkloadProfile is the Schema for the npuworkloadprofiles API. Pods of its namespace
+// labeled npu.ai/workload-profile with its name request the accelerators of the profile:
+// the operator resolves fractional requests, e.g. 2 Furiosa PEs, to the partitions the
+// device plugins advertise and the pod webhook sets them on the accelerator containers,
+// along with the affinity and topology spread rendered from the placement of the profile.
+type NPUWorkloadProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NPUWorkloadProfileSpec   `json:"spec,omitempty"`
+	Status NPUWorkloadProfileStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUWorkloadProfileList contains a list of NPUWorkloadProfile.
+type NPUWorkloadProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUWorkloadProfile `json:"items"`
+}
+
+// GetConditions returns the status conditions of the profile.
+func (p *NPUWorkloadProfile) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+// SetConditions replaces the status conditions of the profile.
+func (p *NPUWorkloadProfile) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUWorkloadProfile{}, &NPUWorkloadProfileList{})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Annotations written by the node agent on pods that were allocated accelerators.
+// The values describe what the kubelet actually assigned, so cost tools such as
+// OpenCost can aggregate by them (e.g. aggregate=annotation:npu.ai/accelerator-model).
+const (
+	// AcceleratorResourceAnnotation is the extended resource name, e.g. nvidia.com/gpu.
+	AcceleratorResourceAnnotation = "npu.ai/accelerator-resource"
+	// AcceleratorModelAnnotation is the device model reported by the node, e.g. NVIDIA-A100-SXM4-80GB.
+	AcceleratorModelAnnotation = "npu.ai/accelerator-model"
+	// AcceleratorCountAnnotation is the number of devices allocated to the pod.
+	AcceleratorCountAnnotation = "npu.ai/accelerator-count"
+	// AcceleratorDevicesAnnotation is the comma separated list of allocated device IDs.
+	AcceleratorDevicesAnnotation = "npu.ai/accelerator-devices"
+)
+
+// Labels that opt workloads and nodes into operator behaviour.
+const (
+	// ManagedDisruptionLabel marks nodes of pools that are drained by managed maintenance.
+	ManagedDisruptionLabel = "npu.ai/managed-disruption"
+	// AutoPDBLabel on a workload or its namespace lets the PDB advisor create a
+	// conservative PodDisruptionBudget instead of only warning about a missing one.
+	AutoPDBLabel = "npu.ai/auto-pdb"
+	// WorkloadProfileLabel on a pod names the NPUWorkloadProfile of its namespace whose
+	// accelerator requests the pod webhook sets on the pod.
+	WorkloadProfileLabel = "npu.ai/workload-profile"
+)
+
+// Labels stamped on the objects the operator manages, pointing back at the owning policy.
+const (
+	PolicyNameLabel      = "npu.ai/policy-name"
+	PolicyNamespaceLabel = "npu.ai/policy-namespace"
+)
+
+// ApproveRecreateAnnotation on a policy resumes self-healing after it was blocked by
+// repeated unexpected deletions. The operator removes it once processed.
+const ApproveRecreateAnnotation = "npu.ai/approve-recreate"
+
+// AcknowledgeSafeModeAnnotation on a policy releases components frozen by safe mode.
+// The value is a component name, or "true" to release all of them.
+const AcknowledgeSafeModeAnnotation = "npu.ai/acknowledge-safe-mode"
+
+// PolicyTemplateLabel names the NPUClusterPolicyTemplate a policy was rendered from.
+const PolicyTemplateLabel = "npu.ai/policy-template"
+
+// RevalidateAnnotation set to "true" on a Node or its NPUNode restarts the validators on
+// the node, so its accelerators are validated again. The annotation is removed once handled.
+const RevalidateAnnotation = "npu.ai/revalidate"
+
+// BurnInAnnotation set to "true" on an NPUNode runs the burn-in Job against its disabled
+// devices now, re-enabling those that pass. The annotation is removed once handled.
+const BurnInAnnotation = "npu.ai/burn-in"
+
+// CheckpointVerifiedAnnotation on a Node records the boot ID for which the node agent
+// last verified the kubelet device manager checkpoint.
+const CheckpointVerifiedAnnotation = "npu.ai/checkpoint-verified-boot-id"
+
+// RebootRequiredAnnotation on a Node asks the reboot daemon of the cluster (e.g. kured
+// with a sentinel command checking it) to reboot the node.
+const RebootRequiredAnnotation = "npu.ai/reboot-required"
+
+// PowerLimitAnnotation on a Node requests a per-device power limit in watts. It is set by
+// the thermal PowerCap action and applied by the vendor driver component.
+const PowerLimitAnnotation = "npu.ai/power-limit-watts"
+
+// ThermalCordonAnnotation on a Node records that the operator cordoned it because of
+// thermal throttling, so only those nodes are uncordoned once they cool down.
+const ThermalCordonAnnotation = "npu.ai/thermal-cordoned"
+
+// ThermalPressureTaint is the PreferNoSchedule taint the operator puts on nodes above a
+// thermal threshold while the accelerator-aware scheduler scores by temperature.
+const ThermalPressureTaint = "npu.ai/thermal-pressure"
+
+// FirmwareVersionLabelPrefix on a Node is followed by a vendor and gives the firmware version
+// of its accelerators, e.g. npu.ai/firmware-version.furiosa=1.9.2. It is set by whatever
+// reports firmware on the node, e.g. a feature discovery hook, and counted in the inventory
+// of the policies.
+const FirmwareVersionLabelPrefix = "npu.ai/firmware-version."
+
+// AlertsSilencedLabel on a Node marks it disrupted by the operator, e.g. rebooted by
+// remediation or upgrading its driver, for alerting rules to suppress its alerts.
+const AlertsSilencedLabel = "npu.ai/alerts-silenced"
+
+// DevicePreferencesLabel on a Node names the device preference pool it belongs to.
+const DevicePreferencesLabel = "npu.ai/device-preferences"
+
+// Labels the operator puts on NPUNodes to select them by accelerator.
+const (
+	// VendorLabel is the accelerator vendor of the node, e.g. nvidia, or MultiVendor on nodes
+	// with accelerators of several vendors.
+	VendorLabel = "npu.ai/vendor"
+	// VendorLabelPrefix is followed by each vendor of the node, e.g. npu.ai/vendor.nvidia=true.
+	VendorLabelPrefix = "npu.ai/vendor."
+	// ModelLabel is the short accelerator model of the node, e.g. A100.
+	ModelLabel = "npu.ai/model"
+	// RackLabel is the rack of the node. It is also the default node label the rack is read from.
+	RackLabel = "npu.ai/rack"
+	// PowerZoneLabel is the power zone of the node. It is also the default node label the
+	// power zone is read from.
+	PowerZoneLabel = "npu.ai/power-zone"
+)
+
+// MultiVendor is the VendorLabel of nodes with accelerators of several vendors.
+const MultiVendor = "multi"
+
+// DiscoveryLabel on a Node lists the device discovery backends of the node agent in order
+// of preference, e.g. "smi,cloud" for pools without /sys access. It overrides the default
+// order of the agent, so it is typically set per pool.
+const DiscoveryLabel = "npu.ai/discovery"
+
+// Node conditions the node agent mirrors the accelerator state of a node into, so tooling
+// that only looks at Node conditions sees accelerator health.
+const (
+	// NodeDriverReady is True while the accelerator drivers of the node run.
+	NodeDriverReady = "NPUDriverReady"
+	// NodePluginRegistered is True while the device plugins registered devices with the kubelet.
+	NodePluginRegistered = "NPUPluginRegistered"
+	// NodeDeviceHealthy is True while every accelerator of the node is allocatable.
+	NodeDeviceHealthy = "NPUDeviceHealthy"
+)
+
+// GrantedQuotaAnnotation on a ResourceQuota records the extra quota applied by active
+// NPUQuotaGrants as JSON, e.g. {"requests.nvidia.com/gpu":"4"}, so it can be reverted.
+const GrantedQuotaAnnotation = "npu.ai/granted-quota"
+
+// TierLabel on an NPUClusterPolicy or a Node set to "production" reconciles it before
+// others, e.g. when every policy and node is queued after an operator restart.
+const TierLabel = "npu.ai/tier"
+
+// TierProduction is the value of TierLabel reconciled first.
+const TierProduction = "production"
+
+// ExportAnnotation on a policy writes a bundle of the policy, its status and the objects it
+// manages to the export location of the operator. The operator removes it once processed.
+const ExportAnnotation = "npu.ai/export"
+
+// ConfigHashAnnotation on the pod template of a component is the hash of the operator-rendered
+// ConfigMaps it mounts, so a configuration change rolls the pods of the DaemonSet.
+const ConfigHashAnnotation = "npu.ai/config-hash"
+
+// PolicyFinalizer keeps an NPUClusterPolicy until the operator deleted its components,
+// which live in kube-system and cannot be garbage-collected through owner references.
+const PolicyFinalizer = "npu.ai/teardown"
+
+// MigratedFieldsAnnotation on a policy or policy template lists the deprecated spec fields the
+// operator moved to their replacement, comma-separated, e.g. "nvidia.devicePluginImage".
+const MigratedFieldsAnnotation = "npu.ai/migrated-fields"
+
+// UnmanagedAnnotation on a Node set to "true" excludes it from every action of the operator,
+// e.g. for lab machines or nodes under manual vendor debugging: the component pods are not
+// scheduled on it, and it is neither labeled, tainted, cordoned, revalidated nor remediated.
+// It stays listed in the NPUNodes and the fleet inventory, marked as unmanaged.
+const UnmanagedAnnotation = "npu.ai/unmanaged"
@@ -21,126 +21,2509 @@ limitations under the License.
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoMIGSpec) DeepCopyInto(out *AutoMIGSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Layouts != nil {
+		in, out := &in.Layouts, &out.Layouts
+		*out = make([]MIGLayout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoMIGSpec.
+func (in *AutoMIGSpec) DeepCopy() *AutoMIGSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoMIGSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenStatus) DeepCopyInto(out *BlueGreenStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStatus.
+func (in *BlueGreenStatus) DeepCopy() *BlueGreenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnInRecord) DeepCopyInto(out *BurnInRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnInRecord.
+func (in *BurnInRecord) DeepCopy() *BurnInRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnInRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogComponent) DeepCopyInto(out *CatalogComponent) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]CatalogVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogComponent.
+func (in *CatalogComponent) DeepCopy() *CatalogComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogVersion) DeepCopyInto(out *CatalogVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogVersion.
+func (in *CatalogVersion) DeepCopy() *CatalogVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerRef) DeepCopyInto(out *CertificateIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerRef.
+func (in *CertificateIssuerRef) DeepCopy() *CertificateIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAPISpec) DeepCopyInto(out *ClusterAPISpec) {
+	*out = *in
+	if in.MachineDeploymentSelector != nil {
+		in, out := &in.MachineDeploymentSelector, &out.MachineDeploymentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAPISpec.
+func (in *ClusterAPISpec) DeepCopy() *ClusterAPISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAPISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDrift) DeepCopyInto(out *ComponentDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDrift.
+func (in *ComponentDrift) DeepCopy() *ComponentDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentPods) DeepCopyInto(out *ComponentPods) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPods.
+func (in *ComponentPods) DeepCopy() *ComponentPods {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(UpgradeHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PrePull != nil {
+		in, out := &in.PrePull, &out.PrePull
+		*out = new(PrePullStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(ComponentPods)
+		**out = **in
+	}
+	if in.NotReadySince != nil {
+		in, out := &in.NotReadySince, &out.NotReadySince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRollout != nil {
+		in, out := &in.LastRollout, &out.LastRollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriverUpgrade != nil {
+		in, out := &in.DriverUpgrade, &out.DriverUpgrade
+		*out = new(DriverUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeHook != nil {
+		in, out := &in.UpgradeHook, &out.UpgradeHook
+		*out = new(UpgradeHookStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevicePreferencePool) DeepCopyInto(out *DevicePreferencePool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PreferSameNUMANode != nil {
+		in, out := &in.PreferSameNUMANode, &out.PreferSameNUMANode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreferNVLink != nil {
+		in, out := &in.PreferNVLink, &out.PreferNVLink
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePreferencePool.
+func (in *DevicePreferencePool) DeepCopy() *DevicePreferencePool {
+	if in == nil {
+		return nil
+	}
+	out := new(DevicePreferencePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveredDevice) DeepCopyInto(out *DiscoveredDevice) {
+	*out = *in
+	if in.NUMANode != nil {
+		in, out := &in.NUMANode, &out.NUMANode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDevice.
+func (in *DiscoveredDevice) DeepCopy() *DiscoveredDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeSpec) DeepCopyInto(out *DriverUpgradeSpec) {
+	*out = *in
+	if in.MaxParallel != nil {
+		in, out := &in.MaxParallel, &out.MaxParallel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WaitForCompletion != nil {
+		in, out := &in.WaitForCompletion, &out.WaitForCompletion
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeSpec.
+func (in *DriverUpgradeSpec) DeepCopy() *DriverUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeStatus) DeepCopyInto(out *DriverUpgradeStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Upgrading != nil {
+		in, out := &in.Upgrading, &out.Upgrading
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeStatus.
+func (in *DriverUpgradeStatus) DeepCopy() *DriverUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EdgeSpec) DeepCopyInto(out *EdgeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeSpec.
+func (in *EdgeSpec) DeepCopy() *EdgeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EdgeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EdgeStatus) DeepCopyInto(out *EdgeStatus) {
+	*out = *in
+	if in.LastConnectedTime != nil {
+		in, out := &in.LastConnectedTime, &out.LastConnectedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeStatus.
+func (in *EdgeStatus) DeepCopy() *EdgeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EdgeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedDevice) DeepCopyInto(out *ExcludedDevice) {
+	*out = *in
+	in.ExcludedTime.DeepCopyInto(&out.ExcludedTime)
+	if in.LastBurnInTime != nil {
+		in, out := &in.LastBurnInTime, &out.LastBurnInTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedDevice.
+func (in *ExcludedDevice) DeepCopy() *ExcludedDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportStatus) DeepCopyInto(out *ExportStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportStatus.
+func (in *ExportStatus) DeepCopy() *ExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraManifestRef) DeepCopyInto(out *ExtraManifestRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraManifestRef.
+func (in *ExtraManifestRef) DeepCopy() *ExtraManifestRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraManifestRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainCapacity) DeepCopyInto(out *FailureDomainCapacity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainCapacity.
+func (in *FailureDomainCapacity) DeepCopy() *FailureDomainCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetInventory) DeepCopyInto(out *FleetInventory) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]VersionCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]ComponentDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unmanaged != nil {
+		in, out := &in.Unmanaged, &out.Unmanaged
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetInventory.
+func (in *FleetInventory) DeepCopy() *FleetInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FuriosaPartitioning) DeepCopyInto(out *FuriosaPartitioning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaPartitioning.
+func (in *FuriosaPartitioning) DeepCopy() *FuriosaPartitioning {
+	if in == nil {
+		return nil
+	}
+	out := new(FuriosaPartitioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FuriosaPluginConfig) DeepCopyInto(out *FuriosaPluginConfig) {
+	*out = *in
+	if in.DisabledDevices != nil {
+		in, out := &in.DisabledDevices, &out.DisabledDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaPluginConfig.
+func (in *FuriosaPluginConfig) DeepCopy() *FuriosaPluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FuriosaPluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FuriosaSpec) DeepCopyInto(out *FuriosaSpec) {
 	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(FuriosaPartitioning)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(FuriosaPluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.VendorComponents.DeepCopyInto(&out.VendorComponents)
+	in.VendorPodSpec.DeepCopyInto(&out.VendorPodSpec)
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(ClusterAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaSpec.
+func (in *FuriosaSpec) DeepCopy() *FuriosaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FuriosaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FuriosaWorkload) DeepCopyInto(out *FuriosaWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaWorkload.
+func (in *FuriosaWorkload) DeepCopy() *FuriosaWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(FuriosaWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatchOperation) DeepCopyInto(out *JSONPatchOperation) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatchOperation.
+func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatchOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGLayout) DeepCopyInto(out *MIGLayout) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[v1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MinNodes != nil {
+		in, out := &in.MinNodes, &out.MinNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGLayout.
+func (in *MIGLayout) DeepCopy() *MIGLayout {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGLayout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGRepartition) DeepCopyInto(out *MIGRepartition) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGRepartition.
+func (in *MIGRepartition) DeepCopy() *MIGRepartition {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGRepartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestObjectReference) DeepCopyInto(out *ManifestObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestObjectReference.
+func (in *ManifestObjectReference) DeepCopy() *ManifestObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricThreshold) DeepCopyInto(out *MetricThreshold) {
+	*out = *in
+	out.Value = in.Value.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricThreshold.
+func (in *MetricThreshold) DeepCopy() *MetricThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsCertificateStatus) DeepCopyInto(out *MetricsCertificateStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RotationTime != nil {
+		in, out := &in.RotationTime, &out.RotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsCertificateStatus.
+func (in *MetricsCertificateStatus) DeepCopy() *MetricsCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsTLSSpec) DeepCopyInto(out *MetricsTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateIssuerRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsTLSSpec.
+func (in *MetricsTLSSpec) DeepCopy() *MetricsTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicy) DeepCopyInto(out *NPUClusterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicy.
+func (in *NPUClusterPolicy) DeepCopy() *NPUClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyList) DeepCopyInto(out *NPUClusterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUClusterPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyList.
+func (in *NPUClusterPolicyList) DeepCopy() *NPUClusterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicySpec) DeepCopyInto(out *NPUClusterPolicySpec) {
+	*out = *in
+	in.Nvidia.DeepCopyInto(&out.Nvidia)
+	in.Furiosa.DeepCopyInto(&out.Furiosa)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.SelfHealing.DeepCopyInto(&out.SelfHealing)
+	in.SafeMode.DeepCopyInto(&out.SafeMode)
+	if in.ExtraManifests != nil {
+		in, out := &in.ExtraManifests, &out.ExtraManifests
+		*out = make([]ExtraManifestRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thermal != nil {
+		in, out := &in.Thermal, &out.Thermal
+		*out = new(ThermalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Edge != nil {
+		in, out := &in.Edge, &out.Edge
+		*out = new(EdgeSpec)
+		**out = **in
+	}
+	if in.PrePull != nil {
+		in, out := &in.PrePull, &out.PrePull
+		*out = new(PrePullSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginUpgrade != nil {
+		in, out := &in.PluginUpgrade, &out.PluginUpgrade
+		*out = new(PluginUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriverUpgrade != nil {
+		in, out := &in.DriverUpgrade, &out.DriverUpgrade
+		*out = new(DriverUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SchedulerSpec)
+		**out = **in
+	}
+	if in.DevicePreferences != nil {
+		in, out := &in.DevicePreferences, &out.DevicePreferences
+		*out = make([]DevicePreferencePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]ObjectPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsTLS != nil {
+		in, out := &in.MetricsTLS, &out.MetricsTLS
+		*out = new(MetricsTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicySpec.
+func (in *NPUClusterPolicySpec) DeepCopy() *NPUClusterPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyStatus) DeepCopyInto(out *NPUClusterPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsCertificate != nil {
+		in, out := &in.MetricsCertificate, &out.MetricsCertificate
+		*out = new(MetricsCertificateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfHealing != nil {
+		in, out := &in.SelfHealing, &out.SelfHealing
+		*out = new(SelfHealingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraManifests != nil {
+		in, out := &in.ExtraManifests, &out.ExtraManifests
+		*out = make([]ManifestObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thermal != nil {
+		in, out := &in.Thermal, &out.Thermal
+		*out = make([]NodeThermalStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Edge != nil {
+		in, out := &in.Edge, &out.Edge
+		*out = new(EdgeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastExport != nil {
+		in, out := &in.LastExport, &out.LastExport
+		*out = new(ExportStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(ReconcileSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(FleetInventory)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MIGRepartitions != nil {
+		in, out := &in.MIGRepartitions, &out.MIGRepartitions
+		*out = make([]MIGRepartition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyStatus.
+func (in *NPUClusterPolicyStatus) DeepCopy() *NPUClusterPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyTemplate) DeepCopyInto(out *NPUClusterPolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyTemplate.
+func (in *NPUClusterPolicyTemplate) DeepCopy() *NPUClusterPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyTemplateList) DeepCopyInto(out *NPUClusterPolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUClusterPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyTemplateList.
+func (in *NPUClusterPolicyTemplateList) DeepCopy() *NPUClusterPolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyTemplateSpec) DeepCopyInto(out *NPUClusterPolicyTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyTemplateSpec.
+func (in *NPUClusterPolicyTemplateSpec) DeepCopy() *NPUClusterPolicyTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyTemplateStatus) DeepCopyInto(out *NPUClusterPolicyTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyTemplateStatus.
+func (in *NPUClusterPolicyTemplateStatus) DeepCopy() *NPUClusterPolicyTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUComponentCatalog) DeepCopyInto(out *NPUComponentCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUComponentCatalog.
+func (in *NPUComponentCatalog) DeepCopy() *NPUComponentCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUComponentCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUComponentCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUComponentCatalogList) DeepCopyInto(out *NPUComponentCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUComponentCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUComponentCatalogList.
+func (in *NPUComponentCatalogList) DeepCopy() *NPUComponentCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUComponentCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUComponentCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUComponentCatalogSpec) DeepCopyInto(out *NPUComponentCatalogSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]CatalogComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUComponentCatalogSpec.
+func (in *NPUComponentCatalogSpec) DeepCopy() *NPUComponentCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUComponentCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUComponentCatalogStatus) DeepCopyInto(out *NPUComponentCatalogStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUComponentCatalogStatus.
+func (in *NPUComponentCatalogStatus) DeepCopy() *NPUComponentCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUComponentCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUNode) DeepCopyInto(out *NPUNode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUNode.
+func (in *NPUNode) DeepCopy() *NPUNode {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUNode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUNodeList) DeepCopyInto(out *NPUNodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUNodeList.
+func (in *NPUNodeList) DeepCopy() *NPUNodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUNodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUNodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUNodeSpec) DeepCopyInto(out *NPUNodeSpec) {
+	*out = *in
+	if in.DisabledDevices != nil {
+		in, out := &in.DisabledDevices, &out.DisabledDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUNodeSpec.
+func (in *NPUNodeSpec) DeepCopy() *NPUNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUNodeStatus) DeepCopyInto(out *NPUNodeStatus) {
+	*out = *in
+	if in.Vendors != nil {
+		in, out := &in.Vendors, &out.Vendors
+		*out = make([]VendorSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]DiscoveredDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationHistory != nil {
+		in, out := &in.RemediationHistory, &out.RemediationHistory
+		*out = make([]RemediationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedDevices != nil {
+		in, out := &in.ExcludedDevices, &out.ExcludedDevices
+		*out = make([]ExcludedDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BurnInHistory != nil {
+		in, out := &in.BurnInHistory, &out.BurnInHistory
+		*out = make([]BurnInRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRevalidationTime != nil {
+		in, out := &in.LastRevalidationTime, &out.LastRevalidationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeRemovedTime != nil {
+		in, out := &in.NodeRemovedTime, &out.NodeRemovedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUNodeStatus.
+func (in *NPUNodeStatus) DeepCopy() *NPUNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUPolicyParameterSet) DeepCopyInto(out *NPUPolicyParameterSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUPolicyParameterSet.
+func (in *NPUPolicyParameterSet) DeepCopy() *NPUPolicyParameterSet {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUPolicyParameterSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUPolicyParameterSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUPolicyParameterSetList) DeepCopyInto(out *NPUPolicyParameterSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUPolicyParameterSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUPolicyParameterSetList.
+func (in *NPUPolicyParameterSetList) DeepCopy() *NPUPolicyParameterSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUPolicyParameterSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUPolicyParameterSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUPolicyParameterSetSpec) DeepCopyInto(out *NPUPolicyParameterSetSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUPolicyParameterSetSpec.
+func (in *NPUPolicyParameterSetSpec) DeepCopy() *NPUPolicyParameterSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUPolicyParameterSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUPolicyParameterSetStatus) DeepCopyInto(out *NPUPolicyParameterSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUPolicyParameterSetStatus.
+func (in *NPUPolicyParameterSetStatus) DeepCopy() *NPUPolicyParameterSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUPolicyParameterSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUQuotaGrant) DeepCopyInto(out *NPUQuotaGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUQuotaGrant.
+func (in *NPUQuotaGrant) DeepCopy() *NPUQuotaGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUQuotaGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUQuotaGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUQuotaGrantList) DeepCopyInto(out *NPUQuotaGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUQuotaGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUQuotaGrantList.
+func (in *NPUQuotaGrantList) DeepCopy() *NPUQuotaGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUQuotaGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUQuotaGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUQuotaGrantSpec) DeepCopyInto(out *NPUQuotaGrantSpec) {
+	*out = *in
+	out.Amount = in.Amount.DeepCopy()
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUQuotaGrantSpec.
+func (in *NPUQuotaGrantSpec) DeepCopy() *NPUQuotaGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUQuotaGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUQuotaGrantStatus) DeepCopyInto(out *NPUQuotaGrantStatus) {
+	*out = *in
+	if in.ExpiryTime != nil {
+		in, out := &in.ExpiryTime, &out.ExpiryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUQuotaGrantStatus.
+func (in *NPUQuotaGrantStatus) DeepCopy() *NPUQuotaGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUQuotaGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUReservation) DeepCopyInto(out *NPUReservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUReservation.
+func (in *NPUReservation) DeepCopy() *NPUReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUReservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUReservationList) DeepCopyInto(out *NPUReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUReservationList.
+func (in *NPUReservationList) DeepCopy() *NPUReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUReservationSpec) DeepCopyInto(out *NPUReservationSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUReservationSpec.
+func (in *NPUReservationSpec) DeepCopy() *NPUReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUReservationStatus) DeepCopyInto(out *NPUReservationStatus) {
+	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]FailureDomainCapacity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUReservationStatus.
+func (in *NPUReservationStatus) DeepCopy() *NPUReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUWorkloadProfile) DeepCopyInto(out *NPUWorkloadProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUWorkloadProfile.
+func (in *NPUWorkloadProfile) DeepCopy() *NPUWorkloadProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUWorkloadProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUWorkloadProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUWorkloadProfileList) DeepCopyInto(out *NPUWorkloadProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUWorkloadProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUWorkloadProfileList.
+func (in *NPUWorkloadProfileList) DeepCopy() *NPUWorkloadProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUWorkloadProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUWorkloadProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUWorkloadProfileSpec) DeepCopyInto(out *NPUWorkloadProfileSpec) {
+	*out = *in
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(ProfileReference)
+		**out = **in
+	}
+	if in.Furiosa != nil {
+		in, out := &in.Furiosa, &out.Furiosa
+		*out = new(FuriosaWorkload)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(WorkloadPlacement)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUWorkloadProfileSpec.
+func (in *NPUWorkloadProfileSpec) DeepCopy() *NPUWorkloadProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUWorkloadProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUWorkloadProfileStatus) DeepCopyInto(out *NPUWorkloadProfileStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InheritedFrom != nil {
+		in, out := &in.InheritedFrom, &out.InheritedFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUWorkloadProfileStatus.
+func (in *NPUWorkloadProfileStatus) DeepCopy() *NPUWorkloadProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUWorkloadProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeThermalStatus) DeepCopyInto(out *NodeThermalStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeThermalStatus.
+func (in *NodeThermalStatus) DeepCopy() *NodeThermalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeThermalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaDevicePluginConfig) DeepCopyInto(out *NvidiaDevicePluginConfig) {
+	*out = *in
+	if in.Sharing != nil {
+		in, out := &in.Sharing, &out.Sharing
+		*out = new(NvidiaSharing)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaDevicePluginConfig.
+func (in *NvidiaDevicePluginConfig) DeepCopy() *NvidiaDevicePluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaDevicePluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaReplicas) DeepCopyInto(out *NvidiaReplicas) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]NvidiaReplicatedResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaReplicas.
+func (in *NvidiaReplicas) DeepCopy() *NvidiaReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaReplicatedResource) DeepCopyInto(out *NvidiaReplicatedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaReplicatedResource.
+func (in *NvidiaReplicatedResource) DeepCopy() *NvidiaReplicatedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaReplicatedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaSharing) DeepCopyInto(out *NvidiaSharing) {
+	*out = *in
+	if in.TimeSlicing != nil {
+		in, out := &in.TimeSlicing, &out.TimeSlicing
+		*out = new(NvidiaReplicas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MPS != nil {
+		in, out := &in.MPS, &out.MPS
+		*out = new(NvidiaReplicas)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaSharing.
+func (in *NvidiaSharing) DeepCopy() *NvidiaSharing {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaSharing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaSpec) DeepCopyInto(out *NvidiaSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoMIG != nil {
+		in, out := &in.AutoMIG, &out.AutoMIG
+		*out = new(AutoMIGSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevicePluginConfig != nil {
+		in, out := &in.DevicePluginConfig, &out.DevicePluginConfig
+		*out = new(NvidiaDevicePluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.VendorComponents.DeepCopyInto(&out.VendorComponents)
+	in.VendorPodSpec.DeepCopyInto(&out.VendorPodSpec)
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(ClusterAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaSpec.
+func (in *NvidiaSpec) DeepCopy() *NvidiaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectPatch) DeepCopyInto(out *ObjectPatch) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]JSONPatchOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaSpec.
-func (in *FuriosaSpec) DeepCopy() *FuriosaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectPatch.
+func (in *ObjectPatch) DeepCopy() *ObjectPatch {
 	if in == nil {
 		return nil
 	}
-	out := new(FuriosaSpec)
+	out := new(ObjectPatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPUClusterPolicy) DeepCopyInto(out *NPUClusterPolicy) {
+func (in *PluginUpgradeSpec) DeepCopyInto(out *PluginUpgradeSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	if in.ValidationTimeout != nil {
+		in, out := &in.ValidationTimeout, &out.ValidationTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicy.
-func (in *NPUClusterPolicy) DeepCopy() *NPUClusterPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpgradeSpec.
+func (in *PluginUpgradeSpec) DeepCopy() *PluginUpgradeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NPUClusterPolicy)
+	out := new(PluginUpgradeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NPUClusterPolicy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrePullSpec) DeepCopyInto(out *PrePullSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrePullSpec.
+func (in *PrePullSpec) DeepCopy() *PrePullSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrePullSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPUClusterPolicyList) DeepCopyInto(out *NPUClusterPolicyList) {
+func (in *PrePullStatus) DeepCopyInto(out *PrePullStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NPUClusterPolicy, len(*in))
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrePullStatus.
+func (in *PrePullStatus) DeepCopy() *PrePullStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrePullStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileReference) DeepCopyInto(out *ProfileReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileReference.
+func (in *ProfileReference) DeepCopy() *ProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileSummary) DeepCopyInto(out *ReconcileSummary) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileSummary.
+func (in *ReconcileSummary) DeepCopy() *ReconcileSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRecord) DeepCopyInto(out *RemediationRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRecord.
+func (in *RemediationRecord) DeepCopy() *RemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationState) DeepCopyInto(out *RemediationState) {
+	*out = *in
+	in.StartedTime.DeepCopyInto(&out.StartedTime)
+	in.StepTime.DeepCopyInto(&out.StepTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationState.
+func (in *RemediationState) DeepCopy() *RemediationState {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PrePull != nil {
+		in, out := &in.PrePull, &out.PrePull
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafeModeSpec) DeepCopyInto(out *SafeModeSpec) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafeModeSpec.
+func (in *SafeModeSpec) DeepCopy() *SafeModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SafeModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSpec.
+func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfHealingSpec) DeepCopyInto(out *SelfHealingSpec) {
+	*out = *in
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHealingSpec.
+func (in *SelfHealingSpec) DeepCopy() *SelfHealingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfHealingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfHealingStatus) DeepCopyInto(out *SelfHealingStatus) {
+	*out = *in
+	if in.LastDeletionTime != nil {
+		in, out := &in.LastDeletionTime, &out.LastDeletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRecreationTime != nil {
+		in, out := &in.LastRecreationTime, &out.LastRecreationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHealingStatus.
+func (in *SelfHealingStatus) DeepCopy() *SelfHealingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfHealingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThermalSpec) DeepCopyInto(out *ThermalSpec) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]MetricThreshold, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]ThermalAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.PowerLimitWatts != nil {
+		in, out := &in.PowerLimitWatts, &out.PowerLimitWatts
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyList.
-func (in *NPUClusterPolicyList) DeepCopy() *NPUClusterPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThermalSpec.
+func (in *ThermalSpec) DeepCopy() *ThermalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NPUClusterPolicyList)
+	out := new(ThermalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NPUClusterPolicyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeHook) DeepCopyInto(out *UpgradeHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHook.
+func (in *UpgradeHook) DeepCopy() *UpgradeHook {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeHook)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPUClusterPolicySpec) DeepCopyInto(out *NPUClusterPolicySpec) {
+func (in *UpgradeHookStatus) DeepCopyInto(out *UpgradeHookStatus) {
 	*out = *in
-	out.Nvidia = in.Nvidia
-	out.Furiosa = in.Furiosa
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicySpec.
-func (in *NPUClusterPolicySpec) DeepCopy() *NPUClusterPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHookStatus.
+func (in *UpgradeHookStatus) DeepCopy() *UpgradeHookStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NPUClusterPolicySpec)
+	out := new(UpgradeHookStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPUClusterPolicyStatus) DeepCopyInto(out *NPUClusterPolicyStatus) {
+func (in *UpgradeHooks) DeepCopyInto(out *UpgradeHooks) {
 	*out = *in
+	if in.PreUpgrade != nil {
+		in, out := &in.PreUpgrade, &out.PreUpgrade
+		*out = new(UpgradeHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostUpgrade != nil {
+		in, out := &in.PostUpgrade, &out.PostUpgrade
+		*out = new(UpgradeHook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyStatus.
-func (in *NPUClusterPolicyStatus) DeepCopy() *NPUClusterPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHooks.
+func (in *UpgradeHooks) DeepCopy() *UpgradeHooks {
 	if in == nil {
 		return nil
 	}
-	out := new(NPUClusterPolicyStatus)
+	out := new(UpgradeHooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NvidiaSpec) DeepCopyInto(out *NvidiaSpec) {
+func (in *VendorComponents) DeepCopyInto(out *VendorComponents) {
 	*out = *in
+	in.DevicePlugin.DeepCopyInto(&out.DevicePlugin)
+	in.Exporter.DeepCopyInto(&out.Exporter)
+	in.Validator.DeepCopyInto(&out.Validator)
+	in.Driver.DeepCopyInto(&out.Driver)
+	in.GFD.DeepCopyInto(&out.GFD)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaSpec.
-func (in *NvidiaSpec) DeepCopy() *NvidiaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VendorComponents.
+func (in *VendorComponents) DeepCopy() *VendorComponents {
 	if in == nil {
 		return nil
 	}
-	out := new(NvidiaSpec)
+	out := new(VendorComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VendorPodSpec) DeepCopyInto(out *VendorPodSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VendorPodSpec.
+func (in *VendorPodSpec) DeepCopy() *VendorPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VendorPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VendorSummary) DeepCopyInto(out *VendorSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VendorSummary.
+func (in *VendorSummary) DeepCopy() *VendorSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(VendorSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionCount) DeepCopyInto(out *VersionCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionCount.
+func (in *VersionCount) DeepCopy() *VersionCount {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacement) DeepCopyInto(out *WorkloadPlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacement.
+func (in *WorkloadPlacement) DeepCopy() *WorkloadPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacement)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// ConversionDataAnnotation holds the fields of the v1alpha1 hub this version has no place for,
+// so a policy read and written back through v1beta1 keeps them. It is only set on v1beta1
+// objects, converting back to the hub moves the fields back into the spec.
+const ConversionDataAnnotation = "npu.ai/conversion-data"
+
+// conversionData are the hub fields kept in the ConversionDataAnnotation.
+type conversionData struct {
+	NvidiaDevicePluginImage  string `json:"nvidiaDevicePluginImage,omitempty"`
+	FuriosaDevicePluginImage string `json:"furiosaDevicePluginImage,omitempty"`
+}
+
+// ConvertTo converts the policy to the v1alpha1 hub.
+func (src *NPUClusterPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*npuv1alpha1.NPUClusterPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+	s, d := &src.Spec, &dst.Spec
+
+	var data conversionData
+	if raw, ok := src.Annotations[ConversionDataAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return fmt.Errorf("decoding annotation %s: %w", ConversionDataAnnotation, err)
+		}
+		dst.Annotations = maps.Clone(src.Annotations)
+		delete(dst.Annotations, ConversionDataAnnotation)
+	}
+
+	d.Nvidia = npuv1alpha1.NvidiaSpec{
+		Enabled:            s.Nvidia.Enabled,
+		DevicePluginImage:  data.NvidiaDevicePluginImage,
+		Env:                s.Nvidia.Env,
+		ExtraVolumes:       s.Nvidia.ExtraVolumes,
+		ExtraVolumeMounts:  s.Nvidia.ExtraVolumeMounts,
+		AutoMIG:            s.Nvidia.AutoMIG,
+		DevicePluginConfig: s.Nvidia.DevicePluginConfig,
+		VendorComponents:   s.Nvidia.VendorComponents,
+		VendorPodSpec:      s.Nvidia.VendorPodSpec,
+		ClusterAPI:         s.Nvidia.ClusterAPI,
+	}
+	d.Furiosa = npuv1alpha1.FuriosaSpec{
+		Enabled:           s.Furiosa.Enabled,
+		DevicePluginImage: data.FuriosaDevicePluginImage,
+		ConfigMapName:     s.Furiosa.ConfigMapName,
+		Env:               s.Furiosa.Env,
+		ExtraVolumes:      s.Furiosa.ExtraVolumes,
+		ExtraVolumeMounts: s.Furiosa.ExtraVolumeMounts,
+		Partitioning:      s.Furiosa.Partitioning,
+		Config:            s.Furiosa.Config,
+		VendorComponents:  s.Furiosa.VendorComponents,
+		VendorPodSpec:     s.Furiosa.VendorPodSpec,
+		ClusterAPI:        s.Furiosa.ClusterAPI,
+	}
+	d.Namespace = s.Namespace
+	d.CreateNamespace = s.CreateNamespace
+	d.ImagePullSecrets = s.ImagePullSecrets
+	if s.PriorityClass != nil {
+		d.PriorityClassName = s.PriorityClass.Name
+		d.CreatePriorityClass = s.PriorityClass.Create
+	}
+	if s.CommonMetadata != nil {
+		d.CommonLabels = s.CommonMetadata.Labels
+		d.CommonAnnotations = s.CommonMetadata.Annotations
+	}
+	d.Paused = s.Paused
+	d.DryRun = s.DryRun
+	d.AdoptExisting = s.AdoptExisting
+	d.SelfHealing = s.SelfHealing
+	d.SafeMode = s.SafeMode
+	d.ExtraManifests = s.ExtraManifests
+	d.Catalog = s.Catalog
+	d.Thermal = s.Thermal
+	d.Edge = s.Edge
+	if s.Upgrades != nil {
+		d.PluginUpgrade = s.Upgrades.Plugin
+		d.DriverUpgrade = s.Upgrades.Driver
+		d.PrePull = s.Upgrades.PrePull
+	}
+	d.Scheduler = s.Scheduler
+	d.DevicePreferences = s.DevicePreferences
+	d.Patches = s.Patches
+	d.ConflictPolicy = s.ConflictPolicy
+	d.MetricsTLS = s.MetricsTLS
+
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub to this version. The deprecated fields of the hub are
+// kept in the ConversionDataAnnotation; the operator migrates them to their replacement on
+// start, after which policies convert without it.
+func (dst *NPUClusterPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*npuv1alpha1.NPUClusterPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+	s, d := &src.Spec, &dst.Spec
+
+	data := conversionData{
+		NvidiaDevicePluginImage:  s.Nvidia.DevicePluginImage,
+		FuriosaDevicePluginImage: s.Furiosa.DevicePluginImage,
+	}
+	if data != (conversionData{}) {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		dst.Annotations = maps.Clone(src.Annotations)
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[ConversionDataAnnotation] = string(raw)
+	}
+
+	d.Nvidia = NvidiaSpec{
+		Enabled:            s.Nvidia.Enabled,
+		Env:                s.Nvidia.Env,
+		ExtraVolumes:       s.Nvidia.ExtraVolumes,
+		ExtraVolumeMounts:  s.Nvidia.ExtraVolumeMounts,
+		AutoMIG:            s.Nvidia.AutoMIG,
+		DevicePluginConfig: s.Nvidia.DevicePluginConfig,
+		VendorComponents:   s.Nvidia.VendorComponents,
+		VendorPodSpec:      s.Nvidia.VendorPodSpec,
+		ClusterAPI:         s.Nvidia.ClusterAPI,
+	}
+	d.Furiosa = FuriosaSpec{
+		Enabled:           s.Furiosa.Enabled,
+		ConfigMapName:     s.Furiosa.ConfigMapName,
+		Env:               s.Furiosa.Env,
+		ExtraVolumes:      s.Furiosa.ExtraVolumes,
+		ExtraVolumeMounts: s.Furiosa.ExtraVolumeMounts,
+		Partitioning:      s.Furiosa.Partitioning,
+		Config:            s.Furiosa.Config,
+		VendorComponents:  s.Furiosa.VendorComponents,
+		VendorPodSpec:     s.Furiosa.VendorPodSpec,
+		ClusterAPI:        s.Furiosa.ClusterAPI,
+	}
+	d.Namespace = s.Namespace
+	d.CreateNamespace = s.CreateNamespace
+	d.ImagePullSecrets = s.ImagePullSecrets
+	d.PriorityClass = nil
+	if s.PriorityClassName != "" || s.CreatePriorityClass {
+		d.PriorityClass = &PriorityClassSpec{Name: s.PriorityClassName, Create: s.CreatePriorityClass}
+	}
+	d.CommonMetadata = nil
+	if len(s.CommonLabels) > 0 || len(s.CommonAnnotations) > 0 {
+		d.CommonMetadata = &CommonMetadata{Labels: s.CommonLabels, Annotations: s.CommonAnnotations}
+	}
+	d.Paused = s.Paused
+	d.DryRun = s.DryRun
+	d.AdoptExisting = s.AdoptExisting
+	d.SelfHealing = s.SelfHealing
+	d.SafeMode = s.SafeMode
+	d.ExtraManifests = s.ExtraManifests
+	d.Catalog = s.Catalog
+	d.Thermal = s.Thermal
+	d.Edge = s.Edge
+	d.Upgrades = nil
+	if s.PluginUpgrade != nil || s.DriverUpgrade != nil || s.PrePull != nil {
+		d.Upgrades = &UpgradesSpec{Plugin: s.PluginUpgrade, Driver: s.DriverUpgrade, PrePull: s.PrePull}
+	}
+	d.Scheduler = s.Scheduler
+	d.DevicePreferences = s.DevicePreferences
+	d.Patches = s.Patches
+	d.ConflictPolicy = s.ConflictPolicy
+	d.MetricsTLS = s.MetricsTLS
+
+	dst.Status = src.Status
+	return nil
+}
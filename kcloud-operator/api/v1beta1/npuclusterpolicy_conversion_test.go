@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/randfill"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// groupFuzzerFuncs keep the groups of this version non-empty, an empty group converts to an
+// absent one.
+func groupFuzzerFuncs(_ serializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(p *PriorityClassSpec, c randfill.Continue) {
+			c.FillNoCustom(p)
+			p.Create = true
+		},
+		func(m *CommonMetadata, c randfill.Continue) {
+			c.FillNoCustom(m)
+			m.Labels = map[string]string{"team": c.String(0)}
+		},
+		func(u *UpgradesSpec, c randfill.Continue) {
+			c.FillNoCustom(u)
+			u.PrePull = &npuv1alpha1.PrePullSpec{}
+		},
+	}
+}
+
+// specJSON is the JSON of the policy without its type, which conversion sets.
+func specJSON(t *testing.T, obj runtime.Object) string {
+	t.Helper()
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	delete(fields, "apiVersion")
+	delete(fields, "kind")
+	data, _ = json.Marshal(fields)
+	return string(data)
+}
+
+func TestConvertHubRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := npuv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := newFuzzer(scheme)
+	for range 100 {
+		hub := &npuv1alpha1.NPUClusterPolicy{}
+		f.Fill(hub)
+
+		policy := &NPUClusterPolicy{}
+		if err := policy.ConvertFrom(hub.DeepCopy()); err != nil {
+			t.Fatal(err)
+		}
+		back := &npuv1alpha1.NPUClusterPolicy{}
+		if err := policy.ConvertTo(back); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := specJSON(t, back), specJSON(t, hub); got != want {
+			t.Fatalf("v1alpha1 round trip changed the policy:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
+
+func TestConvertSpokeRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := newFuzzer(scheme, groupFuzzerFuncs)
+	for range 100 {
+		policy := &NPUClusterPolicy{}
+		f.Fill(policy)
+
+		hub := &npuv1alpha1.NPUClusterPolicy{}
+		if err := policy.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatal(err)
+		}
+		back := &NPUClusterPolicy{}
+		if err := back.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := specJSON(t, back), specJSON(t, policy); got != want {
+			t.Fatalf("v1beta1 round trip changed the policy:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
+
+func TestConvertKeepsLegacyImage(t *testing.T) {
+	hub := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	hub.Spec.Nvidia.Enabled = true
+	hub.Spec.Nvidia.DevicePluginImage = "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"
+	hub.Spec.Nvidia.DevicePlugin.Image = "registry.local/nvidia/k8s-device-plugin"
+	hub.Spec.Catalog = "stable"
+	hub.Spec.PriorityClassName = "npu-critical"
+	hub.Spec.CreatePriorityClass = true
+	hub.Spec.PluginUpgrade = &npuv1alpha1.PluginUpgradeSpec{Strategy: npuv1alpha1.PluginUpgradeBlueGreen}
+
+	policy := &NPUClusterPolicy{}
+	if err := policy.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if hub.Annotations != nil {
+		t.Errorf("conversion changed the hub annotations: %v", hub.Annotations)
+	}
+	want := `{"nvidiaDevicePluginImage":"nvcr.io/nvidia/k8s-device-plugin:v0.17.0"}`
+	if got := policy.Annotations[ConversionDataAnnotation]; got != want {
+		t.Errorf("conversion data = %s, want %s", got, want)
+	}
+	if image := policy.Spec.Nvidia.DevicePlugin.Image; image != "registry.local/nvidia/k8s-device-plugin" {
+		t.Errorf("device plugin image = %s, want it unchanged", image)
+	}
+	if pc := policy.Spec.PriorityClass; pc == nil || pc.Name != "npu-critical" || !pc.Create {
+		t.Errorf("priorityClass = %+v, want npu-critical created", pc)
+	}
+	if u := policy.Spec.Upgrades; u == nil || u.Plugin == nil || u.Driver != nil || u.PrePull != nil {
+		t.Errorf("upgrades = %+v, want the plugin upgrade only", u)
+	}
+
+	// A client writing the policy back through v1beta1 keeps the annotation it read.
+	policy.Spec.Paused = true
+	back := &npuv1alpha1.NPUClusterPolicy{}
+	if err := policy.ConvertTo(back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Spec.Nvidia.DevicePluginImage != hub.Spec.Nvidia.DevicePluginImage {
+		t.Errorf("legacy image = %q, want it restored", back.Spec.Nvidia.DevicePluginImage)
+	}
+	if _, ok := back.Annotations[ConversionDataAnnotation]; ok {
+		t.Error("conversion data was stored on the hub")
+	}
+	if _, ok := policy.Annotations[ConversionDataAnnotation]; !ok {
+		t.Error("conversion changed the v1beta1 annotations")
+	}
+}
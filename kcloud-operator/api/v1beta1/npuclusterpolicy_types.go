@@ -0,0 +1,289 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// The v1beta1 layout of NPUClusterPolicy drops the deprecated devicePluginImage fields and
+// groups related top-level fields of v1alpha1. Sections unchanged between the versions are
+// shared with v1alpha1. The conversion webhook converts the version from and to the v1alpha1
+// storage version, and its schema carries no descriptions to keep the CRD small.
+
+// NvidiaSpec configures the NVIDIA stack.
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.clusterAPI)",message="clusterAPI requires enabled"
+type NvidiaSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// Env is merged into the environment of the device plugin container, replacing variables
+	// of the same name, e.g. MIG_STRATEGY=mixed or DP_DISABLE_HEALTHCHECKS=xids.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ExtraVolumes are added to the device plugin pods, e.g. host paths of custom driver
+	// installs or secrets holding certificates.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are added to the device plugin container, mounting extraVolumes.
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// AutoMIG repartitions idle MIG-capable GPUs by the MIG profiles pending pods request.
+	// +optional
+	AutoMIG *npuv1alpha1.AutoMIGSpec `json:"autoMIG,omitempty"`
+
+	// DevicePluginConfig is the configuration file of the device plugin, rendered into the
+	// ConfigMap nvidia-device-plugin-config and passed with --config-file.
+	// +optional
+	DevicePluginConfig *npuv1alpha1.NvidiaDevicePluginConfig `json:"devicePluginConfig,omitempty"`
+
+	npuv1alpha1.VendorComponents `json:",inline"`
+
+	npuv1alpha1.VendorPodSpec `json:",inline"`
+
+	// ClusterAPI propagates the NVIDIA node labels and taints into Cluster API machine templates.
+	// +optional
+	ClusterAPI *npuv1alpha1.ClusterAPISpec `json:"clusterAPI,omitempty"`
+}
+
+// FuriosaSpec configures the Furiosa stack.
+// +kubebuilder:validation:XValidation:rule="self.enabled || !has(self.clusterAPI)",message="clusterAPI requires enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.partitioning) || !has(self.config) || !has(self.config.defaultPe) || self.config.defaultPe == 'Single'",message="partitioning requires config.defaultPe Single"
+type FuriosaSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// ConfigMapName is the ConfigMap holding the config.yaml of the device plugin.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Env is merged into the environment of the device plugin container, replacing variables
+	// of the same name, e.g. RUST_LOG=debug.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ExtraVolumes are added to the device plugin pods, e.g. host paths of custom driver
+	// installs or secrets holding certificates.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are added to the device plugin container, mounting extraVolumes.
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Partitioning advertises the cards as partitions of processing elements (PEs), so
+	// workloads can request a fraction of a card. Whole cards are advertised without it.
+	// +optional
+	Partitioning *npuv1alpha1.FuriosaPartitioning `json:"partitioning,omitempty"`
+
+	// Config is rendered into the config.yaml of the device plugin, in the ConfigMap named
+	// configMapName.
+	// +optional
+	Config *npuv1alpha1.FuriosaPluginConfig `json:"config,omitempty"`
+
+	npuv1alpha1.VendorComponents `json:",inline"`
+
+	npuv1alpha1.VendorPodSpec `json:",inline"`
+
+	// ClusterAPI propagates the Furiosa node labels and taints into Cluster API machine templates.
+	// +optional
+	ClusterAPI *npuv1alpha1.ClusterAPISpec `json:"clusterAPI,omitempty"`
+}
+
+// PriorityClassSpec selects the priority class of the component pods.
+type PriorityClassSpec struct {
+	// Name of the class. Defaults to system-node-critical, which outside kube-system must be
+	// allowed by a ResourceQuota of the namespace.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Create creates the class when it does not exist, with the highest value allowed for
+	// classes other than the system ones. The class is left in place when the policy is deleted.
+	// +optional
+	Create bool `json:"create,omitempty"`
+}
+
+// CommonMetadata is added to every object the operator creates for the policy and to the pods
+// of its DaemonSets, Deployments and Jobs, e.g. for cost allocation. Labels the operator sets
+// itself, such as app.kubernetes.io/name, are kept.
+type CommonMetadata struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// UpgradesSpec selects how component changes are rolled out.
+type UpgradesSpec struct {
+	// Plugin selects how device plugin image changes are rolled out.
+	// +optional
+	Plugin *npuv1alpha1.PluginUpgradeSpec `json:"plugin,omitempty"`
+
+	// Driver selects how driver image changes are rolled out across the nodes.
+	// +optional
+	Driver *npuv1alpha1.DriverUpgradeSpec `json:"driver,omitempty"`
+
+	// PrePull pulls new component images onto the target nodes before a component is updated.
+	// +optional
+	PrePull *npuv1alpha1.PrePullSpec `json:"prePull,omitempty"`
+}
+
+// NPUClusterPolicySpec defines the desired state of NPUClusterPolicy. Optional sections
+// are off while absent and carry no defaults of their own, so a CRD upgrade adding a
+// section never enables it on existing policies.
+type NPUClusterPolicySpec struct {
+	Nvidia  NvidiaSpec  `json:"nvidia"`
+	Furiosa FuriosaSpec `json:"furiosa"`
+
+	// Namespace the components are deployed to. Defaults to kube-system and cannot be changed.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CreateNamespace creates the namespace of the components when it does not exist. The
+	// namespace is left in place when the policy is deleted.
+	// +optional
+	CreateNamespace bool `json:"createNamespace,omitempty"`
+
+	// ImagePullSecrets are Secrets of the component namespace used to pull the images of every
+	// pod the operator runs, e.g. from a private Harbor registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PriorityClass of the component pods, so device plugins and drivers survive node
+	// pressure instead of being evicted as best-effort pods.
+	// +optional
+	PriorityClass *PriorityClassSpec `json:"priorityClass,omitempty"`
+
+	// CommonMetadata are the labels and annotations added to every object of the policy.
+	// +optional
+	CommonMetadata *CommonMetadata `json:"commonMetadata,omitempty"`
+
+	// Paused stops the operator from creating, updating or deleting any object of the policy,
+	// e.g. while debugging a node, so manual changes are kept. The status is still reported.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// DryRun renders the DaemonSets and ConfigMaps of the policy into the ConfigMap
+	// <policy name>-dry-run in the policy namespace instead of applying them, so changes can
+	// be reviewed before they are enforced. Objects already deployed are left as they are.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// AdoptExisting takes over device plugin DaemonSets installed by other means, e.g. the
+	// upstream Helm chart, that register the same resources on the same nodes.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// SelfHealing controls how managed DaemonSets deleted out-of-band are recreated.
+	// +optional
+	SelfHealing npuv1alpha1.SelfHealingSpec `json:"selfHealing,omitempty"`
+
+	// SafeMode freezes a component whose pods crash loop shortly after the operator changed it.
+	// +optional
+	SafeMode npuv1alpha1.SafeModeSpec `json:"safeMode,omitempty"`
+
+	// ExtraManifests reference ConfigMaps in the policy namespace holding raw YAML
+	// manifests that are applied along with the policy and garbage-collected with it.
+	// +optional
+	ExtraManifests []npuv1alpha1.ExtraManifestRef `json:"extraManifests,omitempty"`
+
+	// Catalog is the name of the NPUComponentCatalog that component images are resolved
+	// from. Components then select a version only and must not set an image.
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+
+	// Thermal responds to sustained thermal throttling reported by the exporters.
+	// +optional
+	Thermal *npuv1alpha1.ThermalSpec `json:"thermal,omitempty"`
+
+	// Edge keeps components running while the image registry is intermittently unreachable.
+	// +optional
+	Edge *npuv1alpha1.EdgeSpec `json:"edge,omitempty"`
+
+	// Upgrades selects how plugin and driver changes are rolled out and whether their images
+	// are pulled beforehand.
+	// +optional
+	Upgrades *UpgradesSpec `json:"upgrades,omitempty"`
+
+	// Scheduler deploys a secondary scheduler that scores nodes by accelerator fragmentation
+	// and temperature. Workloads opt in by setting spec.schedulerName.
+	// +optional
+	Scheduler *npuv1alpha1.SchedulerSpec `json:"scheduler,omitempty"`
+
+	// DevicePreferences are the device selection preferences of the device plugins per node
+	// pool. Nodes outside every pool use the defaults.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	DevicePreferences []npuv1alpha1.DevicePreferencePool `json:"devicePreferences,omitempty"`
+
+	// Patches are RFC 6902 JSON patches applied to the rendered objects before they are
+	// applied, for customizations the structured fields cannot express.
+	// +optional
+	Patches []npuv1alpha1.ObjectPatch `json:"patches,omitempty"`
+
+	// ConflictPolicy resolves fields of managed objects that another field manager changed.
+	// +kubebuilder:default=Force
+	// +optional
+	ConflictPolicy npuv1alpha1.ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// MetricsTLS serves the metrics of the exporters over TLS.
+	// +optional
+	MetricsTLS *npuv1alpha1.MetricsTLSSpec `json:"metricsTLS,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Nvidia Ready",type=string,JSONPath=`.status.nvidiaReady`
+// +kubebuilder:printcolumn:name="Furiosa Ready",type=string,JSONPath=`.status.furiosaReady`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NPUClusterPolicy is the Schema for the npuclusterpolicies API.
+type NPUClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="(has(self.namespace) ? self.namespace : 'kube-system') == (has(oldSelf.namespace) ? oldSelf.namespace : 'kube-system')",message="namespace is immutable"
+	Spec   NPUClusterPolicySpec               `json:"spec,omitempty"`
+	Status npuv1alpha1.NPUClusterPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NPUClusterPolicyList contains a list of NPUClusterPolicy.
+type NPUClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NPUClusterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NPUClusterPolicy{}, &NPUClusterPolicyList{})
+}
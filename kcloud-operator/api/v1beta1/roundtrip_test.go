@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/randfill"
+)
+
+// fuzzerFuncs fill the fields the default filler cannot produce valid JSON for.
+func fuzzerFuncs(_ serializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(q *resource.Quantity, c randfill.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(j *apiextensionsv1.JSON, c randfill.Continue) {
+			var value interface{} = c.String(0)
+			if c.Bool() {
+				value = map[string]interface{}{"key": value}
+			}
+			j.Raw, _ = json.Marshal(value)
+		},
+	}
+}
+
+// newFuzzer returns a filler of random values for the kinds of the scheme, using the funcs on
+// top of fuzzerFuncs.
+func newFuzzer(scheme *runtime.Scheme, funcs ...fuzzer.FuzzerFuncs) (*randfill.Filler, serializer.CodecFactory) {
+	codecs := serializer.NewCodecFactory(scheme)
+	funcs = append([]fuzzer.FuzzerFuncs{metafuzzer.Funcs, fuzzerFuncs}, funcs...)
+	f := fuzzer.FuzzerFor(fuzzer.MergeFuzzerFuncs(funcs...), rand.NewSource(rand.Int63()), codecs)
+	return f, codecs
+}
+
+// TestRoundTripTypes fills every kind of the group with random values and checks that
+// they survive deep-copying and a JSON round trip unchanged.
+func TestRoundTripTypes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	f, codecs := newFuzzer(scheme)
+	roundtrip.RoundTripExternalTypesWithoutProtobuf(t, scheme, codecs, f, nil)
+}
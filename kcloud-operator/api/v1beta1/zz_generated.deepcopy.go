@@ -0,0 +1,344 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"npu-operator/api/v1alpha1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonMetadata) DeepCopyInto(out *CommonMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonMetadata.
+func (in *CommonMetadata) DeepCopy() *CommonMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FuriosaSpec) DeepCopyInto(out *FuriosaSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(v1alpha1.FuriosaPartitioning)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.FuriosaPluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.VendorComponents.DeepCopyInto(&out.VendorComponents)
+	in.VendorPodSpec.DeepCopyInto(&out.VendorPodSpec)
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(v1alpha1.ClusterAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FuriosaSpec.
+func (in *FuriosaSpec) DeepCopy() *FuriosaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FuriosaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicy) DeepCopyInto(out *NPUClusterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicy.
+func (in *NPUClusterPolicy) DeepCopy() *NPUClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicyList) DeepCopyInto(out *NPUClusterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NPUClusterPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicyList.
+func (in *NPUClusterPolicyList) DeepCopy() *NPUClusterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NPUClusterPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPUClusterPolicySpec) DeepCopyInto(out *NPUClusterPolicySpec) {
+	*out = *in
+	in.Nvidia.DeepCopyInto(&out.Nvidia)
+	in.Furiosa.DeepCopyInto(&out.Furiosa)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityClass != nil {
+		in, out := &in.PriorityClass, &out.PriorityClass
+		*out = new(PriorityClassSpec)
+		**out = **in
+	}
+	if in.CommonMetadata != nil {
+		in, out := &in.CommonMetadata, &out.CommonMetadata
+		*out = new(CommonMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	in.SelfHealing.DeepCopyInto(&out.SelfHealing)
+	in.SafeMode.DeepCopyInto(&out.SafeMode)
+	if in.ExtraManifests != nil {
+		in, out := &in.ExtraManifests, &out.ExtraManifests
+		*out = make([]v1alpha1.ExtraManifestRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thermal != nil {
+		in, out := &in.Thermal, &out.Thermal
+		*out = new(v1alpha1.ThermalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Edge != nil {
+		in, out := &in.Edge, &out.Edge
+		*out = new(v1alpha1.EdgeSpec)
+		**out = **in
+	}
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = new(UpgradesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(v1alpha1.SchedulerSpec)
+		**out = **in
+	}
+	if in.DevicePreferences != nil {
+		in, out := &in.DevicePreferences, &out.DevicePreferences
+		*out = make([]v1alpha1.DevicePreferencePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]v1alpha1.ObjectPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsTLS != nil {
+		in, out := &in.MetricsTLS, &out.MetricsTLS
+		*out = new(v1alpha1.MetricsTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPUClusterPolicySpec.
+func (in *NPUClusterPolicySpec) DeepCopy() *NPUClusterPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NPUClusterPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvidiaSpec) DeepCopyInto(out *NvidiaSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoMIG != nil {
+		in, out := &in.AutoMIG, &out.AutoMIG
+		*out = new(v1alpha1.AutoMIGSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevicePluginConfig != nil {
+		in, out := &in.DevicePluginConfig, &out.DevicePluginConfig
+		*out = new(v1alpha1.NvidiaDevicePluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.VendorComponents.DeepCopyInto(&out.VendorComponents)
+	in.VendorPodSpec.DeepCopyInto(&out.VendorPodSpec)
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(v1alpha1.ClusterAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvidiaSpec.
+func (in *NvidiaSpec) DeepCopy() *NvidiaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NvidiaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClassSpec) DeepCopyInto(out *PriorityClassSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassSpec.
+func (in *PriorityClassSpec) DeepCopy() *PriorityClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradesSpec) DeepCopyInto(out *UpgradesSpec) {
+	*out = *in
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(v1alpha1.PluginUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(v1alpha1.DriverUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrePull != nil {
+		in, out := &in.PrePull, &out.PrePull
+		*out = new(v1alpha1.PrePullSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradesSpec.
+func (in *UpgradesSpec) DeepCopy() *UpgradesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
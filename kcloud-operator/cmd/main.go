@@ -21,16 +21,22 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -38,7 +44,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	npuv1alpha1 "npu-operator/api/v1alpha1"
+	npuv1beta1 "npu-operator/api/v1beta1"
+	"npu-operator/internal/audit"
+	"npu-operator/internal/capabilities"
+	"npu-operator/internal/cloudevents"
 	"npu-operator/internal/controller"
+	"npu-operator/internal/export"
+	"npu-operator/internal/health"
+	"npu-operator/internal/migration"
+	"npu-operator/internal/silence"
+	"npu-operator/internal/statestore"
+	webhookv1 "npu-operator/internal/webhook/v1"
+	webhookv1alpha1 "npu-operator/internal/webhook/v1alpha1"
+	webhookv1beta1 "npu-operator/internal/webhook/v1beta1"
+	"npu-operator/internal/whatif"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -51,6 +70,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(npuv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(npuv1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -63,6 +84,24 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enablePDBAdvisor bool
+	var pdbAdvisorNodeSelector string
+	var cloudEventsSinkURL string
+	var remediation controller.RemediationConfig
+	var rebootWindow string
+	var burnIn controller.BurnInConfig
+	var stateNamespace, stateConfigMap string
+	var exportLocation string
+	var restoreFrom string
+	var auditSink string
+	var alertSilencer, alertmanagerURL, alertmanagerNodeLabel string
+	var alertSilenceDuration time.Duration
+	var restoreTimeout time.Duration
+	var enableWebhooks bool
+	var npuNodeRetention time.Duration
+	var maxConcurrentReconciles, policyWorkers, npuNodeWorkers int
+	failureDomains := controller.DefaultFailureDomainLabels
+	rateLimiter := controller.DefaultRateLimiterConfig
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +120,72 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enablePDBAdvisor, "enable-pdb-advisor", false,
+		"If set, accelerator workloads without a PodDisruptionBudget on disruption-managed nodes are reported.")
+	flag.StringVar(&pdbAdvisorNodeSelector, "pdb-advisor-node-selector", npuv1alpha1.ManagedDisruptionLabel+"=true",
+		"Label selector for the nodes whose pools are subject to managed disruptions.")
+	flag.StringVar(&cloudEventsSinkURL, "cloudevents-sink-url", "",
+		"If set, lifecycle transitions are posted to this HTTP endpoint as CloudEvents.")
+	flag.BoolVar(&remediation.Enabled, "enable-allocatable-remediation", false,
+		"If set, nodes whose accelerator allocatable drops to zero while the device plugin runs are remediated.")
+	flag.DurationVar(&remediation.StepTimeout, "remediation-step-timeout", 5*time.Minute,
+		"How long to wait for the allocatable to recover after each remediation step.")
+	flag.StringVar(&rebootWindow, "remediation-reboot-window", "",
+		"Daily UTC window such as 02:00-04:00 in which remediation may request a node reboot. Empty never reboots.")
+	flag.StringVar(&burnIn.Image, "burn-in-image", "",
+		"Image of the Job burning in devices disabled on NPUNodes, re-enabling those that pass. Empty disables burn-in.")
+	flag.StringVar(&burnIn.Namespace, "burn-in-namespace", "kube-system", "The namespace the burn-in Jobs run in.")
+	flag.DurationVar(&burnIn.After, "burn-in-after", 0,
+		"How long a device stays disabled before it is burned in, and between failed burn-ins. "+
+			"0 only burns in devices when requested with the npu.ai/burn-in annotation.")
+	flag.DurationVar(&burnIn.Timeout, "burn-in-timeout", 30*time.Minute, "How long a burn-in may run before it fails.")
+	flag.DurationVar(&npuNodeRetention, "npunode-retention", 7*24*time.Hour,
+		"How long the NPUNode of a removed node is kept before it is deleted, leaving a tombstone in the state "+
+			"ConfigMap. Zero keeps it forever.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of objects each controller reconciles concurrently unless overridden for the controller.")
+	flag.IntVar(&policyWorkers, "npuclusterpolicy-workers", 0,
+		"The number of NPUClusterPolicies reconciled concurrently. 0 uses --max-concurrent-reconciles.")
+	flag.IntVar(&npuNodeWorkers, "npunode-workers", 0,
+		"The number of nodes reconciled concurrently. 0 uses --max-concurrent-reconciles.")
+	flag.DurationVar(&rateLimiter.BaseDelay, "rate-limiter-base-delay", rateLimiter.BaseDelay,
+		"The delay before the first retry of a failed policy or node, doubled on each further failure.")
+	flag.DurationVar(&rateLimiter.MaxDelay, "rate-limiter-max-delay", rateLimiter.MaxDelay,
+		"The longest delay between retries of a failed policy or node.")
+	flag.Float64Var(&rateLimiter.QPS, "rate-limiter-qps", rateLimiter.QPS,
+		"The overall number of retries per second of the policy and node controllers.")
+	flag.IntVar(&rateLimiter.Burst, "rate-limiter-burst", rateLimiter.Burst,
+		"The bucket size of the overall retry rate, i.e. how many retries may exceed the rate at once.")
+	flag.StringVar(&failureDomains.Rack, "rack-label", failureDomains.Rack,
+		"The node label holding the rack of a node. Reboots never span more than one rack at a time.")
+	flag.StringVar(&failureDomains.PowerZone, "power-zone-label", failureDomains.PowerZone,
+		"The node label holding the power zone of a node.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the admission webhooks are served. They need the [WEBHOOK] sections of config/default.")
+	flag.StringVar(&stateNamespace, "state-namespace", "kube-system",
+		"The namespace of the ConfigMap persisting rollout and remediation bookkeeping.")
+	flag.StringVar(&stateConfigMap, "state-configmap", "npu-operator-state",
+		"The name of the ConfigMap persisting rollout and remediation bookkeeping. Empty keeps it in status only.")
+	flag.StringVar(&exportLocation, "export-location", "",
+		"Where policy exports requested with the npu.ai/export annotation are stored: an absolute directory, "+
+			"e.g. a mounted PersistentVolumeClaim, or an http(s) object storage URL accepting PUT requests.")
+	flag.StringVar(&restoreFrom, "restore-from", "",
+		"Bootstrap a replacement cluster from exported bundles: a bundle file, or a directory whose latest bundle "+
+			"of each policy is used. Missing policies are recreated and their convergence is verified.")
+	flag.DurationVar(&restoreTimeout, "restore-timeout", 30*time.Minute,
+		"How long to wait for a restored policy to become Ready before reporting it.")
+	flag.StringVar(&auditSink, "audit-sink", "",
+		"If set, every write the operator performs is recorded with its cause and changed fields: "+
+			"log writes records to the operator log, an http(s) URL receives them as JSON POSTs.")
+	flag.StringVar(&alertSilencer, "alert-silencer", "",
+		"Suppresses the alerts of nodes rebooted by remediation or upgrading their driver: alertmanager creates "+
+			"silences through --alertmanager-url, node-label labels the nodes with "+npuv1alpha1.AlertsSilencedLabel+
+			" for alerting rules to match.")
+	flag.StringVar(&alertmanagerURL, "alertmanager-url", "", "The Alertmanager URL silences are created through.")
+	flag.StringVar(&alertmanagerNodeLabel, "alertmanager-node-label", "node",
+		"The alert label naming the node, matched by the silences.")
+	flag.DurationVar(&alertSilenceDuration, "alert-silence-duration", 4*time.Hour,
+		"How long a silence lasts, so silences the operator fails to remove expire by themselves.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -89,6 +194,34 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if maxConcurrentReconciles < 1 || policyWorkers < 0 || npuNodeWorkers < 0 {
+		setupLog.Error(nil, "invalid worker counts, --max-concurrent-reconciles must be positive and "+
+			"the per-controller worker counts must not be negative")
+		os.Exit(1)
+	}
+	if rateLimiter.BaseDelay <= 0 || rateLimiter.MaxDelay < rateLimiter.BaseDelay ||
+		rateLimiter.QPS <= 0 || rateLimiter.Burst < 1 {
+		setupLog.Error(nil, "invalid rate limiter, the delays, qps and burst must be positive "+
+			"and --rate-limiter-max-delay must not be below --rate-limiter-base-delay")
+		os.Exit(1)
+	}
+	switch alertSilencer {
+	case "", "node-label":
+	case "alertmanager":
+		if alertmanagerURL == "" || alertmanagerNodeLabel == "" || alertSilenceDuration <= 0 {
+			setupLog.Error(nil, "invalid alert silencer, --alertmanager-url and --alertmanager-node-label must be set "+
+				"and --alert-silence-duration must be positive")
+			os.Exit(1)
+		}
+	default:
+		setupLog.Error(nil, "invalid --alert-silencer, expected alertmanager or node-label", "silencer", alertSilencer)
+		os.Exit(1)
+	}
+	if burnIn.Image != "" && (burnIn.Timeout <= 0 || burnIn.After < 0) {
+		setupLog.Error(nil, "invalid burn-in, --burn-in-timeout must be positive and --burn-in-after must not be negative")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -185,6 +318,7 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "35b22ec5.ai",
+		Controller:             config.Controller{MaxConcurrentReconciles: maxConcurrentReconciles},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -202,13 +336,185 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Writes of the reconcilers go through the audit client when an audit sink is configured.
+	writer := mgr.GetClient()
+	if auditSink != "" {
+		sink, err := audit.NewSink(auditSink)
+		if err != nil {
+			setupLog.Error(err, "invalid audit sink")
+			os.Exit(1)
+		}
+		writer = audit.NewClient(writer, sink)
+	}
+
+	var events cloudevents.Emitter
+	if cloudEventsSinkURL != "" {
+		setupLog.Info("Delivering lifecycle CloudEvents", "sink", cloudEventsSinkURL)
+		events = cloudevents.NewHTTPSink(cloudEventsSinkURL, 10*time.Second)
+	}
+
+	var silencer silence.Silencer
+	switch alertSilencer {
+	case "alertmanager":
+		setupLog.Info("Silencing alerts of disrupted nodes", "alertmanager", alertmanagerURL)
+		silencer = silence.NewAlertmanager(alertmanagerURL, alertmanagerNodeLabel, alertSilenceDuration, 10*time.Second)
+	case "node-label":
+		silencer = &silence.NodeLabel{Client: writer}
+	}
+
+	var state *statestore.Store
+	if stateConfigMap != "" {
+		state = statestore.New(writer, stateNamespace, stateConfigMap)
+	}
+
+	var exportSink export.Sink
+	if exportLocation != "" {
+		if exportSink, err = export.NewSink(exportLocation); err != nil {
+			setupLog.Error(err, "invalid export location")
+			os.Exit(1)
+		}
+	}
+
+	// Integrations whose APIs are missing are switched off. Without discovery they fail on use as before.
+	var caps *capabilities.Capabilities
+	if dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig()); err != nil {
+		setupLog.Error(err, "unable to create discovery client, assuming every integration is available")
+	} else if caps, err = capabilities.Detect(dc); err != nil {
+		setupLog.Error(err, "unable to detect cluster capabilities, assuming every integration is available")
+	} else {
+		setupLog.Info("Detected cluster capabilities", "capabilities", caps.Available())
+	}
+
 	if err := (&controller.NPUClusterPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:       writer,
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("npuclusterpolicy-controller"),
+		Events:       events,
+		Silencer:     silencer,
+		State:        state,
+		Export:       exportSink,
+		Capabilities: caps,
+		Workers:      policyWorkers,
+		RateLimiter:  rateLimiter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NPUClusterPolicy")
 		os.Exit(1)
 	}
+	if err := (&controller.NPUPolicyParameterSetReconciler{
+		Client:   writer,
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("npupolicyparameterset-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NPUPolicyParameterSet")
+		os.Exit(1)
+	}
+	if rebootWindow != "" {
+		window, err := controller.ParseMaintenanceWindow(rebootWindow)
+		if err != nil {
+			setupLog.Error(err, "invalid --remediation-reboot-window")
+			os.Exit(1)
+		}
+		remediation.RebootWindow = window
+	}
+	if err := (&controller.NPUNodeReconciler{
+		Client:         writer,
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("npunode-controller"),
+		Events:         events,
+		Remediation:    remediation,
+		Silencer:       silencer,
+		BurnIn:         burnIn,
+		Retention:      npuNodeRetention,
+		State:          state,
+		FailureDomains: failureDomains,
+		Workers:        npuNodeWorkers,
+		RateLimiter:    rateLimiter,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NPUNode")
+		os.Exit(1)
+	}
+	if enablePDBAdvisor {
+		nodeSelector, err := labels.Parse(pdbAdvisorNodeSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --pdb-advisor-node-selector")
+			os.Exit(1)
+		}
+		if err := (&controller.PDBAdvisorReconciler{
+			Client:       writer,
+			Recorder:     mgr.GetEventRecorderFor("pdb-advisor"),
+			NodeSelector: nodeSelector,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PDBAdvisor")
+			os.Exit(1)
+		}
+	}
+	if err := (&controller.NPUReservationReconciler{
+		Client:         writer,
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("npureservation-controller"),
+		FailureDomains: failureDomains,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NPUReservation")
+		os.Exit(1)
+	}
+	if err := (&controller.NPUQuotaGrantReconciler{
+		Client:   writer,
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("npuquotagrant-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NPUQuotaGrant")
+		os.Exit(1)
+	}
+	if err := (&controller.NPUWorkloadProfileReconciler{
+		Client:   writer,
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("npuworkloadprofile-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NPUWorkloadProfile")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err := webhookv1.SetupPodWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pod")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupNPUClusterPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NPUClusterPolicy")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupNPUClusterPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NPUClusterPolicy")
+			os.Exit(1)
+		}
+	}
+	var fatal health.Fatal
+	if restoreFrom != "" {
+		bundles, err := export.LoadBundles(restoreFrom)
+		if err != nil {
+			setupLog.Error(err, "unable to load bundles", "path", restoreFrom)
+			os.Exit(1)
+		}
+		setupLog.Info("Restoring policies from bundles", "path", restoreFrom, "policies", len(bundles))
+		if err := mgr.Add(&export.Restorer{
+			Client:   writer,
+			Recorder: mgr.GetEventRecorderFor("npu-operator-restore"),
+			Bundles:  bundles,
+			Timeout:  restoreTimeout,
+			Interval: 10 * time.Second,
+			Fatal:    &fatal,
+		}); err != nil {
+			setupLog.Error(err, "unable to add restorer to manager")
+			os.Exit(1)
+		}
+	}
+	if err := mgr.Add(&migration.Migrator{
+		Client:   writer,
+		Recorder: mgr.GetEventRecorderFor("npu-operator-migration"),
+		Fatal:    &fatal,
+	}); err != nil {
+		setupLog.Error(err, "unable to add field migration to manager")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -227,14 +533,50 @@ func main() {
 		}
 	}
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+	// Liveness only fails when the process cannot serve. Readiness also fails while the operator
+	// cannot do its work, and its checks are reported as JSON on /diagnostics of the metrics server.
+	liveness, readiness := &health.Checks{}, &health.Checks{}
+	liveness.Add("healthz", healthz.Ping)
+	readiness.Add("readyz", healthz.Ping)
+	readiness.Add("informers", health.InformersSynced(mgr.GetCache(), time.Second))
+	probeConfig := rest.CopyConfig(mgr.GetConfig())
+	probeConfig.Timeout = 5 * time.Second
+	probeDiscovery, err := discovery.NewDiscoveryClientForConfig(probeConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client for the ready check")
+		os.Exit(1)
+	}
+	readiness.Add("apiserver", health.APIServer(probeDiscovery))
+	if len(webhookCertPath) > 0 {
+		readiness.Add("webhook-certificate", health.CertificateValid(filepath.Join(webhookCertPath, webhookCertName), 0))
+	}
+	if len(metricsCertPath) > 0 {
+		readiness.Add("metrics-certificate", health.CertificateValid(filepath.Join(metricsCertPath, metricsCertName), 0))
+	}
+	readiness.Add("fatal", fatal.Check)
+	if err := liveness.Each(mgr.AddHealthzCheck); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := readiness.Each(mgr.AddReadyzCheck); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddMetricsServerExtraHandler("/diagnostics", readiness); err != nil {
+		setupLog.Error(err, "unable to set up diagnostics")
+		os.Exit(1)
+	}
+	// Policy changes are simulated against the cluster by posting the policy to /whatif. The
+	// simulation reads the whole cluster, so it is only served behind the authn/authz of secure
+	// metrics; callers need the whatif-simulator role.
+	if secureMetrics {
+		if err := mgr.AddMetricsServerExtraHandler("/whatif", &whatif.Handler{Client: mgr.GetClient()}); err != nil {
+			setupLog.Error(err, "unable to set up the what-if simulation")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("The what-if simulation is disabled, as metrics are served without authentication")
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
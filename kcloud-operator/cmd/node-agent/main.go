@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/nodeagent"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(npuv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var nodeName string
+	var podResourcesSocket string
+	var checkpointPath string
+	var syncInterval time.Duration
+	var discovery string
+	var sysfsRoot string
+	var nodeConditions bool
+	flag.StringVar(&nodeName, "node-name", os.Getenv("NODE_NAME"), "The name of the node the agent runs on.")
+	flag.StringVar(&podResourcesSocket, "pod-resources-socket", nodeagent.DefaultPodResourcesSocket,
+		"The kubelet pod resources API socket.")
+	flag.StringVar(&checkpointPath, "checkpoint-path", nodeagent.DefaultCheckpointPath,
+		"The kubelet device manager checkpoint, verified once after every node boot.")
+	flag.DurationVar(&syncInterval, "sync-interval", 30*time.Second, "How often the agent syncs node state.")
+	flag.StringVar(&discovery, "discovery", strings.Join(nodeagent.DefaultDiscovery, ","),
+		"The device discovery backends (pci, smi, cloud) in order of preference. "+
+			"The npu.ai/discovery label of the node overrides it.")
+	flag.StringVar(&sysfsRoot, "sysfs-root", "/sys", "The sysfs mount scanned by the pci discovery backend.")
+	flag.BoolVar(&nodeConditions, "node-conditions", false,
+		"Mirror the driver, device plugin and device health of the node into Node conditions.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if nodeName == "" {
+		setupLog.Error(nil, "node name must be set via --node-name or NODE_NAME")
+		os.Exit(1)
+	}
+
+	cfg := ctrl.GetConfigOrDie()
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "npu-node-agent", Host: nodeName})
+
+	devices, err := nodeagent.NewKubeletDeviceLister(podResourcesSocket)
+	if err != nil {
+		setupLog.Error(err, "unable to connect to the kubelet pod resources API", "socket", podResourcesSocket)
+		os.Exit(1)
+	}
+	defer devices.Close() //nolint:errcheck
+
+	annotator := &nodeagent.PodAnnotator{Client: c, NodeName: nodeName, Devices: devices}
+	verifier := &nodeagent.CheckpointVerifier{
+		Client:         c,
+		NodeName:       nodeName,
+		CheckpointPath: checkpointPath,
+		Devices:        devices,
+		Recorder:       recorder,
+	}
+
+	discoverer := &nodeagent.DeviceDiscovery{
+		Client:   c,
+		NodeName: nodeName,
+		Backends: nodeagent.DiscoveryBackends(sysfsRoot),
+		Order:    strings.Split(discovery, ","),
+	}
+
+	var reporter *nodeagent.NodeConditionReporter
+	if nodeConditions {
+		reporter = &nodeagent.NodeConditionReporter{Client: c, NodeName: nodeName, Devices: devices}
+	}
+
+	ctx := logf.IntoContext(ctrl.SetupSignalHandler(), ctrl.Log.WithName("node-agent").WithValues("node", nodeName))
+	setupLog.Info("starting node agent", "node", nodeName, "interval", syncInterval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := verifier.Verify(ctx); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to verify the kubelet device checkpoint")
+		}
+		if err := annotator.Sync(ctx); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to sync pod accelerator annotations")
+		}
+		if err := discoverer.Sync(ctx); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to discover devices")
+		}
+		if reporter != nil {
+			if err := reporter.Sync(ctx); err != nil {
+				logf.FromContext(ctx).Error(err, "failed to sync node conditions")
+			}
+		}
+	}, syncInterval)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command status-reader-role generates read-only ClusterRoles for every CRD in
+// config/crd/bases, aggregated into a single status-reader role that dashboards
+// can be bound to. It fails if a CRD version is served without a status subresource.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const aggregateLabel = "npu.ai/aggregate-to-status-reader"
+
+func main() {
+	var crdDir, out string
+	flag.StringVar(&crdDir, "crd-dir", "config/crd/bases", "Directory with the generated CRDs.")
+	flag.StringVar(&out, "out", "config/rbac/status_reader_role.yaml", "Output file.")
+	flag.Parse()
+
+	if err := run(crdDir, out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(crdDir, out string) error {
+	files, err := filepath.Glob(filepath.Join(crdDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	resources := map[string][]string{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Served && (v.Subresources == nil || v.Subresources.Status == nil) {
+				return fmt.Errorf("%s: version %s has no status subresource; add +kubebuilder:subresource:status",
+					crd.Name, v.Name)
+			}
+		}
+		resources[crd.Spec.Group] = append(resources[crd.Spec.Group], crd.Spec.Names.Plural)
+	}
+
+	groups := make([]string, 0, len(resources))
+	for g := range resources {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+
+	var rules []rbacv1.PolicyRule
+	for _, g := range groups {
+		plurals := resources[g]
+		sort.Strings(plurals)
+		status := make([]string, 0, len(plurals))
+		for _, p := range plurals {
+			status = append(status, p+"/status")
+		}
+		rules = append(rules,
+			rbacv1.PolicyRule{APIGroups: []string{g}, Resources: plurals, Verbs: []string{"get", "list", "watch"}},
+			rbacv1.PolicyRule{APIGroups: []string{g}, Resources: status, Verbs: []string{"get"}},
+		)
+	}
+
+	commonLabels := map[string]string{
+		"app.kubernetes.io/name":       "npu-operator",
+		"app.kubernetes.io/managed-by": "kustomize",
+	}
+	aggregated := rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: "status-reader-role", Labels: commonLabels},
+		AggregationRule: &rbacv1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{aggregateLabel: "true"}}},
+		},
+	}
+	crdLabels := map[string]string{aggregateLabel: "true"}
+	for k, v := range commonLabels {
+		crdLabels[k] = v
+	}
+	crds := rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: "status-reader-crds-role", Labels: crdLabels},
+		Rules:      rules,
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by hack/status-reader-role. DO NOT EDIT.\n")
+	buf.WriteString("#\n# Read-only access to every npu-operator CRD and its status, for dashboards and\n")
+	buf.WriteString("# other status-only consumers. Bind subjects to status-reader-role; further roles\n")
+	buf.WriteString("# labeled " + aggregateLabel + "=true are aggregated into it.\n")
+	for i, role := range []rbacv1.ClusterRole{aggregated, crds} {
+		data, err := yaml.Marshal(role)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		data = bytes.ReplaceAll(data, []byte("  creationTimestamp: null\n"), nil)
+		// The controller manager fills in the rules of aggregated roles.
+		buf.Write(bytes.ReplaceAll(data, []byte("rules: null\n"), []byte("rules: []\n")))
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command trim-crd drops the descriptions from the schemas of the CRD versions that are
+// not stored, so served spoke versions do not double the size of a CRD. kubectl explain
+// documents the storage version in full. It fails if a CRD still exceeds maxSize as JSON,
+// which leaves headroom below the 1.5MiB object limit of etcd.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+const maxSize = 1 << 20
+
+func main() {
+	var crdDir string
+	flag.StringVar(&crdDir, "crd-dir", "config/crd/bases", "Directory with the generated CRDs.")
+	flag.Parse()
+
+	if err := run(crdDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(crdDir string) error {
+	files, err := filepath.Glob(filepath.Join(crdDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		// The CRD is edited as a map, so fields unknown to the apiextensions types and the
+		// layout of controller-gen survive the round trip.
+		var crd map[string]any
+		if err := yaml.Unmarshal(data, &crd); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+		spec, _ := crd["spec"].(map[string]any)
+		versions, _ := spec["versions"].([]any)
+		for _, v := range versions {
+			version, _ := v.(map[string]any)
+			if stored, _ := version["storage"].(bool); !stored {
+				dropDescriptions(version["schema"], false)
+			}
+		}
+
+		raw, err := json.Marshal(crd)
+		if err != nil {
+			return err
+		}
+		if len(raw) > maxSize {
+			return fmt.Errorf("%s: %d bytes as JSON, more than %d; shorten the field docs", f, len(raw), maxSize)
+		}
+		out, err := yaml.Marshal(crd)
+		if err != nil {
+			return err
+		}
+		out = append([]byte("---\n"), out...)
+		if bytes.Equal(out, data) {
+			continue
+		}
+		if err := os.WriteFile(f, out, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropDescriptions removes the description of a schema and its nested schemas. The keys
+// of properties are field names, so a field named description is kept.
+func dropDescriptions(node any, properties bool) {
+	switch n := node.(type) {
+	case map[string]any:
+		if !properties {
+			delete(n, "description")
+		}
+		for k, v := range n {
+			dropDescriptions(v, !properties && k == "properties")
+		}
+	case []any:
+		for _, v := range n {
+			dropDescriptions(v, false)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every write the operator performs against the API server, with
+// the object that caused it and a summary of the changed fields, for change audits.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Actor is recorded as the author of every write.
+const Actor = "npu-operator"
+
+// maxChanges bounds the changed fields recorded per write.
+const maxChanges = 20
+
+// changeDepth is how deep changed fields are reported, e.g. spec.template.spec.
+const changeDepth = 4
+
+// Record is one write performed by the operator.
+type Record struct {
+	Time  metav1.Time `json:"time"`
+	Actor string      `json:"actor"`
+	// Verb is create, update, patch, delete or deletecollection.
+	Verb        string `json:"verb"`
+	SubResource string `json:"subresource,omitempty"`
+	APIVersion  string `json:"apiVersion"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	// Cause is the object whose reconciliation performed the write.
+	Cause *Cause `json:"cause,omitempty"`
+	// Changes are the fields the write set or changed.
+	Changes []string `json:"changes,omitempty"`
+	// Error is set when the API server rejected the write.
+	Error string `json:"error,omitempty"`
+}
+
+// Cause identifies the object, at a generation, that a write was performed for.
+type Cause struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+}
+
+type causeKey struct{}
+
+// WithCause returns a context whose writes are attributed to obj.
+func WithCause(ctx context.Context, kind string, obj client.Object) context.Context {
+	return context.WithValue(ctx, causeKey{}, &Cause{
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+	})
+}
+
+func causeFrom(ctx context.Context) *Cause {
+	cause, _ := ctx.Value(causeKey{}).(*Cause)
+	return cause
+}
+
+// Sink receives audit records.
+type Sink interface {
+	Write(ctx context.Context, r Record) error
+}
+
+// NewSink returns the sink of a location: "log" writes records to the operator log, an
+// http(s) URL receives each record as a JSON POST.
+func NewSink(location string) (Sink, error) {
+	switch {
+	case location == "log":
+		return &LogSink{Logger: logf.Log.WithName("audit")}, nil
+	case strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://"):
+		return &WebhookSink{URL: location, Client: &http.Client{Timeout: 5 * time.Second}}, nil
+	}
+	return nil, fmt.Errorf("audit sink %q must be log or an http(s) URL", location)
+}
+
+// LogSink writes records as structured log lines.
+type LogSink struct {
+	Logger logr.Logger
+}
+
+// Write logs the record.
+func (s *LogSink) Write(_ context.Context, r Record) error {
+	kv := []interface{}{"actor", r.Actor, "verb", r.Verb, "apiVersion", r.APIVersion, "kind", r.Kind,
+		"namespace", r.Namespace, "name", r.Name, "changes", r.Changes}
+	if r.SubResource != "" {
+		kv = append(kv, "subresource", r.SubResource)
+	}
+	if r.Cause != nil {
+		kv = append(kv, "cause", fmt.Sprintf("%s %s/%s", r.Cause.Kind, r.Cause.Namespace, r.Cause.Name),
+			"causeGeneration", r.Cause.Generation)
+	}
+	if r.Error != "" {
+		kv = append(kv, "error", r.Error)
+	}
+	s.Logger.Info("Audit", kv...)
+	return nil
+}
+
+// WebhookSink posts each record as JSON to a URL.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Write posts the record and fails on any non-2xx response.
+func (s *WebhookSink) Write(ctx context.Context, r Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit sink %s returned %s", s.URL, resp.Status)
+	}
+	return nil
+}
+
+// Client records the writes of the wrapped client. Reads pass through unchanged.
+type Client struct {
+	client.Client
+	Sink Sink
+}
+
+// NewClient returns a client that records its writes to sink.
+func NewClient(c client.Client, sink Sink) *Client {
+	return &Client{Client: c, Sink: sink}
+}
+
+// Create creates the object and records the write.
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	changes := fieldPaths(toMap(obj))
+	err := c.Client.Create(ctx, obj, opts...)
+	c.record(ctx, "create", "", obj, changes, err)
+	return err
+}
+
+// Update updates the object and records the fields that differ from the cached object.
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	changes := c.updatedFields(ctx, obj)
+	err := c.Client.Update(ctx, obj, opts...)
+	c.record(ctx, "update", "", obj, changes, err)
+	return err
+}
+
+// Patch patches the object and records the fields of the patch.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	changes := patchedFields(patch, obj)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.record(ctx, "patch", "", obj, changes, err)
+	return err
+}
+
+// Delete deletes the object and records the write.
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.record(ctx, "delete", "", obj, nil, err)
+	return err
+}
+
+// DeleteAllOf deletes the matching objects and records the write.
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	c.record(ctx, "deletecollection", "", obj, nil, err)
+	return err
+}
+
+// Status returns a writer for the status subresource that records its writes.
+func (c *Client) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource returns a client for the subresource that records its writes.
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceClient{SubResourceClient: c.Client.SubResource(subResource), parent: c, name: subResource}
+}
+
+type subResourceClient struct {
+	client.SubResourceClient
+	parent *Client
+	name   string
+}
+
+func (s *subResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object,
+	opts ...client.SubResourceCreateOption) error {
+	err := s.SubResourceClient.Create(ctx, obj, subResource, opts...)
+	s.parent.record(ctx, "create", s.name, obj, nil, err)
+	return err
+}
+
+func (s *subResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	changes := s.parent.updatedFields(ctx, obj)
+	err := s.SubResourceClient.Update(ctx, obj, opts...)
+	s.parent.record(ctx, "update", s.name, obj, changes, err)
+	return err
+}
+
+func (s *subResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.SubResourcePatchOption) error {
+	changes := patchedFields(patch, obj)
+	err := s.SubResourceClient.Patch(ctx, obj, patch, opts...)
+	s.parent.record(ctx, "patch", s.name, obj, changes, err)
+	return err
+}
+
+// -- record sends the record of a write to the sink. Delivery is best effort and never fails the write.
+func (c *Client) record(ctx context.Context, verb, subResource string, obj client.Object, changes []string, err error) {
+	r := Record{
+		Time:        metav1.Now(),
+		Actor:       Actor,
+		Verb:        verb,
+		SubResource: subResource,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Cause:       causeFrom(ctx),
+		Changes:     changes,
+	}
+	if gvk, gvkErr := apiutil.GVKForObject(obj, c.Scheme()); gvkErr == nil {
+		r.APIVersion, r.Kind = gvk.GroupVersion().String(), gvk.Kind
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if sinkErr := c.Sink.Write(ctx, r); sinkErr != nil {
+		logf.FromContext(ctx).Error(sinkErr, "failed to write audit record", "kind", r.Kind, "name", r.Name)
+	}
+}
+
+// -- updatedFields returns the fields of obj that differ from the object currently known
+func (c *Client) updatedFields(ctx context.Context, obj client.Object) []string {
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok || c.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, current) != nil {
+		return nil
+	}
+	var paths []string
+	diffPaths(&paths, "", toMap(current), toMap(obj), 0)
+	return limit(paths)
+}
+
+// -- patchedFields returns the fields a patch sets, or the operations of a JSON patch
+func patchedFields(patch client.Patch, obj client.Object) []string {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return nil
+	}
+	if patch.Type() == types.JSONPatchType {
+		var ops []struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}
+		if json.Unmarshal(data, &ops) != nil {
+			return nil
+		}
+		paths := make([]string, 0, len(ops))
+		for _, op := range ops {
+			paths = append(paths, op.Op+" "+op.Path)
+		}
+		return limit(paths)
+	}
+	var doc map[string]interface{}
+	if json.Unmarshal(data, &doc) != nil {
+		return nil
+	}
+	return fieldPaths(doc)
+}
+
+func toMap(obj runtime.Object) map[string]interface{} {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent()
+	}
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// -- fieldPaths lists the fields set in a document down to changeDepth
+func fieldPaths(doc map[string]interface{}) []string {
+	var paths []string
+	diffPaths(&paths, "", nil, doc, 0)
+	return limit(paths)
+}
+
+// -- diffPaths appends the paths below prefix at which the documents differ, down to changeDepth
+func diffPaths(paths *[]string, prefix string, before, after map[string]interface{}, depth int) {
+	keys := map[string]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if ignoredField(path) || equality.Semantic.DeepEqual(before[k], after[k]) {
+			continue
+		}
+		beforeMap, _ := before[k].(map[string]interface{})
+		afterMap, isMap := after[k].(map[string]interface{})
+		if isMap && depth+1 < changeDepth {
+			diffPaths(paths, path, beforeMap, afterMap, depth+1)
+			continue
+		}
+		*paths = append(*paths, path)
+	}
+}
+
+func ignoredField(path string) bool {
+	switch path {
+	case "apiVersion", "kind", "metadata.resourceVersion", "metadata.managedFields", "metadata.generation",
+		"metadata.creationTimestamp", "metadata.uid":
+		return true
+	}
+	return false
+}
+
+func limit(paths []string) []string {
+	sort.Strings(paths)
+	if len(paths) > maxChanges {
+		paths = append(paths[:maxChanges], fmt.Sprintf("... %d more", len(paths)-maxChanges))
+	}
+	return paths
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// memorySink keeps the records it receives.
+type memorySink struct {
+	records []Record
+}
+
+func (s *memorySink) Write(_ context.Context, r Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+var _ = Describe("Audit client", func() {
+	var (
+		sink *memorySink
+		c    *Client
+		ctx  context.Context
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		sink = &memorySink{}
+		c = NewClient(fake.NewClientBuilder().WithScheme(scheme).Build(), sink)
+		cause := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default", Generation: 4}}
+		ctx = WithCause(context.Background(), "NPUClusterPolicy", cause)
+	})
+
+	It("records creates, updates, patches and deletes with their cause and changes", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "plugin-config", Namespace: "kube-system"},
+			Data:       map[string]string{"config.yaml": "a"},
+		}
+		Expect(c.Create(ctx, cm)).To(Succeed())
+
+		cm.Data["config.yaml"] = "b"
+		Expect(c.Update(ctx, cm)).To(Succeed())
+
+		patch := client.MergeFrom(cm.DeepCopy())
+		cm.Labels = map[string]string{"team": "ml"}
+		Expect(c.Patch(ctx, cm, patch)).To(Succeed())
+
+		Expect(c.Delete(ctx, cm)).To(Succeed())
+
+		Expect(sink.records).To(HaveLen(4))
+		for _, r := range sink.records {
+			Expect(r.Actor).To(Equal(Actor))
+			Expect(r.Kind).To(Equal("ConfigMap"))
+			Expect(r.APIVersion).To(Equal("v1"))
+			Expect(r.Namespace).To(Equal("kube-system"))
+			Expect(r.Name).To(Equal("plugin-config"))
+			Expect(r.Cause).To(Equal(&Cause{Kind: "NPUClusterPolicy", Namespace: "default", Name: "cluster", Generation: 4}))
+			Expect(r.Error).To(BeEmpty())
+		}
+		Expect(sink.records[0].Verb).To(Equal("create"))
+		Expect(sink.records[0].Changes).To(ContainElement("data.config.yaml"))
+		Expect(sink.records[1].Verb).To(Equal("update"))
+		Expect(sink.records[1].Changes).To(Equal([]string{"data.config.yaml"}))
+		Expect(sink.records[2].Verb).To(Equal("patch"))
+		Expect(sink.records[2].Changes).To(Equal([]string{"metadata.labels.team"}))
+		Expect(sink.records[3].Verb).To(Equal("delete"))
+	})
+
+	It("records rejected writes and status writes", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1"}}
+		Expect(c.Status().Update(ctx, node)).NotTo(Succeed())
+
+		Expect(sink.records).To(HaveLen(1))
+		Expect(sink.records[0].Verb).To(Equal("update"))
+		Expect(sink.records[0].SubResource).To(Equal("status"))
+		Expect(sink.records[0].Error).NotTo(BeEmpty())
+	})
+
+	It("posts records to a webhook", func() {
+		received := make(chan Record, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var record Record
+			Expect(json.NewDecoder(r.Body).Decode(&record)).To(Succeed())
+			received <- record
+		}))
+		defer server.Close()
+
+		s, err := NewSink(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Write(context.Background(), Record{Actor: Actor, Verb: "delete", Kind: "DaemonSet"})).To(Succeed())
+		Expect(received).To(Receive(HaveField("Verb", "delete")))
+
+		_, err = NewSink("syslog")
+		Expect(err).To(HaveOccurred())
+	})
+})
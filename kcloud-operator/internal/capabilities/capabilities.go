@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capabilities detects the optional APIs and features of the cluster at startup,
+// so integrations depending on them are switched off and reported instead of failing
+// reconciles. APIs served by CRDs are followed afterwards, so integrations are switched
+// on once their CRD is installed.
+package capabilities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// Capabilities of a cluster. The zero value has none.
+type Capabilities struct {
+	// PodSecurityPolicy is served, i.e. the cluster predates Kubernetes 1.25.
+	PodSecurityPolicy bool
+	// PodSecurityAdmission enforces the pod-security.kubernetes.io namespace labels.
+	PodSecurityAdmission bool
+	// ServiceMonitor is served by the Prometheus Operator.
+	ServiceMonitor bool
+	// PrometheusRule is served by the Prometheus Operator.
+	PrometheusRule bool
+	// CertManager serves cert-manager.io Certificates.
+	CertManager bool
+	// ClusterAPI serves MachineDeployments.
+	ClusterAPI bool
+	// RuntimeClass is served by node.k8s.io/v1.
+	RuntimeClass bool
+	// DynamicResourceAllocation serves ResourceSlices, i.e. the DRA feature gate is on.
+	DynamicResourceAllocation bool
+	// KueueResourceFlavor is served by Kueue.
+	KueueResourceFlavor bool
+	// OpenShift serves SecurityContextConstraints.
+	OpenShift bool
+}
+
+// All returns capabilities with every integration available. It stands in when detection
+// is not possible, so integrations fail on the missing API as before.
+func All() *Capabilities {
+	return &Capabilities{
+		PodSecurityAdmission:      true,
+		ServiceMonitor:            true,
+		PrometheusRule:            true,
+		CertManager:               true,
+		ClusterAPI:                true,
+		RuntimeClass:              true,
+		DynamicResourceAllocation: true,
+		KueueResourceFlavor:       true,
+	}
+}
+
+// resources identify each API capability by a group, a version or "" for any, and a resource.
+var resources = []struct {
+	group, version, resource string
+	field                    func(*Capabilities) *bool
+}{
+	{"policy", "v1beta1", "podsecuritypolicies", func(c *Capabilities) *bool { return &c.PodSecurityPolicy }},
+	{"monitoring.coreos.com", "v1", "servicemonitors", func(c *Capabilities) *bool { return &c.ServiceMonitor }},
+	{"monitoring.coreos.com", "v1", "prometheusrules", func(c *Capabilities) *bool { return &c.PrometheusRule }},
+	{"cert-manager.io", "v1", "certificates", func(c *Capabilities) *bool { return &c.CertManager }},
+	{"cluster.x-k8s.io", "v1beta1", "machinedeployments", func(c *Capabilities) *bool { return &c.ClusterAPI }},
+	{"node.k8s.io", "v1", "runtimeclasses", func(c *Capabilities) *bool { return &c.RuntimeClass }},
+	{"resource.k8s.io", "", "resourceslices", func(c *Capabilities) *bool { return &c.DynamicResourceAllocation }},
+	{"kueue.x-k8s.io", "v1beta1", "resourceflavors", func(c *Capabilities) *bool { return &c.KueueResourceFlavor }},
+	{"security.openshift.io", "v1", "securitycontextconstraints", func(c *Capabilities) *bool { return &c.OpenShift }},
+}
+
+// podSecurityAdmissionMinor is the first minor release enabling Pod Security Admission by default.
+const podSecurityAdmissionMinor = 23
+
+// Detect queries the discovery API. Groups that fail discovery, e.g. because their
+// aggregated API server is down, are treated as absent.
+func Detect(client discovery.DiscoveryInterface) (*Capabilities, error) {
+	caps := &Capabilities{}
+
+	_, lists, err := client.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discovering API resources: %w", err)
+	}
+	for _, list := range lists {
+		group, version, _ := strings.Cut(list.GroupVersion, "/")
+		if version == "" {
+			group, version = "", group
+		}
+		for _, r := range resources {
+			if r.group != group || (r.version != "" && r.version != version) {
+				continue
+			}
+			for _, res := range list.APIResources {
+				if res.Name == r.resource {
+					*r.field(caps) = true
+				}
+			}
+		}
+	}
+
+	info, err := client.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("discovering server version: %w", err)
+	}
+	// Managed distributions report minors like "27+".
+	minor, err := strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing server minor version %q: %w", info.Minor, err)
+	}
+	caps.PodSecurityAdmission = info.Major == "1" && minor >= podSecurityAdmissionMinor
+	return caps, nil
+}
+
+// Available lists the names of the present capabilities, for logging.
+func (c *Capabilities) Available() []string {
+	var names []string
+	for _, entry := range []struct {
+		name    string
+		present bool
+	}{
+		{"PodSecurityPolicy", c.PodSecurityPolicy},
+		{"PodSecurityAdmission", c.PodSecurityAdmission},
+		{"ServiceMonitor", c.ServiceMonitor},
+		{"PrometheusRule", c.PrometheusRule},
+		{"CertManager", c.CertManager},
+		{"ClusterAPI", c.ClusterAPI},
+		{"RuntimeClass", c.RuntimeClass},
+		{"DynamicResourceAllocation", c.DynamicResourceAllocation},
+		{"KueueResourceFlavor", c.KueueResourceFlavor},
+		{"OpenShift", c.OpenShift},
+	} {
+		if entry.present {
+			names = append(names, entry.name)
+		}
+	}
+	return names
+}
+
+// ObserveCRD updates the capability served by a CRD, which is present once the CRD is
+// established and serves the required version. It reports whether the capability changed.
+func (c *Capabilities) ObserveCRD(crd *apiextensionsv1.CustomResourceDefinition, deleted bool) bool {
+	for _, r := range resources {
+		if crd.Name != r.resource+"."+r.group {
+			continue
+		}
+		present := !deleted && established(crd) && servesVersion(crd, r.version)
+		field := r.field(c)
+		changed := *field != present
+		*field = present
+		return changed
+	}
+	return false
+}
+
+// IsIntegrationCRD reports whether a CRD serves a capability.
+func IsIntegrationCRD(name string) bool {
+	for _, r := range resources {
+		if name == r.resource+"."+r.group {
+			return true
+		}
+	}
+	return false
+}
+
+func established(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established {
+			return cond.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func servesVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) bool {
+	for _, v := range crd.Spec.Versions {
+		if v.Served && (version == "" || v.Name == version) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func discoveryOf(minor string, lists ...*metav1.APIResourceList) *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake:               &clienttesting.Fake{Resources: lists},
+		FakedServerVersion: &version.Info{Major: "1", Minor: minor},
+	}
+}
+
+func resourceList(groupVersion string, names ...string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, name := range names {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: name})
+	}
+	return list
+}
+
+var _ = Describe("Detect", func() {
+	It("should detect the served APIs", func() {
+		caps, err := Detect(discoveryOf("33",
+			resourceList("v1", "pods", "nodes"),
+			resourceList("node.k8s.io/v1", "runtimeclasses"),
+			resourceList("monitoring.coreos.com/v1", "servicemonitors", "podmonitors"),
+			resourceList("resource.k8s.io/v1beta1", "resourceslices", "deviceclasses"),
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*caps).To(Equal(Capabilities{
+			PodSecurityAdmission:      true,
+			ServiceMonitor:            true,
+			RuntimeClass:              true,
+			DynamicResourceAllocation: true,
+		}))
+		Expect(caps.Available()).To(Equal([]string{"PodSecurityAdmission", "ServiceMonitor", "RuntimeClass", "DynamicResourceAllocation"}))
+	})
+
+	It("should detect PodSecurityPolicy and OpenShift on older clusters", func() {
+		caps, err := Detect(discoveryOf("22+",
+			resourceList("policy/v1beta1", "podsecuritypolicies"),
+			resourceList("security.openshift.io/v1", "securitycontextconstraints"),
+			resourceList("cert-manager.io/v1", "certificates"),
+			resourceList("cluster.x-k8s.io/v1beta1", "machinedeployments"),
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*caps).To(Equal(Capabilities{
+			PodSecurityPolicy: true,
+			CertManager:       true,
+			ClusterAPI:        true,
+			OpenShift:         true,
+		}))
+	})
+
+	It("should ignore resources of other versions", func() {
+		caps, err := Detect(discoveryOf("30", resourceList("cert-manager.io/v1alpha2", "certificates")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(caps.CertManager).To(BeFalse())
+	})
+})
+
+func crd(name, version string, established bool) *apiextensionsv1.CustomResourceDefinition {
+	status := apiextensionsv1.ConditionFalse
+	if established {
+		status = apiextensionsv1.ConditionTrue
+	}
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: version, Served: true}},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{Type: apiextensionsv1.Established, Status: status}},
+		},
+	}
+}
+
+var _ = Describe("ObserveCRD", func() {
+	It("should enable a capability once its CRD is established", func() {
+		caps := &Capabilities{}
+		Expect(caps.ObserveCRD(crd("servicemonitors.monitoring.coreos.com", "v1", false), false)).To(BeFalse())
+		Expect(caps.ServiceMonitor).To(BeFalse())
+
+		Expect(caps.ObserveCRD(crd("servicemonitors.monitoring.coreos.com", "v1", true), false)).To(BeTrue())
+		Expect(caps.ServiceMonitor).To(BeTrue())
+		Expect(caps.ObserveCRD(crd("servicemonitors.monitoring.coreos.com", "v1", true), false)).To(BeFalse())
+
+		Expect(caps.ObserveCRD(crd("servicemonitors.monitoring.coreos.com", "v1", true), true)).To(BeTrue())
+		Expect(caps.ServiceMonitor).To(BeFalse())
+	})
+
+	It("should require the version the integration uses", func() {
+		caps := &Capabilities{}
+		Expect(caps.ObserveCRD(crd("resourceflavors.kueue.x-k8s.io", "v1alpha1", true), false)).To(BeFalse())
+		Expect(caps.ObserveCRD(crd("resourceflavors.kueue.x-k8s.io", "v1beta1", true), false)).To(BeTrue())
+		Expect(caps.KueueResourceFlavor).To(BeTrue())
+	})
+
+	It("should ignore unrelated CRDs", func() {
+		Expect(IsIntegrationCRD("prometheusrules.monitoring.coreos.com")).To(BeTrue())
+		Expect(IsIntegrationCRD("npuclusterpolicies.npu.ai")).To(BeFalse())
+		caps := &Capabilities{}
+		Expect(caps.ObserveCRD(crd("npuclusterpolicies.npu.ai", "v1alpha1", true), false)).To(BeFalse())
+		Expect(*caps).To(Equal(Capabilities{}))
+	})
+})
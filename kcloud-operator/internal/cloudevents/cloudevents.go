@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudevents delivers operator lifecycle transitions to an external HTTP
+// sink as CloudEvents (v1.0, binary content mode), so automation can react
+// without polling the API server.
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// Event types emitted by the operator.
+const (
+	TypePolicyReady     = "ai.npu.policy.ready"
+	TypeRolloutStarted  = "ai.npu.rollout.started"
+	TypeRolloutFinished = "ai.npu.rollout.finished"
+	TypeNodeQuarantined = "ai.npu.node.quarantined"
+	TypeNodeThrottled   = "ai.npu.node.throttled"
+)
+
+const specVersion = "1.0"
+
+// Event is a lifecycle transition to deliver.
+type Event struct {
+	// Type is one of the Type* constants.
+	Type string
+	// Source identifies the emitting object, e.g. /namespaces/default/npuclusterpolicies/cluster.
+	Source string
+	// Subject optionally narrows the source, e.g. a vendor or node name.
+	Subject string
+	// Data is serialized as the JSON payload.
+	Data interface{}
+}
+
+// Emitter delivers events to a sink.
+type Emitter interface {
+	Emit(ctx context.Context, e Event) error
+}
+
+// HTTPSink posts events to a URL using the CloudEvents HTTP binding.
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPSink returns a sink that posts to url with the given request timeout.
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{URL: url, Client: &http.Client{Timeout: timeout}}
+}
+
+// Emit posts the event and fails on any non-2xx response.
+func (s *HTTPSink) Emit(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", specVersion)
+	req.Header.Set("ce-id", string(uuid.NewUUID()))
+	req.Header.Set("ce-type", e.Type)
+	req.Header.Set("ce-source", e.Source)
+	req.Header.Set("ce-time", time.Now().UTC().Format(time.RFC3339Nano))
+	if e.Subject != "" {
+		req.Header.Set("ce-subject", e.Subject)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("cloudevents sink %s returned %s", s.URL, resp.Status)
+	}
+	return nil
+}
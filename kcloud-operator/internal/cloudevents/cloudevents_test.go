@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPSink", func() {
+	It("posts events using the CloudEvents HTTP binary binding", func() {
+		var got *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sink := NewHTTPSink(server.URL, time.Second)
+		Expect(sink.Emit(context.Background(), Event{
+			Type:    TypePolicyReady,
+			Source:  "/apis/npu.ai/v1alpha1/namespaces/default/npuclusterpolicies/cluster",
+			Subject: "nvidia",
+			Data:    map[string]string{"phase": "Ready"},
+		})).To(Succeed())
+
+		Expect(got.Header.Get("ce-specversion")).To(Equal("1.0"))
+		Expect(got.Header.Get("ce-type")).To(Equal(TypePolicyReady))
+		Expect(got.Header.Get("ce-source")).To(HaveSuffix("/npuclusterpolicies/cluster"))
+		Expect(got.Header.Get("ce-subject")).To(Equal("nvidia"))
+		Expect(got.Header.Get("ce-id")).NotTo(BeEmpty())
+		Expect(got.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(string(body)).To(MatchJSON(`{"phase":"Ready"}`))
+	})
+
+	It("fails when the sink rejects the event", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewHTTPSink(server.URL, time.Second).Emit(context.Background(), Event{Type: TypeRolloutStarted})
+		Expect(err).To(MatchError(ContainSubstring("503")))
+	})
+})
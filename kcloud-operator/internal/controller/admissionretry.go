@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	admissionRetryBaseDelay = 2 * time.Second
+	admissionRetryMaxDelay  = time.Minute
+	// admissionRetryLimit is the number of retries, about 6 minutes, before transient failures
+	// are reported as errors.
+	admissionRetryLimit = 10
+)
+
+// -- transientAdmissionFailure reports whether the error is expected to resolve on its own, e.g.
+// a webhook of an optional integration whose certificate is not ready yet, or a restarting
+// API server
+func transientAdmissionFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && apierrors.IsInternalError(err) &&
+		strings.Contains(status.Status().Message, "failed calling webhook") {
+		return true
+	}
+	return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err)
+}
+
+// admissionRetries counts the consecutive transient failures of each policy, so they are
+// retried with their own backoff instead of the rate limiter of failed reconciles.
+type admissionRetries struct {
+	mu       sync.Mutex
+	attempts map[types.NamespacedName]int
+}
+
+// -- next counts a failure of the policy and returns the attempt and its delay, or false once
+// the retries are exhausted
+func (a *admissionRetries) next(key types.NamespacedName) (int, time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.attempts == nil {
+		a.attempts = map[types.NamespacedName]int{}
+	}
+	attempt := a.attempts[key] + 1
+	if attempt > admissionRetryLimit {
+		return attempt, 0, false
+	}
+	a.attempts[key] = attempt
+	return attempt, min(admissionRetryBaseDelay<<(attempt-1), admissionRetryMaxDelay), true
+}
+
+// -- forget resets the failures of the policy after a successful reconcile
+func (a *admissionRetries) forget(key types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.attempts, key)
+}
+
+// -- retryAdmission requeues the policy after a transient failure and reports the retry by the
+// Progressing condition. It returns false once the retries are exhausted, so the failure is
+// reported as an error.
+func (r *NPUClusterPolicyReconciler) retryAdmission(ctx context.Context, key types.NamespacedName,
+	reconcileErr error) (ctrl.Result, bool) {
+	attempt, delay, ok := r.admissionRetries.next(key)
+	if !ok {
+		return ctrl.Result{}, false
+	}
+	log := logf.FromContext(ctx)
+	log.Info("Retrying after a transient failure", "attempt", attempt, "after", delay, "error", reconcileErr.Error())
+
+	policy := &npuv1alpha1.NPUClusterPolicy{}
+	if err := r.Get(ctx, key, policy); err != nil {
+		return ctrl.Result{RequeueAfter: delay}, true
+	}
+	base := policy.DeepCopy()
+	conditions.MarkTrue(policy, conditions.Progressing, conditions.ReasonAdmissionRetry,
+		fmt.Sprintf("Retrying after a transient failure (attempt %d of %d): %v", attempt, admissionRetryLimit, reconcileErr))
+	if err := r.Status().Patch(ctx, policy, client.MergeFrom(base)); err != nil {
+		log.Error(err, "failed to report the retry")
+	}
+	return ctrl.Result{RequeueAfter: delay}, true
+}
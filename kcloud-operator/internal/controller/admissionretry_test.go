@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Admission retries", func() {
+	It("should tell transient failures from permanent ones", func() {
+		webhook := apierrors.NewInternalError(errors.New(`Internal error occurred: failed calling webhook "mutate.example.com": ` +
+			`tls: failed to verify certificate`))
+		Expect(transientAdmissionFailure(webhook)).To(BeTrue())
+		Expect(transientAdmissionFailure(fmt.Errorf("apply device plugin: %w", webhook))).To(BeTrue())
+		Expect(transientAdmissionFailure(apierrors.NewServiceUnavailable("restarting"))).To(BeTrue())
+		Expect(transientAdmissionFailure(apierrors.NewTooManyRequests("slow down", 1))).To(BeTrue())
+		Expect(transientAdmissionFailure(fmt.Errorf("dial: %w", syscall.ECONNREFUSED))).To(BeTrue())
+
+		Expect(transientAdmissionFailure(nil)).To(BeFalse())
+		Expect(transientAdmissionFailure(apierrors.NewInternalError(errors.New("etcd is on fire")))).To(BeFalse())
+		Expect(transientAdmissionFailure(apierrors.NewForbidden(schema.GroupResource{Resource: "daemonsets"}, "plugin",
+			errors.New("denied by policy")))).To(BeFalse())
+		Expect(transientAdmissionFailure(errors.New("invalid image"))).To(BeFalse())
+	})
+
+	It("should back off per policy until the retries are exhausted", func() {
+		var retries admissionRetries
+		key := types.NamespacedName{Namespace: "default", Name: "cluster"}
+		var delays []time.Duration
+		for range admissionRetryLimit {
+			_, delay, ok := retries.next(key)
+			Expect(ok).To(BeTrue())
+			delays = append(delays, delay)
+		}
+		Expect(delays[:3]).To(Equal([]time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}))
+		Expect(delays[admissionRetryLimit-1]).To(Equal(admissionRetryMaxDelay))
+		_, _, ok := retries.next(key)
+		Expect(ok).To(BeFalse())
+
+		attempt, _, ok := retries.next(types.NamespacedName{Namespace: "default", Name: "other"})
+		Expect(ok).To(BeTrue())
+		Expect(attempt).To(Equal(1))
+
+		retries.forget(key)
+		attempt, delay, ok := retries.next(key)
+		Expect(ok).To(BeTrue())
+		Expect(attempt).To(Equal(1))
+		Expect(delay).To(Equal(admissionRetryBaseDelay))
+	})
+})
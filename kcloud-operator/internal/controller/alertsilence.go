@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"npu-operator/internal/silence"
+)
+
+// -- silenceNode suppresses the alerts of a node the operator is about to disrupt. Failures are
+// only logged, as planned work does not wait for the alerting stack.
+func silenceNode(ctx context.Context, silencer silence.Silencer, node, comment string) {
+	if silencer == nil {
+		return
+	}
+	if err := silencer.Silence(ctx, node, comment); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to silence alerts of node", "node", node)
+	}
+}
+
+// -- unsilenceNode restores the alerts of a node once the operator is done with it
+func unsilenceNode(ctx context.Context, silencer silence.Silencer, node string) {
+	if silencer == nil {
+		return
+	}
+	if err := silencer.Unsilence(ctx, node); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to restore alerts of node", "node", node)
+	}
+}
+
+// -- unsilenceFinished restores the alerts of the nodes no longer disrupted
+func unsilenceFinished(ctx context.Context, silencer silence.Silencer, previous, current []string) {
+	for _, node := range previous {
+		if !slices.Contains(current, node) {
+			unsilenceNode(ctx, silencer, node)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recordingSilencer records the silenced nodes.
+type recordingSilencer struct {
+	silenced map[string]string
+	err      error
+}
+
+func (s *recordingSilencer) Silence(_ context.Context, node, comment string) error {
+	if s.silenced == nil {
+		s.silenced = map[string]string{}
+	}
+	s.silenced[node] = comment
+	return s.err
+}
+
+func (s *recordingSilencer) Unsilence(_ context.Context, node string) error {
+	delete(s.silenced, node)
+	return s.err
+}
+
+var _ = Describe("Alert silencing", func() {
+	ctx := context.Background()
+
+	It("should restore the alerts of the nodes no longer disrupted", func() {
+		silencer := &recordingSilencer{}
+		for _, node := range []string{"gpu-1", "gpu-2", "gpu-3"} {
+			silenceNode(ctx, silencer, node, "Upgrade of nvidia-driver")
+		}
+		unsilenceFinished(ctx, silencer, []string{"gpu-1", "gpu-2", "gpu-3"}, []string{"gpu-2", "gpu-4"})
+		Expect(silencer.silenced).To(Equal(map[string]string{"gpu-2": "Upgrade of nvidia-driver"}))
+	})
+
+	It("should not fail the disruption when silencing fails", func() {
+		silencer := &recordingSilencer{err: errors.New("alertmanager is down")}
+		silenceNode(ctx, silencer, "gpu-1", "Reboot")
+		unsilenceNode(ctx, silencer, "gpu-1")
+		silenceNode(ctx, nil, "gpu-1", "Reboot")
+		unsilenceFinished(ctx, nil, []string{"gpu-1"}, nil)
+	})
+})
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/reservation"
+)
+
+const (
+	// Labels of the NVIDIA MIG manager: the configuration to apply, and the state of the last one.
+	migConfigLabel      = "nvidia.com/mig.config"
+	migConfigStateLabel = "nvidia.com/mig.config.state"
+	migCapableLabel     = "nvidia.com/mig.capable"
+
+	defaultMIGCooldown  = 30 * time.Minute
+	autoMIGPollInterval = time.Minute
+	// maxMIGRepartitions bounds the repartitions kept in the status.
+	maxMIGRepartitions = 10
+)
+
+// migDemand is the MIG resources requested by unschedulable pods.
+type migDemand struct {
+	resource corev1.ResourceName
+	count    int64
+	pods     int
+}
+
+// -- autoMIG follows the repartition in progress and starts the next one the pending demand calls
+// for, and reports whether auto-MIG is enabled
+func (r *NPUClusterPolicyReconciler) autoMIG(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	spec := policy.Spec.Nvidia.AutoMIG
+	if spec == nil || !spec.Enabled || !policy.Spec.Nvidia.Enabled {
+		policy.Status.MIGRepartitions = nil
+		return false, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return true, err
+	}
+	byName := map[string]*corev1.Node{}
+	for i := range nodes.Items {
+		byName[nodes.Items[i].Name] = &nodes.Items[i]
+	}
+	if last := lastMIGRepartition(policy); last != nil && last.Phase == npuv1alpha1.MIGRepartitionInProgress {
+		observeMIGRepartition(last, byName[last.Node])
+		switch last.Phase {
+		case npuv1alpha1.MIGRepartitionInProgress:
+			return true, nil
+		case npuv1alpha1.MIGRepartitionSucceeded:
+			r.Recorder.Eventf(policy, corev1.EventTypeNormal, "MIGRepartitioned",
+				"Node %s was repartitioned to %s", last.Node, last.To)
+		default:
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, "MIGRepartitionFailed",
+				"Node %s could not be repartitioned to %s", last.Node, last.To)
+		}
+	}
+	if !inMaintenanceWindow(spec.MaintenanceWindows, time.Now()) {
+		return true, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		return true, err
+	}
+	step := planMIGRepartition(spec, nodes.Items, pods.Items, policy.Status.MIGRepartitions, time.Now())
+	if step == nil {
+		return true, nil
+	}
+
+	node := byName[step.Node]
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Labels[migConfigLabel] = step.To
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return true, err
+	}
+	log.Info("Repartitioning MIG node", "node", step.Node, "from", step.From, "to", step.To, "reason", step.Reason)
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "MIGRepartitionStarted",
+		"Repartitioning node %s from %s to %s: %s", step.Node, migLayoutName(step.From), step.To, step.Reason)
+	policy.Status.MIGRepartitions = append(policy.Status.MIGRepartitions, *step)
+	if n := len(policy.Status.MIGRepartitions); n > maxMIGRepartitions {
+		policy.Status.MIGRepartitions = policy.Status.MIGRepartitions[n-maxMIGRepartitions:]
+	}
+	return true, nil
+}
+
+// -- lastMIGRepartition returns the latest repartition, or nil
+func lastMIGRepartition(policy *npuv1alpha1.NPUClusterPolicy) *npuv1alpha1.MIGRepartition {
+	if n := len(policy.Status.MIGRepartitions); n > 0 {
+		return &policy.Status.MIGRepartitions[n-1]
+	}
+	return nil
+}
+
+// -- observeMIGRepartition completes the repartition once the MIG manager reported its state
+func observeMIGRepartition(step *npuv1alpha1.MIGRepartition, node *corev1.Node) {
+	switch {
+	case node == nil || node.Labels[migConfigLabel] != step.To:
+		// The node is gone or was relabeled by someone else.
+		step.Phase = npuv1alpha1.MIGRepartitionFailed
+	case node.Labels[migConfigStateLabel] == "success":
+		step.Phase = npuv1alpha1.MIGRepartitionSucceeded
+	case node.Labels[migConfigStateLabel] == "failed":
+		step.Phase = npuv1alpha1.MIGRepartitionFailed
+	default:
+		return
+	}
+	now := metav1.Now()
+	step.CompletionTime = &now
+}
+
+// -- planMIGRepartition picks the idle node to repartition to the layout providing the most
+// requested pending MIG resource, within the node limits of the layouts, or returns nil
+func planMIGRepartition(spec *npuv1alpha1.AutoMIGSpec, nodes []corev1.Node, pods []corev1.Pod,
+	history []npuv1alpha1.MIGRepartition, now time.Time) *npuv1alpha1.MIGRepartition {
+	selector := labels.SelectorFromSet(spec.NodeSelector)
+	if len(spec.NodeSelector) == 0 {
+		selector = labels.SelectorFromSet(map[string]string{migCapableLabel: "true"})
+	}
+	cooldown := defaultMIGCooldown
+	if spec.Cooldown != nil {
+		cooldown = spec.Cooldown.Duration
+	}
+	lastChange := map[string]time.Time{}
+	for _, step := range history {
+		lastChange[step.Node] = step.StartTime.Time
+	}
+
+	busy := map[string]bool{}
+	for i := range pods {
+		if pod := &pods[i]; pod.Spec.NodeName != "" && !podFinished(pod) && acceleratorRequests(pod) > 0 {
+			busy[pod.Spec.NodeName] = true
+		}
+	}
+	onLayout := map[string]int32{}
+	var candidates []*corev1.Node
+	for i := range nodes {
+		node := &nodes[i]
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		onLayout[node.Labels[migConfigLabel]]++
+		if busy[node.Name] || unmanagedNode(node) || node.Spec.Unschedulable || now.Sub(lastChange[node.Name]) < cooldown {
+			continue
+		}
+		if state := node.Labels[migConfigStateLabel]; state == "pending" || state == "rebooting" {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	slices.SortFunc(candidates, func(a, b *corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, demand := range pendingMIGDemand(spec, pods) {
+		layouts := slices.Clone(spec.Layouts)
+		slices.SortStableFunc(layouts, func(a, b npuv1alpha1.MIGLayout) int {
+			return cmp.Compare(b.Resources[demand.resource], a.Resources[demand.resource])
+		})
+		for _, layout := range layouts {
+			if layout.Resources[demand.resource] == 0 {
+				break
+			}
+			if layout.MaxNodes != nil && onLayout[layout.Name] >= *layout.MaxNodes {
+				continue
+			}
+			for _, node := range candidates {
+				from := node.Labels[migConfigLabel]
+				if from == layout.Name || !migLayoutReleasable(spec, from, onLayout[from]) {
+					continue
+				}
+				return &npuv1alpha1.MIGRepartition{
+					Node:      node.Name,
+					From:      from,
+					To:        layout.Name,
+					Reason:    fmt.Sprintf("%d pending pods request %d %s", demand.pods, demand.count, demand.resource),
+					Phase:     npuv1alpha1.MIGRepartitionInProgress,
+					StartTime: metav1.NewTime(now),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// -- pendingMIGDemand sums the MIG resources of the layouts requested by unschedulable pods, most
+// requested first
+func pendingMIGDemand(spec *npuv1alpha1.AutoMIGSpec, pods []corev1.Pod) []migDemand {
+	var resources []corev1.ResourceName
+	for _, layout := range spec.Layouts {
+		for resource := range layout.Resources {
+			if !slices.Contains(resources, resource) {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	var demand []migDemand
+	for _, resource := range resources {
+		d := migDemand{resource: resource}
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Spec.NodeName != "" || pod.DeletionTimestamp != nil || !unschedulable(pod) {
+				continue
+			}
+			if n := reservation.Request(pod, string(resource)); n > 0 {
+				d.count += n
+				d.pods++
+			}
+		}
+		if d.pods > 0 {
+			demand = append(demand, d)
+		}
+	}
+	slices.SortFunc(demand, func(a, b migDemand) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.resource), string(b.resource))
+	})
+	return demand
+}
+
+// -- migLayoutReleasable reports whether a node may leave its layout without going below the
+// minimum nodes of the layout
+func migLayoutReleasable(spec *npuv1alpha1.AutoMIGSpec, name string, nodes int32) bool {
+	for _, layout := range spec.Layouts {
+		if layout.Name == name && layout.MinNodes != nil {
+			return nodes > *layout.MinNodes
+		}
+	}
+	return true
+}
+
+// -- inMaintenanceWindow reports whether now is within one of the windows, or true without windows
+func inMaintenanceWindow(windows []npuv1alpha1.MaintenanceWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	now = now.UTC()
+	for _, w := range windows {
+		start, err := time.Parse("15:04", w.Start)
+		if err != nil {
+			continue
+		}
+		// A window opened on the previous day may still be open.
+		for _, day := range []time.Time{now, now.AddDate(0, 0, -1)} {
+			if len(w.Days) > 0 && !slices.Contains(w.Days, npuv1alpha1.Weekday(day.Weekday().String()[:3])) {
+				continue
+			}
+			open := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+			if !now.Before(open) && now.Before(open.Add(w.Duration.Duration)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// -- unschedulable reports whether the scheduler failed to place the pod
+func unschedulable(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled {
+			return c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable
+		}
+	}
+	return false
+}
+
+func podFinished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func migLayoutName(name string) string {
+	if name == "" {
+		return "no layout"
+	}
+	return name
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Auto MIG", func() {
+	now := time.Date(2025, time.March, 8, 3, 0, 0, 0, time.UTC) // a Saturday
+
+	migNode := func(name, layout string) corev1.Node {
+		labels := map[string]string{migCapableLabel: "true", migConfigStateLabel: "success"}
+		if layout != "" {
+			labels[migConfigLabel] = layout
+		}
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	migPod := func(name, node string, resourceName corev1.ResourceName, count string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ml"},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+				Containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{resourceName: resource.MustParse(count)},
+				}}},
+			},
+		}
+		if node == "" {
+			pod.Status.Conditions = []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable,
+			}}
+		} else {
+			pod.Status.Phase = corev1.PodRunning
+		}
+		return pod
+	}
+	spec := func() *npuv1alpha1.AutoMIGSpec {
+		return &npuv1alpha1.AutoMIGSpec{
+			Enabled: true,
+			Layouts: []npuv1alpha1.MIGLayout{
+				{Name: "all-1g.10gb", Resources: map[corev1.ResourceName]int64{"nvidia.com/mig-1g.10gb": 56}},
+				{Name: "all-3g.40gb", Resources: map[corev1.ResourceName]int64{"nvidia.com/mig-3g.40gb": 16}},
+				{Name: "all-disabled", Resources: map[corev1.ResourceName]int64{"nvidia.com/gpu": 8}, MinNodes: ptrTo[int32](1)},
+			},
+		}
+	}
+
+	It("should repartition an idle node to the layout of the most requested pending resource", func() {
+		nodes := []corev1.Node{migNode("a100-a", "all-3g.40gb"), migNode("a100-b", "all-3g.40gb"), migNode("a100-c", "all-disabled")}
+		pods := []corev1.Pod{
+			migPod("busy", "a100-a", "nvidia.com/mig-3g.40gb", "1"),
+			migPod("small-1", "", "nvidia.com/mig-1g.10gb", "2"),
+			migPod("small-2", "", "nvidia.com/mig-1g.10gb", "4"),
+			migPod("large", "", "nvidia.com/mig-3g.40gb", "1"),
+		}
+
+		step := planMIGRepartition(spec(), nodes, pods, nil, now)
+		Expect(step).NotTo(BeNil())
+		Expect(step.Node).To(Equal("a100-b"))
+		Expect(step.From).To(Equal("all-3g.40gb"))
+		Expect(step.To).To(Equal("all-1g.10gb"))
+		Expect(step.Reason).To(Equal("2 pending pods request 6 nvidia.com/mig-1g.10gb"))
+		Expect(step.Phase).To(Equal(npuv1alpha1.MIGRepartitionInProgress))
+	})
+
+	It("should keep the guardrails of the layouts and the cooldown of the nodes", func() {
+		nodes := []corev1.Node{migNode("a100-a", "all-disabled"), migNode("a100-b", "all-1g.10gb")}
+		pods := []corev1.Pod{migPod("large", "", "nvidia.com/mig-3g.40gb", "1")}
+		migSpec := spec()
+
+		// a100-a is the last node of all-disabled, a100-b was just repartitioned.
+		history := []npuv1alpha1.MIGRepartition{{Node: "a100-b", To: "all-1g.10gb", StartTime: metav1.NewTime(now.Add(-time.Minute))}}
+		Expect(planMIGRepartition(migSpec, nodes, pods, history, now)).To(BeNil())
+
+		step := planMIGRepartition(migSpec, nodes, pods, history, now.Add(defaultMIGCooldown))
+		Expect(step).NotTo(BeNil())
+		Expect(step.Node).To(Equal("a100-b"))
+
+		migSpec.Layouts[1].MaxNodes = ptrTo[int32](0)
+		Expect(planMIGRepartition(migSpec, nodes, pods, nil, now)).To(BeNil())
+	})
+
+	It("should leave nodes alone without unschedulable MIG pods", func() {
+		nodes := []corev1.Node{migNode("a100-a", "all-3g.40gb")}
+		pending := migPod("new", "", "nvidia.com/mig-1g.10gb", "1")
+		pending.Status.Conditions = nil
+		Expect(planMIGRepartition(spec(), nodes, []corev1.Pod{pending}, nil, now)).To(BeNil())
+	})
+
+	It("should complete the repartition by the state reported by the MIG manager", func() {
+		node := migNode("a100-a", "all-1g.10gb")
+		node.Labels[migConfigStateLabel] = "pending"
+		step := &npuv1alpha1.MIGRepartition{Node: "a100-a", To: "all-1g.10gb", Phase: npuv1alpha1.MIGRepartitionInProgress}
+		observeMIGRepartition(step, &node)
+		Expect(step.Phase).To(Equal(npuv1alpha1.MIGRepartitionInProgress))
+
+		node.Labels[migConfigStateLabel] = "success"
+		observeMIGRepartition(step, &node)
+		Expect(step.Phase).To(Equal(npuv1alpha1.MIGRepartitionSucceeded))
+		Expect(step.CompletionTime).NotTo(BeNil())
+
+		step = &npuv1alpha1.MIGRepartition{Node: "a100-a", To: "all-1g.10gb", Phase: npuv1alpha1.MIGRepartitionInProgress}
+		observeMIGRepartition(step, nil)
+		Expect(step.Phase).To(Equal(npuv1alpha1.MIGRepartitionFailed))
+	})
+
+	It("should only repartition within the maintenance windows", func() {
+		windows := []npuv1alpha1.MaintenanceWindow{{
+			Days:     []npuv1alpha1.Weekday{"Fri"},
+			Start:    "22:00",
+			Duration: metav1.Duration{Duration: 6 * time.Hour},
+		}}
+		Expect(inMaintenanceWindow(nil, now)).To(BeTrue())
+		Expect(inMaintenanceWindow(windows, now)).To(BeTrue())
+		Expect(inMaintenanceWindow(windows, now.Add(2*time.Hour))).To(BeFalse())
+		Expect(inMaintenanceWindow(windows, now.Add(-6*time.Hour))).To(BeFalse())
+	})
+})
@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	defaultResourceSuffix    = "-green"
+	defaultValidationTimeout = 10 * time.Minute
+	blueGreenPollInterval    = 15 * time.Second
+	resourceSuffixEnv        = "RESOURCE_NAME_SUFFIX"
+)
+
+// -- blueGreenReady runs the blue/green upgrade of a device plugin and reports whether the
+// plugin DaemonSet may be updated to the image
+func (r *NPUClusterPolicyReconciler) blueGreenReady(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	desired *appsv1.DaemonSet, image string) (bool, error) {
+	log := logf.FromContext(ctx)
+	status := componentStatus(policy, desired.Name)
+	spec := policy.Spec.PluginUpgrade
+
+	isPlugin := desired.Name == nvidiaDevicePluginName || desired.Name == furiosaDevicePluginName
+	if !isPlugin || spec == nil || spec.Strategy != npuv1alpha1.PluginUpgradeBlueGreen {
+		if status.BlueGreen != nil {
+			status.BlueGreen = nil
+			return true, r.deleteGreenDaemonSet(ctx, policy, desired.Name)
+		}
+		return true, nil
+	}
+	// A new plugin pod starts before the old one stops, so the resource stays registered.
+	desired.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxSurge:       ptrTo(intstr.FromInt32(1)),
+			MaxUnavailable: ptrTo(intstr.FromInt32(0)),
+		},
+	}
+
+	bg := status.BlueGreen
+	if status.Image == "" || status.Image == image {
+		if bg == nil {
+			return true, nil
+		}
+		if bg.Phase == npuv1alpha1.BlueGreenSwitchingOver && bg.Image == image {
+			switched, err := r.rolledOut(ctx, policy, desired.Name)
+			if err != nil || !switched {
+				return true, err
+			}
+			r.Recorder.Eventf(policy, corev1.EventTypeNormal, "BlueGreenCompleted",
+				"Switched %s over to %s", desired.Name, image)
+		}
+		status.BlueGreen = nil
+		return true, r.deleteGreenDaemonSet(ctx, policy, desired.Name)
+	}
+
+	if bg == nil || bg.Image != image {
+		log.Info("Starting blue/green upgrade of device plugin", "component", desired.Name, "image", image)
+		bg = &npuv1alpha1.BlueGreenStatus{Image: image, Phase: npuv1alpha1.BlueGreenValidating, StartTime: metav1.Now()}
+		status.BlueGreen = bg
+	}
+	if bg.Phase == npuv1alpha1.BlueGreenFailed {
+		return false, nil
+	}
+
+	suffix := spec.ResourceSuffix
+	if suffix == "" {
+		suffix = defaultResourceSuffix
+	}
+	green := greenDaemonSet(desired, suffix)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, green, func() error {
+		built := greenDaemonSet(desired, suffix)
+		green.Labels = built.Labels
+		green.Spec = built.Spec
+		return r.setOwner(policy, green)
+	}); err != nil {
+		return false, err
+	}
+
+	validated, err := r.greenAdvertised(ctx, desired, suffix)
+	if err != nil {
+		return false, err
+	}
+	timeout := defaultValidationTimeout
+	if spec.ValidationTimeout != nil {
+		timeout = spec.ValidationTimeout.Duration
+	}
+	switch {
+	case validated:
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "BlueGreenSwitchOver",
+			"Green %s is advertised on every node, switching over to %s", desired.Name, image)
+		bg.Phase = npuv1alpha1.BlueGreenSwitchingOver
+		return true, nil
+	case time.Since(bg.StartTime.Time) >= timeout:
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "BlueGreenFailed",
+			"Green %s running %s was not advertised on every node within %s, keeping the current plugin",
+			desired.Name, image, timeout)
+		bg.Phase = npuv1alpha1.BlueGreenFailed
+		return false, r.deleteGreenDaemonSet(ctx, policy, desired.Name)
+	}
+	return false, nil
+}
+
+// -- greenAdvertised reports whether every node of the plugin advertises the suffixed resource
+func (r *NPUClusterPolicyReconciler) greenAdvertised(ctx context.Context, desired *appsv1.DaemonSet, suffix string) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels(desired.Spec.Template.Spec.NodeSelector)); err != nil {
+		return false, err
+	}
+	vendor, _, _ := strings.Cut(desired.Name, "-")
+	for _, node := range nodes.Items {
+		advertised := false
+		for name, q := range node.Status.Allocatable {
+			if acceleratorVendor(string(name)) == vendor && strings.HasSuffix(string(name), suffix) && !q.IsZero() {
+				advertised = true
+				break
+			}
+		}
+		if !advertised {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// -- greenDaemonSet builds the green plugin DaemonSet, which advertises suffixed resources
+func greenDaemonSet(desired *appsv1.DaemonSet, suffix string) *appsv1.DaemonSet {
+	name := desired.Name + "-green"
+	labels := map[string]string{"app.kubernetes.io/name": name}
+
+	spec := *desired.Spec.Template.Spec.DeepCopy()
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{Name: resourceSuffixEnv, Value: suffix})
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: desired.Namespace,
+			// Not labeled with the policy, so removing it is not taken for an out-of-band deletion.
+			Labels: mergeLabels(labels, map[string]string{
+				"app.kubernetes.io/managed-by": "npu-operator",
+				"app.kubernetes.io/component":  "green",
+			}),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       spec,
+			},
+		},
+	}
+}
+
+func (r *NPUClusterPolicyReconciler) deleteGreenDaemonSet(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, component string) error {
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: component + "-green", Namespace: componentNamespace(policy)}}
+	if err := r.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// -- blueGreenInProgress reports whether a device plugin upgrade waits for validation or switch-over
+func blueGreenInProgress(policy *npuv1alpha1.NPUClusterPolicy) bool {
+	for _, c := range policy.Status.Components {
+		if c.BlueGreen != nil && c.BlueGreen.Phase != npuv1alpha1.BlueGreenFailed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Blue/green plugin upgrades", func() {
+	const (
+		resourceName = "blue-green"
+		nodeName     = "blue-green-node"
+	)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+	greenKey := types.NamespacedName{Name: nvidiaDevicePluginName + "-green", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.0"},
+					},
+				},
+				PluginUpgrade: &npuv1alpha1.PluginUpgradeSpec{Strategy: npuv1alpha1.PluginUpgradeBlueGreen},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: nvidiaNodeLabels,
+		}})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+	})
+
+	It("should switch over only once the green plugin is advertised on every node", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(20),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("running the new image as a green plugin next to the current one")
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.0"))
+		green := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, greenKey, green)).To(Succeed())
+		Expect(green.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+		Expect(green.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: resourceSuffixEnv, Value: defaultResourceSuffix}))
+
+		By("switching over once the node advertises the green resource")
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"nvidia.com/gpu-green": resource.MustParse("8")}
+		node.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu-green": resource.MustParse("8")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+		Expect(plugin.Spec.UpdateStrategy.RollingUpdate.MaxSurge.IntValue()).To(Equal(1))
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(componentStatus(policy, nvidiaDevicePluginName).BlueGreen.Phase).To(Equal(npuv1alpha1.BlueGreenSwitchingOver))
+
+		By("removing the green plugin once the switch-over rolled out")
+		plugin.Status.ObservedGeneration = plugin.Generation
+		Expect(k8sClient.Status().Update(ctx, plugin)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(componentStatus(policy, nvidiaDevicePluginName).BlueGreen).To(BeNil())
+		err = k8sClient.Get(ctx, greenKey, green)
+		Expect(apierrors.IsNotFound(err) || green.DeletionTimestamp != nil).To(BeTrue())
+	})
+})
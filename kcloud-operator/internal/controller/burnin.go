@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	burnInName         = "npu-burn-in"
+	burnInPollInterval = 30 * time.Second
+	maxBurnInHistory   = 50
+
+	resultPassed = "Passed"
+)
+
+// BurnInConfig configures the burn-in of devices disabled on NPUNodes. Devices that pass
+// are re-enabled, so a device excluded for a transient fault returns to rotation.
+type BurnInConfig struct {
+	// Image of the burn-in Job. It tests the device named by BURN_IN_DEVICE, of the vendor
+	// named by BURN_IN_VENDOR, and exits non-zero when it fails. Empty disables burn-in.
+	Image string
+	// Namespace the burn-in Jobs run in.
+	Namespace string
+	// After is how long a device stays excluded before it is burned in, and between
+	// failed attempts. Zero only burns in when requested through npu.ai/burn-in.
+	After time.Duration
+	// Timeout is how long a burn-in may run before it fails.
+	Timeout time.Duration
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// -- burnInRequested removes the burn-in annotation from the NPUNode and reports whether it was set
+func (r *NPUNodeReconciler) burnInRequested(ctx context.Context, npuNode *npuv1alpha1.NPUNode) (bool, error) {
+	if npuNode.Annotations[npuv1alpha1.BurnInAnnotation] != "true" {
+		return false, nil
+	}
+	patch := client.MergeFrom(npuNode.DeepCopy())
+	delete(npuNode.Annotations, npuv1alpha1.BurnInAnnotation)
+	return true, r.Patch(ctx, npuNode, patch)
+}
+
+// -- burnIn tracks the excluded devices of the node, burns them in when due and re-enables
+// those that pass. It returns when to check again.
+func (r *NPUNodeReconciler) burnIn(ctx context.Context, node *corev1.Node, npuNode *npuv1alpha1.NPUNode,
+	requested bool) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	now := metav1.Now()
+
+	var excluded []npuv1alpha1.ExcludedDevice
+	for _, device := range npuNode.Spec.DisabledDevices {
+		if i := slices.IndexFunc(npuNode.Status.ExcludedDevices, func(d npuv1alpha1.ExcludedDevice) bool {
+			return d.Device == device
+		}); i >= 0 {
+			excluded = append(excluded, npuNode.Status.ExcludedDevices[i])
+			continue
+		}
+		excluded = append(excluded, npuv1alpha1.ExcludedDevice{Device: device, ExcludedTime: now})
+	}
+	// Jobs of devices enabled again by hand are no longer needed.
+	for _, d := range npuNode.Status.ExcludedDevices {
+		if d.BurnInJob != "" && !slices.Contains(npuNode.Spec.DisabledDevices, d.Device) {
+			if err := r.deleteBurnInJob(ctx, d.BurnInJob); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if r.BurnIn.Image == "" {
+		npuNode.Status.ExcludedDevices = excluded
+		return 0, nil
+	}
+
+	var passed []string
+	var wait time.Duration
+	for i := range excluded {
+		d := &excluded[i]
+		if d.BurnInJob == "" {
+			since := d.ExcludedTime.Time
+			if d.LastBurnInTime != nil {
+				since = d.LastBurnInTime.Time
+			}
+			due := requested || (r.BurnIn.After > 0 && now.Sub(since) >= r.BurnIn.After)
+			if !due {
+				if r.BurnIn.After > 0 {
+					wait = minRequeue(wait, r.BurnIn.After-now.Sub(since))
+				}
+				continue
+			}
+			job := r.burnInJob(node, npuNode, d.Device, now.Time)
+			if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+				return 0, err
+			}
+			log.Info("Burning in excluded device", "device", d.Device, "job", job.Name)
+			d.BurnInJob = job.Name
+			d.LastBurnInTime = &now
+			wait = minRequeue(wait, burnInPollInterval)
+			continue
+		}
+
+		job := &batchv1.Job{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: r.BurnIn.Namespace, Name: d.BurnInJob}, job)
+		switch {
+		case apierrors.IsNotFound(err):
+			appendBurnIn(npuNode, d, resultFailed, "Burn-in Job was deleted before it finished")
+			d.BurnInJob = ""
+		case err != nil:
+			return 0, err
+		case jobFinished(job, batchv1.JobComplete):
+			appendBurnIn(npuNode, d, resultPassed, "Burn-in passed, device re-enabled")
+			r.Recorder.Eventf(node, corev1.EventTypeNormal, "DeviceReenabled",
+				"Device %s passed burn-in and was re-enabled", d.Device)
+			passed = append(passed, d.Device)
+		case jobFinished(job, batchv1.JobFailed):
+			appendBurnIn(npuNode, d, resultFailed, "Burn-in failed, device stays disabled")
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, "BurnInFailed",
+				"Device %s failed burn-in and stays disabled", d.Device)
+			if err := r.deleteBurnInJob(ctx, d.BurnInJob); err != nil {
+				return 0, err
+			}
+			d.BurnInJob = ""
+		default:
+			wait = minRequeue(wait, burnInPollInterval)
+		}
+	}
+
+	if len(passed) > 0 {
+		if err := r.reenableDevices(ctx, npuNode, passed); err != nil {
+			return 0, err
+		}
+		for _, d := range excluded {
+			if slices.Contains(passed, d.Device) {
+				if err := r.deleteBurnInJob(ctx, d.BurnInJob); err != nil {
+					return 0, err
+				}
+			}
+		}
+		excluded = slices.DeleteFunc(excluded, func(d npuv1alpha1.ExcludedDevice) bool {
+			return slices.Contains(passed, d.Device)
+		})
+	}
+	npuNode.Status.ExcludedDevices = excluded
+	return wait, nil
+}
+
+// -- reenableDevices removes devices from the disabled devices of the NPUNode. The patch is
+// applied to a copy, so the status computed by the reconcile is kept.
+func (r *NPUNodeReconciler) reenableDevices(ctx context.Context, npuNode *npuv1alpha1.NPUNode, devices []string) error {
+	updated := npuNode.DeepCopy()
+	updated.Spec.DisabledDevices = slices.DeleteFunc(updated.Spec.DisabledDevices, func(device string) bool {
+		return slices.Contains(devices, device)
+	})
+	if err := r.Patch(ctx, updated, client.MergeFrom(npuNode)); err != nil {
+		return err
+	}
+	npuNode.ObjectMeta = updated.ObjectMeta
+	npuNode.Spec = updated.Spec
+	return nil
+}
+
+func (r *NPUNodeReconciler) deleteBurnInJob(ctx context.Context, name string) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.BurnIn.Namespace}}
+	err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
+
+// -- burnInJob builds the Job burning in a device of the node. The device is disabled in the
+// device plugin, so the Job is privileged and addresses the device directly.
+func (r *NPUNodeReconciler) burnInJob(node *corev1.Node, npuNode *npuv1alpha1.NPUNode, disabled string,
+	start time.Time) *batchv1.Job {
+	h := fnv.New64a()
+	for _, s := range []string{node.Name, disabled, start.UTC().Format(time.RFC3339)} {
+		h.Write([]byte(s)) //nolint:errcheck
+		h.Write([]byte{0}) //nolint:errcheck
+	}
+	labels := map[string]string{"app.kubernetes.io/name": burnInName, "app.kubernetes.io/managed-by": "npu-operator"}
+	vendor, device := deviceVendor(npuNode, nodeVendors(node), disabled)
+	env := []corev1.EnvVar{{Name: "BURN_IN_DEVICE", Value: device}}
+	if vendor != "" {
+		env = append(env, corev1.EnvVar{Name: "BURN_IN_VENDOR", Value: vendor})
+	}
+	if vendor == "nvidia" {
+		env = append(env, corev1.EnvVar{Name: "NVIDIA_VISIBLE_DEVICES", Value: device})
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%x", burnInName, h.Sum64()),
+			Namespace: r.BurnIn.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptrTo(int32(0)),
+			ActiveDeadlineSeconds: ptrTo(int64(r.BurnIn.Timeout.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeName:      node.Name,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:            "burn-in",
+						Image:           r.BurnIn.Image,
+						Env:             env,
+						SecurityContext: &corev1.SecurityContext{Privileged: boolPtr(true)},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func appendBurnIn(npuNode *npuv1alpha1.NPUNode, d *npuv1alpha1.ExcludedDevice, result, message string) {
+	history := append(npuNode.Status.BurnInHistory, npuv1alpha1.BurnInRecord{
+		Time:    metav1.Now(),
+		Device:  d.Device,
+		Job:     d.BurnInJob,
+		Result:  result,
+		Message: message,
+	})
+	if len(history) > maxBurnInHistory {
+		history = history[len(history)-maxBurnInHistory:]
+	}
+	npuNode.Status.BurnInHistory = history
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Device burn-in", func() {
+	const nodeName = "gpu-node-burn-in"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: nodeName}
+
+	// finishJob marks a Job finished the way the Job controller does.
+	finishJob := func(name string, passed bool) {
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "kube-system"}, job)).To(Succeed())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		if passed {
+			job.Status.CompletionTime = &now
+			job.Status.Succeeded = 1
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+		} else {
+			job.Status.Failed = 1
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue},
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			}
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"nvidia.com/gpu.present": "true"},
+		}})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        nodeName,
+				Annotations: map[string]string{npuv1alpha1.BurnInAnnotation: "true"},
+			},
+			Spec: npuv1alpha1.NPUNodeSpec{DisabledDevices: []string{"1", "2"}},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace("kube-system"),
+			client.MatchingLabels{"app.kubernetes.io/name": burnInName},
+			client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+	})
+
+	It("should burn in the disabled devices on request and re-enable those that pass", func() {
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUNodeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+			BurnIn:   BurnInConfig{Image: "burn-in", Namespace: "kube-system", Timeout: time.Minute},
+		}
+
+		By("starting a burn-in Job per disabled device")
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(burnInPollInterval))
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Annotations).NotTo(HaveKey(npuv1alpha1.BurnInAnnotation))
+		Expect(npuNode.Status.ExcludedDevices).To(HaveLen(2))
+		jobs := map[string]string{}
+		for _, d := range npuNode.Status.ExcludedDevices {
+			Expect(d.BurnInJob).NotTo(BeEmpty())
+			jobs[d.Device] = d.BurnInJob
+		}
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobs["1"], Namespace: "kube-system"}, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.NodeName).To(Equal(nodeName))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "BURN_IN_DEVICE", Value: "1"}))
+
+		By("re-enabling the device that passed and keeping the one that failed disabled")
+		finishJob(jobs["1"], true)
+		finishJob(jobs["2"], false)
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Spec.DisabledDevices).To(Equal([]string{"2"}))
+		Expect(npuNode.Status.ExcludedDevices).To(HaveLen(1))
+		Expect(npuNode.Status.ExcludedDevices[0].BurnInJob).To(BeEmpty())
+		Expect(npuNode.Status.ExcludedDevices[0].LastBurnInTime).NotTo(BeNil())
+		Expect(npuNode.Status.BurnInHistory).To(ConsistOf(
+			HaveField("Result", resultPassed),
+			HaveField("Result", resultFailed),
+		))
+		events := drainEvents(recorder)
+		Expect(events).To(ContainElement(ContainSubstring("DeviceReenabled")))
+		Expect(events).To(ContainElement(ContainSubstring("BurnInFailed")))
+	})
+})
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// catalogIndex indexes policies by spec.catalog.
+const catalogIndex = "spec.catalog"
+
+// -- componentCatalog fetches the catalog referenced by the policy, or nil when none is set
+func (r *NPUClusterPolicyReconciler) componentCatalog(ctx context.Context,
+	policy *npuv1alpha1.NPUClusterPolicy) (*npuv1alpha1.NPUComponentCatalog, error) {
+	if policy.Spec.Catalog == "" {
+		return nil, nil
+	}
+	catalog := &npuv1alpha1.NPUComponentCatalog{}
+	if err := r.Get(ctx, types.NamespacedName{Name: policy.Spec.Catalog}, catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// -- resolveCatalogImage returns the image reference of a component version, pinned by digest when known
+func resolveCatalogImage(catalog *npuv1alpha1.NPUComponentCatalog, name, version string) (string, error) {
+	for _, c := range catalog.Spec.Components {
+		if c.Name != name {
+			continue
+		}
+		for _, v := range c.Versions {
+			if v.Version != version {
+				continue
+			}
+			image := c.Image
+			if v.Image != "" {
+				image = v.Image
+			}
+			if v.Digest != "" {
+				return image + "@" + v.Digest, nil
+			}
+			return image + ":" + v.Version, nil
+		}
+		return "", fmt.Errorf("version %s of %s is not in catalog %s", version, name, catalog.Name)
+	}
+	return "", fmt.Errorf("component %s is not in catalog %s", name, catalog.Name)
+}
+
+// -- policiesForCatalog maps a catalog to the policies that resolve images from it
+func (r *NPUClusterPolicyReconciler) policiesForCatalog(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies, client.MatchingFields{catalogIndex: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list policies for catalog", "catalog", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+	return requests
+}
+
+func catalogName(obj client.Object) []string {
+	policy := obj.(*npuv1alpha1.NPUClusterPolicy)
+	if policy.Spec.Catalog == "" {
+		return nil
+	}
+	return []string{policy.Spec.Catalog}
+}
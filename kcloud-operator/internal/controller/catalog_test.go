@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const pluginDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var _ = Describe("Component catalog", func() {
+	const resourceName = "catalog"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+	BeforeEach(func() {
+		catalog := &npuv1alpha1.NPUComponentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "approved"},
+			Spec: npuv1alpha1.NPUComponentCatalogSpec{
+				Components: []npuv1alpha1.CatalogComponent{{
+					Name:  nvidiaDevicePluginName,
+					Image: "registry.example.com/nvidia/k8s-device-plugin",
+					Versions: []npuv1alpha1.CatalogVersion{
+						{Version: "v0.17.0"},
+						{Version: "v0.17.1", Digest: pluginDigest},
+					},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, catalog)).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Catalog: "approved",
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Version: "v0.17.1"},
+						Exporter:     npuv1alpha1.ComponentSpec{Enabled: boolPtr(true), Version: "4.1.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		catalog := &npuv1alpha1.NPUComponentCatalog{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "approved"}, catalog)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, catalog)).To(Succeed())
+	})
+
+	It("should resolve images from the catalog by version", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com/nvidia/k8s-device-plugin@" + pluginDigest))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		exporter := conditions.Get(policy, conditions.ComponentReady("nvidia-dcgm-exporter"))
+		Expect(exporter).NotTo(BeNil())
+		Expect(exporter.Status).To(Equal(metav1.ConditionFalse))
+		Expect(exporter.Reason).To(Equal(conditions.ReasonImageUnresolved))
+	})
+
+	It("should fall back to the version tag without a digest", func() {
+		catalog := &npuv1alpha1.NPUComponentCatalog{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "approved"}, catalog)).To(Succeed())
+		image, err := resolveCatalogImage(catalog, nvidiaDevicePluginName, "v0.17.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image).To(Equal("registry.example.com/nvidia/k8s-device-plugin:v0.17.0"))
+
+		_, err = resolveCatalogImage(catalog, nvidiaDevicePluginName, "v0.18.0")
+		Expect(err).To(MatchError(ContainSubstring("not in catalog")))
+	})
+})
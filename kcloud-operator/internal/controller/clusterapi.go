@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var machineDeploymentGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"}
+
+const (
+	kubeadmConfigTemplateKind = "KubeadmConfigTemplate"
+
+	// Cluster autoscaler reads these annotations to build node templates when scaling from zero.
+	autoscalerLabelsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/labels"
+	autoscalerTaintsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/taints"
+)
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigtemplates,verbs=get;list;watch;update;patch
+
+// -- syncClusterAPITemplates writes the vendor node labels and taints into the selected MachineDeployments
+func (r *NPUClusterPolicyReconciler) syncClusterAPITemplates(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	spec *npuv1alpha1.ClusterAPISpec, vendorLabels map[string]string) error {
+	log := logf.FromContext(ctx)
+
+	if spec == nil || !spec.Enabled || !r.capabilities().ClusterAPI {
+		return nil
+	}
+	if spec.MachineDeploymentSelector == nil {
+		return fmt.Errorf("clusterAPI.machineDeploymentSelector must be set")
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.MachineDeploymentSelector)
+	if err != nil {
+		return fmt.Errorf("invalid clusterAPI.machineDeploymentSelector: %w", err)
+	}
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = policy.Namespace
+	}
+
+	labels := make(map[string]string, len(vendorLabels)+len(spec.NodeLabels))
+	for k, v := range vendorLabels {
+		labels[k] = v
+	}
+	for k, v := range spec.NodeLabels {
+		labels[k] = v
+	}
+
+	mds := &unstructured.UnstructuredList{}
+	mds.SetGroupVersionKind(machineDeploymentGVK.GroupVersion().WithKind(machineDeploymentGVK.Kind + "List"))
+	if err := r.List(ctx, mds, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		if meta.IsNoMatchError(err) {
+			log.Info("Cluster API is not installed, skipping MachineDeployment sync")
+			return nil
+		}
+		return err
+	}
+
+	for i := range mds.Items {
+		md := &mds.Items[i]
+		if err := r.syncMachineDeployment(ctx, md, labels, spec.NodeTaints); err != nil {
+			log.Error(err, "failed to sync machinedeployment", "machineDeployment", client.ObjectKeyFromObject(md))
+			return err
+		}
+	}
+	return nil
+}
+
+// -- syncMachineDeployment updates the autoscaler annotations and the bootstrap template of one MachineDeployment
+func (r *NPUClusterPolicyReconciler) syncMachineDeployment(ctx context.Context, md *unstructured.Unstructured,
+	labels map[string]string, taints []corev1.Taint) error {
+	annotations := md.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	wantLabels := mergeKeyValueList(annotations[autoscalerLabelsAnnotation], labels)
+	wantTaints := mergeAutoscalerTaints(annotations[autoscalerTaintsAnnotation], taints)
+	if annotations[autoscalerLabelsAnnotation] != wantLabels || (wantTaints != "" && annotations[autoscalerTaintsAnnotation] != wantTaints) {
+		annotations[autoscalerLabelsAnnotation] = wantLabels
+		if wantTaints != "" {
+			annotations[autoscalerTaintsAnnotation] = wantTaints
+		}
+		md.SetAnnotations(annotations)
+		if err := r.Update(ctx, md); err != nil {
+			return err
+		}
+	}
+
+	ref, found, err := unstructured.NestedStringMap(md.Object, "spec", "template", "spec", "bootstrap", "configRef")
+	if err != nil || !found || ref["kind"] != kubeadmConfigTemplateKind {
+		// Only kubeadm bootstrap templates can carry node registration settings.
+		return nil
+	}
+
+	tmpl := &unstructured.Unstructured{}
+	tmpl.SetAPIVersion(ref["apiVersion"])
+	tmpl.SetKind(ref["kind"])
+	namespace := ref["namespace"]
+	if namespace == "" {
+		namespace = md.GetNamespace()
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref["name"]}, tmpl); err != nil {
+		return err
+	}
+
+	path := []string{"spec", "template", "spec", "joinConfiguration", "nodeRegistration"}
+	registration, _, err := unstructured.NestedMap(tmpl.Object, path...)
+	if err != nil {
+		return err
+	}
+	if registration == nil {
+		registration = map[string]interface{}{}
+	}
+	before := runtime.DeepCopyJSON(registration)
+
+	extraArgs, _, _ := unstructured.NestedStringMap(registration, "kubeletExtraArgs")
+	if extraArgs == nil {
+		extraArgs = map[string]string{}
+	}
+	extraArgs["node-labels"] = mergeKeyValueList(extraArgs["node-labels"], labels)
+	if err := unstructured.SetNestedStringMap(registration, extraArgs, "kubeletExtraArgs"); err != nil {
+		return err
+	}
+
+	if len(taints) > 0 {
+		existing, _, _ := unstructured.NestedSlice(registration, "taints")
+		registration["taints"] = mergeTaintList(existing, taints)
+	}
+
+	if equality.Semantic.DeepEqual(before, registration) {
+		return nil
+	}
+	if err := unstructured.SetNestedMap(tmpl.Object, registration, path...); err != nil {
+		return err
+	}
+	return r.Update(ctx, tmpl)
+}
+
+// -- mergeKeyValueList merges labels into a comma separated key=value list, keeping foreign keys
+func mergeKeyValueList(existing string, labels map[string]string) string {
+	merged := map[string]string{}
+	for _, kv := range strings.Split(existing, ",") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(kv), "="); ok && k != "" {
+			merged[k] = v
+		}
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+merged[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// -- mergeAutoscalerTaints merges taints into the key=value:Effect list format of the cluster autoscaler
+func mergeAutoscalerTaints(existing string, taints []corev1.Taint) string {
+	if len(taints) == 0 {
+		return existing
+	}
+	merged := map[string]string{}
+	for _, t := range strings.Split(existing, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		kv, effect, _ := strings.Cut(t, ":")
+		k, _, _ := strings.Cut(kv, "=")
+		merged[k+":"+effect] = t
+	}
+	for _, t := range taints {
+		merged[t.Key+":"+string(t.Effect)] = fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, merged[k])
+	}
+	return strings.Join(out, ",")
+}
+
+// -- mergeTaintList merges taints into a kubeadm nodeRegistration taint list, replacing entries with the same key and effect
+func mergeTaintList(existing []interface{}, taints []corev1.Taint) []interface{} {
+	out := make([]interface{}, 0, len(existing)+len(taints))
+	replaced := map[string]bool{}
+	for _, t := range taints {
+		replaced[t.Key+":"+string(t.Effect)] = true
+	}
+	for _, e := range existing {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if replaced[fmt.Sprint(m["key"])+":"+fmt.Sprint(m["effect"])] {
+			continue
+		}
+		out = append(out, m)
+	}
+	for _, t := range taints {
+		entry := map[string]interface{}{"key": t.Key, "effect": string(t.Effect)}
+		if t.Value != "" {
+			entry["value"] = t.Value
+		}
+		out = append(out, entry)
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Cluster API template sync", func() {
+	It("merges node labels into an existing key=value list", func() {
+		Expect(mergeKeyValueList("zone=a, furiosa=false", map[string]string{"furiosa": "true"})).
+			To(Equal("furiosa=true,zone=a"))
+		Expect(mergeKeyValueList("", nvidiaNodeLabels)).To(Equal("nvidia.com/gpu.present=true"))
+	})
+
+	It("renders autoscaler taints and replaces entries with the same key and effect", func() {
+		taints := []corev1.Taint{{Key: "nvidia.com/gpu", Value: "present", Effect: corev1.TaintEffectNoSchedule}}
+		Expect(mergeAutoscalerTaints("nvidia.com/gpu=old:NoSchedule,dedicated=ml:NoExecute", taints)).
+			To(Equal("dedicated=ml:NoExecute,nvidia.com/gpu=present:NoSchedule"))
+	})
+
+	It("merges taints into a kubeadm nodeRegistration list", func() {
+		existing := []interface{}{
+			map[string]interface{}{"key": "nvidia.com/gpu", "effect": "NoSchedule", "value": "old"},
+			map[string]interface{}{"key": "dedicated", "effect": "NoExecute"},
+		}
+		taints := []corev1.Taint{{Key: "nvidia.com/gpu", Value: "present", Effect: corev1.TaintEffectNoSchedule}}
+		Expect(mergeTaintList(existing, taints)).To(Equal([]interface{}{
+			map[string]interface{}{"key": "dedicated", "effect": "NoExecute"},
+			map[string]interface{}{"key": "nvidia.com/gpu", "effect": "NoSchedule", "value": "present"},
+		}))
+	})
+})
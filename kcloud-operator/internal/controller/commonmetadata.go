@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// -- withCommonMetadata adds the common labels and annotations of the policy to the object and to
+// the pods of its template
+func withCommonMetadata(policy *npuv1alpha1.NPUClusterPolicy, obj client.Object) {
+	addMetadata(obj, policy.Spec.CommonLabels, policy.Spec.CommonAnnotations)
+}
+
+// -- withComponentMetadata adds the labels and annotations of the component, then the common ones
+func withComponentMetadata(policy *npuv1alpha1.NPUClusterPolicy, c component, obj client.Object) {
+	addMetadata(obj, mergeLabels(policy.Spec.CommonLabels, c.spec.Labels),
+		mergeLabels(policy.Spec.CommonAnnotations, c.spec.Annotations))
+}
+
+// -- addMetadata adds the labels and annotations to the object and to the pods of its template.
+// Those already set, e.g. the selector labels, are kept.
+func addMetadata(obj client.Object, labels, annotations map[string]string) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return
+	}
+	metas := []metav1.Object{obj}
+	switch o := obj.(type) {
+	case *appsv1.DaemonSet:
+		metas = append(metas, &o.Spec.Template.ObjectMeta)
+	case *appsv1.Deployment:
+		metas = append(metas, &o.Spec.Template.ObjectMeta)
+	case *batchv1.Job:
+		metas = append(metas, &o.Spec.Template.ObjectMeta)
+	}
+	for _, meta := range metas {
+		if len(labels) > 0 {
+			meta.SetLabels(mergeLabels(labels, meta.GetLabels()))
+		}
+		if len(annotations) > 0 {
+			meta.SetAnnotations(mergeLabels(annotations, meta.GetAnnotations()))
+		}
+	}
+}
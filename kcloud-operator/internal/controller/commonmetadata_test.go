@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Common labels and annotations", func() {
+	It("should label the component DaemonSets and pods, component labels first", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		policy.Spec.CommonLabels = map[string]string{
+			"cost-center":            "ml-platform",
+			"team":                   "infra",
+			"app.kubernetes.io/name": "ignored",
+		}
+		policy.Spec.CommonAnnotations = map[string]string{"owner": "infra@example.com"}
+		policy.Spec.Nvidia.DevicePlugin.Labels = map[string]string{"team": "gpu"}
+
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		for _, meta := range []metav1.ObjectMeta{plugin.ObjectMeta, plugin.Spec.Template.ObjectMeta} {
+			Expect(meta.Labels).To(HaveKeyWithValue("cost-center", "ml-platform"))
+			Expect(meta.Labels).To(HaveKeyWithValue("team", "gpu"))
+			Expect(meta.Labels).To(HaveKeyWithValue("app.kubernetes.io/name", nvidiaDevicePluginName))
+			Expect(meta.Annotations).To(HaveKeyWithValue("owner", "infra@example.com"))
+		}
+		Expect(plugin.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app.kubernetes.io/name": nvidiaDevicePluginName}))
+
+		driver, err := renderComponent(policy, nvidiaComponents(policy)[0], "driver", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Labels).To(HaveKeyWithValue("team", "infra"))
+	})
+
+	It("should label the ConfigMaps and Jobs of the policy", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		policy.Spec.CommonLabels = map[string]string{"cost-center": "ml-platform"}
+
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: policyLabels(policy)}}
+		withCommonMetadata(policy, configMap)
+		Expect(configMap.Labels).To(HaveKeyWithValue("cost-center", "ml-platform"))
+		Expect(configMap.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyNameLabel, "cluster"))
+		Expect(configMap.Annotations).To(BeNil())
+
+		job := prePullJob(policy, "gpu-1", []string{"image"})
+		withCommonMetadata(policy, job)
+		Expect(job.Labels).To(HaveKeyWithValue("cost-center", "ml-platform"))
+		Expect(job.Spec.Template.Labels).To(HaveKeyWithValue("cost-center", "ml-platform"))
+	})
+})
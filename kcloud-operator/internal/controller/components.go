@@ -0,0 +1,619 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// component is one independently managed DaemonSet of a vendor stack.
+type component struct {
+	// name of the DaemonSet, also used for the status entry and the Ready condition.
+	name string
+	// vendor of the component, e.g. nvidia.
+	vendor  string
+	enabled bool
+	spec    npuv1alpha1.ComponentSpec
+	// legacyImage is used when neither a catalog nor spec.image is set.
+	legacyImage string
+	// metricsPort is the port serving Prometheus metrics, zero if the component has none.
+	metricsPort int32
+	build       func(policy *npuv1alpha1.NPUClusterPolicy, image string) *appsv1.DaemonSet
+}
+
+// componentTemplate describes the pod of a generic component.
+type componentTemplate struct {
+	privileged  bool
+	hostPID     bool
+	metricsPort int32
+	hostPaths   map[string]string
+}
+
+// -- nvidiaComponents lists the NVIDIA components in rollout order
+func nvidiaComponents(policy *npuv1alpha1.NPUClusterPolicy) []component {
+	spec := policy.Spec.Nvidia
+	return withVendor("nvidia", []component{
+		genericComponent("nvidia-driver", spec.Driver, nvidiaNodeLabels, componentTemplate{
+			privileged: true,
+			hostPID:    true,
+			hostPaths:  map[string]string{"run-nvidia": "/run/nvidia", "modules": "/lib/modules"},
+		}),
+		{
+			name:        nvidiaDevicePluginName,
+			enabled:     spec.DevicePlugin.Enabled == nil || *spec.DevicePlugin.Enabled,
+			spec:        spec.DevicePlugin,
+			legacyImage: spec.DevicePluginImage,
+			build:       nvidiaDevicePluginDaemonSet,
+		},
+		genericComponent("nvidia-gpu-feature-discovery", spec.GFD, nvidiaNodeLabels, componentTemplate{
+			hostPaths: map[string]string{"features-d": "/etc/kubernetes/node-feature-discovery/features.d"},
+		}),
+		genericComponent("nvidia-dcgm-exporter", spec.Exporter, nvidiaNodeLabels, componentTemplate{
+			metricsPort: 9400,
+		}),
+		genericComponent("nvidia-validator", spec.Validator, nvidiaNodeLabels, componentTemplate{
+			privileged: true,
+			hostPaths:  map[string]string{"dev": "/dev"},
+		}),
+	})
+}
+
+// -- furiosaComponents lists the Furiosa components in rollout order
+func furiosaComponents(policy *npuv1alpha1.NPUClusterPolicy) []component {
+	spec := policy.Spec.Furiosa
+	return withVendor("furiosa", []component{
+		genericComponent("furiosa-driver", spec.Driver, furiosaNodeLabels, componentTemplate{
+			privileged: true,
+			hostPID:    true,
+			hostPaths:  map[string]string{"dev": "/dev", "modules": "/lib/modules"},
+		}),
+		{
+			name:        furiosaDevicePluginName,
+			enabled:     spec.DevicePlugin.Enabled == nil || *spec.DevicePlugin.Enabled,
+			spec:        spec.DevicePlugin,
+			legacyImage: spec.DevicePluginImage,
+			build:       furiosaDevicePluginDaemonSet,
+		},
+		genericComponent("furiosa-feature-discovery", spec.GFD, furiosaNodeLabels, componentTemplate{
+			hostPaths: map[string]string{"features-d": "/etc/kubernetes/node-feature-discovery/features.d"},
+		}),
+		genericComponent("furiosa-metrics-exporter", spec.Exporter, furiosaNodeLabels, componentTemplate{
+			metricsPort: 6254,
+		}),
+		genericComponent("furiosa-validator", spec.Validator, furiosaNodeLabels, componentTemplate{
+			privileged: true,
+			hostPaths:  map[string]string{"dev": "/dev", "sys": "/sys"},
+		}),
+	})
+}
+
+func withVendor(vendor string, components []component) []component {
+	for i := range components {
+		components[i].vendor = vendor
+	}
+	return components
+}
+
+// -- enabledComponents lists the enabled components of the enabled vendors
+func enabledComponents(policy *npuv1alpha1.NPUClusterPolicy) []component {
+	var components []component
+	if policy.Spec.Nvidia.Enabled {
+		components = append(components, nvidiaComponents(policy)...)
+	}
+	if policy.Spec.Furiosa.Enabled {
+		components = append(components, furiosaComponents(policy)...)
+	}
+	var enabled []component
+	for _, c := range components {
+		if c.enabled {
+			enabled = append(enabled, c)
+		}
+	}
+	return enabled
+}
+
+// -- image resolves the image of the component from the catalog, or from its spec without one
+func (c component) image(catalog *npuv1alpha1.NPUComponentCatalog) (string, error) {
+	if catalog == nil {
+		switch {
+		case c.spec.Image == "":
+			if c.legacyImage == "" {
+				return "", fmt.Errorf("no image is configured")
+			}
+			return c.legacyImage, nil
+		case c.spec.Version == "":
+			return c.spec.Image, nil
+		default:
+			return c.spec.Image + ":" + c.spec.Version, nil
+		}
+	}
+	if c.spec.Image != "" {
+		return "", fmt.Errorf("image must not be set, images are resolved from catalog %s", catalog.Name)
+	}
+	if c.spec.Version == "" {
+		return "", fmt.Errorf("no version is set")
+	}
+	return resolveCatalogImage(catalog, c.name, c.spec.Version)
+}
+
+func genericComponent(name string, spec npuv1alpha1.ComponentSpec, nodeLabels map[string]string,
+	tmpl componentTemplate) component {
+	return component{
+		name:        name,
+		enabled:     spec.Enabled != nil && *spec.Enabled,
+		spec:        spec,
+		metricsPort: tmpl.metricsPort,
+		build: func(policy *npuv1alpha1.NPUClusterPolicy, image string) *appsv1.DaemonSet {
+			return genericDaemonSet(policy, name, image, nodeLabels, tmpl)
+		},
+	}
+}
+
+// -- genericDaemonSet builds the DaemonSet of a single-container component
+func genericDaemonSet(policy *npuv1alpha1.NPUClusterPolicy, name, image string, nodeLabels map[string]string,
+	tmpl componentTemplate) *appsv1.DaemonSet {
+	labels := map[string]string{
+		"app.kubernetes.io/name": name,
+	}
+	container := corev1.Container{
+		Name:            name,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env: []corev1.EnvVar{
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               boolPtr(tmpl.privileged),
+			AllowPrivilegeEscalation: boolPtr(tmpl.privileged),
+		},
+	}
+	if tmpl.metricsPort != 0 {
+		container.Ports = []corev1.ContainerPort{{Name: "metrics", ContainerPort: tmpl.metricsPort}}
+	}
+	var volumes []corev1.Volume
+	for _, volume := range slices.Sorted(maps.Keys(tmpl.hostPaths)) {
+		path := tmpl.hostPaths[volume]
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volume, MountPath: path})
+		volumes = append(volumes, corev1.Volume{
+			Name:         volume,
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: path}},
+		})
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: componentNamespace(policy),
+			Labels:    mergeLabels(labels, policyLabels(policy)),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeSelector: nodeLabels,
+					HostPID:      tmpl.hostPID,
+					Containers:   []corev1.Container{container},
+					Volumes:      volumes,
+				},
+			},
+		},
+	}
+	if tmpl.metricsPort != 0 && metricsTLSEnabled(policy) {
+		ds = withMetricsTLS(ds)
+	}
+	return ds
+}
+
+// pendingComponent is a component whose DaemonSet passed every gate and is ready to apply.
+type pendingComponent struct {
+	component
+	ds           *appsv1.DaemonSet
+	image        string
+	prePullStart *metav1.Time
+	// generation of the existing DaemonSet, zero when it does not exist yet.
+	generation int64
+}
+
+// -- ensureComponents applies each enabled component and sets its Ready condition. The gates of
+// the components run in order, the DaemonSets that pass them are applied concurrently.
+func (r *NPUClusterPolicyReconciler) ensureComponents(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	catalog *npuv1alpha1.NPUComponentCatalog, components []component) error {
+	log := logf.FromContext(ctx)
+
+	rendered := renderedConfig(policy)
+	unmanaged, err := r.unmanagedNodes(ctx)
+	if err != nil {
+		return err
+	}
+	var pending []pendingComponent
+	for _, c := range components {
+		condition := conditions.ComponentReady(c.name)
+		if !c.enabled {
+			conditions.Remove(policy, condition)
+			continue
+		}
+		if inSafeMode(policy, c.name) {
+			log.Info("Component is in safe mode, skipping changes", "component", c.name)
+			continue
+		}
+		image, err := c.image(catalog)
+		if err != nil {
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, "InvalidSpec", "Cannot resolve the image of %s: %v", c.name, err)
+			conditions.MarkFalse(policy, condition, conditions.ReasonImageUnresolved, err.Error())
+			continue
+		}
+		if current := componentImage(policy, c.name); current != "" && current != image && disconnected(policy) {
+			log.Info("Registry is unreachable, deferring image change", "component", c.name, "image", image)
+			continue
+		}
+
+		ds, err := renderComponent(policy, c, image, rendered)
+		if err != nil {
+			r.Recorder.Event(policy, corev1.EventTypeWarning, "PatchFailed", err.Error())
+			conditions.MarkFalse(policy, condition, conditions.ReasonPatchFailed, err.Error())
+			continue
+		}
+		excludeNodes(&ds.Spec.Template.Spec, unmanaged)
+		if err := r.setOwner(policy, ds); err != nil {
+			return err
+		}
+		free, err := r.pluginRegistrationFree(ctx, policy, ds)
+		if err != nil {
+			log.Error(err, "failed to check for conflicting device plugins", "component", c.name)
+			return err
+		}
+		if !free {
+			continue
+		}
+		var prePullStart *metav1.Time
+		if p := componentStatus(policy, c.name).PrePull; p != nil && p.Image == image {
+			prePullStart = p.StartTime.DeepCopy()
+		}
+		ready, err := r.prePulled(ctx, policy, ds, image)
+		if err != nil {
+			log.Error(err, "failed to pre-pull component image", "component", c.name)
+			return err
+		}
+		if !ready {
+			continue
+		}
+		if ready, err = r.preUpgradeHookPassed(ctx, policy, c, image); err != nil {
+			log.Error(err, "failed to run pre-upgrade hook", "component", c.name)
+			return err
+		}
+		if !ready {
+			continue
+		}
+		if ready, err = r.blueGreenReady(ctx, policy, ds, image); err != nil {
+			log.Error(err, "failed to run blue/green upgrade", "component", c.name)
+			return err
+		}
+		if !ready {
+			continue
+		}
+		applied, generation, err := r.daemonSetToApply(ctx, ds)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, pendingComponent{
+			component: c, ds: applied, image: image, prePullStart: prePullStart, generation: generation,
+		})
+	}
+
+	objs := make([]client.Object, 0, len(pending))
+	for _, p := range pending {
+		objs = append(objs, p.ds)
+	}
+	applyStart := time.Now()
+	var transient error
+	var failed []error
+	for i, err := range r.applyConcurrently(ctx, policy, objs) {
+		p := pending[i]
+		condition := conditions.ComponentReady(p.name)
+		var conflict *fieldConflictError
+		if errors.As(err, &conflict) {
+			if conflict.policy == npuv1alpha1.ConflictPolicyIgnore {
+				conditions.MarkTrue(policy, condition, conditions.ReasonFieldConflict, conflict.Error())
+			} else {
+				conditions.MarkFalse(policy, condition, conditions.ReasonFieldConflict, conflict.Error())
+			}
+			continue
+		}
+		if transientAdmissionFailure(err) {
+			// Returned as is, so the caller retries it with the admission backoff.
+			transient = err
+			continue
+		}
+		if err != nil {
+			log.Error(err, "failed to apply component", "component", p.name)
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, "ApplyFailed", "Failed to apply DaemonSet %s/%s: %v", p.ds.Namespace, p.name, err)
+			conditions.MarkFalse(policy, condition, conditions.ReasonReconcileFailed, err.Error())
+			failed = append(failed, err)
+			continue
+		}
+		switch {
+		case p.generation == 0:
+			markChanged(policy, p.name)
+			r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ComponentCreated", "Created DaemonSet %s/%s running %s", p.ds.Namespace, p.name, p.image)
+		case p.generation != p.ds.Generation:
+			markChanged(policy, p.name)
+			r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ComponentUpdated", "Updated DaemonSet %s/%s running %s", p.ds.Namespace, p.name, p.image)
+		}
+		if previous := componentImage(policy, p.name); previous != p.image {
+			startRollout(policy, p.name, previous, p.image, p.prePullStart, applyStart)
+		}
+		componentStatus(policy, p.name).Image = p.image
+		conditions.MarkTrue(policy, condition, conditions.ReasonReconciled,
+			fmt.Sprintf("DaemonSet %s/%s runs %s", p.ds.Namespace, p.name, p.image))
+	}
+	if transient != nil {
+		return transient
+	}
+	return utilerrors.NewAggregate(failed)
+}
+
+// -- renderComponent builds the DaemonSet of a component as it is applied, with the priority class
+// and resources of the component, the edge pull policy, the update strategy of workload-aware
+// driver upgrades, the patches of the policy and the hash of the rendered configuration. It only
+// fails when a patch does not apply.
+func renderComponent(policy *npuv1alpha1.NPUClusterPolicy, c component, image string,
+	rendered map[string]map[string]string) (*appsv1.DaemonSet, error) {
+	ds := c.build(policy, image)
+	applyVendorPodSpec(policy, c, &ds.Spec.Template.Spec)
+	ds.Spec.Template.Spec.PriorityClassName = priorityClassName(policy)
+	if workloadAwareDriver(policy, c.name) {
+		// The operator replaces the driver pods itself, in the order of the load of their nodes.
+		ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+	} else if c.spec.UpdateStrategy != nil {
+		ds.Spec.UpdateStrategy = *c.spec.UpdateStrategy.DeepCopy()
+	}
+	if c.spec.Resources != nil {
+		for i := range ds.Spec.Template.Spec.Containers {
+			ds.Spec.Template.Spec.Containers[i].Resources = *c.spec.Resources.DeepCopy()
+		}
+	}
+	for i, container := range ds.Spec.Template.Spec.Containers {
+		if container.Name == c.name && len(c.spec.Args) > 0 {
+			ds.Spec.Template.Spec.Containers[i].Args = append(slices.Clone(container.Args), c.spec.Args...)
+		}
+	}
+	if env := devicePluginEnv(policy, c.name); len(env) > 0 {
+		for i := range ds.Spec.Template.Spec.Containers {
+			ds.Spec.Template.Spec.Containers[i].Env = mergeEnv(ds.Spec.Template.Spec.Containers[i].Env, env)
+		}
+	}
+	if err := applySecurityContexts(c, &ds.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	addExtraVolumes(policy, c, &ds.Spec.Template.Spec)
+	withComponentMetadata(policy, c, ds)
+	if pullPolicy := componentPullPolicy(policy, c); pullPolicy != "" {
+		for i := range ds.Spec.Template.Spec.Containers {
+			ds.Spec.Template.Spec.Containers[i].ImagePullPolicy = pullPolicy
+		}
+	}
+	if err := patchObject(policy, patchKindDaemonSet, c.name, ds); err != nil {
+		return nil, err
+	}
+	return withConfigHash(ds, rendered), nil
+}
+
+// -- vendorPodSpec returns the pod settings of a vendor
+func vendorPodSpec(policy *npuv1alpha1.NPUClusterPolicy, vendor string) npuv1alpha1.VendorPodSpec {
+	switch vendor {
+	case "nvidia":
+		return policy.Spec.Nvidia.VendorPodSpec
+	case "furiosa":
+		return policy.Spec.Furiosa.VendorPodSpec
+	}
+	return npuv1alpha1.VendorPodSpec{}
+}
+
+// -- vendorNodeLabels returns the labels selecting the nodes of a vendor
+func vendorNodeLabels(policy *npuv1alpha1.NPUClusterPolicy, vendor string) map[string]string {
+	if selector := vendorPodSpec(policy, vendor).NodeSelector; len(selector) > 0 {
+		return selector
+	}
+	if vendor == "furiosa" {
+		return furiosaNodeLabels
+	}
+	return nvidiaNodeLabels
+}
+
+// -- applyVendorPodSpec places the pods of the component by the settings of its vendor. Device
+// plugins tolerate every taint without tolerations, as nodes without a plugin never advertise
+// their accelerators.
+func applyVendorPodSpec(policy *npuv1alpha1.NPUClusterPolicy, c component, pod *corev1.PodSpec) {
+	spec := vendorPodSpec(policy, c.vendor)
+	pod.NodeSelector = vendorNodeLabels(policy, c.vendor)
+	pod.Affinity = spec.Affinity
+	pod.Tolerations = spec.Tolerations
+	pod.ImagePullSecrets = imagePullSecrets(policy, c.vendor)
+	if runtimeClass := spec.RuntimeClassName; runtimeClass != "" && !strings.HasSuffix(c.name, "-driver") {
+		pod.RuntimeClassName = &runtimeClass
+	}
+	if len(pod.Tolerations) == 0 && (c.name == nvidiaDevicePluginName || c.name == furiosaDevicePluginName) {
+		pod.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	}
+}
+
+// -- applySecurityContexts overrides the security contexts of the component pod and container
+// by those of its spec
+func applySecurityContexts(c component, pod *corev1.PodSpec) error {
+	if c.spec.PodSecurityContext != nil {
+		merged, err := mergeSecurityContext(pod.SecurityContext, c.spec.PodSecurityContext)
+		if err != nil {
+			return fmt.Errorf("invalid podSecurityContext: %w", err)
+		}
+		pod.SecurityContext = merged
+	}
+	if c.spec.SecurityContext == nil {
+		return nil
+	}
+	for i, container := range pod.Containers {
+		if container.Name != c.name {
+			continue
+		}
+		merged, err := mergeSecurityContext(container.SecurityContext, c.spec.SecurityContext)
+		if err != nil {
+			return fmt.Errorf("invalid securityContext: %w", err)
+		}
+		pod.Containers[i].SecurityContext = merged
+	}
+	return nil
+}
+
+// -- mergeSecurityContext overlays the fields set in the override onto the security context set
+// by the operator, keeping the defaults of the fields it does not set
+func mergeSecurityContext[T any](defaults, override *T) (*T, error) {
+	if defaults == nil {
+		defaults = new(T)
+	}
+	original, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(override)
+	if err != nil {
+		return nil, err
+	}
+	data, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return nil, err
+	}
+	merged := new(T)
+	return merged, json.Unmarshal(data, merged)
+}
+
+// -- devicePluginEnv returns the variables added to the environment of the component when it is a
+// device plugin
+func devicePluginEnv(policy *npuv1alpha1.NPUClusterPolicy, name string) []corev1.EnvVar {
+	switch name {
+	case nvidiaDevicePluginName:
+		return policy.Spec.Nvidia.Env
+	case furiosaDevicePluginName:
+		return policy.Spec.Furiosa.Env
+	}
+	return nil
+}
+
+// -- addExtraVolumes appends the extra volumes of the vendor to the pods of its device plugin
+func addExtraVolumes(policy *npuv1alpha1.NPUClusterPolicy, c component, pod *corev1.PodSpec) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	switch c.name {
+	case nvidiaDevicePluginName:
+		volumes, mounts = policy.Spec.Nvidia.ExtraVolumes, policy.Spec.Nvidia.ExtraVolumeMounts
+	case furiosaDevicePluginName:
+		volumes, mounts = policy.Spec.Furiosa.ExtraVolumes, policy.Spec.Furiosa.ExtraVolumeMounts
+	default:
+		return
+	}
+	for _, volume := range volumes {
+		pod.Volumes = append(pod.Volumes, *volume.DeepCopy())
+	}
+	for i := range pod.Containers {
+		if pod.Containers[i].Name != c.name {
+			continue
+		}
+		for _, mount := range mounts {
+			pod.Containers[i].VolumeMounts = append(pod.Containers[i].VolumeMounts, *mount.DeepCopy())
+		}
+	}
+}
+
+// -- mergeEnv replaces the variables of env set in extra and appends the others
+func mergeEnv(env, extra []corev1.EnvVar) []corev1.EnvVar {
+	merged := slices.Clone(env)
+	for _, v := range extra {
+		if i := slices.IndexFunc(merged, func(e corev1.EnvVar) bool { return e.Name == v.Name }); i >= 0 {
+			merged[i] = *v.DeepCopy()
+		} else {
+			merged = append(merged, *v.DeepCopy())
+		}
+	}
+	return merged
+}
+
+// -- componentPullPolicy returns the pull policy forced on the containers of the component, or ""
+// to keep the one of its template. Edge sites never pull images they already have.
+func componentPullPolicy(policy *npuv1alpha1.NPUClusterPolicy, c component) corev1.PullPolicy {
+	switch {
+	case policy.Spec.Edge != nil:
+		return edgePullPolicy(policy)
+	case c.spec.ImagePullPolicy != "":
+		return c.spec.ImagePullPolicy
+	default:
+		return vendorPodSpec(policy, c.vendor).ImagePullPolicy
+	}
+}
+
+// -- imagePullSecrets returns the pull secrets of the policy followed by those of the vendors
+func imagePullSecrets(policy *npuv1alpha1.NPUClusterPolicy, vendors ...string) []corev1.LocalObjectReference {
+	secrets := slices.Clone(policy.Spec.ImagePullSecrets)
+	for _, vendor := range vendors {
+		for _, secret := range vendorPodSpec(policy, vendor).ImagePullSecrets {
+			if !slices.Contains(secrets, secret) {
+				secrets = append(secrets, secret)
+			}
+		}
+	}
+	return secrets
+}
+
+// -- daemonSetToApply returns the DaemonSet to server-side apply and the generation of the existing
+// one, zero when it does not exist yet, so the apply can report whether it was created or its spec
+// changed. Every field the operator sets is owned by it, so changes to the policy reach the running
+// DaemonSet and edits are resolved by the conflict policy. Each component has its own DaemonSet, so
+// upgrading one component never restarts another.
+func (r *NPUClusterPolicyReconciler) daemonSetToApply(ctx context.Context, desired *appsv1.DaemonSet) (*appsv1.DaemonSet, int64, error) {
+	existing := &appsv1.DaemonSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil && !apierrors.IsNotFound(err) {
+		return nil, 0, err
+	}
+	ds := desired.DeepCopy()
+	ds.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "DaemonSet"}
+	ds.ResourceVersion = ""
+	ds.Status = appsv1.DaemonSetStatus{}
+	return ds, existing.Generation, nil
+}
@@ -0,0 +1,619 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Vendor components", func() {
+	const resourceName = "components"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+	exporterKey := types.NamespacedName{Name: "nvidia-dcgm-exporter", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.1"},
+						Exporter: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/k8s/dcgm-exporter",
+							Version: "3.3.9-3.6.1-ubuntu22.04",
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should upgrade the exporter without touching the device plugin", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		exporter := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, exporterKey, exporter)).To(Succeed())
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsTrue(policy, conditions.ComponentReady("nvidia-dcgm-exporter"))).To(BeTrue())
+		Expect(conditions.Get(policy, conditions.ComponentReady("nvidia-validator"))).To(BeNil())
+
+		By("bumping the exporter version")
+		policy.Spec.Nvidia.Exporter.Version = "4.1.1-4.0.4-ubuntu22.04"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		upgraded := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, exporterKey, upgraded)).To(Succeed())
+		Expect(upgraded.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s/dcgm-exporter:4.1.1-4.0.4-ubuntu22.04"))
+
+		untouched := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, untouched)).To(Succeed())
+		Expect(untouched.Generation).To(Equal(plugin.Generation))
+	})
+
+	It("should record events for created and updated components", func() {
+		recorder := record.NewFakeRecorder(20)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drainEvents(recorder)).To(ContainElements(
+			HavePrefix("Normal ComponentCreated Created DaemonSet kube-system/"+nvidiaDevicePluginName),
+			HavePrefix("Normal ComponentCreated Created DaemonSet kube-system/nvidia-dcgm-exporter"),
+		))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Nvidia.Exporter.Version = "4.1.1-4.0.4-ubuntu22.04"
+		policy.Spec.Nvidia.Validator = npuv1alpha1.ComponentSpec{Enabled: boolPtr(true)}
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		events := drainEvents(recorder)
+		Expect(events).To(ContainElements(
+			HavePrefix("Normal ComponentUpdated Updated DaemonSet kube-system/nvidia-dcgm-exporter"),
+			HavePrefix("Warning InvalidSpec Cannot resolve the image of nvidia-validator"),
+		))
+		Expect(events).NotTo(ContainElement(ContainSubstring(nvidiaDevicePluginName)))
+	})
+
+	It("should revert edits to a managed DaemonSet", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("editing the device plugin by hand")
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		image := plugin.Spec.Template.Spec.Containers[0].Image
+		plugin.Spec.Template.Spec.Containers[0].Image = "example.com/patched-device-plugin:dev"
+		Expect(k8sClient.Update(ctx, plugin)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		reverted := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, reverted)).To(Succeed())
+		Expect(reverted.Spec.Template.Spec.Containers[0].Image).To(Equal(image))
+	})
+})
+
+var _ = Describe("Field conflicts", func() {
+	const resourceName = "field-conflicts"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.1"},
+					},
+				},
+				ConflictPolicy: npuv1alpha1.ConflictPolicyFail,
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should report fields taken by another manager and resolve them by the conflict policy", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("taking the image with another field manager")
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		image := plugin.Spec.Template.Spec.Containers[0].Image
+		plugin.Spec.Template.Spec.Containers[0].Image = "example.com/patched-device-plugin:dev"
+		Expect(k8sClient.Update(ctx, plugin, client.FieldOwner("kubectl-edit"))).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		ready := conditions.Get(policy, conditions.ComponentReady(nvidiaDevicePluginName))
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(conditions.ReasonFieldConflict))
+		Expect(ready.Message).To(ContainSubstring(`"kubectl-edit"`))
+		Expect(conditions.IsTrue(policy, conditions.FieldConflict)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal("example.com/patched-device-plugin:dev"))
+
+		By("forcing the fields back")
+		policy.Spec.ConflictPolicy = npuv1alpha1.ConflictPolicyForce
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal(image))
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		fieldConflict := conditions.Get(policy, conditions.FieldConflict)
+		Expect(fieldConflict).NotTo(BeNil())
+		Expect(fieldConflict.Message).To(ContainSubstring("(Force)"))
+		Expect(fieldConflict.Message).To(ContainSubstring(`"kubectl-edit"`))
+	})
+})
+
+var _ = Describe("Owner references", func() {
+	ctx := context.Background()
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+
+	newPolicy := func(namespace string) *npuv1alpha1.NPUClusterPolicy {
+		return &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner-references", Namespace: namespace},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.1"},
+					},
+				},
+			},
+		}
+	}
+
+	reconcilePolicy := func(policy *npuv1alpha1.NPUClusterPolicy) *appsv1.DaemonSet {
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(func() {
+			deletePolicy(ctx, policy)
+		})
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		Expect(err).NotTo(HaveOccurred())
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		return plugin
+	}
+
+	It("should make a policy in kube-system the controller of its objects", func() {
+		policy := newPolicy("kube-system")
+		plugin := reconcilePolicy(policy)
+		Expect(metav1.IsControlledBy(plugin, policy)).To(BeTrue())
+	})
+
+	It("should not reference a policy in another namespace", func() {
+		plugin := reconcilePolicy(newPolicy("default"))
+		Expect(plugin.OwnerReferences).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Vendor pod settings", func() {
+	It("should override the security contexts, keeping the defaults of the unset fields", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Validator.SecurityContext = &corev1.SecurityContext{
+			ReadOnlyRootFilesystem: ptrTo(true),
+			RunAsUser:              ptrTo[int64](0),
+		}
+		policy.Spec.Nvidia.Validator.PodSecurityContext = &corev1.PodSecurityContext{
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		policy.Spec.Nvidia.DevicePlugin.SecurityContext = &corev1.SecurityContext{Privileged: ptrTo(true)}
+
+		validator, err := renderComponent(policy, nvidiaComponents(policy)[4], "validator", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validator.Spec.Template.Spec.Containers[0].SecurityContext).To(Equal(&corev1.SecurityContext{
+			Privileged:               ptrTo(true),
+			AllowPrivilegeEscalation: ptrTo(true),
+			ReadOnlyRootFilesystem:   ptrTo(true),
+			RunAsUser:                ptrTo[int64](0),
+		}))
+		Expect(validator.Spec.Template.Spec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Containers[0].SecurityContext).To(Equal(&corev1.SecurityContext{
+			Privileged:               ptrTo(true),
+			AllowPrivilegeEscalation: ptrTo(false),
+		}))
+		Expect(plugin.Spec.Template.Spec.SecurityContext).To(BeNil())
+	})
+
+	It("should roll the component DaemonSets by their update strategy", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.DevicePlugin.UpdateStrategy = &appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: ptrTo(intstr.FromString("10%")),
+			},
+		}
+		policy.Spec.Nvidia.Driver.UpdateStrategy = &appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.String()).To(Equal("10%"))
+		driver, err := renderComponent(policy, nvidiaComponents(policy)[0], "driver", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+		exporter, err := renderComponent(policy, nvidiaComponents(policy)[3], "exporter", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exporter.Spec.UpdateStrategy).To(Equal(appsv1.DaemonSetUpdateStrategy{}))
+	})
+
+	It("should run the components of the vendor but the driver with its runtime class", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.RuntimeClassName = "nvidia"
+
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.RuntimeClassName).To(HaveValue(Equal("nvidia")))
+		exporter, err := renderComponent(policy, nvidiaComponents(policy)[3], "exporter", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exporter.Spec.Template.Spec.RuntimeClassName).To(HaveValue(Equal("nvidia")))
+
+		driver, err := renderComponent(policy, nvidiaComponents(policy)[0], "driver", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+		furiosa, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(furiosa.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+	})
+
+	It("should add the extra volumes to the device plugins", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		certs := corev1.Volume{Name: "certs", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "registry-certs"},
+		}}
+		policy.Spec.Nvidia.ExtraVolumes = []corev1.Volume{certs}
+		policy.Spec.Nvidia.ExtraVolumeMounts = []corev1.VolumeMount{{Name: "certs", MountPath: "/etc/certs", ReadOnly: true}}
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Volumes).To(ContainElements(HaveField("Name", "device-plugin"), certs))
+		Expect(plugin.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(HaveField("MountPath", "/etc/certs")))
+
+		furiosa, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(furiosa.Spec.Template.Spec.Volumes).NotTo(ContainElement(certs))
+	})
+
+	It("should append the extra args to the component container", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.DevicePlugin.Args = []string{"--fail-on-init-error=false", "--pass-device-specs"}
+		policy.Spec.Furiosa.DevicePlugin.Args = []string{"--verbose"}
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"--fail-on-init-error=false", "--pass-device-specs"}))
+
+		furiosa, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(furiosa.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"--config-file", "/etc/furiosa/config.yaml", "--verbose"}))
+	})
+
+	It("should merge the extra variables into the environment of the device plugins", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Env = []corev1.EnvVar{{Name: "MIG_STRATEGY", Value: "mixed"}}
+		policy.Spec.Furiosa.Env = []corev1.EnvVar{{Name: "RUST_LOG", Value: "debug"}}
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "MIG_STRATEGY", Value: "mixed"}))
+		driver, err := renderComponent(policy, nvidiaComponents(policy)[0], "driver", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Spec.Template.Spec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", "MIG_STRATEGY")))
+
+		furiosa, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		env := furiosa.Spec.Template.Spec.Containers[0].Env
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "RUST_LOG", Value: "debug"}))
+		Expect(env).NotTo(ContainElement(corev1.EnvVar{Name: "RUST_LOG", Value: "info"}))
+		Expect(env).To(ContainElement(HaveField("Name", "NODE_NAME")))
+	})
+
+	It("should set the pull policy of the vendor unless the component or the edge site sets one", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		plugin, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+
+		policy.Spec.Furiosa.ImagePullPolicy = corev1.PullNever
+		policy.Spec.Furiosa.GFD.ImagePullPolicy = corev1.PullAlways
+		for _, c := range furiosaComponents(policy) {
+			ds, err := renderComponent(policy, c, "image", nil)
+			Expect(err).NotTo(HaveOccurred())
+			expected := corev1.PullNever
+			if c.name == "furiosa-feature-discovery" {
+				expected = corev1.PullAlways
+			}
+			Expect(ds.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(Equal(expected), c.name)
+		}
+
+		policy.Spec.Edge = &npuv1alpha1.EdgeSpec{}
+		gfd, err := renderComponent(policy, furiosaComponents(policy)[2], "gfd", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gfd.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+	})
+
+	It("should tolerate every taint in the device plugins unless tolerations are set", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Validator.Enabled = boolPtr(true)
+		components := nvidiaComponents(policy)
+		plugin, err := renderComponent(policy, components[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{Operator: corev1.TolerationOpExists}))
+		validator, err := renderComponent(policy, components[4], "validator", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validator.Spec.Template.Spec.Tolerations).To(BeEmpty())
+
+		gpuTaint := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpEqual,
+			Value: "present", Effect: corev1.TaintEffectNoSchedule}
+		policy.Spec.Nvidia.Tolerations = []corev1.Toleration{gpuTaint}
+		for _, c := range nvidiaComponents(policy) {
+			ds, err := renderComponent(policy, c, "image", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ds.Spec.Template.Spec.Tolerations).To(ConsistOf(gpuTaint), c.name)
+		}
+	})
+
+	It("should replace the node selector of the vendor and set its affinity", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "pool", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"inference"}},
+				}}},
+			},
+		}}
+		policy.Spec.Furiosa.NodeSelector = map[string]string{"accelerator": "rngd"}
+		policy.Spec.Furiosa.Affinity = affinity
+		for _, c := range furiosaComponents(policy) {
+			ds, err := renderComponent(policy, c, "image", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"accelerator": "rngd"}), c.name)
+			Expect(ds.Spec.Template.Spec.Affinity).To(Equal(affinity), c.name)
+		}
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "image", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.NodeSelector).To(Equal(nvidiaNodeLabels))
+		Expect(plugin.Spec.Template.Spec.Affinity).To(BeNil())
+	})
+
+	It("should add the pull secrets of the policy and of the vendor to every pod", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		harbor := corev1.LocalObjectReference{Name: "harbor"}
+		nvcr := corev1.LocalObjectReference{Name: "nvcr"}
+		policy.Spec.ImagePullSecrets = []corev1.LocalObjectReference{harbor}
+		policy.Spec.Nvidia.ImagePullSecrets = []corev1.LocalObjectReference{nvcr, harbor}
+
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{harbor, nvcr}))
+		Expect(prePullDaemonSet(plugin).Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{harbor, nvcr}))
+		furiosa, err := renderComponent(policy, furiosaComponents(policy)[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(furiosa.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{harbor}))
+
+		hook := hookJob(policy, nvidiaDevicePluginName, "pre-upgrade", &npuv1alpha1.UpgradeHook{Image: "hook"}, "a", "b")
+		Expect(hook.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{harbor, nvcr}))
+		Expect(prePullJob(policy, "node", []string{"image"}).Spec.Template.Spec.ImagePullSecrets).
+			To(ConsistOf(harbor, nvcr))
+	})
+
+	It("should set the resources of a component on its container", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		resources := &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		}
+		policy.Spec.Nvidia.DevicePlugin.Resources = resources
+		components := nvidiaComponents(policy)
+		plugin, err := renderComponent(policy, components[1], "plugin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Resources).To(Equal(*resources))
+		gfd, err := renderComponent(policy, components[2], "gfd", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gfd.Spec.Template.Spec.Containers[0].Resources).To(BeZero())
+	})
+})
+
+var _ = Describe("Multi-vendor nodes", func() {
+	It("should render both vendor stacks onto a node with accelerators of both vendors", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Enabled = true
+		policy.Spec.Furiosa.Enabled = true
+		policy.Spec.Furiosa.ConfigMapName = "furiosa-device-plugin"
+		for _, components := range []*npuv1alpha1.VendorComponents{&policy.Spec.Nvidia.VendorComponents, &policy.Spec.Furiosa.VendorComponents} {
+			for _, spec := range []*npuv1alpha1.ComponentSpec{&components.Driver, &components.GFD, &components.Exporter, &components.Validator} {
+				spec.Enabled = boolPtr(true)
+			}
+		}
+		node := mergeLabels(nvidiaNodeLabels, furiosaNodeLabels)
+
+		names := map[string]bool{}
+		ports := map[int32]string{}
+		configMaps := map[string]string{}
+		for _, c := range append(nvidiaComponents(policy), furiosaComponents(policy)...) {
+			Expect(c.enabled).To(BeTrue(), c.name)
+			ds, err := renderComponent(policy, c, "image", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).NotTo(HaveKey(ds.Name))
+			names[ds.Name] = true
+			for k, v := range ds.Spec.Template.Spec.NodeSelector {
+				Expect(node).To(HaveKeyWithValue(k, v), c.name)
+			}
+			if c.metricsPort != 0 {
+				Expect(ports).NotTo(HaveKey(c.metricsPort), c.name)
+				ports[c.metricsPort] = c.name
+			}
+			for _, v := range ds.Spec.Template.Spec.Volumes {
+				if v.ConfigMap != nil {
+					Expect(configMaps).NotTo(HaveKey(v.ConfigMap.Name), c.name)
+					configMaps[v.ConfigMap.Name] = c.name
+				}
+			}
+		}
+		Expect(names).To(HaveKey(nvidiaDevicePluginName))
+		Expect(names).To(HaveKey(furiosaDevicePluginName))
+	})
+})
+
+var _ = Describe("Component apply", func() {
+	It("should apply every component of a vendor and aggregate the failures", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if obj.GetName() != nvidiaDevicePluginName {
+					return errors.New("rejected")
+				}
+				return nil
+			},
+		}).Build()
+		recorder := record.NewFakeRecorder(20)
+		r := &NPUClusterPolicyReconciler{Client: c, Recorder: recorder}
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "apply", Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.1"},
+						Exporter: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/k8s/dcgm-exporter",
+							Version: "3.3.9-3.6.1-ubuntu22.04",
+						},
+						Validator: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/cloud-native/gpu-operator-validator",
+							Version: "v25.3.0",
+						},
+					},
+				},
+			},
+		}
+
+		err := r.ensureComponents(context.Background(), policy, nil, nvidiaComponents(policy))
+		var failed utilerrors.Aggregate
+		Expect(errors.As(err, &failed)).To(BeTrue())
+		Expect(failed.Errors()).To(HaveLen(2))
+		Expect(conditions.IsTrue(policy, conditions.ComponentReady(nvidiaDevicePluginName))).To(BeTrue())
+		for _, name := range []string{"nvidia-dcgm-exporter", "nvidia-validator"} {
+			Expect(conditions.IsFalse(policy, conditions.ComponentReady(name))).To(BeTrue(), name)
+		}
+		Expect(drainEvents(recorder)).To(ContainElements(
+			HavePrefix("Normal ComponentCreated Created DaemonSet kube-system/"+nvidiaDevicePluginName),
+			HavePrefix("Warning ApplyFailed Failed to apply DaemonSet kube-system/nvidia-dcgm-exporter"),
+			HavePrefix("Warning ApplyFailed Failed to apply DaemonSet kube-system/nvidia-validator"),
+		))
+	})
+})
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
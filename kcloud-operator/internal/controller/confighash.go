@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// -- renderedConfig returns the data of the ConfigMaps the operator renders for the components, by name.
+// ConfigMaps that fail to render are left out; their apply step reports the failure.
+func renderedConfig(policy *npuv1alpha1.NPUClusterPolicy) map[string]map[string]string {
+	rendered := map[string]map[string]string{}
+	if policy.Spec.Nvidia.Enabled && policy.Spec.Nvidia.DevicePluginConfig != nil {
+		if configMap, err := nvidiaDevicePluginConfigMap(policy); err == nil &&
+			patchObject(policy, patchKindConfigMap, configMap.Name, configMap) == nil {
+			rendered[configMap.Name] = configMap.Data
+		}
+	}
+	if policy.Spec.Furiosa.Enabled {
+		configMap := furiosaConfigMap(policy)
+		if err := patchObject(policy, patchKindConfigMap, configMap.Name, configMap); err == nil {
+			rendered[configMap.Name] = configMap.Data
+		}
+	}
+	for vendor, enabled := range map[string]bool{"nvidia": policy.Spec.Nvidia.Enabled, "furiosa": policy.Spec.Furiosa.Enabled} {
+		if !enabled {
+			continue
+		}
+		if data, err := renderDevicePreferences(policy.Spec.DevicePreferences, vendor == "nvidia"); err == nil {
+			rendered[devicePreferencesConfigMapName(vendor)] = data
+		}
+	}
+	if metricsTLSEnabled(policy) {
+		rendered[metricsWebConfigName] = metricsWebConfig(policy).Data
+	}
+	return rendered
+}
+
+// -- withConfigHash stamps the hash of the rendered ConfigMaps mounted by a DaemonSet on its pod template.
+// Pods do not restart when a mounted ConfigMap changes, but they roll when their template does.
+func withConfigHash(ds *appsv1.DaemonSet, rendered map[string]map[string]string) *appsv1.DaemonSet {
+	var names []string
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.ConfigMap == nil {
+			continue
+		}
+		if _, ok := rendered[v.ConfigMap.Name]; ok {
+			names = append(names, v.ConfigMap.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ds
+	}
+	slices.Sort(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		data := rendered[name]
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		h.Write([]byte(name + "\x00"))
+		for _, k := range keys {
+			h.Write([]byte(k + "\x00" + data[k] + "\x00"))
+		}
+	}
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+	ds.Spec.Template.Annotations[npuv1alpha1.ConfigHashAnnotation] = hex.EncodeToString(h.Sum(nil))[:16]
+	return ds
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Config hash", func() {
+	var policy *npuv1alpha1.NPUClusterPolicy
+
+	BeforeEach(func() {
+		policy = &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "confighash"}}
+		policy.Spec.Furiosa = npuv1alpha1.FuriosaSpec{Enabled: true, ConfigMapName: "furiosa-config"}
+	})
+
+	It("should roll the device plugin when its configuration changes", func() {
+		ds := withConfigHash(furiosaDevicePluginDaemonSet(policy, "plugin:1"), renderedConfig(policy))
+		before := ds.Spec.Template.Annotations[npuv1alpha1.ConfigHashAnnotation]
+		Expect(before).NotTo(BeEmpty())
+
+		same := withConfigHash(furiosaDevicePluginDaemonSet(policy, "plugin:2"), renderedConfig(policy))
+		Expect(same.Spec.Template.Annotations[npuv1alpha1.ConfigHashAnnotation]).To(Equal(before))
+
+		policy.Spec.Patches = []npuv1alpha1.ObjectPatch{{
+			Kind: "ConfigMap",
+			Name: "furiosa-config",
+			Operations: []npuv1alpha1.JSONPatchOperation{{
+				Op:    "replace",
+				Path:  "/data/config.yaml",
+				Value: &apiextensionsv1.JSON{Raw: []byte(`"defaultPe: Single"`)},
+			}},
+		}}
+		changed := withConfigHash(furiosaDevicePluginDaemonSet(policy, "plugin:1"), renderedConfig(policy))
+		Expect(changed.Spec.Template.Annotations[npuv1alpha1.ConfigHashAnnotation]).NotTo(Equal(before))
+	})
+
+	It("should leave DaemonSets without rendered configuration alone", func() {
+		ds := genericComponent("furiosa-validator", npuv1alpha1.ComponentSpec{}, furiosaNodeLabels, componentTemplate{}).
+			build(policy, "validator:1")
+		Expect(withConfigHash(ds, renderedConfig(policy)).Spec.Template.Annotations).
+			NotTo(HaveKey(npuv1alpha1.ConfigHashAnnotation))
+	})
+})
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// applyParallelism bounds the objects applied at once by applyConcurrently.
+const applyParallelism = 8
+
+// fieldConflictError is returned by apply when fields of the object are owned by another
+// field manager and the conflict policy does not force them back.
+type fieldConflictError struct {
+	object    string
+	conflicts []string
+	policy    npuv1alpha1.ConflictPolicy
+}
+
+func (e *fieldConflictError) Error() string {
+	return fmt.Sprintf("%s has fields managed elsewhere (conflictPolicy %s): %s",
+		e.object, e.policy, strings.Join(e.conflicts, "; "))
+}
+
+// -- apply server-side applies the object and resolves field conflicts by the conflict policy
+func (r *NPUClusterPolicyReconciler) apply(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, obj client.Object) error {
+	start := time.Now()
+	withCommonMetadata(policy, obj)
+	err := r.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner))
+	conflicts := fieldConflicts(err)
+	if len(conflicts) == 0 {
+		if err == nil {
+			recordApply(ctx, appliedResult(obj, start))
+		}
+		return err
+	}
+
+	object := fmt.Sprintf("%s %s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+	conflictPolicy := policy.Spec.ConflictPolicy
+	if conflictPolicy == "" {
+		conflictPolicy = npuv1alpha1.ConflictPolicyForce
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeWarning, "FieldConflict", "%s has fields managed elsewhere, resolving by %s: %s",
+		object, conflictPolicy, strings.Join(conflicts, "; "))
+	recordConflict(ctx, fmt.Sprintf("%s (%s): %s", object, conflictPolicy, strings.Join(conflicts, "; ")))
+	if conflictPolicy != npuv1alpha1.ConflictPolicyForce {
+		return &fieldConflictError{object: object, conflicts: conflicts, policy: conflictPolicy}
+	}
+	if err := r.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership); err != nil {
+		return err
+	}
+	recordApply(ctx, appliedResult(obj, start))
+	return nil
+}
+
+// -- recordConflict adds the field conflicts of an object to the tally of the context, if any
+func recordConflict(ctx context.Context, conflict string) {
+	tally, ok := ctx.Value(applyTallyKey{}).(*applyTally)
+	if !ok {
+		return
+	}
+	tally.mu.Lock()
+	defer tally.mu.Unlock()
+	tally.conflicts = append(tally.conflicts, conflict)
+}
+
+// -- reportFieldConflicts sets the FieldConflict condition from the conflicts the applies of this
+// reconcile met, whatever the conflict policy made of them
+func reportFieldConflicts(policy *npuv1alpha1.NPUClusterPolicy, tally *applyTally) {
+	tally.mu.Lock()
+	conflicts := slices.Sorted(slices.Values(tally.conflicts))
+	tally.mu.Unlock()
+	if len(conflicts) == 0 {
+		conditions.Remove(policy, conditions.FieldConflict)
+		return
+	}
+	conditions.MarkTrue(policy, conditions.FieldConflict, conditions.ReasonFieldConflict, strings.Join(conflicts, "; "))
+}
+
+// -- applyConcurrently applies independent objects with bounded parallelism and returns the error
+// of each object by index, nil when it was applied
+func (r *NPUClusterPolicyReconciler) applyConcurrently(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	objs []client.Object) []error {
+	errs := make([]error, len(objs))
+	var g errgroup.Group
+	g.SetLimit(applyParallelism)
+	for i, obj := range objs {
+		g.Go(func() error {
+			errs[i] = r.apply(ctx, policy, obj)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return errs
+}
+
+// -- fieldConflicts returns the field manager conflicts of a failed apply as "<field>: conflict with <manager>"
+func fieldConflicts(err error) []string {
+	var status apierrors.APIStatus
+	if !apierrors.IsConflict(err) || !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	var conflicts []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause.Field+": "+cause.Message)
+		}
+	}
+	return conflicts
+}
+
+// -- conflictIgnored reports whether the error is a conflict the policy leaves to the other manager
+func conflictIgnored(err error) bool {
+	var conflict *fieldConflictError
+	return errors.As(err, &conflict) && conflict.policy == npuv1alpha1.ConflictPolicyIgnore
+}
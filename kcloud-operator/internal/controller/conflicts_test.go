@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Concurrent apply", func() {
+	It("should apply with bounded parallelism and report the error of each object", func() {
+		var inFlight, maxInFlight atomic.Int32
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				if obj.GetName() == "broken" {
+					return errors.New("rejected")
+				}
+				return nil
+			},
+		}).Build()
+		r := &NPUClusterPolicyReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+		var objs []client.Object
+		for _, name := range []string{"a", "b", "broken", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+			objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"}})
+		}
+		errs := r.applyConcurrently(context.Background(), &npuv1alpha1.NPUClusterPolicy{}, objs)
+		Expect(errs).To(HaveLen(len(objs)))
+		for i, err := range errs {
+			if i == 2 {
+				Expect(err).To(MatchError("rejected"))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+		Expect(maxInFlight.Load()).To(BeNumerically(">", 1))
+		Expect(maxInFlight.Load()).To(BeNumerically("<=", applyParallelism))
+	})
+})
+
+var _ = Describe("Field conflicts", func() {
+	// conflicted fails applies that do not force ownership with a conflict on the image.
+	conflicted := func() client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				if patchOpts.Force != nil && *patchOpts.Force {
+					return nil
+				}
+				return apierrors.NewApplyConflict([]metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Field:   ".spec.template.spec.containers[name=\"plugin\"].image",
+					Message: `conflict with "kubectl-edit" using apps/v1`,
+				}}, "Apply failed with 1 conflict")
+			},
+		}).Build()
+	}
+
+	It("should report the conflicts in the FieldConflict condition under every conflict policy", func() {
+		for _, conflictPolicy := range []npuv1alpha1.ConflictPolicy{
+			"", npuv1alpha1.ConflictPolicyForce, npuv1alpha1.ConflictPolicyFail, npuv1alpha1.ConflictPolicyIgnore,
+		} {
+			r := &NPUClusterPolicyReconciler{Client: conflicted(), Recorder: record.NewFakeRecorder(10)}
+			policy := &npuv1alpha1.NPUClusterPolicy{Spec: npuv1alpha1.NPUClusterPolicySpec{ConflictPolicy: conflictPolicy}}
+			ctx, tally := withApplyTally(context.Background())
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "plugin", Namespace: "kube-system"}}
+			_ = r.apply(ctx, policy, obj)
+
+			reportFieldConflicts(policy, tally)
+			cond := conditions.Get(policy, conditions.FieldConflict)
+			Expect(cond).NotTo(BeNil(), string(conflictPolicy))
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(conditions.ReasonFieldConflict))
+			Expect(cond.Message).To(ContainSubstring("kube-system/plugin"))
+			Expect(cond.Message).To(ContainSubstring(`.spec.template.spec.containers[name="plugin"].image`))
+			Expect(cond.Message).To(ContainSubstring(`"kubectl-edit"`))
+		}
+	})
+
+	It("should remove the FieldConflict condition once the applies meet no conflict", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		conditions.MarkTrue(policy, conditions.FieldConflict, conditions.ReasonFieldConflict, "stale")
+		_, tally := withApplyTally(context.Background())
+		reportFieldConflicts(policy, tally)
+		Expect(conditions.Get(policy, conditions.FieldConflict)).To(BeNil())
+	})
+})
+
+// appliedResources are the resources written with apply, by its callers in components.go,
+// metricstls.go, npuclusterpolicy_controller.go and nvidiaconfig.go.
+var appliedResources = []rbacv1.PolicyRule{
+	{APIGroups: []string{"apps"}, Resources: []string{"daemonsets"}},
+	{APIGroups: []string{""}, Resources: []string{"configmaps", "secrets", "services"}},
+	{APIGroups: []string{"cert-manager.io"}, Resources: []string{"certificates"}},
+	{APIGroups: []string{"monitoring.coreos.com"}, Resources: []string{"servicemonitors"}},
+}
+
+var _ = Describe("Apply permissions", func() {
+	It("should grant the verbs of server-side apply on every applied resource", func() {
+		data, err := os.ReadFile(filepath.Join("..", "..", "config", "rbac", "role.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		role := &rbacv1.ClusterRole{}
+		Expect(yaml.Unmarshal(data, role)).To(Succeed())
+
+		// An apply is a patch, which creates the object when it does not exist yet.
+		for _, applied := range appliedResources {
+			group := applied.APIGroups[0]
+			for _, resource := range applied.Resources {
+				var verbs []string
+				for _, rule := range role.Rules {
+					if slices.Contains(rule.APIGroups, group) && slices.Contains(rule.Resources, resource) {
+						verbs = append(verbs, rule.Verbs...)
+					}
+				}
+				Expect(verbs).To(ContainElements("create", "patch"), "%s.%s", resource, group)
+			}
+		}
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	devicePreferencesDefaultPool = "default"
+	devicePreferencesMountPath   = "/etc/npu-device-plugin/preferences"
+)
+
+// devicePreferences is the preferences document read by the device plugins.
+type devicePreferences struct {
+	Allocation allocationPreferences `json:"allocation"`
+}
+
+type allocationPreferences struct {
+	PreferSameNUMANode bool  `json:"preferSameNUMANode"`
+	PreferNVLink       *bool `json:"preferNVLink,omitempty"`
+}
+
+func devicePreferencesConfigMapName(vendor string) string {
+	return vendor + "-device-plugin-preferences"
+}
+
+// -- ensureDevicePreferences renders the preferences of every pool for the enabled vendors and labels the pool nodes
+func (r *NPUClusterPolicyReconciler) ensureDevicePreferences(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+
+	for vendor, enabled := range map[string]bool{"nvidia": policy.Spec.Nvidia.Enabled, "furiosa": policy.Spec.Furiosa.Enabled} {
+		if !enabled {
+			continue
+		}
+		data, err := renderDevicePreferences(policy.Spec.DevicePreferences, vendor == "nvidia")
+		if err != nil {
+			return err
+		}
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      devicePreferencesConfigMapName(vendor),
+			Namespace: componentNamespace(policy),
+		}}
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+			configMap.Labels = mergeLabels(configMap.Labels, policyLabels(policy))
+			configMap.Data = data
+			return r.setOwner(policy, configMap)
+		})
+		if err != nil {
+			log.Error(err, "failed to apply device preferences", "configmap", configMap.Name)
+			return err
+		}
+		recordApply(ctx, result)
+	}
+	return r.labelDevicePreferencePools(ctx, policy.Spec.DevicePreferences)
+}
+
+// -- renderDevicePreferences renders one preferences document per pool, plus the defaults
+func renderDevicePreferences(pools []npuv1alpha1.DevicePreferencePool, nvlink bool) (map[string]string, error) {
+	render := func(sameNUMANode, preferNVLink *bool) (string, error) {
+		doc := devicePreferences{Allocation: allocationPreferences{
+			PreferSameNUMANode: sameNUMANode == nil || *sameNUMANode,
+		}}
+		if nvlink {
+			doc.Allocation.PreferNVLink = boolPtr(preferNVLink == nil || *preferNVLink)
+		}
+		raw, err := yaml.Marshal(doc)
+		return string(raw), err
+	}
+
+	data := map[string]string{}
+	var err error
+	if data[devicePreferencesDefaultPool+".yaml"], err = render(nil, nil); err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		if data[pool.Name+".yaml"], err = render(pool.PreferSameNUMANode, pool.PreferNVLink); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// -- labelDevicePreferencePools labels each managed accelerator node with the first pool selecting it
+func (r *NPUClusterPolicyReconciler) labelDevicePreferencePools(ctx context.Context, pools []npuv1alpha1.DevicePreferencePool) error {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if unmanagedNode(node) {
+			continue
+		}
+		want := ""
+		if isAcceleratorNode(node) {
+			for _, pool := range pools {
+				if labels.SelectorFromSet(pool.NodeSelector).Matches(labels.Set(node.Labels)) {
+					want = pool.Name
+					break
+				}
+			}
+		}
+		if node.Labels[npuv1alpha1.DevicePreferencesLabel] == want {
+			continue
+		}
+		patch := client.MergeFrom(node.DeepCopy())
+		if want == "" {
+			delete(node.Labels, npuv1alpha1.DevicePreferencesLabel)
+		} else {
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[npuv1alpha1.DevicePreferencesLabel] = want
+		}
+		if err := r.Patch(ctx, node, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -- withDevicePreferences mounts the preferences of the vendor into the device plugin. The
+// plugin reads <pool>.yaml for the pool named by the node label, and default.yaml otherwise.
+func withDevicePreferences(ds *appsv1.DaemonSet, vendor string) *appsv1.DaemonSet {
+	pod := &ds.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "device-preferences",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: devicePreferencesConfigMapName(vendor)},
+				Optional:             boolPtr(true),
+			},
+		},
+	})
+	container := &pod.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name: "device-preferences", MountPath: devicePreferencesMountPath, ReadOnly: true,
+	})
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "DEVICE_PREFERENCES_DIR", Value: devicePreferencesMountPath},
+		corev1.EnvVar{Name: "DEVICE_PREFERENCES_NODE_LABEL", Value: npuv1alpha1.DevicePreferencesLabel},
+	)
+	return ds
+}
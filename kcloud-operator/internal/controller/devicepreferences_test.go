@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Device preferences", func() {
+	const resourceName = "device-preferences"
+	const nodeName = "device-preferences-node"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+	BeforeEach(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"nvidia.com/gpu.present": "true", "pool": "training"},
+		}})).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+					},
+				},
+				DevicePreferences: []npuv1alpha1.DevicePreferencePool{{
+					Name:         "training",
+					NodeSelector: map[string]string{"pool": "training"},
+					PreferNVLink: boolPtr(false),
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+	})
+
+	It("should render the pool preferences and label the pool nodes", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Name: devicePreferencesConfigMapName("nvidia"), Namespace: "kube-system",
+		}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKey("default.yaml"))
+		Expect(configMap.Data["training.yaml"]).To(ContainSubstring("preferNVLink: false"))
+
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(node.Labels).To(HaveKeyWithValue(npuv1alpha1.DevicePreferencesLabel, "training"))
+	})
+})
+
+var _ = Describe("Device preference rendering", func() {
+	It("should default to NUMA and NVLink locality", func() {
+		data, err := renderDevicePreferences(nil, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(HaveLen(1))
+		Expect(data["default.yaml"]).To(ContainSubstring("preferSameNUMANode: true"))
+		Expect(data["default.yaml"]).To(ContainSubstring("preferNVLink: true"))
+	})
+
+	It("should leave NVLink out for other vendors", func() {
+		data, err := renderDevicePreferences([]npuv1alpha1.DevicePreferencePool{{
+			Name: "inference", PreferSameNUMANode: boolPtr(false),
+		}}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data["inference.yaml"]).To(ContainSubstring("preferSameNUMANode: false"))
+		Expect(data["inference.yaml"]).NotTo(ContainSubstring("NVLink"))
+	})
+})
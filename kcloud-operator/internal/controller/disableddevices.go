@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const disabledDevicesMountPath = "/etc/npu-device-plugin/disabled-devices"
+
+// disabledDevices is the document of the devices disabled on a node, read by the device plugins.
+type disabledDevices struct {
+	DisabledDevices []string `json:"disabledDevices"`
+}
+
+func disabledDevicesConfigMapName(vendor string) string {
+	return vendor + "-device-plugin-disabled-devices"
+}
+
+// -- ensureDisabledDevices renders the devices disabled on each node for the device plugins of the
+// enabled vendors. The documents are not part of the config hash, so excluding a device does not
+// restart every plugin; only the plugin of a node whose disabled devices changed is restarted.
+func (r *NPUClusterPolicyReconciler) ensureDisabledDevices(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := r.List(ctx, npuNodes); err != nil {
+		return err
+	}
+
+	for vendor, enabled := range map[string]bool{"nvidia": policy.Spec.Nvidia.Enabled, "furiosa": policy.Spec.Furiosa.Enabled} {
+		if !enabled {
+			continue
+		}
+		data, err := renderDisabledDevices(npuNodes.Items, vendor)
+		if err != nil {
+			return err
+		}
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      disabledDevicesConfigMapName(vendor),
+			Namespace: componentNamespace(policy),
+		}}
+		var previous map[string]string
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+			previous = configMap.Data
+			configMap.Labels = mergeLabels(configMap.Labels, policyLabels(policy))
+			configMap.Data = data
+			return r.setOwner(policy, configMap)
+		})
+		if err != nil {
+			log.Error(err, "failed to apply disabled devices", "configmap", configMap.Name)
+			return err
+		}
+		recordApply(ctx, result)
+		if result != controllerutil.OperationResultUpdated {
+			continue
+		}
+		changed := map[string]bool{}
+		for key := range previous {
+			changed[key] = previous[key] != data[key]
+		}
+		for key := range data {
+			changed[key] = changed[key] || previous[key] != data[key]
+		}
+		for _, key := range slices.Sorted(maps.Keys(changed)) {
+			if !changed[key] {
+				continue
+			}
+			node := strings.TrimSuffix(key, ".yaml")
+			if err := r.restartPlugin(ctx, policy, vendor+"-device-plugin", node); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// -- renderDisabledDevices renders one document per node of the vendor with disabled devices
+// of the vendor
+func renderDisabledDevices(npuNodes []npuv1alpha1.NPUNode, vendor string) (map[string]string, error) {
+	data := map[string]string{}
+	for _, n := range npuNodes {
+		vendors := npuNodeVendors(&n)
+		var devices []string
+		for _, d := range n.Spec.DisabledDevices {
+			if owner, device := deviceVendor(&n, vendors, d); owner == vendor {
+				devices = append(devices, device)
+			}
+		}
+		if len(devices) == 0 {
+			continue
+		}
+		raw, err := yaml.Marshal(disabledDevices{DisabledDevices: devices})
+		if err != nil {
+			return nil, err
+		}
+		data[n.Name+".yaml"] = string(raw)
+	}
+	return data, nil
+}
+
+// -- deviceVendor returns the vendor of a disabled device and the device as named to the device
+// plugin. Devices are owned by the vendor they are prefixed with, e.g. furiosa:0, by the vendor
+// the node agent found them with, or by the only vendor of the node. On nodes with several
+// vendors, other devices are owned by none.
+func deviceVendor(npuNode *npuv1alpha1.NPUNode, vendors []string, device string) (string, string) {
+	if vendor, name, ok := strings.Cut(device, ":"); ok && slices.Contains(vendorNames, vendor) {
+		return vendor, name
+	}
+	for _, d := range npuNode.Status.Devices {
+		if d.ID == device && d.Vendor != "" {
+			return d.Vendor, device
+		}
+	}
+	if len(vendors) == 1 {
+		return vendors[0], device
+	}
+	return "", device
+}
+
+// -- restartPlugin deletes the pod of a device plugin on a node, so it is recreated and rereads its configuration
+func (r *NPUClusterPolicyReconciler) restartPlugin(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	plugin, node string) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(componentNamespace(policy)),
+		client.MatchingLabels{"app.kubernetes.io/name": plugin}, client.MatchingFields{podNodeNameIndex: node}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		logf.FromContext(ctx).Info("Restarting device plugin to apply disabled devices", "plugin", plugin, "node", node)
+		if err := r.Delete(ctx, &pods.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "DisabledDevicesChanged",
+			"Restarted %s on node %s to apply its disabled devices", plugin, node)
+	}
+	return nil
+}
+
+// -- withDisabledDevices mounts the disabled devices of the vendor into the device plugin. The
+// plugin reads <node>.yaml for the node it runs on and uses every device without one.
+func withDisabledDevices(ds *appsv1.DaemonSet, vendor string) *appsv1.DaemonSet {
+	pod := &ds.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "disabled-devices",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: disabledDevicesConfigMapName(vendor)},
+				Optional:             boolPtr(true),
+			},
+		},
+	})
+	container := &pod.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name: "disabled-devices", MountPath: disabledDevicesMountPath, ReadOnly: true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{Name: "DISABLED_DEVICES_DIR", Value: disabledDevicesMountPath})
+	return ds
+}
+
+// -- policiesForDisabledDevices maps an NPUNode to the policies deploying device plugins
+func (r *NPUClusterPolicyReconciler) policiesForDisabledDevices(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list policies for disabled devices", "node", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policies.Items {
+		if p.Spec.Nvidia.Enabled || p.Spec.Furiosa.Enabled {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Disabled devices", func() {
+	const resourceName = "disabled-devices"
+	const nodeName = "disabled-devices-node"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: "disabled-devices-plugin", Namespace: npuv1alpha1.DefaultComponentNamespace}
+
+	BeforeEach(func() {
+		npuNode := &npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: map[string]string{npuv1alpha1.VendorLabel: "nvidia"}},
+			Spec:       npuv1alpha1.NPUNodeSpec{DisabledDevices: []string{"3"}},
+		}
+		Expect(k8sClient.Create(ctx, npuNode)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pluginKey.Name,
+				Namespace: pluginKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "nvidia-device-plugin"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "plugin", Image: "plugin"}},
+			},
+		})).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pluginKey.Name, Namespace: pluginKey.Namespace}}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0)))).To(Succeed())
+	})
+
+	It("should render the disabled devices and restart only the plugin of the changed node", func() {
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Name: disabledDevicesConfigMapName("nvidia"), Namespace: npuv1alpha1.DefaultComponentNamespace,
+		}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKeyWithValue(nodeName+".yaml", "disabledDevices:\n- \"3\"\n"))
+		By("leaving the plugin running when the configuration is created")
+		pod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, pluginKey, pod)).To(Succeed())
+		Expect(pod.DeletionTimestamp).To(BeNil())
+
+		By("restarting the plugin when the disabled devices change")
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, npuNode)).To(Succeed())
+		npuNode.Spec.DisabledDevices = []string{"3", "GPU-1234"}
+		Expect(k8sClient.Update(ctx, npuNode)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Name: disabledDevicesConfigMapName("nvidia"), Namespace: npuv1alpha1.DefaultComponentNamespace,
+		}, configMap)).To(Succeed())
+		Expect(configMap.Data[nodeName+".yaml"]).To(ContainSubstring("GPU-1234"))
+		pod = &corev1.Pod{}
+		err = k8sClient.Get(ctx, pluginKey, pod)
+		Expect(apierrors.IsNotFound(err) || pod.DeletionTimestamp != nil).To(BeTrue())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DisabledDevicesChanged")))
+	})
+
+	It("should mount the disabled devices into the device plugin", func() {
+		ds := nvidiaDevicePluginDaemonSet(&npuv1alpha1.NPUClusterPolicy{}, "plugin")
+		Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(
+			HaveField("MountPath", disabledDevicesMountPath)))
+	})
+})
+
+var _ = Describe("Disabled devices of multi-vendor nodes", func() {
+	It("should hand each device to the plugin of its vendor", func() {
+		workstation := npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "workstation", Labels: map[string]string{
+				npuv1alpha1.VendorLabel:                   npuv1alpha1.MultiVendor,
+				npuv1alpha1.VendorLabelPrefix + "nvidia":  "true",
+				npuv1alpha1.VendorLabelPrefix + "furiosa": "true",
+			}},
+			Spec: npuv1alpha1.NPUNodeSpec{DisabledDevices: []string{"furiosa:1", "GPU-8f3c", "0000:3b:00.0", "2"}},
+			Status: npuv1alpha1.NPUNodeStatus{Devices: []npuv1alpha1.DiscoveredDevice{
+				{ID: "GPU-8f3c", Vendor: "nvidia"},
+				{ID: "0000:3b:00.0", Vendor: "furiosa"},
+			}},
+		}
+		gpuNode := npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: map[string]string{npuv1alpha1.VendorLabel: "nvidia"}},
+			Spec:       npuv1alpha1.NPUNodeSpec{DisabledDevices: []string{"3"}},
+		}
+		nodes := []npuv1alpha1.NPUNode{workstation, gpuNode}
+
+		nvidia, err := renderDisabledDevices(nodes, "nvidia")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nvidia).To(Equal(map[string]string{
+			"workstation.yaml": "disabledDevices:\n- GPU-8f3c\n",
+			"gpu-node.yaml":    "disabledDevices:\n- \"3\"\n",
+		}))
+		furiosa, err := renderDisabledDevices(nodes, "furiosa")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(furiosa).To(Equal(map[string]string{
+			"workstation.yaml": "disabledDevices:\n- \"1\"\n- 0000:3b:00.0\n",
+		}))
+
+		By("leaving devices named only by index to no plugin")
+		vendor, device := deviceVendor(&workstation, npuNodeVendors(&workstation), "2")
+		Expect(vendor).To(BeEmpty())
+		Expect(device).To(Equal("2"))
+	})
+})
@@ -0,0 +1,228 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const driverUpgradePollInterval = 15 * time.Second
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+
+// nodeWorkload is the load of a node running an outdated driver.
+type nodeWorkload struct {
+	node string
+	pod  *corev1.Pod
+	// requested is the number of accelerators requested by the pods of the node.
+	requested int64
+	// running is how long the longest running of those pods has been running.
+	running time.Duration
+}
+
+// -- workloadAwareDriver reports whether the component is a driver upgraded by the WorkloadAware strategy
+func workloadAwareDriver(policy *npuv1alpha1.NPUClusterPolicy, name string) bool {
+	spec := policy.Spec.DriverUpgrade
+	return spec != nil && spec.Strategy == npuv1alpha1.DriverUpgradeWorkloadAware && strings.HasSuffix(name, "-driver")
+}
+
+// -- upgradeDrivers replaces the outdated pods of the drivers upgraded by the WorkloadAware strategy,
+// least loaded nodes first, and reports whether an upgrade is still in progress
+func (r *NPUClusterPolicyReconciler) upgradeDrivers(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (bool, error) {
+	inProgress := false
+	for _, c := range enabledComponents(policy) {
+		if !workloadAwareDriver(policy, c.name) {
+			if status := findComponentStatus(policy, c.name); status != nil {
+				status.DriverUpgrade = nil
+			}
+			continue
+		}
+		if inSafeMode(policy, c.name) {
+			continue
+		}
+		upgrading, err := r.upgradeDriver(ctx, policy, c.name)
+		if err != nil {
+			return false, err
+		}
+		inProgress = inProgress || upgrading
+	}
+	return inProgress, nil
+}
+
+// -- upgradeDriver advances the upgrade of one driver. Outdated driver pods are deleted so the
+// DaemonSet recreates them with the new image, at most maxParallel nodes at a time.
+func (r *NPUClusterPolicyReconciler) upgradeDriver(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	name string) (bool, error) {
+	log := logf.FromContext(ctx)
+	spec := policy.Spec.DriverUpgrade
+	status := componentStatus(policy, name)
+
+	ds := &appsv1.DaemonSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: componentNamespace(policy)}, ds); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if len(ds.Spec.Template.Spec.Containers) == 0 {
+		return false, nil
+	}
+	image := ds.Spec.Template.Spec.Containers[0].Image
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(ds.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels)); err != nil {
+		return false, err
+	}
+
+	var previous []string
+	if status.DriverUpgrade != nil {
+		previous = status.DriverUpgrade.Upgrading
+	}
+	var upgrading []string
+	var outdated []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		switch {
+		case pod.Spec.NodeName == "":
+		case pod.DeletionTimestamp != nil:
+			upgrading = append(upgrading, pod.Spec.NodeName)
+		case len(pod.Spec.Containers) > 0 && pod.Spec.Containers[0].Image != image:
+			outdated = append(outdated, pod)
+		case !podReady(pod):
+			// The new driver is still starting on the node.
+			upgrading = append(upgrading, pod.Spec.NodeName)
+		}
+	}
+	if len(outdated) == 0 {
+		if status.DriverUpgrade != nil && len(upgrading) == 0 {
+			r.Recorder.Eventf(policy, corev1.EventTypeNormal, "DriverUpgradeCompleted",
+				"Every node runs %s of %s", image, name)
+			status.DriverUpgrade = nil
+		}
+		if status.DriverUpgrade != nil {
+			status.DriverUpgrade.Upgrading = sortedNodes(upgrading)
+			status.DriverUpgrade.Waiting = nil
+			status.DriverUpgrade.Remaining = 0
+		}
+		unsilenceFinished(ctx, r.Silencer, previous, upgrading)
+		return status.DriverUpgrade != nil, nil
+	}
+
+	upgrade := status.DriverUpgrade
+	if upgrade == nil || upgrade.Image != image {
+		log.Info("Starting workload-aware driver upgrade", "component", name, "image", image, "nodes", len(outdated))
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "DriverUpgradeStarted",
+			"Upgrading %s to %s on %d nodes, least loaded nodes first", name, image, len(outdated))
+		upgrade = &npuv1alpha1.DriverUpgradeStatus{Image: image, StartTime: metav1.Now()}
+		status.DriverUpgrade = upgrade
+	}
+
+	workloads, err := r.nodeWorkloads(ctx, outdated)
+	if err != nil {
+		return false, err
+	}
+	maxParallel := int32(1)
+	if spec.MaxParallel != nil {
+		maxParallel = *spec.MaxParallel
+	}
+	slots := int(maxParallel) - len(upgrading)
+	var waiting []string
+	remaining := len(workloads)
+	for _, w := range workloads {
+		if slots <= 0 {
+			break
+		}
+		if w.requested > 0 && spec.WaitForCompletion != nil && time.Since(upgrade.StartTime.Time) < spec.WaitForCompletion.Duration {
+			waiting = append(waiting, w.node)
+			continue
+		}
+		log.Info("Replacing outdated driver", "component", name, "node", w.node, "requested", w.requested)
+		silenceNode(ctx, r.Silencer, w.node, fmt.Sprintf("Upgrade of %s to %s", name, image))
+		if err := r.Delete(ctx, w.pod); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		upgrading = append(upgrading, w.node)
+		remaining--
+		slots--
+	}
+	upgrade.Upgrading = sortedNodes(upgrading)
+	upgrade.Waiting = waiting
+	upgrade.Remaining = int32(remaining)
+	unsilenceFinished(ctx, r.Silencer, previous, upgrading)
+	return true, nil
+}
+
+// -- nodeWorkloads returns the load of the nodes of the outdated driver pods, in upgrade order:
+// idle nodes first, then by the accelerators requested, and among equally loaded nodes those
+// whose pods have been running the longest last
+func (r *NPUClusterPolicyReconciler) nodeWorkloads(ctx context.Context, outdated []*corev1.Pod) ([]nodeWorkload, error) {
+	now := time.Now()
+	workloads := make([]nodeWorkload, 0, len(outdated))
+	for _, driver := range outdated {
+		w := nodeWorkload{node: driver.Spec.NodeName, pod: driver}
+		// The index is registered by the NPUNode controller; without a cache it is served as a
+		// field selector by the API server.
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.MatchingFields{podNodeNameIndex: w.node}); err != nil {
+			return nil, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			requested := acceleratorRequests(pod)
+			if requested == 0 || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			w.requested += requested
+			if pod.Status.StartTime != nil {
+				w.running = max(w.running, now.Sub(pod.Status.StartTime.Time))
+			}
+		}
+		workloads = append(workloads, w)
+	}
+	slices.SortStableFunc(workloads, func(a, b nodeWorkload) int {
+		return cmp.Or(cmp.Compare(a.requested, b.requested), cmp.Compare(a.running, b.running), cmp.Compare(a.node, b.node))
+	})
+	return workloads, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func sortedNodes(nodes []string) []string {
+	if len(nodes) == 0 {
+		return nil
+	}
+	slices.Sort(nodes)
+	return slices.Compact(nodes)
+}
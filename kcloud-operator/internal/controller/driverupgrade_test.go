@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Workload-aware driver upgrades", func() {
+	ctx := context.Background()
+
+	driverPod := func(node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "furiosa-driver-" + node,
+				Namespace: "kube-system",
+				Labels:    map[string]string{"app.kubernetes.io/name": "furiosa-driver"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   node,
+				Containers: []corev1.Container{{Name: "furiosa-driver", Image: "ghcr.io/furiosa-ai/driver:2025.1"}},
+			},
+		}
+	}
+	replaced := func(pod *corev1.Pod) bool {
+		current := &corev1.Pod{}
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), current)
+		return apierrors.IsNotFound(err) || current.DeletionTimestamp != nil
+	}
+
+	It("should replace the driver on idle nodes before busy ones", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "driver-upgrade", Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "driver-upgrade-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+						Driver: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true), Image: "ghcr.io/furiosa-ai/driver", Version: "2025.2",
+						},
+					},
+				},
+				DriverUpgrade: &npuv1alpha1.DriverUpgradeSpec{Strategy: npuv1alpha1.DriverUpgradeWorkloadAware},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(50),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		Expect(err).NotTo(HaveOccurred())
+		ds := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "furiosa-driver", Namespace: "kube-system"}, ds)).To(Succeed())
+		Expect(ds.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+
+		idle, busy := driverPod("driver-idle-node"), driverPod("driver-busy-node")
+		workload := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "driver-upgrade-training", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: busy.Spec.NodeName,
+				Containers: []corev1.Container{{
+					Name:  "train",
+					Image: "busybox",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{"furiosa.ai/rngd": resource.MustParse("1")},
+						Limits:   corev1.ResourceList{"furiosa.ai/rngd": resource.MustParse("1")},
+					},
+				}},
+			},
+		}
+		for _, pod := range []*corev1.Pod{busy, idle, workload} {
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		}
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		upgrading, err := controllerReconciler.upgradeDrivers(ctx, policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upgrading).To(BeTrue())
+		Expect(replaced(idle)).To(BeTrue())
+		Expect(replaced(busy)).To(BeFalse())
+		upgrade := componentStatus(policy, "furiosa-driver").DriverUpgrade
+		Expect(upgrade.Image).To(Equal("ghcr.io/furiosa-ai/driver:2025.2"))
+		Expect(upgrade.Upgrading).To(Equal([]string{"driver-idle-node"}))
+		Expect(upgrade.Remaining).To(Equal(int32(1)))
+
+		By("waiting for the replaced node before the next one")
+		_, err = controllerReconciler.upgradeDrivers(ctx, policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replaced(busy)).To(BeFalse())
+
+		for _, pod := range []*corev1.Pod{busy, idle, workload} {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0)))).To(Succeed())
+		}
+		deletePolicy(ctx, policy)
+	})
+})
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// -- dryRunConfigMapName returns the name of the ConfigMap publishing the rendered objects of a policy
+func dryRunConfigMapName(policy *npuv1alpha1.NPUClusterPolicy) string {
+	return policy.Name + "-dry-run"
+}
+
+// -- reconcileDryRun publishes the objects the policy would apply into its dry-run ConfigMap and
+// reports the status of the components already deployed. No component object is changed.
+func (r *NPUClusterPolicyReconciler) reconcileDryRun(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	tally *applyTally, before []metav1.Condition) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	statusBefore := policy.Status.DeepCopy()
+
+	catalog, err := r.componentCatalog(ctx, policy)
+	if err != nil {
+		log.Error(err, "failed to get component catalog", "catalog", policy.Spec.Catalog)
+		return ctrl.Result{}, err
+	}
+	unmanaged, err := r.unmanagedNodes(ctx)
+	if err != nil {
+		log.Error(err, "failed to list unmanaged nodes")
+		return ctrl.Result{}, err
+	}
+	data, failures, err := renderPolicy(policy, catalog, unmanaged)
+	if err != nil {
+		log.Error(err, "failed to render the objects of the policy")
+		return ctrl.Result{}, err
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: dryRunConfigMapName(policy), Namespace: policy.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = mergeLabels(configMap.Labels, policyLabels(policy))
+		configMap.Data = data
+		return r.setOwner(policy, configMap)
+	}); err != nil {
+		log.Error(err, "failed to publish the rendered objects")
+		return ctrl.Result{}, err
+	}
+
+	message := fmt.Sprintf("spec.dryRun is set, %d rendered objects are published in ConfigMap %s instead of being applied",
+		len(data), client.ObjectKeyFromObject(configMap))
+	if len(failures) > 0 {
+		message += "; " + strings.Join(failures, "; ")
+	}
+	if !conditions.IsTrue(policy, conditions.DryRun) {
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "DryRun",
+			"Publishing rendered objects in ConfigMap %s, components are left as they are", client.ObjectKeyFromObject(configMap))
+	}
+	conditions.MarkTrue(policy, conditions.DryRun, conditions.ReasonDryRun, message)
+
+	progress, requeue, err := r.observeDaemonSets(ctx, policy)
+	if err != nil {
+		log.Error(err, "failed to observe component DaemonSets")
+		return ctrl.Result{}, err
+	}
+	summarizeConditions(policy, progress)
+	summarizeReconcile(policy, tally, nil)
+
+	if !equality.Semantic.DeepEqual(statusBefore, &policy.Status) {
+		if err := r.Status().Update(ctx, policy); err != nil {
+			log.Error(err, "failed to update NPUClusterPolicy status")
+			return ctrl.Result{}, err
+		}
+	}
+	r.notifyTransitions(ctx, policy, before)
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// -- renderPolicy renders the DaemonSets and ConfigMaps of the policy as YAML keyed by kind and name.
+// Components that cannot be rendered are left out and described by the returned failures, and the
+// DaemonSets keep off the unmanaged nodes.
+func renderPolicy(policy *npuv1alpha1.NPUClusterPolicy,
+	catalog *npuv1alpha1.NPUComponentCatalog, unmanaged []string) (map[string]string, []string, error) {
+	data := map[string]string{}
+	var failures []string
+	add := func(kind string, obj client.Object) error {
+		raw, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		data[kind+"."+obj.GetName()+".yaml"] = string(raw)
+		return nil
+	}
+
+	if policy.Spec.Nvidia.Enabled && policy.Spec.Nvidia.DevicePluginConfig != nil {
+		configMap, err := nvidiaDevicePluginConfigMap(policy)
+		if err == nil {
+			err = patchObject(policy, patchKindConfigMap, configMap.Name, configMap)
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+		} else if err := add("ConfigMap", configMap); err != nil {
+			return nil, nil, err
+		}
+	}
+	if policy.Spec.Furiosa.Enabled {
+		configMap := furiosaConfigMap(policy)
+		if err := patchObject(policy, patchKindConfigMap, configMap.Name, configMap); err != nil {
+			failures = append(failures, err.Error())
+		} else if err := add("ConfigMap", configMap); err != nil {
+			return nil, nil, err
+		}
+	}
+	rendered := renderedConfig(policy)
+	for _, c := range enabledComponents(policy) {
+		image, err := c.image(catalog)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("cannot resolve the image of %s: %v", c.name, err))
+			continue
+		}
+		ds, err := renderComponent(policy, c, image, rendered)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		excludeNodes(&ds.Spec.Template.Spec, unmanaged)
+		ds.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "DaemonSet"}
+		if err := add("DaemonSet", ds); err != nil {
+			return nil, nil, err
+		}
+	}
+	return data, failures, nil
+}
+
+// -- endDryRun clears the DryRun condition and deletes the published objects once spec.dryRun is unset
+func (r *NPUClusterPolicyReconciler) endDryRun(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if conditions.Get(policy, conditions.DryRun) == nil {
+		return nil
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: dryRunConfigMapName(policy), Namespace: policy.Namespace}}
+	if err := r.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	conditions.Remove(policy, conditions.DryRun)
+	r.Recorder.Event(policy, corev1.EventTypeNormal, "DryRunEnded", "Dry run ended, rendered objects are applied")
+	return nil
+}
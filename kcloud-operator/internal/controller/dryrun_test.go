@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Dry run", func() {
+	ctx := context.Background()
+
+	It("should publish the rendered objects instead of applying them", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dry-run", Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				DryRun: true,
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "dry-run-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		recorder := record.NewFakeRecorder(50)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		reconcilePolicy := func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		}
+		pluginKey := types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "kube-system"}
+		publishedKey := types.NamespacedName{Name: "dry-run-dry-run", Namespace: "default"}
+
+		reconcilePolicy()
+		Expect(conditions.IsTrue(policy, conditions.DryRun)).To(BeTrue())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DryRun")))
+		published := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, publishedKey, published)).To(Succeed())
+		Expect(published.Data).To(HaveKey("ConfigMap.dry-run-furiosa-config.yaml"))
+		Expect(published.Data).To(HaveKeyWithValue("DaemonSet.furiosa-device-plugin.yaml",
+			ContainSubstring("ghcr.io/furiosa-ai/k8s-device-plugin:0.10.1")))
+		err := k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: "dry-run-furiosa-config", Namespace: "kube-system"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		By("ending the dry run")
+		policy.Spec.DryRun = false
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		reconcilePolicy()
+		Expect(conditions.Get(policy, conditions.DryRun)).To(BeNil())
+		Expect(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})).To(Succeed())
+		err = k8sClient.Get(ctx, publishedKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		deletePolicy(ctx, policy)
+	})
+})
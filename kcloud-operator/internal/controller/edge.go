@@ -0,0 +1,278 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	prePullName       = "npu-prepull"
+	edgePollInterval  = time.Minute
+	prePullDeadline   = int64(30 * 60)
+	prePullBackoffMax = int32(2)
+)
+
+// imagePullFailures are the waiting reasons of containers whose image cannot be pulled.
+var imagePullFailures = []string{"ErrImagePull", "ImagePullBackOff"}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// -- checkConnectivity sets the Disconnected condition from image pull failures of the managed pods
+func (r *NPUClusterPolicyReconciler) checkConnectivity(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if policy.Spec.Edge == nil {
+		conditions.Remove(policy, conditions.Disconnected)
+		policy.Status.Edge = nil
+		return nil
+	}
+	if policy.Status.Edge == nil {
+		policy.Status.Edge = &npuv1alpha1.EdgeStatus{}
+	}
+
+	names := append(r.managedDaemonSetNames(policy), prePullName)
+	var failing []string
+	for _, name := range names {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(componentNamespace(policy)),
+			client.MatchingLabels{"app.kubernetes.io/name": name}); err != nil {
+			return err
+		}
+		for _, pod := range pods.Items {
+			if failsImagePull(&pod) {
+				failing = append(failing, pod.Name)
+			}
+		}
+	}
+
+	if len(failing) == 0 {
+		// Refreshed once per poll so the status is not rewritten on every reconcile.
+		if last := policy.Status.Edge.LastConnectedTime; last == nil || time.Since(last.Time) >= edgePollInterval {
+			now := metav1.Now()
+			policy.Status.Edge.LastConnectedTime = &now
+		}
+		conditions.MarkFalse(policy, conditions.Disconnected, conditions.ReasonReconciled,
+			"Component images are pulled without errors")
+		return nil
+	}
+	slices.Sort(failing)
+	message := fmt.Sprintf("%d pods cannot pull their images, image changes are deferred: %s",
+		len(failing), strings.Join(failing, ", "))
+	if !conditions.IsTrue(policy, conditions.Disconnected) {
+		r.Recorder.Event(policy, corev1.EventTypeWarning, "RegistryUnreachable", message)
+	}
+	conditions.MarkTrue(policy, conditions.Disconnected, conditions.ReasonImagePullFailed, message)
+	return nil
+}
+
+func failsImagePull(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, s := range statuses {
+			if s.State.Waiting != nil && slices.Contains(imagePullFailures, s.State.Waiting.Reason) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// -- disconnected reports whether image changes must be deferred
+func disconnected(policy *npuv1alpha1.NPUClusterPolicy) bool {
+	return policy.Spec.Edge != nil && conditions.IsTrue(policy, conditions.Disconnected)
+}
+
+// -- edgePullPolicy returns the pull policy forced on the component containers, or "" outside edge mode
+func edgePullPolicy(policy *npuv1alpha1.NPUClusterPolicy) corev1.PullPolicy {
+	switch {
+	case policy.Spec.Edge == nil:
+		return ""
+	case policy.Spec.Edge.PullPolicy != "":
+		return policy.Spec.Edge.PullPolicy
+	default:
+		return corev1.PullIfNotPresent
+	}
+}
+
+// -- prePullImages runs a pre-pull Job per accelerator node for the current component images,
+// and removes the Jobs of previous images
+func (r *NPUClusterPolicyReconciler) prePullImages(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	edge := policy.Spec.Edge
+	desired := map[string]*batchv1.Job{}
+	if edge != nil && edge.PrePull {
+		vendors := []struct {
+			enabled    bool
+			nodeLabels map[string]string
+			components []component
+		}{
+			{policy.Spec.Nvidia.Enabled, vendorNodeLabels(policy, "nvidia"), nvidiaComponents(policy)},
+			{policy.Spec.Furiosa.Enabled, vendorNodeLabels(policy, "furiosa"), furiosaComponents(policy)},
+		}
+		for _, v := range vendors {
+			if !v.enabled {
+				continue
+			}
+			var images []string
+			for _, c := range v.components {
+				if image := componentImage(policy, c.name); c.enabled && image != "" && !slices.Contains(images, image) {
+					images = append(images, image)
+				}
+			}
+			if len(images) == 0 {
+				continue
+			}
+			nodes := &corev1.NodeList{}
+			if err := r.List(ctx, nodes, client.MatchingLabels(v.nodeLabels)); err != nil {
+				return 0, err
+			}
+			for _, node := range nodes.Items {
+				job := prePullJob(policy, node.Name, images)
+				desired[job.Name] = job
+			}
+		}
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(componentNamespace(policy)),
+		client.MatchingLabels(mergeLabels(policyLabels(policy), map[string]string{"app.kubernetes.io/name": prePullName}))); err != nil {
+		return 0, err
+	}
+	var prePulled int32
+	existing := map[string]bool{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		_, wanted := desired[job.Name]
+		failed := jobFinished(job, batchv1.JobFailed)
+		if wanted && !failed {
+			existing[job.Name] = true
+			if jobFinished(job, batchv1.JobComplete) {
+				prePulled++
+			}
+			continue
+		}
+		// Failed Jobs are recreated once the registry is reachable again.
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			return 0, err
+		}
+	}
+
+	if !disconnected(policy) {
+		for name, job := range desired {
+			if existing[name] {
+				continue
+			}
+			log.Info("Pre-pulling component images", "node", job.Spec.Template.Spec.NodeName)
+			if err := r.setOwner(policy, job); err != nil {
+				return 0, err
+			}
+			withCommonMetadata(policy, job)
+			if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+				return 0, err
+			}
+		}
+	}
+
+	if policy.Status.Edge != nil {
+		policy.Status.Edge.PrePullNodes = int32(len(desired))
+		policy.Status.Edge.PrePulledNodes = prePulled
+	}
+	if edge == nil {
+		return 0, nil
+	}
+	return edgePollInterval, nil
+}
+
+// -- componentImage returns the image last applied to a component
+func componentImage(policy *npuv1alpha1.NPUClusterPolicy, name string) string {
+	for _, c := range policy.Status.Components {
+		if c.Name == name {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+// -- prePullJob builds the Job pulling the images onto a node. Its name derives from the
+// policy, the node and the images, so new images get a new Job.
+func prePullJob(policy *npuv1alpha1.NPUClusterPolicy, node string, images []string) *batchv1.Job {
+	h := fnv.New64a()
+	for _, s := range append([]string{policy.Namespace, policy.Name, node}, images...) {
+		h.Write([]byte(s)) //nolint:errcheck
+		h.Write([]byte{0}) //nolint:errcheck
+	}
+	labels := map[string]string{"app.kubernetes.io/name": prePullName}
+
+	var containers []corev1.Container
+	for i, image := range images {
+		containers = append(containers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sh", "-c", "exit 0"},
+		})
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%x", prePullName, h.Sum64()),
+			Namespace: componentNamespace(policy),
+			Labels:    mergeLabels(labels, policyLabels(policy)),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptrTo(prePullBackoffMax),
+			ActiveDeadlineSeconds: ptrTo(prePullDeadline),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeName:         node,
+					RestartPolicy:    corev1.RestartPolicyNever,
+					Tolerations:      []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					ImagePullSecrets: imagePullSecrets(policy, vendorNames...),
+					Containers:       containers,
+				},
+			},
+		},
+	}
+}
+
+func jobFinished(job *batchv1.Job, t batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == t && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
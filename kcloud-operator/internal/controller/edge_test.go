@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Disconnected operation", func() {
+	const (
+		resourceName = "edge"
+		nodeName     = "edge-node"
+	)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+	podKey := types.NamespacedName{Name: "nvidia-device-plugin-edge", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.0"},
+					},
+				},
+				Edge: &npuv1alpha1.EdgeSpec{PullPolicy: corev1.PullNever, PrePull: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: nvidiaNodeLabels,
+		}})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace("kube-system"),
+			client.MatchingLabels{"app.kubernetes.io/name": prePullName})).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: podKey.Name, Namespace: podKey.Namespace,
+		}}))).To(Succeed())
+	})
+
+	It("should pre-pull images and defer image changes while the registry is unreachable", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsFalse(policy, conditions.Disconnected)).To(BeTrue())
+		Expect(policy.Status.Edge.PrePullNodes).To(Equal(int32(1)))
+		jobs := &batchv1.JobList{}
+		Expect(k8sClient.List(ctx, jobs, client.InNamespace("kube-system"),
+			client.MatchingLabels{"app.kubernetes.io/name": prePullName})).To(Succeed())
+		Expect(jobs.Items).To(HaveLen(1))
+		Expect(jobs.Items[0].Spec.Template.Spec.NodeName).To(Equal(nodeName))
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullNever))
+
+		By("reporting a plugin pod that cannot pull its image")
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": nvidiaDevicePluginName},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nvidia-device-plugin", Image: "busybox"}}},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "nvidia-device-plugin",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsTrue(policy, conditions.Disconnected)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.0"))
+	})
+})
+
+var _ = Describe("Pre-pull Jobs", func() {
+	It("should name Jobs after the node and the images", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"}}
+		job := prePullJob(policy, "node-a", []string{"plugin:v1"})
+		Expect(job.Name).To(Equal(prePullJob(policy, "node-a", []string{"plugin:v1"}).Name))
+		Expect(job.Name).NotTo(Equal(prePullJob(policy, "node-b", []string{"plugin:v1"}).Name))
+		Expect(job.Name).NotTo(Equal(prePullJob(policy, "node-a", []string{"plugin:v2"}).Name))
+		Expect(job.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+})
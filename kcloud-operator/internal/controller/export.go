@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/export"
+)
+
+// -- exportPolicy writes a bundle of the policy when requested by the export annotation.
+// A failed export is reported in the status and by an event; it is not retried.
+func (r *NPUClusterPolicyReconciler) exportPolicy(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if _, ok := policy.Annotations[npuv1alpha1.ExportAnnotation]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(policy.DeepCopy())
+	delete(policy.Annotations, npuv1alpha1.ExportAnnotation)
+	if err := r.Patch(ctx, policy, patch); err != nil {
+		return err
+	}
+
+	status := &npuv1alpha1.ExportStatus{Time: metav1.Now()}
+	location, err := r.writeBundle(ctx, policy, status.Time)
+	if err != nil {
+		status.Error = err.Error()
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "ExportFailed", "Failed to export the policy: %v", err)
+	} else {
+		status.Location = location
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "Exported", "Exported the policy to %s", location)
+	}
+	policy.Status.LastExport = status
+	return nil
+}
+
+// -- writeBundle snapshots the policy and the objects labeled with it and stores the bundle
+func (r *NPUClusterPolicyReconciler) writeBundle(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	now metav1.Time) (string, error) {
+	if r.Export == nil {
+		return "", errors.New("no export location is configured")
+	}
+	bundle := &export.Bundle{Time: now, Policy: policy.DeepCopy()}
+	for _, list := range []client.ObjectList{
+		&appsv1.DaemonSetList{}, &appsv1.DeploymentList{}, &batchv1.JobList{}, &corev1.ConfigMapList{},
+		&corev1.ServiceAccountList{}, &rbacv1.RoleBindingList{}, &rbacv1.ClusterRoleBindingList{},
+	} {
+		if err := r.List(ctx, list, client.MatchingLabels(policyLabels(policy))); err != nil {
+			return "", err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range items {
+			obj, err := r.toUnstructured(item)
+			if err != nil {
+				return "", err
+			}
+			bundle.Objects = append(bundle.Objects, *obj)
+		}
+	}
+	gvk, err := apiutil.GVKForObject(policy, r.Scheme)
+	if err != nil {
+		return "", err
+	}
+	bundle.Policy.SetGroupVersionKind(gvk)
+
+	data, err := export.Encode(bundle)
+	if err != nil {
+		return "", err
+	}
+	return r.Export.Write(ctx, bundle.Name(), data)
+}
+
+func (r *NPUClusterPolicyReconciler) toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	fieldOwner = "npu-operator"
+
+	// extraManifestsIndex indexes policies by the ConfigMaps named in spec.extraManifests.
+	extraManifestsIndex = "spec.extraManifests.name"
+)
+
+// -- applyExtraManifests applies the manifests referenced by the policy and prunes objects no longer listed
+func (r *NPUClusterPolicyReconciler) applyExtraManifests(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+
+	objs, err := r.loadExtraManifests(ctx, policy)
+	if err != nil {
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "ExtraManifestInvalid", "%v", err)
+		return err
+	}
+
+	toApply := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		obj.SetNamespace(policy.Namespace)
+		obj.SetLabels(mergeLabels(obj.GetLabels(), policyLabels(policy)))
+		if err := controllerutil.SetOwnerReference(policy, obj, r.Scheme); err != nil {
+			return err
+		}
+		toApply = append(toApply, obj)
+	}
+	var failed []error
+	for i, err := range r.applyConcurrently(ctx, policy, toApply) {
+		if err != nil && !conflictIgnored(err) {
+			obj := objs[i]
+			log.Error(err, "failed to apply extra manifest", "kind", obj.GetKind(), "name", obj.GetName())
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, "ExtraManifestFailed",
+				"Failed to apply %s %s: %v", obj.GetKind(), obj.GetName(), err)
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return utilerrors.NewAggregate(failed)
+	}
+
+	applied := make([]npuv1alpha1.ManifestObjectReference, 0, len(objs))
+	for _, obj := range objs {
+		applied = append(applied, npuv1alpha1.ManifestObjectReference{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+		})
+	}
+	sort.Slice(applied, func(i, j int) bool {
+		a, b := applied[i], applied[j]
+		if a.APIVersion+a.Kind != b.APIVersion+b.Kind {
+			return a.APIVersion+a.Kind < b.APIVersion+b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	keep := map[npuv1alpha1.ManifestObjectReference]bool{}
+	for _, ref := range applied {
+		keep[ref] = true
+	}
+	for _, ref := range policy.Status.ExtraManifests {
+		if keep[ref] {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		obj.SetNamespace(policy.Namespace)
+		obj.SetName(ref.Name)
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			log.Error(err, "failed to prune extra manifest", "kind", ref.Kind, "name", ref.Name)
+			return err
+		}
+		log.Info("Pruned extra manifest", "kind", ref.Kind, "name", ref.Name)
+	}
+
+	if len(applied) == 0 {
+		applied = nil
+	}
+	policy.Status.ExtraManifests = applied
+	return nil
+}
+
+// -- loadExtraManifests decodes the objects from the referenced ConfigMaps
+func (r *NPUClusterPolicyReconciler) loadExtraManifests(ctx context.Context,
+	policy *npuv1alpha1.NPUClusterPolicy) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, ref := range policy.Spec.ExtraManifests {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, fmt.Errorf("extra manifests configmap %s: %w", ref.Name, err)
+		}
+
+		keys := []string{ref.Key}
+		if ref.Key == "" {
+			keys = keys[:0]
+			for k := range cm.Data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		}
+		for _, k := range keys {
+			data, ok := cm.Data[k]
+			if !ok {
+				return nil, fmt.Errorf("extra manifests configmap %s has no key %q", ref.Name, k)
+			}
+			decoded, err := decodeManifests(data)
+			if err != nil {
+				return nil, fmt.Errorf("extra manifests configmap %s key %q: %w", ref.Name, k, err)
+			}
+			for _, obj := range decoded {
+				namespaced, err := r.IsObjectNamespaced(obj)
+				if err != nil {
+					return nil, fmt.Errorf("extra manifests configmap %s: %w", ref.Name, err)
+				}
+				if !namespaced {
+					return nil, fmt.Errorf("extra manifests configmap %s: %s %s is cluster-scoped",
+						ref.Name, obj.GetKind(), obj.GetName())
+				}
+				if ns := obj.GetNamespace(); ns != "" && ns != policy.Namespace {
+					return nil, fmt.Errorf("extra manifests configmap %s: %s %s must be in namespace %s",
+						ref.Name, obj.GetKind(), obj.GetName(), policy.Namespace)
+				}
+			}
+			objs = append(objs, decoded...)
+		}
+	}
+	return objs, nil
+}
+
+// -- decodeManifests splits a multi-document YAML or JSON string into objects
+func decodeManifests(data string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(data)), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("manifest is missing apiVersion, kind or metadata.name")
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// -- policiesForConfigMap maps a ConfigMap to the policies that reference it in spec.extraManifests or rendered it
+func (r *NPUClusterPolicyReconciler) policiesForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{extraManifestsIndex: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list policies for configmap", "configmap", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policies.Items)+1)
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+	// ConfigMaps rendered by a policy are labeled with it, so edits and deletions are corrected.
+	if key, ok := policyKeyFromLabels(obj); ok {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
+
+func extraManifestNames(obj client.Object) []string {
+	policy := obj.(*npuv1alpha1.NPUClusterPolicy)
+	names := make([]string, 0, len(policy.Spec.ExtraManifests))
+	for _, ref := range policy.Spec.ExtraManifests {
+		names = append(names, ref.Name)
+	}
+	return names
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const extraServiceManifest = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: npu-exporter-extra
+spec:
+  ports:
+  - name: metrics
+    port: 9400
+---
+`
+
+var _ = Describe("Extra manifests", func() {
+	const resourceName = "extra-manifests"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	cmKey := types.NamespacedName{Name: "extra-manifests", Namespace: "default"}
+	svcKey := types.NamespacedName{Name: "npu-exporter-extra", Namespace: "default"}
+
+	BeforeEach(func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cmKey.Name, Namespace: cmKey.Namespace},
+			Data:       map[string]string{"service.yaml": extraServiceManifest},
+		}
+		Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				ExtraManifests: []npuv1alpha1.ExtraManifestRef{{Name: cmKey.Name}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, cmKey, cm)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, cm)).To(Succeed())
+	})
+
+	It("should apply the manifests and prune them once dropped", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		svc := &corev1.Service{}
+		Expect(k8sClient.Get(ctx, svcKey, svc)).To(Succeed())
+		Expect(svc.OwnerReferences).To(HaveLen(1))
+		Expect(svc.OwnerReferences[0].Name).To(Equal(resourceName))
+		Expect(svc.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyNameLabel, resourceName))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.ExtraManifests).To(ConsistOf(npuv1alpha1.ManifestObjectReference{
+			APIVersion: "v1", Kind: "Service", Name: svcKey.Name,
+		}))
+
+		By("dropping the reference from the policy")
+		policy.Spec.ExtraManifests = nil
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = k8sClient.Get(ctx, svcKey, svc)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should reject cluster-scoped objects", func() {
+		objs, err := decodeManifests("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: extra\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, cmKey, cm)).To(Succeed())
+		cm.Data = map[string]string{"namespace.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: extra\n"}
+		Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).To(MatchError(ContainSubstring("cluster-scoped")))
+	})
+})
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// FailureDomainLabels names the node labels the rack and power zone of a node are read from.
+type FailureDomainLabels struct {
+	Rack      string
+	PowerZone string
+}
+
+// DefaultFailureDomainLabels reads the failure domains from the npu.ai labels.
+var DefaultFailureDomainLabels = FailureDomainLabels{
+	Rack:      npuv1alpha1.RackLabel,
+	PowerZone: npuv1alpha1.PowerZoneLabel,
+}
+
+// -- of returns the rack and power zone of a node, empty when the node is not labeled
+func (l FailureDomainLabels) of(node *corev1.Node) (rack, powerZone string) {
+	if l.Rack != "" {
+		rack = node.Labels[l.Rack]
+	}
+	if l.PowerZone != "" {
+		powerZone = node.Labels[l.PowerZone]
+	}
+	return rack, powerZone
+}
+
+// -- rackInMaintenance returns another rack whose nodes have a reboot pending, so nodes of
+// different racks are never rebooted at the same time. Nodes without a rack do not hold
+// back others and are only held back by racks in maintenance.
+func (r *NPUNodeReconciler) rackInMaintenance(ctx context.Context, npuNode *npuv1alpha1.NPUNode) (string, error) {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := r.List(ctx, npuNodes); err != nil {
+		return "", err
+	}
+	for i := range npuNodes.Items {
+		other := &npuNodes.Items[i]
+		if other.Name == npuNode.Name || other.Status.Rack == "" || other.Status.Rack == npuNode.Status.Rack {
+			continue
+		}
+		if state := other.Status.Remediation; state != nil && state.Step == npuv1alpha1.RemediationReboot {
+			return other.Status.Rack, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	hookPreUpgrade  = "pre-upgrade"
+	hookPostUpgrade = "post-upgrade"
+
+	hookRunning   = "Running"
+	hookSucceeded = "Succeeded"
+	hookFailed    = "Failed"
+
+	defaultHookTimeout = 10 * time.Minute
+	hookPollInterval   = 15 * time.Second
+
+	hookComponentLabel = "npu.ai/upgrade-hook-component"
+)
+
+// -- preUpgradeHookPassed runs the pre-upgrade hook of an image change of the component and
+// reports whether the update may proceed
+func (r *NPUClusterPolicyReconciler) preUpgradeHookPassed(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	c component, image string) (bool, error) {
+	current := componentImage(policy, c.name)
+	if c.spec.Hooks == nil || current == "" || current == image {
+		return true, nil
+	}
+	passed, err := r.runHook(ctx, policy, c.name, hookPreUpgrade, c.spec.Hooks.PreUpgrade, current, image)
+	if err != nil || passed {
+		return passed, err
+	}
+	if hook := componentStatus(policy, c.name).UpgradeHook; hook.Result == hookFailed {
+		conditions.MarkFalse(policy, conditions.ComponentReady(c.name), conditions.ReasonHookFailed,
+			fmt.Sprintf("Pre-upgrade hook Job %s/%s failed, not updating to %s", componentNamespace(policy), hook.Job, image))
+	}
+	return false, nil
+}
+
+// -- runHook runs an upgrade hook of a component and reports whether the upgrade may proceed.
+// A finished hook is not run again for the same image; a failed hook with the Ignore policy
+// lets the upgrade proceed.
+func (r *NPUClusterPolicyReconciler) runHook(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	component, phase string, hook *npuv1alpha1.UpgradeHook, from, to string) (proceed bool, err error) {
+	if hook == nil {
+		return true, nil
+	}
+	status := componentStatus(policy, component)
+
+	desired := hookJob(policy, component, phase, hook, from, to)
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if err := r.deleteHookJobs(ctx, policy, component, phase); err != nil {
+			return false, err
+		}
+		logf.FromContext(ctx).Info("Running upgrade hook", "component", component, "phase", phase, "job", desired.Name)
+		if err := r.setOwner(policy, desired); err != nil {
+			return false, err
+		}
+		withCommonMetadata(policy, desired)
+		if err := r.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "UpgradeHookStarted",
+			"Running %s hook %s of %s for %s", phase, desired.Name, component, to)
+		job = desired
+	}
+
+	result := hookRunning
+	switch {
+	case jobFinished(job, batchv1.JobComplete):
+		result = hookSucceeded
+	case jobFinished(job, batchv1.JobFailed):
+		result = hookFailed
+	}
+	current := &npuv1alpha1.UpgradeHookStatus{Phase: phase, Job: job.Name, Image: to, Result: result}
+	reported := status.UpgradeHook != nil && *status.UpgradeHook == *current
+	status.UpgradeHook = current
+	ignored := result == hookFailed && hook.FailurePolicy == npuv1alpha1.HookFailureIgnore
+	proceed = result == hookSucceeded || ignored
+	if reported {
+		return proceed, nil
+	}
+
+	switch {
+	case result == hookSucceeded:
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "UpgradeHookSucceeded",
+			"The %s hook of %s for %s succeeded", phase, component, to)
+	case ignored:
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "UpgradeHookFailed",
+			"The %s hook of %s for %s failed, continuing as its failure policy is Ignore", phase, component, to)
+	case result == hookFailed:
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "UpgradeHookFailed",
+			"The %s hook of %s for %s failed, see Job %s/%s", phase, component, to, job.Namespace, job.Name)
+	}
+	return proceed, nil
+}
+
+// -- hookJob builds the Job of an upgrade hook. Its name derives from the target image, so
+// each upgrade runs the hook once.
+func hookJob(policy *npuv1alpha1.NPUClusterPolicy, component, phase string, hook *npuv1alpha1.UpgradeHook,
+	from, to string) *batchv1.Job {
+	h := fnv.New32a()
+	h.Write([]byte(to)) //nolint:errcheck
+
+	timeout := defaultHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+	// Components are named after their vendor, e.g. nvidia-device-plugin.
+	vendor, _, _ := strings.Cut(component, "-")
+	labels := map[string]string{
+		"app.kubernetes.io/name":      "upgrade-hook",
+		"app.kubernetes.io/component": phase,
+		hookComponentLabel:            component,
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%08x", component, phase, h.Sum32()),
+			Namespace: componentNamespace(policy),
+			Labels:    mergeLabels(labels, policyLabels(policy)),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptrTo(int32(0)),
+			ActiveDeadlineSeconds: ptrTo(int64(timeout.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: imagePullSecrets(policy, vendor),
+					Containers: []corev1.Container{{
+						Name:    "hook",
+						Image:   hook.Image,
+						Command: hook.Command,
+						Args:    hook.Args,
+						Env: []corev1.EnvVar{
+							{Name: "COMPONENT", Value: component},
+							{Name: "FROM_IMAGE", Value: from},
+							{Name: "TO_IMAGE", Value: to},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// -- deleteHookJobs removes the hook Jobs of a component, of one phase or of all with an empty phase
+func (r *NPUClusterPolicyReconciler) deleteHookJobs(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, component, phase string) error {
+	selector := client.MatchingLabels{"app.kubernetes.io/name": "upgrade-hook", hookComponentLabel: component}
+	if phase != "" {
+		selector["app.kubernetes.io/component"] = phase
+	}
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(componentNamespace(policy)), selector); err != nil {
+		return err
+	}
+	for i := range jobs.Items {
+		if err := r.Delete(ctx, &jobs.Items[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// -- componentHooks returns the upgrade hooks configured for a component
+func componentHooks(policy *npuv1alpha1.NPUClusterPolicy, name string) *npuv1alpha1.UpgradeHooks {
+	for _, c := range enabledComponents(policy) {
+		if c.name == name {
+			return c.spec.Hooks
+		}
+	}
+	return nil
+}
+
+func hooksRunning(policy *npuv1alpha1.NPUClusterPolicy) bool {
+	for _, c := range policy.Status.Components {
+		if c.UpgradeHook != nil && c.UpgradeHook.Result == hookRunning {
+			return true
+		}
+	}
+	return false
+}
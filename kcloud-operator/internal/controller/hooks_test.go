@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Upgrade hooks", func() {
+	const resourceName = "upgrade-hooks"
+	const component = "nvidia-gpu-feature-discovery"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	gfdKey := types.NamespacedName{Name: component, Namespace: "kube-system"}
+
+	reconcileOnce := func(r *NPUClusterPolicyReconciler) *npuv1alpha1.NPUClusterPolicy {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		return policy
+	}
+
+	finishJob := func(name string, condition batchv1.JobConditionType) {
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "kube-system"}, job)).To(Succeed())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		if condition == batchv1.JobComplete {
+			job.Status.CompletionTime = &now
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue, LastTransitionTime: now})
+		} else {
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, LastTransitionTime: now})
+		}
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type: condition, Status: corev1.ConditionTrue, LastTransitionTime: now})
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	markRolledOut := func() {
+		gfd := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, gfdKey, gfd)).To(Succeed())
+		gfd.Status.ObservedGeneration = gfd.Generation
+		Expect(k8sClient.Status().Update(ctx, gfd)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+						GFD: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/k8s-device-plugin",
+							Version: "v0.17.1",
+							Hooks: &npuv1alpha1.UpgradeHooks{
+								PreUpgrade:  &npuv1alpha1.UpgradeHook{Image: "busybox", Command: []string{"true"}},
+								PostUpgrade: &npuv1alpha1.UpgradeHook{Image: "busybox", Command: []string{"true"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace("kube-system"),
+			client.MatchingLabels{"app.kubernetes.io/name": "upgrade-hook"},
+			client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name: gfdKey.Name, Namespace: gfdKey.Namespace}})).To(Succeed())
+	})
+
+	upgrade := func(policy *npuv1alpha1.NPUClusterPolicy) {
+		policy.Spec.Nvidia.GFD.Version = "v0.17.2"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+	}
+
+	It("should not run hooks on the first deployment", func() {
+		r := &NPUClusterPolicyReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(20)}
+		reconcileOnce(r)
+		markRolledOut()
+		policy := reconcileOnce(r)
+
+		status := componentStatus(policy, component)
+		Expect(status.UpgradeHook).To(BeNil())
+		Expect(status.LastKnownGoodImage).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+	})
+
+	It("should run the pre-upgrade hook before updating and the post-upgrade hook afterwards", func() {
+		r := &NPUClusterPolicyReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(20)}
+		reconcileOnce(r)
+		markRolledOut()
+		upgrade(reconcileOnce(r))
+
+		By("holding the update while the pre-upgrade hook runs")
+		policy := reconcileOnce(r)
+		hook := componentStatus(policy, component).UpgradeHook
+		Expect(hook).NotTo(BeNil())
+		Expect(hook.Phase).To(Equal(hookPreUpgrade))
+		Expect(hook.Result).To(Equal(hookRunning))
+		Expect(componentImage(policy, component)).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hook.Job, Namespace: "kube-system"}, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "TO_IMAGE", Value: "nvcr.io/nvidia/k8s-device-plugin:v0.17.2"}))
+
+		By("updating once the pre-upgrade hook succeeded")
+		finishJob(hook.Job, batchv1.JobComplete)
+		policy = reconcileOnce(r)
+		Expect(componentImage(policy, component)).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.2"))
+
+		By("running the post-upgrade hook once the DaemonSet is ready")
+		markRolledOut()
+		policy = reconcileOnce(r)
+		status := componentStatus(policy, component)
+		Expect(status.UpgradeHook.Phase).To(Equal(hookPostUpgrade))
+		Expect(status.LastRollout.CompletionTime).To(BeNil())
+
+		finishJob(status.UpgradeHook.Job, batchv1.JobComplete)
+		policy = reconcileOnce(r)
+		status = componentStatus(policy, component)
+		Expect(status.LastRollout.CompletionTime).NotTo(BeNil())
+		Expect(status.LastKnownGoodImage).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.2"))
+	})
+
+	It("should abort the upgrade when the pre-upgrade hook fails", func() {
+		r := &NPUClusterPolicyReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(20)}
+		reconcileOnce(r)
+		markRolledOut()
+		upgrade(reconcileOnce(r))
+
+		policy := reconcileOnce(r)
+		finishJob(componentStatus(policy, component).UpgradeHook.Job, batchv1.JobFailed)
+		policy = reconcileOnce(r)
+
+		Expect(componentImage(policy, component)).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+		cond := conditions.Get(policy, conditions.ComponentReady(component))
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(conditions.ReasonHookFailed))
+	})
+})
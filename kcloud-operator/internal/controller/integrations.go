@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/capabilities"
+	"npu-operator/pkg/conditions"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+
+// -- capabilities returns a snapshot of the cluster capabilities, or all of them when detection is off
+func (r *NPUClusterPolicyReconciler) capabilities() *capabilities.Capabilities {
+	r.capabilitiesMu.RLock()
+	defer r.capabilitiesMu.RUnlock()
+	if r.Capabilities == nil {
+		return capabilities.All()
+	}
+	caps := *r.Capabilities
+	return &caps
+}
+
+// integrationCRD passes the CRDs serving a capability
+var integrationCRD = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return capabilities.IsIntegrationCRD(obj.GetName())
+})
+
+// -- onIntegrationCRD follows the installation and removal of a CRD serving a capability, and
+// reconciles every policy when the capability changed, so integrations follow without a restart
+func (r *NPUClusterPolicyReconciler) onIntegrationCRD(ctx context.Context, obj client.Object, deleted bool,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		return
+	}
+	r.capabilitiesMu.Lock()
+	changed := r.Capabilities != nil && r.Capabilities.ObserveCRD(crd, deleted)
+	r.capabilitiesMu.Unlock()
+	if !changed {
+		return
+	}
+	log := logf.FromContext(ctx)
+	log.Info("Cluster capabilities changed", "crd", crd.Name, "capabilities", r.capabilities().Available())
+
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		log.Error(err, "failed to list policies for CRD", "crd", crd.Name)
+		return
+	}
+	for _, p := range policies.Items {
+		q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+}
+
+func (r *NPUClusterPolicyReconciler) onIntegrationCRDCreated(ctx context.Context, e event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	r.onIntegrationCRD(ctx, e.Object, false, q)
+}
+
+func (r *NPUClusterPolicyReconciler) onIntegrationCRDUpdated(ctx context.Context, e event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	r.onIntegrationCRD(ctx, e.ObjectNew, false, q)
+}
+
+func (r *NPUClusterPolicyReconciler) onIntegrationCRDDeleted(ctx context.Context, e event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	r.onIntegrationCRD(ctx, e.Object, true, q)
+}
+
+// -- checkIntegrations reports the integrations the policy requests that the cluster cannot serve.
+// They are skipped by their reconcile steps instead of failing them.
+func (r *NPUClusterPolicyReconciler) checkIntegrations(policy *npuv1alpha1.NPUClusterPolicy) {
+	caps := r.capabilities()
+	var missing []string
+	if tls := policy.Spec.MetricsTLS; metricsTLSEnabled(policy) {
+		if tls.IssuerRef != nil && !caps.CertManager {
+			missing = append(missing, "metricsTLS.issuerRef (cert-manager is not installed, using a self-signed certificate)")
+		}
+		if tls.ServiceMonitor && !caps.ServiceMonitor {
+			missing = append(missing, "metricsTLS.serviceMonitor (Prometheus Operator is not installed)")
+		}
+	}
+	for vendor, spec := range map[string]*npuv1alpha1.ClusterAPISpec{
+		"nvidia": policy.Spec.Nvidia.ClusterAPI, "furiosa": policy.Spec.Furiosa.ClusterAPI} {
+		if spec != nil && spec.Enabled && !caps.ClusterAPI {
+			missing = append(missing, vendor+".clusterAPI (Cluster API is not installed)")
+		}
+	}
+
+	if len(missing) == 0 {
+		conditions.MarkTrue(policy, conditions.IntegrationsAvailable, conditions.ReasonReconciled,
+			"All requested integrations are available")
+		return
+	}
+	sort.Strings(missing)
+	conditions.MarkFalse(policy, conditions.IntegrationsAvailable, conditions.ReasonAPIUnavailable,
+		"Disabled integrations: "+strings.Join(missing, ", "))
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/capabilities"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Integrations depending on optional APIs", func() {
+	ctx := context.Background()
+	var policy *npuv1alpha1.NPUClusterPolicy
+
+	BeforeEach(func() {
+		policy = &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "integrations"}}
+		policy.Spec.MetricsTLS = &npuv1alpha1.MetricsTLSSpec{
+			Enabled:        true,
+			IssuerRef:      &npuv1alpha1.CertificateIssuerRef{Name: "ca"},
+			ServiceMonitor: true,
+		}
+		policy.Spec.Nvidia.ClusterAPI = &npuv1alpha1.ClusterAPISpec{Enabled: true}
+	})
+
+	It("should assume every integration is available without detection", func() {
+		(&NPUClusterPolicyReconciler{}).checkIntegrations(policy)
+		Expect(conditions.IsTrue(policy, conditions.IntegrationsAvailable)).To(BeTrue())
+	})
+
+	It("should report the requested integrations the cluster cannot serve", func() {
+		r := &NPUClusterPolicyReconciler{Capabilities: &capabilities.Capabilities{ServiceMonitor: true}}
+		r.checkIntegrations(policy)
+		c := conditions.Get(policy, conditions.IntegrationsAvailable)
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal(conditions.ReasonAPIUnavailable))
+		Expect(c.Message).To(ContainSubstring("metricsTLS.issuerRef"))
+		Expect(c.Message).To(ContainSubstring("nvidia.clusterAPI"))
+		Expect(c.Message).NotTo(ContainSubstring("serviceMonitor"))
+	})
+	It("should enable an integration once its CRD is installed", func() {
+		r := &NPUClusterPolicyReconciler{Client: k8sClient, Capabilities: &capabilities.Capabilities{}}
+		r.checkIntegrations(policy)
+		Expect(conditions.Get(policy, conditions.IntegrationsAvailable).Message).To(ContainSubstring("serviceMonitor"))
+
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+		monitors := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "servicemonitors.monitoring.coreos.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			}},
+		}
+		r.onIntegrationCRDCreated(ctx, event.CreateEvent{Object: monitors}, q)
+
+		r.checkIntegrations(policy)
+		Expect(conditions.Get(policy, conditions.IntegrationsAvailable).Message).NotTo(ContainSubstring("serviceMonitor"))
+	})
+})
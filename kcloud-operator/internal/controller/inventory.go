@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// maxInventoryVersions bounds the version combinations listed in the status of a policy.
+const maxInventoryVersions = 50
+
+// maxInventoryUnmanaged bounds the unmanaged nodes listed in the status of a policy.
+const maxInventoryUnmanaged = 50
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npunodes,verbs=get;list;watch
+
+// -- summarizeInventory sets the fleet inventory of the policy from the NPUNodes and the pods
+// of its components
+func (r *NPUClusterPolicyReconciler) summarizeInventory(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := r.List(ctx, npuNodes); err != nil {
+		return err
+	}
+	pods := map[string][]corev1.Pod{}
+	for _, c := range enabledComponents(policy) {
+		list := &corev1.PodList{}
+		if err := r.List(ctx, list, client.InNamespace(componentNamespace(policy)),
+			client.MatchingLabels{"app.kubernetes.io/name": c.name}); err != nil {
+			return err
+		}
+		pods[c.name] = list.Items
+	}
+	policy.Status.Inventory = fleetInventory(policy, npuNodes.Items, pods)
+	return nil
+}
+
+// -- fleetInventory counts the nodes of the enabled vendors by versions, and the nodes of each
+// component running its target image. pods are the pods of each component by name.
+func fleetInventory(policy *npuv1alpha1.NPUClusterPolicy, npuNodes []npuv1alpha1.NPUNode,
+	pods map[string][]corev1.Pod) *npuv1alpha1.FleetInventory {
+	vendors := map[string]bool{"nvidia": policy.Spec.Nvidia.Enabled, "furiosa": policy.Spec.Furiosa.Enabled}
+	if !vendors["nvidia"] && !vendors["furiosa"] {
+		return nil
+	}
+	pluginVersions := map[string]map[string]string{}
+	for vendor, plugin := range map[string]string{"nvidia": nvidiaDevicePluginName, "furiosa": furiosaDevicePluginName} {
+		pluginVersions[vendor] = map[string]string{}
+		for _, pod := range pods[plugin] {
+			if pod.Spec.NodeName != "" && pod.DeletionTimestamp == nil && len(pod.Spec.Containers) > 0 {
+				pluginVersions[vendor][pod.Spec.NodeName] = imageTag(pod.Spec.Containers[0].Image)
+			}
+		}
+	}
+
+	inventory := &npuv1alpha1.FleetInventory{}
+	counts := map[npuv1alpha1.VersionCount]int32{}
+	for _, npuNode := range npuNodes {
+		counted := false
+		for _, v := range npuNode.Status.Vendors {
+			if !vendors[v.Vendor] {
+				continue
+			}
+			counted = true
+			counts[npuv1alpha1.VersionCount{
+				Vendor:          v.Vendor,
+				DriverVersion:   v.DriverVersion,
+				FirmwareVersion: v.FirmwareVersion,
+				PluginVersion:   pluginVersions[v.Vendor][npuNode.Name],
+			}]++
+		}
+		if counted {
+			inventory.Nodes++
+			if npuNode.Status.Unmanaged {
+				inventory.Unmanaged = append(inventory.Unmanaged, npuNode.Name)
+			}
+		}
+	}
+	slices.Sort(inventory.Unmanaged)
+	if len(inventory.Unmanaged) > maxInventoryUnmanaged {
+		inventory.Unmanaged = inventory.Unmanaged[:maxInventoryUnmanaged]
+	}
+	for version, n := range counts {
+		version.Nodes = n
+		inventory.Versions = append(inventory.Versions, version)
+	}
+	slices.SortFunc(inventory.Versions, func(a, b npuv1alpha1.VersionCount) int {
+		return cmp.Or(cmp.Compare(b.Nodes, a.Nodes), cmp.Compare(a.Vendor, b.Vendor),
+			cmp.Compare(a.DriverVersion, b.DriverVersion), cmp.Compare(a.FirmwareVersion, b.FirmwareVersion),
+			cmp.Compare(a.PluginVersion, b.PluginVersion))
+	})
+	if len(inventory.Versions) > maxInventoryVersions {
+		inventory.Versions = inventory.Versions[:maxInventoryVersions]
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pods)) {
+		target := componentImage(policy, name)
+		if target == "" {
+			continue
+		}
+		// A node is on target once any of its pods runs the target image, as the pod of the
+		// previous image may still be terminating.
+		onTarget := map[string]bool{}
+		for _, pod := range pods[name] {
+			if pod.Spec.NodeName == "" || len(pod.Spec.Containers) == 0 {
+				continue
+			}
+			onTarget[pod.Spec.NodeName] = onTarget[pod.Spec.NodeName] || pod.Spec.Containers[0].Image == target
+		}
+		drift := npuv1alpha1.ComponentDrift{Component: name, Target: target, Nodes: int32(len(onTarget))}
+		for _, ok := range onTarget {
+			if ok {
+				drift.OnTarget++
+			}
+		}
+		drift.DriftPercent = "0.0"
+		if drift.Nodes > 0 {
+			drift.DriftPercent = fmt.Sprintf("%.1f", float64(drift.Nodes-drift.OnTarget)*100/float64(drift.Nodes))
+		}
+		inventory.Drift = append(inventory.Drift, drift)
+	}
+	return inventory
+}
+
+// -- imageTag returns the tag of an image reference, or its digest without one
+func imageTag(image string) string {
+	image, digest, _ := strings.Cut(image, "@")
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, ok := strings.Cut(name, ":"); ok {
+		return tag
+	}
+	return digest
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Fleet inventory", func() {
+	pod := func(node, image string) corev1.Pod {
+		return corev1.Pod{Spec: corev1.PodSpec{NodeName: node, Containers: []corev1.Container{{Image: image}}}}
+	}
+	npuNode := func(name string, vendors ...npuv1alpha1.VendorSummary) npuv1alpha1.NPUNode {
+		return npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     npuv1alpha1.NPUNodeStatus{Vendors: vendors},
+		}
+	}
+
+	It("should count the nodes of each version combination and their drift from the target", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Enabled = true
+		policy.Status.Components = []npuv1alpha1.ComponentStatus{
+			{Name: nvidiaDevicePluginName, Image: "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"},
+		}
+		old := npuv1alpha1.VendorSummary{Vendor: "nvidia", DriverVersion: "550.54", FirmwareVersion: "96.00"}
+		upgraded := npuv1alpha1.VendorSummary{Vendor: "nvidia", DriverVersion: "570.86", FirmwareVersion: "96.00"}
+		nodes := []npuv1alpha1.NPUNode{
+			npuNode("a", old), npuNode("b", old), npuNode("c", upgraded),
+			npuNode("d", npuv1alpha1.VendorSummary{Vendor: "furiosa"}),
+		}
+		pods := map[string][]corev1.Pod{nvidiaDevicePluginName: {
+			pod("a", "nvcr.io/nvidia/k8s-device-plugin:v0.16.2"),
+			pod("b", "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"),
+			pod("c", "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"),
+			pod("", "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"),
+		}}
+
+		inventory := fleetInventory(policy, nodes, pods)
+		Expect(inventory.Nodes).To(Equal(int32(3)))
+		Expect(inventory.Versions).To(Equal([]npuv1alpha1.VersionCount{
+			{Vendor: "nvidia", DriverVersion: "550.54", FirmwareVersion: "96.00", PluginVersion: "v0.16.2", Nodes: 1},
+			{Vendor: "nvidia", DriverVersion: "550.54", FirmwareVersion: "96.00", PluginVersion: "v0.17.0", Nodes: 1},
+			{Vendor: "nvidia", DriverVersion: "570.86", FirmwareVersion: "96.00", PluginVersion: "v0.17.0", Nodes: 1},
+		}))
+		Expect(inventory.Drift).To(Equal([]npuv1alpha1.ComponentDrift{{
+			Component:    nvidiaDevicePluginName,
+			Target:       "nvcr.io/nvidia/k8s-device-plugin:v0.17.0",
+			Nodes:        3,
+			OnTarget:     2,
+			DriftPercent: "33.3",
+		}}))
+
+		policy.Spec.Nvidia.Enabled = false
+		Expect(fleetInventory(policy, nodes, pods)).To(BeNil())
+	})
+
+	It("should read the tag of image references", func() {
+		Expect(imageTag("registry:5000/furiosa/device-plugin:2025.1")).To(Equal("2025.1"))
+		Expect(imageTag("registry:5000/furiosa/device-plugin")).To(BeEmpty())
+		Expect(imageTag("device-plugin@sha256:abc")).To(Equal("sha256:abc"))
+		Expect(imageTag("device-plugin:v1@sha256:abc")).To(Equal("v1"))
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// metricsBreakerThreshold is the number of consecutive failed scrapes opening the circuit of an exporter.
+	metricsBreakerThreshold = 3
+	metricsBreakerCooldown  = time.Minute
+	metricsBreakerMaxDelay  = 10 * time.Minute
+)
+
+// metricsBreakers are circuit breakers around the scrapes of the exporters, keyed by component
+// and node. An exporter failing repeatedly is not scraped until its cooldown passed, so a down
+// metrics backend does not slow down every reconcile with timeouts. The cooldown doubles on
+// each failure after the circuit opened.
+type metricsBreakers struct {
+	mu        sync.Mutex
+	endpoints map[string]*metricsBreaker
+}
+
+type metricsBreaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+// -- allow reports whether the exporter may be scraped, which it may once the cooldown passed
+func (b *metricsBreakers) allow(key string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e, ok := b.endpoints[key]
+	return !ok || !now.Before(e.openUntil)
+}
+
+// -- record closes the circuit after a successful scrape and opens it after too many failures
+func (b *metricsBreakers) record(key string, err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.endpoints, key)
+		return
+	}
+	if b.endpoints == nil {
+		b.endpoints = map[string]*metricsBreaker{}
+	}
+	e, ok := b.endpoints[key]
+	if !ok {
+		e = &metricsBreaker{}
+		b.endpoints[key] = e
+	}
+	e.failures++
+	if e.failures < metricsBreakerThreshold {
+		return
+	}
+	delay := metricsBreakerCooldown << min(e.failures-metricsBreakerThreshold, 10)
+	e.openUntil = now.Add(min(delay, metricsBreakerMaxDelay))
+}
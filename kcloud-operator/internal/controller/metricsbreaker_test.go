@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics circuit breakers", func() {
+	It("should open after repeated failures and close after a successful scrape", func() {
+		var breakers metricsBreakers
+		now := time.Now()
+		failure := errors.New("connection refused")
+
+		for range metricsBreakerThreshold - 1 {
+			breakers.record("exporter/node", failure, now)
+			Expect(breakers.allow("exporter/node", now)).To(BeTrue())
+		}
+		breakers.record("exporter/node", failure, now)
+		Expect(breakers.allow("exporter/node", now)).To(BeFalse())
+		Expect(breakers.allow("exporter/other", now)).To(BeTrue())
+
+		By("doubling the cooldown when the scrape after it fails again")
+		now = now.Add(metricsBreakerCooldown)
+		Expect(breakers.allow("exporter/node", now)).To(BeTrue())
+		breakers.record("exporter/node", failure, now)
+		Expect(breakers.allow("exporter/node", now.Add(metricsBreakerCooldown))).To(BeFalse())
+		Expect(breakers.allow("exporter/node", now.Add(2*metricsBreakerCooldown))).To(BeTrue())
+
+		By("closing once a scrape succeeds")
+		breakers.record("exporter/node", nil, now)
+		Expect(breakers.allow("exporter/node", now)).To(BeTrue())
+	})
+
+	It("should cap the cooldown", func() {
+		var breakers metricsBreakers
+		now := time.Now()
+		for range 50 {
+			breakers.record("exporter/node", errors.New("timeout"), now)
+		}
+		Expect(breakers.allow("exporter/node", now.Add(metricsBreakerMaxDelay))).To(BeTrue())
+	})
+})
@@ -0,0 +1,408 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	// metricsTLSSecretName holds the serving certificate of the exporters, and its CA in ca.crt.
+	metricsTLSSecretName = "npu-exporter-metrics-tls"
+	// metricsWebConfigName holds the exporter-toolkit web configuration enabling TLS.
+	metricsWebConfigName = "npu-exporter-web-config"
+
+	metricsTLSDir       = "/etc/npu-operator/metrics-tls"
+	metricsWebConfigDir = "/etc/npu-operator/web-config"
+
+	// Self-signed certificates are valid for 90 days and rotated 30 days before they expire.
+	metricsCertValidity    = 90 * 24 * time.Hour
+	metricsCertRenewBefore = 30 * 24 * time.Hour
+
+	selfSignedIssuer = "SelfSigned"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch
+
+func metricsTLSEnabled(policy *npuv1alpha1.NPUClusterPolicy) bool {
+	return policy.Spec.MetricsTLS != nil && policy.Spec.MetricsTLS.Enabled
+}
+
+// -- metricsDNSNames lists the names the serving certificate is valid for, the Services of every
+// exporter of both vendors, so enabling a vendor does not reissue the certificate
+func metricsDNSNames(policy *npuv1alpha1.NPUClusterPolicy) []string {
+	var names []string
+	for _, c := range append(nvidiaComponents(policy), furiosaComponents(policy)...) {
+		if c.metricsPort != 0 {
+			names = append(names, c.name, exporterServerName(policy, c.name), exporterServerName(policy, c.name)+".cluster.local")
+		}
+	}
+	return names
+}
+
+func exporterServerName(policy *npuv1alpha1.NPUClusterPolicy, name string) string {
+	return name + "." + componentNamespace(policy) + ".svc"
+}
+
+// -- ensureMetricsTLS maintains the serving certificate of the exporters and the objects scraping
+// them, and returns when the self-signed certificate is due for rotation
+func (r *NPUClusterPolicyReconciler) ensureMetricsTLS(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (time.Duration, error) {
+	if !metricsTLSEnabled(policy) {
+		policy.Status.MetricsCertificate = nil
+		return 0, nil
+	}
+	spec := policy.Spec.MetricsTLS
+
+	if err := r.apply(ctx, policy, metricsWebConfig(policy)); err != nil && !conflictIgnored(err) {
+		return 0, err
+	}
+
+	var wait time.Duration
+	if spec.IssuerRef != nil && r.capabilities().CertManager {
+		if err := r.applyMetricsCertificate(ctx, policy, spec.IssuerRef); err != nil {
+			return 0, err
+		}
+	} else {
+		var err error
+		if wait, err = r.rotateMetricsCertificate(ctx, policy); err != nil {
+			return 0, err
+		}
+	}
+
+	if spec.ServiceMonitor && r.capabilities().ServiceMonitor {
+		if err := r.ensureServiceMonitors(ctx, policy); err != nil {
+			return 0, err
+		}
+	}
+	return wait, nil
+}
+
+// -- metricsWebConfig builds the exporter-toolkit web configuration serving the certificate
+func metricsWebConfig(policy *npuv1alpha1.NPUClusterPolicy) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metricsWebConfigName,
+			Namespace: componentNamespace(policy),
+			Labels:    policyLabels(policy),
+		},
+		Data: map[string]string{
+			"web-config.yml": fmt.Sprintf("tls_server_config:\n  cert_file: %[1]s/tls.crt\n  key_file: %[1]s/tls.key\n  min_version: TLS12\n",
+				metricsTLSDir),
+		},
+	}
+}
+
+// -- applyMetricsCertificate requests the serving certificate from cert-manager
+func (r *NPUClusterPolicyReconciler) applyMetricsCertificate(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	issuer *npuv1alpha1.CertificateIssuerRef) error {
+	kind, group := issuer.Kind, issuer.Group
+	if kind == "" {
+		kind = "Issuer"
+	}
+	if group == "" {
+		group = "cert-manager.io"
+	}
+	dnsNames := make([]interface{}, 0)
+	for _, name := range metricsDNSNames(policy) {
+		dnsNames = append(dnsNames, name)
+	}
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      metricsTLSSecretName,
+			"namespace": componentNamespace(policy),
+		},
+		"spec": map[string]interface{}{
+			"secretName":  metricsTLSSecretName,
+			"dnsNames":    dnsNames,
+			"duration":    metricsCertValidity.String(),
+			"renewBefore": metricsCertRenewBefore.String(),
+			"privateKey":  map[string]interface{}{"algorithm": "ECDSA", "size": int64(256), "rotationPolicy": "Always"},
+			"issuerRef":   map[string]interface{}{"name": issuer.Name, "kind": kind, "group": group},
+		},
+	}}
+	cert.SetLabels(policyLabels(policy))
+	if err := r.apply(ctx, policy, cert); err != nil && !conflictIgnored(err) {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("metricsTLS.issuerRef requires cert-manager, which is not installed")
+		}
+		return err
+	}
+
+	status := &npuv1alpha1.MetricsCertificateStatus{Issuer: fmt.Sprintf("%s/%s", kind, issuer.Name)}
+	if notAfter, found, _ := unstructured.NestedString(cert.Object, "status", "notAfter"); found {
+		if t, err := time.Parse(time.RFC3339, notAfter); err == nil {
+			status.NotAfter = &metav1.Time{Time: t}
+		}
+	}
+	policy.Status.MetricsCertificate = status
+	return nil
+}
+
+// -- rotateMetricsCertificate issues a self-signed serving certificate when it is missing, does not
+// cover the exporters or is due for renewal, and returns when it is due next
+func (r *NPUClusterPolicyReconciler) rotateMetricsCertificate(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	dnsNames := metricsDNSNames(policy)
+	now := time.Now()
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: metricsTLSSecretName, Namespace: componentNamespace(policy)}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+	status := policy.Status.MetricsCertificate
+	if err == nil {
+		if cert, err := parseCertificate(secret.Data[corev1.TLSCertKey]); err == nil &&
+			slices.Equal(cert.DNSNames, dnsNames) && cert.NotAfter.Sub(now) > metricsCertRenewBefore {
+			if status == nil || status.Issuer != selfSignedIssuer {
+				status = &npuv1alpha1.MetricsCertificateStatus{Issuer: selfSignedIssuer}
+			}
+			status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+			policy.Status.MetricsCertificate = status
+			return cert.NotAfter.Sub(now) - metricsCertRenewBefore, nil
+		}
+	}
+
+	ca, crt, key, notAfter, err := issueSelfSignedCertificate(dnsNames, now)
+	if err != nil {
+		return 0, err
+	}
+	desired := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metricsTLSSecretName,
+			Namespace: componentNamespace(policy),
+			Labels:    policyLabels(policy),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{"ca.crt": ca, corev1.TLSCertKey: crt, corev1.TLSPrivateKeyKey: key},
+	}
+	if err := r.apply(ctx, policy, desired); err != nil && !conflictIgnored(err) {
+		return 0, err
+	}
+	log.Info("Issued exporter metrics certificate", "notAfter", notAfter)
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "MetricsCertificateIssued",
+		"Issued the exporter metrics certificate, valid until %s", notAfter.UTC().Format(time.RFC3339))
+	policy.Status.MetricsCertificate = &npuv1alpha1.MetricsCertificateStatus{
+		Issuer:       selfSignedIssuer,
+		NotAfter:     &metav1.Time{Time: notAfter},
+		RotationTime: &metav1.Time{Time: now},
+	}
+	return notAfter.Sub(now) - metricsCertRenewBefore, nil
+}
+
+// -- issueSelfSignedCertificate creates a CA and a serving certificate signed by it, PEM encoded
+func issueSelfSignedCertificate(dnsNames []string, now time.Time) (ca, crt, key []byte, notAfter time.Time, err error) {
+	notBefore := now.Add(-5 * time.Minute)
+	notAfter = now.Add(metricsCertValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: "npu-operator metrics CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+
+	servingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	servingTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	servingDER, err := x509.CreateCertificate(rand.Reader, servingTemplate, caTemplate, &servingKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(servingKey)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: servingDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		notAfter, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// -- ensureServiceMonitors creates a Service and a ServiceMonitor scraping each enabled exporter over HTTPS
+func (r *NPUClusterPolicyReconciler) ensureServiceMonitors(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+	var objs []client.Object
+	for _, c := range enabledComponents(policy) {
+		if c.metricsPort == 0 {
+			continue
+		}
+		selector := map[string]string{"app.kubernetes.io/name": c.name}
+		service := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.name,
+				Namespace: componentNamespace(policy),
+				Labels:    mergeLabels(selector, policyLabels(policy)),
+			},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: corev1.ClusterIPNone,
+				Selector:  selector,
+				Ports:     []corev1.ServicePort{{Name: "metrics", Port: c.metricsPort}},
+			},
+		}
+
+		monitor := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      c.name,
+				"namespace": componentNamespace(policy),
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app.kubernetes.io/name": c.name}},
+				"endpoints": []interface{}{map[string]interface{}{
+					"port":   "metrics",
+					"scheme": "https",
+					"tlsConfig": map[string]interface{}{
+						"ca":         map[string]interface{}{"secret": map[string]interface{}{"name": metricsTLSSecretName, "key": "ca.crt"}},
+						"serverName": exporterServerName(policy, c.name),
+					},
+				}},
+			},
+		}}
+		monitor.SetLabels(policyLabels(policy))
+		objs = append(objs, service, monitor)
+	}
+
+	var failed []error
+	skipped := false
+	for _, err := range r.applyConcurrently(ctx, policy, objs) {
+		switch {
+		case err == nil || conflictIgnored(err):
+		case meta.IsNoMatchError(err):
+			skipped = true
+		default:
+			failed = append(failed, err)
+		}
+	}
+	if skipped {
+		log.Info("Prometheus Operator is not installed, skipping ServiceMonitors")
+	}
+	return utilerrors.NewAggregate(failed)
+}
+
+// -- withMetricsTLS makes an exporter DaemonSet serve its metrics with the operator-managed certificate
+func withMetricsTLS(ds *appsv1.DaemonSet) *appsv1.DaemonSet {
+	pod := &ds.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes,
+		corev1.Volume{Name: "metrics-tls", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: metricsTLSSecretName},
+		}},
+		corev1.Volume{Name: "web-config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: metricsWebConfigName}},
+		}},
+	)
+	container := &pod.Containers[0]
+	container.Args = append(container.Args, "--web-config-file="+metricsWebConfigDir+"/web-config.yml")
+	container.VolumeMounts = append(container.VolumeMounts,
+		corev1.VolumeMount{Name: "metrics-tls", MountPath: metricsTLSDir, ReadOnly: true},
+		corev1.VolumeMount{Name: "web-config", MountPath: metricsWebConfigDir, ReadOnly: true},
+	)
+	return ds
+}
+
+// -- exporterScraper returns the scraper and URL scheme of an exporter, trusting the CA of the
+// serving certificate when metrics are served over TLS
+func (r *NPUClusterPolicyReconciler) exporterScraper(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	c component) (MetricsScraper, string, error) {
+	if !metricsTLSEnabled(policy) {
+		if r.Metrics != nil {
+			return r.Metrics, "http", nil
+		}
+		return &HTTPMetricsScraper{Client: &http.Client{Timeout: 5 * time.Second}}, "http", nil
+	}
+	if r.Metrics != nil {
+		return r.Metrics, "https", nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: metricsTLSSecretName, Namespace: componentNamespace(policy)}, secret); err != nil {
+		return nil, "", err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data["ca.crt"]) {
+		return nil, "", fmt.Errorf("secret %s has no CA certificate in ca.crt", metricsTLSSecretName)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		ServerName: exporterServerName(policy, c.name),
+		MinVersion: tls.VersionTLS12,
+	}
+	return &HTTPMetricsScraper{Client: &http.Client{Timeout: 5 * time.Second, Transport: transport}}, "https", nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Exporter metrics TLS", func() {
+	const resourceName = "metrics-tls"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	secretKey := types.NamespacedName{Name: metricsTLSSecretName, Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+						Exporter: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/k8s/dcgm-exporter",
+							Version: "4.1.1",
+						},
+					},
+				},
+				MetricsTLS: &npuv1alpha1.MetricsTLSSpec{Enabled: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should serve the exporter metrics with a self-signed certificate that is kept until renewal", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, secretKey, secret)).To(Succeed())
+		cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.DNSNames).To(ContainElement("nvidia-dcgm-exporter.kube-system.svc"))
+
+		exporter := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "nvidia-dcgm-exporter", Namespace: "kube-system"}, exporter)).To(Succeed())
+		container := exporter.Spec.Template.Spec.Containers[0]
+		Expect(container.Args).To(ContainElement("--web-config-file=" + metricsWebConfigDir + "/web-config.yml"))
+		Expect(container.VolumeMounts).To(ContainElement(HaveField("MountPath", metricsTLSDir)))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.MetricsCertificate).NotTo(BeNil())
+		Expect(policy.Status.MetricsCertificate.Issuer).To(Equal(selfSignedIssuer))
+
+		By("keeping the certificate while it is valid")
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		again := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, secretKey, again)).To(Succeed())
+		Expect(again.Data).To(Equal(secret.Data))
+	})
+})
+
+var _ = Describe("Self-signed metrics certificates", func() {
+	It("should be signed by the CA and valid for the exporter names", func() {
+		names := []string{"nvidia-dcgm-exporter", "nvidia-dcgm-exporter.kube-system.svc"}
+		ca, crt, _, notAfter, err := issueSelfSignedCertificate(names, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notAfter).To(BeTemporally("~", time.Now().Add(metricsCertValidity), time.Minute))
+
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(ca)).To(BeTrue())
+		cert, err := parseCertificate(crt)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: names[1], Roots: roots})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
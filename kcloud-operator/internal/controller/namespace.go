@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;create
+
+// -- ensureNamespace creates the component namespace when the policy asks for it. The namespace
+// is labeled but not owned by the policy and is left in place when the policy is deleted, as it
+// may hold objects the operator does not manage.
+func (r *NPUClusterPolicyReconciler) ensureNamespace(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if !policy.Spec.CreateNamespace {
+		return nil
+	}
+	name := componentNamespace(policy)
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &corev1.Namespace{}); !apierrors.IsNotFound(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Creating component namespace", "namespace", name)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: policyLabels(policy)}}
+	if err := r.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "NamespaceCreated", "Created namespace %s for the components", name)
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Component namespace", func() {
+	ctx := context.Background()
+
+	It("should create the namespace and deploy the components there", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "own-namespace", Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Namespace:       "npu-system",
+				CreateNamespace: true,
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "own-namespace-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		recorder := record.NewFakeRecorder(50)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		Expect(err).NotTo(HaveOccurred())
+
+		ns := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "npu-system"}, ns)).To(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyNameLabel, "own-namespace"))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("NamespaceCreated")))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "npu-system"},
+			&appsv1.DaemonSet{})).To(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "own-namespace-furiosa-config", Namespace: "npu-system"},
+			&corev1.ConfigMap{})).To(Succeed())
+
+		By("changing the namespace")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		policy.Spec.Namespace = "other-system"
+		Expect(k8sClient.Update(ctx, policy)).To(MatchError(ContainSubstring("namespace is immutable")))
+
+		deletePolicy(ctx, policy)
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// nodeTombstone is the record of a garbage-collected NPUNode kept in the state store for audit.
+type nodeTombstone struct {
+	Model              string                          `json:"model,omitempty"`
+	DriverVersion      string                          `json:"driverVersion,omitempty"`
+	Capacity           corev1.ResourceList             `json:"capacity,omitempty"`
+	RemediationHistory []npuv1alpha1.RemediationRecord `json:"remediationHistory,omitempty"`
+	CreationTime       metav1.Time                     `json:"creationTime"`
+	NodeRemovedTime    metav1.Time                     `json:"nodeRemovedTime"`
+	DeletedTime        metav1.Time                     `json:"deletedTime"`
+}
+
+func tombstoneKey(name string) string {
+	return "tombstone." + name
+}
+
+// -- collectStaleNPUNode marks the NPUNode of a removed node and deletes it once the
+// retention period expired, leaving a tombstone. It returns when to check again.
+func (r *NPUNodeReconciler) collectStaleNPUNode(ctx context.Context, name string) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	npuNode := &npuv1alpha1.NPUNode{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, npuNode); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if npuNode.Status.NodeRemovedTime == nil {
+		log.Info("Node was removed, retaining its NPUNode", "retention", r.Retention)
+		now := metav1.Now()
+		npuNode.Status.NodeRemovedTime = &now
+		if err := r.Status().Update(ctx, npuNode); err != nil {
+			return 0, err
+		}
+	}
+	if wait := r.Retention - time.Since(npuNode.Status.NodeRemovedTime.Time); wait > 0 {
+		return wait, nil
+	}
+
+	if r.State != nil {
+		tombstone := nodeTombstone{
+			Model:              npuNode.Status.Model,
+			DriverVersion:      npuNode.Status.DriverVersion,
+			Capacity:           npuNode.Status.Capacity,
+			RemediationHistory: npuNode.Status.RemediationHistory,
+			CreationTime:       npuNode.CreationTimestamp,
+			NodeRemovedTime:    *npuNode.Status.NodeRemovedTime,
+			DeletedTime:        metav1.Now(),
+		}
+		if err := r.State.Save(ctx, tombstoneKey(name), tombstone); err != nil {
+			return 0, err
+		}
+		if err := r.State.Delete(ctx, nodeStateKey(name)); err != nil {
+			return 0, err
+		}
+	}
+	log.Info("Retention of removed node expired, deleting its NPUNode")
+	if err := r.Delete(ctx, npuNode); err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+	return 0, nil
+}
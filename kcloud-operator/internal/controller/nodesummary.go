@@ -0,0 +1,292 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// acceleratorProductLabels maps an accelerator resource prefix to the node label naming the device model.
+var acceleratorProductLabels = map[string]string{
+	"nvidia.com/": "nvidia.com/gpu.product",
+	"furiosa.ai/": "furiosa.ai/npu.product",
+}
+
+// acceleratorDriverLabels maps an accelerator resource prefix to the node label with the driver version.
+var acceleratorDriverLabels = map[string]string{
+	"nvidia.com/": "nvidia.com/cuda.driver-version.full",
+	"furiosa.ai/": "furiosa.ai/driver.version",
+}
+
+// modelPrefixes are stripped from product names to get the short model, e.g. NVIDIA-A100-SXM4-80GB is A100.
+var modelPrefixes = []string{"NVIDIA-", "Tesla-", "Furiosa-"}
+
+// -- summarizeNode fills the model, count, driver version, per-vendor summary, failure domains and
+// allocated count of the NPUNode
+func (r *NPUNodeReconciler) summarizeNode(ctx context.Context, node *corev1.Node, npuNode *npuv1alpha1.NPUNode) error {
+	status := &npuNode.Status
+	status.Rack, status.PowerZone = r.FailureDomains.of(node)
+	status.Vendors = summarizeVendors(node, status)
+	status.Model, status.DriverVersion, status.Count = "", "", 0
+	for _, v := range status.Vendors {
+		status.Count += v.Count
+		if status.Model == "" {
+			status.Model = v.Model
+		}
+		if status.DriverVersion == "" {
+			status.DriverVersion = v.DriverVersion
+		}
+	}
+
+	pods, err := r.podsOnNode(ctx, node)
+	if err != nil {
+		return err
+	}
+	status.Allocated = 0
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		status.Allocated += acceleratorRequests(&pod)
+	}
+	return nil
+}
+
+// -- summarizeVendors summarizes the accelerators of each vendor labeled on the node, advertised
+// by its capacity or found by the node agent
+func summarizeVendors(node *corev1.Node, status *npuv1alpha1.NPUNodeStatus) []npuv1alpha1.VendorSummary {
+	summaries := map[string]*npuv1alpha1.VendorSummary{}
+	summary := func(vendor string) *npuv1alpha1.VendorSummary {
+		if summaries[vendor] == nil {
+			summaries[vendor] = &npuv1alpha1.VendorSummary{Vendor: vendor}
+		}
+		return summaries[vendor]
+	}
+	for _, vendor := range nodeVendors(node) {
+		summary(vendor)
+	}
+	for _, name := range slices.Sorted(maps.Keys(status.Capacity)) {
+		q := status.Capacity[name]
+		prefix := resourcePrefix(string(name))
+		v := summary(acceleratorVendors[prefix])
+		v.Count += q.Value()
+		if v.Model == "" {
+			v.Model = node.Labels[acceleratorProductLabels[prefix]]
+		}
+		if v.DriverVersion == "" {
+			v.DriverVersion = node.Labels[acceleratorDriverLabels[prefix]]
+		}
+	}
+	for _, d := range status.Devices {
+		if d.Vendor == "" {
+			continue
+		}
+		// Without feature discovery labels, fall back to the model found by the node agent.
+		if v := summary(d.Vendor); v.Model == "" {
+			v.Model = d.Model
+		}
+	}
+
+	var vendors []npuv1alpha1.VendorSummary
+	for _, vendor := range slices.Sorted(maps.Keys(summaries)) {
+		summaries[vendor].FirmwareVersion = node.Labels[npuv1alpha1.FirmwareVersionLabelPrefix+vendor]
+		vendors = append(vendors, *summaries[vendor])
+	}
+	return vendors
+}
+
+// -- nodeHealth summarizes the accelerators of the NPUNode once remediation ran
+func nodeHealth(npuNode *npuv1alpha1.NPUNode) npuv1alpha1.NodeHealth {
+	switch {
+	case len(npuNode.Status.Capacity) == 0:
+		return ""
+	case npuNode.Status.Remediation != nil && npuNode.Status.Remediation.Step != npuv1alpha1.RemediationExhausted:
+		return npuv1alpha1.NodeRemediating
+	}
+	for name, capacity := range npuNode.Status.Capacity {
+		if allocatable := npuNode.Status.Allocatable[name]; allocatable.Cmp(capacity) < 0 {
+			return npuv1alpha1.NodeDegraded
+		}
+	}
+	return npuv1alpha1.NodeHealthy
+}
+
+// -- setNodeConditions sets the Ready, Degraded and Remediating conditions of the NPUNode
+// from its capacity and remediation, so consumers need not match on status.health
+func setNodeConditions(npuNode *npuv1alpha1.NPUNode) {
+	if len(npuNode.Status.Capacity) == 0 {
+		conditions.MarkUnknown(npuNode, conditions.Ready, conditions.ReasonNoAccelerators, "no accelerator capacity reported")
+		conditions.Remove(npuNode, conditions.Degraded)
+		conditions.Remove(npuNode, conditions.Remediating)
+		return
+	}
+
+	var unallocatable []string
+	for _, name := range slices.Sorted(maps.Keys(npuNode.Status.Capacity)) {
+		capacity := npuNode.Status.Capacity[name]
+		if allocatable := npuNode.Status.Allocatable[name]; allocatable.Cmp(capacity) < 0 {
+			unallocatable = append(unallocatable, fmt.Sprintf("%s: %s of %s allocatable", name, allocatable.String(), capacity.String()))
+		}
+	}
+	if len(unallocatable) > 0 {
+		conditions.MarkTrue(npuNode, conditions.Degraded, conditions.ReasonUnallocatable, strings.Join(unallocatable, ", "))
+	} else {
+		conditions.MarkFalse(npuNode, conditions.Degraded, conditions.ReasonAllocatable, "")
+	}
+
+	switch r := npuNode.Status.Remediation; {
+	case r == nil:
+		conditions.MarkFalse(npuNode, conditions.Remediating, conditions.ReasonReconciled, "")
+	case r.Step == npuv1alpha1.RemediationExhausted:
+		conditions.MarkFalse(npuNode, conditions.Remediating, string(r.Step), fmt.Sprintf("remediation of %s exhausted", r.Resource))
+	default:
+		conditions.MarkTrue(npuNode, conditions.Remediating, string(r.Step), fmt.Sprintf("remediating %s", r.Resource))
+	}
+
+	switch npuNode.Status.Health {
+	case npuv1alpha1.NodeHealthy:
+		conditions.MarkTrue(npuNode, conditions.Ready, conditions.ReasonAllocatable, "every accelerator is allocatable")
+	case npuv1alpha1.NodeRemediating:
+		conditions.MarkFalse(npuNode, conditions.Ready, conditions.ReasonRemediating, "the operator is remediating the node")
+	default:
+		conditions.MarkFalse(npuNode, conditions.Ready, conditions.ReasonUnallocatable, "some accelerators are not allocatable")
+	}
+}
+
+// npuNodeLabelKeys are the labels of NPUNodes kept current by the operator.
+var npuNodeLabelKeys = func() []string {
+	keys := []string{npuv1alpha1.VendorLabel, npuv1alpha1.ModelLabel, npuv1alpha1.RackLabel, npuv1alpha1.PowerZoneLabel}
+	for _, vendor := range vendorNames {
+		keys = append(keys, npuv1alpha1.VendorLabelPrefix+vendor)
+	}
+	return keys
+}()
+
+// -- npuNodeLabels returns the vendor, model and failure domain labels of the NPUNode
+func npuNodeLabels(npuNode *npuv1alpha1.NPUNode) map[string]string {
+	labels := map[string]string{}
+	for _, v := range npuNode.Status.Vendors {
+		labels[npuv1alpha1.VendorLabel] = v.Vendor
+		labels[npuv1alpha1.VendorLabelPrefix+v.Vendor] = "true"
+	}
+	if len(npuNode.Status.Vendors) > 1 {
+		labels[npuv1alpha1.VendorLabel] = npuv1alpha1.MultiVendor
+	}
+	if model := shortModel(npuNode.Status.Model); model != "" {
+		labels[npuv1alpha1.ModelLabel] = model
+	}
+	if npuNode.Status.Rack != "" {
+		labels[npuv1alpha1.RackLabel] = npuNode.Status.Rack
+	}
+	if npuNode.Status.PowerZone != "" {
+		labels[npuv1alpha1.PowerZoneLabel] = npuNode.Status.PowerZone
+	}
+	return labels
+}
+
+// -- labelNPUNode keeps the labels of the NPUNode current
+func (r *NPUNodeReconciler) labelNPUNode(ctx context.Context, npuNode *npuv1alpha1.NPUNode) error {
+	want := npuNodeLabels(npuNode)
+	current := true
+	for _, key := range npuNodeLabelKeys {
+		current = current && npuNode.Labels[key] == want[key]
+	}
+	if current {
+		return nil
+	}
+	patch := client.MergeFrom(npuNode.DeepCopy())
+	if npuNode.Labels == nil {
+		npuNode.Labels = map[string]string{}
+	}
+	for _, key := range npuNodeLabelKeys {
+		if value, ok := want[key]; ok {
+			npuNode.Labels[key] = value
+		} else {
+			delete(npuNode.Labels, key)
+		}
+	}
+	return r.Patch(ctx, npuNode, patch)
+}
+
+func shortModel(product string) string {
+	for _, prefix := range modelPrefixes {
+		product = strings.TrimPrefix(product, prefix)
+	}
+	model, _, _ := strings.Cut(product, "-")
+	return model
+}
+
+// -- npuNodeVendors returns the vendors the NPUNode is labeled with
+func npuNodeVendors(npuNode *npuv1alpha1.NPUNode) []string {
+	var vendors []string
+	for _, vendor := range vendorNames {
+		if npuNode.Labels[npuv1alpha1.VendorLabelPrefix+vendor] == "true" || npuNode.Labels[npuv1alpha1.VendorLabel] == vendor {
+			vendors = append(vendors, vendor)
+		}
+	}
+	return vendors
+}
+
+func resourcePrefix(resource string) string {
+	for prefix := range acceleratorVendors {
+		if strings.HasPrefix(resource, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func (r *NPUNodeReconciler) podsOnNode(ctx context.Context, node *corev1.Node) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingFields{podNodeNameIndex: node.Name}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// -- acceleratorRequests sums the accelerator requests of the containers of a pod
+func acceleratorRequests(pod *corev1.Pod) int64 {
+	var total int64
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			if acceleratorVendor(string(name)) != "" {
+				total += q.Value()
+			}
+		}
+	}
+	return total
+}
+
+// -- nodeForPod maps a pod requesting accelerators to the node it runs on
+func nodeForPod(_ context.Context, obj client.Object) []reconcile.Request {
+	pod := obj.(*corev1.Pod)
+	if pod.Spec.NodeName == "" || acceleratorRequests(pod) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: pod.Spec.NodeName}}}
+}
@@ -18,29 +18,93 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/yaml"
 
 	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/audit"
+	"npu-operator/internal/capabilities"
+	"npu-operator/internal/cloudevents"
+	"npu-operator/internal/export"
+	"npu-operator/internal/silence"
+	"npu-operator/internal/statestore"
+	"npu-operator/pkg/conditions"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	nvidiaDevicePluginName  = "nvidia-device-plugin"
+	furiosaDevicePluginName = "furiosa-device-plugin"
+)
+
+var (
+	nvidiaNodeLabels  = map[string]string{"nvidia.com/gpu.present": "true"}
+	furiosaNodeLabels = map[string]string{"furiosa": "true"}
+)
+
 // NPUClusterPolicyReconciler reconciles a NPUClusterPolicy object
 type NPUClusterPolicyReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Events receives lifecycle transitions as CloudEvents. Nil disables delivery.
+	Events cloudevents.Emitter
+
+	// Silencer suppresses the alerts of nodes whose driver is upgraded. Nil leaves alerts alone.
+	Silencer silence.Silencer
+
+	// Metrics scrapes the exporters for thermal responses. Nil scrapes over HTTP.
+	Metrics MetricsScraper
+
+	// State persists rollout bookkeeping across operator restarts. Nil keeps it in the status only.
+	State *statestore.Store
+
+	// Capabilities of the cluster, which switch off integrations whose APIs are missing. They
+	// follow the installation of CRDs afterwards. Nil assumes every integration is available.
+	Capabilities   *capabilities.Capabilities
+	capabilitiesMu sync.RWMutex
+
+	// Export stores the bundles requested with the npu.ai/export annotation. Nil rejects requests.
+	Export export.Sink
+
+	// Workers is the number of policies reconciled concurrently. Zero uses the manager default.
+	Workers int
+
+	// RateLimiter configures the retries of failed policies.
+	RateLimiter RateLimiterConfig
+
+	deletions        deletionTracker
+	breakers         metricsBreakers
+	admissionRetries admissionRetries
 }
 
 // +kubebuilder:rbac:groups=npu.ai,resources=npuclusterpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=npu.ai,resources=npuclusterpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=npu.ai,resources=npuclusterpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=npu.ai,resources=npucomponentcatalogs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -51,60 +115,390 @@ type NPUClusterPolicyReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
-func (r *NPUClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NPUClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 	logger.Info("Reconciling NPUClusterPolicy", "name", req.NamespacedName)
 
 	//-- Get CR
 	var policy npuv1alpha1.NPUClusterPolicy
 	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.deletions.clear(req.NamespacedName)
+			if r.State != nil {
+				return ctrl.Result{}, r.State.Delete(ctx, policyStateKey(req.NamespacedName))
+			}
+		}
 		logger.Error(err, "unable to fetch NPUClusterPolicy")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx = audit.WithCause(ctx, "NPUClusterPolicy", &policy)
+	ctx, tally := withApplyTally(ctx)
+	defer func() {
+		switch {
+		case err == nil:
+			r.admissionRetries.forget(req.NamespacedName)
+		case !policy.DeletionTimestamp.IsZero():
+		case transientAdmissionFailure(err):
+			if retry, ok := r.retryAdmission(ctx, req.NamespacedName, err); ok {
+				result, err = retry, nil
+				return
+			}
+			r.recordFailedReconcile(ctx, &policy, tally, err)
+		default:
+			r.recordFailedReconcile(ctx, &policy, tally, err)
+		}
+	}()
+
+	//-- Teardown of the components once the policy is deleted
+	if !policy.DeletionTimestamp.IsZero() {
+		if err := r.teardown(ctx, &policy); err != nil {
+			logger.Error(err, "failed to delete components")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if controllerutil.AddFinalizer(&policy, npuv1alpha1.PolicyFinalizer) {
+		if err := r.Update(ctx, &policy); err != nil {
+			logger.Error(err, "failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+	before := append([]metav1.Condition(nil), policy.Status.Conditions...)
+
+	//-- Conflicts with older policies managing the same DaemonSets
+	winner, shared, err := r.conflictingPolicy(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to check for conflicting policies")
+		return ctrl.Result{}, err
+	}
+	if winner != nil {
+		logger.Info("Policy conflicts with an older policy, skipping reconcile", "olderPolicy", client.ObjectKeyFromObject(winner))
+		conflictBefore := policy.Status.DeepCopy()
+		r.markConflicted(&policy, winner, shared)
+		if !equality.Semantic.DeepEqual(conflictBefore, &policy.Status) {
+			if err := r.Status().Update(ctx, &policy); err != nil {
+				logger.Error(err, "failed to update NPUClusterPolicy status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	//-- Paused policies only report their status
+	if policy.Spec.Paused {
+		logger.Info("Policy is paused, only reporting status")
+		return r.reconcilePaused(ctx, &policy, tally, before)
+	}
+
+	//-- Dry runs only publish the rendered objects
+	if policy.Spec.DryRun {
+		logger.Info("Policy is a dry run, publishing rendered objects")
+		return r.reconcileDryRun(ctx, &policy, tally, before)
+	}
+
+	//-- Self-healing of components deleted out-of-band
+	blocked, wait, err := r.healDeletedComponents(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to process deleted components")
+		return ctrl.Result{}, err
+	}
+	if blocked {
+		logger.Info("Component recreation requires approval, skipping reconcile")
+		return ctrl.Result{}, nil
+	}
+	if wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	//-- Release components frozen by safe mode once acknowledged
+	statusBefore := policy.Status.DeepCopy()
+	conditions.Remove(&policy, conditions.Conflicted)
+	r.resume(&policy)
+	if err := r.endDryRun(ctx, &policy); err != nil {
+		logger.Error(err, "failed to end dry run")
+		return ctrl.Result{}, err
+	}
+	if err := r.restorePolicyState(ctx, &policy); err != nil {
+		logger.Error(err, "failed to restore state")
+		return ctrl.Result{}, err
+	}
+	if err := r.acknowledgeSafeMode(ctx, &policy); err != nil {
+		logger.Error(err, "failed to acknowledge safe mode")
+		return ctrl.Result{}, err
+	}
+
+	//-- Registry connectivity of edge sites
+	if err := r.checkConnectivity(ctx, &policy); err != nil {
+		logger.Error(err, "failed to check registry connectivity")
+		return ctrl.Result{}, err
+	}
+
+	//-- Component catalog
+	catalog, err := r.componentCatalog(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to get component catalog", "catalog", policy.Spec.Catalog)
+		return ctrl.Result{}, err
+	}
+
+	//-- Integrations depending on optional APIs
+	r.checkIntegrations(&policy)
+
+	//-- Namespace of the components
+	if err := r.ensureNamespace(ctx, &policy); err != nil {
+		logger.Error(err, "failed to ensure component namespace", "namespace", componentNamespace(&policy))
+		return ctrl.Result{}, err
+	}
+
+	//-- Priority class of the components
+	if err := r.ensurePriorityClass(ctx, &policy); err != nil {
+		logger.Error(err, "failed to ensure priority class", "priorityClass", priorityClassName(&policy))
+		return ctrl.Result{}, err
+	}
+
+	//-- Exporter metrics TLS
+	certRequeue, err := r.ensureMetricsTLS(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to ensure exporter metrics TLS")
+		return ctrl.Result{}, err
+	}
 
 	//-- NVIDIA
 	if policy.Spec.Nvidia.Enabled {
-		logger.Info("Ensuring NVIDIA Device Plugin DaemonSet")
-		if err := r.ensureNvidiaDevicePlugin(ctx, &policy); err != nil {
-			logger.Error(err, "failed to ensure NVIDIA Device Plugin")
+		logger.Info("Ensuring NVIDIA components")
+		if err := r.ensureNvidiaDevicePluginConfig(ctx, &policy); err != nil {
+			logger.Error(err, "failed to ensure NVIDIA device plugin config")
+			return ctrl.Result{}, err
+		}
+		if err := r.ensureComponents(ctx, &policy, catalog, nvidiaComponents(&policy)); err != nil {
+			logger.Error(err, "failed to ensure NVIDIA components")
+			return ctrl.Result{}, err
+		}
+		if err := r.syncClusterAPITemplates(ctx, &policy, policy.Spec.Nvidia.ClusterAPI, vendorNodeLabels(&policy, "nvidia")); err != nil {
+			logger.Error(err, "failed to sync NVIDIA Cluster API templates")
 			return ctrl.Result{}, err
 		}
 	}
 
 	//-- Furiosa
 	if policy.Spec.Furiosa.Enabled {
-		logger.Info("Ensuring Furiosa Device Plugin DaemonSet")
-		if err := r.ensureFuriosaDevicePlugin(ctx, &policy); err != nil {
-			logger.Error(err, "failed to ensure Furiosa Device Plugin")
+		logger.Info("Ensuring Furiosa components")
+		if err := r.ensureFuriosaConfigMap(ctx, &policy); err != nil {
+			logger.Error(err, "failed to ensure Furiosa ConfigMap")
+			return ctrl.Result{}, err
+		}
+		if err := r.ensureComponents(ctx, &policy, catalog, furiosaComponents(&policy)); err != nil {
+			logger.Error(err, "failed to ensure Furiosa components")
+			return ctrl.Result{}, err
+		}
+		if err := r.syncClusterAPITemplates(ctx, &policy, policy.Spec.Furiosa.ClusterAPI, vendorNodeLabels(&policy, "furiosa")); err != nil {
+			logger.Error(err, "failed to sync Furiosa Cluster API templates")
+			return ctrl.Result{}, err
+		}
+	}
+
+	//-- Device plugins held back by another plugin registering the same resource
+	reportPluginConflicts(&policy)
+
+	//-- Components of disabled vendors
+	if err := r.pruneDisabledComponents(ctx, &policy); err != nil {
+		logger.Error(err, "failed to delete disabled components")
+		return ctrl.Result{}, err
+	}
+
+	//-- Device selection preferences of the device plugins
+	if err := r.ensureDevicePreferences(ctx, &policy); err != nil {
+		logger.Error(err, "failed to ensure device preferences")
+		return ctrl.Result{}, err
+	}
+
+	//-- Devices disabled on single nodes
+	if err := r.ensureDisabledDevices(ctx, &policy); err != nil {
+		logger.Error(err, "failed to ensure disabled devices")
+		return ctrl.Result{}, err
+	}
+
+	//-- Extra manifests
+	if err := r.applyExtraManifests(ctx, &policy); err != nil {
+		logger.Error(err, "failed to apply extra manifests")
+		return ctrl.Result{}, err
+	}
+
+	//-- Patches of rendered objects
+	reportPatches(&policy)
+
+	//-- Safe mode on crash loops following an operator change
+	requeue, err := r.checkSafeMode(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to check for crash looping components")
+		return ctrl.Result{}, err
+	}
+
+	//-- Thermal throttling responses
+	thermalRequeue, err := r.checkThermal(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to check thermal throttling")
+		return ctrl.Result{}, err
+	}
+	requeue = minRequeue(requeue, thermalRequeue)
+	requeue = minRequeue(requeue, certRequeue)
+
+	//-- Accelerator-aware scheduler
+	if err := r.ensureScheduler(ctx, &policy); err != nil {
+		logger.Error(err, "failed to ensure scheduler")
+		return ctrl.Result{}, err
+	}
+
+	//-- Image pre-pull for disconnected operation
+	prePullRequeue, err := r.prePullImages(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to pre-pull component images")
+		return ctrl.Result{}, err
+	}
+	requeue = minRequeue(requeue, prePullRequeue)
+	if prePulling(&policy) {
+		requeue = minRequeue(requeue, prePullPollInterval)
+	}
+	if blueGreenInProgress(&policy) {
+		requeue = minRequeue(requeue, blueGreenPollInterval)
+	}
+	if hooksRunning(&policy) {
+		requeue = minRequeue(requeue, hookPollInterval)
+	}
+
+	//-- Workload-aware driver upgrades
+	upgrading, err := r.upgradeDrivers(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to upgrade drivers")
+		return ctrl.Result{}, err
+	}
+	if upgrading {
+		requeue = minRequeue(requeue, driverUpgradePollInterval)
+	}
+
+	//-- Repartitioning of MIG GPUs by the demand of pending pods
+	autoMIG, err := r.autoMIG(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to repartition MIG nodes")
+		return ctrl.Result{}, err
+	}
+	if autoMIG {
+		requeue = minRequeue(requeue, autoMIGPollInterval)
+	}
+
+	//-- Rollout timing
+	rollingOut, err := r.trackRollouts(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to track component rollouts")
+		return ctrl.Result{}, err
+	}
+	if rollingOut {
+		requeue = minRequeue(requeue, rolloutPollInterval)
+	}
+
+	//-- Health of the component DaemonSets
+	progress, healthRequeue, err := r.observeDaemonSets(ctx, &policy)
+	if err != nil {
+		logger.Error(err, "failed to observe component DaemonSets")
+		return ctrl.Result{}, err
+	}
+	requeue = minRequeue(requeue, healthRequeue)
+	if rollingOut && len(progress) == 0 {
+		progress = append(progress, "validating rollouts")
+	}
+
+	//-- Fleet inventory
+	if err := r.summarizeInventory(ctx, &policy); err != nil {
+		logger.Error(err, "failed to summarize the fleet inventory")
+		return ctrl.Result{}, err
+	}
+
+	//-- Fields of applied objects owned by other managers
+	reportFieldConflicts(&policy, tally)
+
+	//-- Summary conditions
+	summarizeConditions(&policy, progress)
+
+	//-- Export on request
+	if err := r.exportPolicy(ctx, &policy); err != nil {
+		logger.Error(err, "failed to export policy")
+		return ctrl.Result{}, err
+	}
+	summarizeReconcile(&policy, tally, nil)
+	if !equality.Semantic.DeepEqual(statusBefore, &policy.Status) {
+		if err := r.Status().Update(ctx, &policy); err != nil {
+			logger.Error(err, "failed to update NPUClusterPolicy status")
 			return ctrl.Result{}, err
 		}
 	}
-	return ctrl.Result{}, nil
+	if err := r.savePolicyState(ctx, &policy); err != nil {
+		logger.Error(err, "failed to save state")
+		return ctrl.Result{}, err
+	}
+
+	r.notifyTransitions(ctx, &policy, before)
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
-// -- ensureNvidiaDevicePlugin creates a DaemonSet for NVIDIA
-func (r *NPUClusterPolicyReconciler) ensureNvidiaDevicePlugin(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+// -- notifyTransitions emits CloudEvents for lifecycle conditions that changed during this reconcile
+func (r *NPUClusterPolicyReconciler) notifyTransitions(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, before []metav1.Condition) {
+	if r.Events == nil {
+		return
+	}
 	log := logf.FromContext(ctx)
 
+	wasTrue := func(t conditions.ConditionType) bool {
+		return meta.IsStatusConditionTrue(before, string(t))
+	}
+	var types []string
+	if !wasTrue(conditions.Ready) && conditions.IsTrue(policy, conditions.Ready) {
+		types = append(types, cloudevents.TypePolicyReady)
+	}
+	if !wasTrue(conditions.Progressing) && conditions.IsTrue(policy, conditions.Progressing) {
+		types = append(types, cloudevents.TypeRolloutStarted)
+	}
+	if wasTrue(conditions.Progressing) && conditions.IsFalse(policy, conditions.Progressing) {
+		types = append(types, cloudevents.TypeRolloutFinished)
+	}
+
+	source := fmt.Sprintf("/apis/%s/namespaces/%s/npuclusterpolicies/%s",
+		npuv1alpha1.GroupVersion.String(), policy.Namespace, policy.Name)
+	for _, t := range types {
+		event := cloudevents.Event{
+			Type:   t,
+			Source: source,
+			Data: map[string]interface{}{
+				"generation": policy.Generation,
+				"conditions": policy.Status.Conditions,
+			},
+		}
+		if err := r.Events.Emit(ctx, event); err != nil {
+			// Delivery is best effort and must not block reconciliation.
+			log.Error(err, "failed to emit cloudevent", "type", t)
+		}
+	}
+}
+
+// -- nvidiaDevicePluginDaemonSet builds the DaemonSet of the NVIDIA device plugin
+func nvidiaDevicePluginDaemonSet(policy *npuv1alpha1.NPUClusterPolicy, image string) *appsv1.DaemonSet {
 	labels := map[string]string{
-		"app.kubernetes.io/name": "nvidia-device-plugin",
+		"app.kubernetes.io/name": nvidiaDevicePluginName,
 	}
-	ds := &appsv1.DaemonSet{
+	ds := withDisabledDevices(withDevicePreferences(&appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "nvidia-device-plugin",
-			Namespace: "kube-system",
-			Labels:    labels,
+			Name:      nvidiaDevicePluginName,
+			Namespace: componentNamespace(policy),
+			Labels:    mergeLabels(labels, policyLabels(policy)),
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{Labels: labels},
 				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{"nvidia.com/gpu.present": "true"},
+					NodeSelector: nvidiaNodeLabels,
 					Containers: []corev1.Container{
 						{
 							Name:            "nvidia-device-plugin",
-							Image:           policy.Spec.Nvidia.DevicePluginImage,
+							Image:           image,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							SecurityContext: &corev1.SecurityContext{
 								AllowPrivilegeEscalation: boolPtr(false),
@@ -125,58 +519,108 @@ func (r *NPUClusterPolicyReconciler) ensureNvidiaDevicePlugin(ctx context.Contex
 				},
 			},
 		},
+	}, "nvidia"), "nvidia")
+	if policy.Spec.Nvidia.DevicePluginConfig != nil {
+		ds = withNvidiaDevicePluginConfig(ds)
 	}
+	return ds
+}
+
+// -- ensureFuriosaConfigMap applies the ConfigMap of the Furiosa device plugin, reverting edits to its data
+func (r *NPUClusterPolicyReconciler) ensureFuriosaConfigMap(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
 
-	if err := r.Client.Create(ctx, ds); err != nil && !apierrors.IsAlreadyExists(err) {
-		log.Error(err, "failed to create nvidia device plugin daemonset")
+	configMap := furiosaConfigMap(policy)
+	if err := r.setOwner(policy, configMap); err != nil {
+		return err
+	}
+	if err := patchObject(policy, patchKindConfigMap, configMap.Name, configMap); err != nil {
+		// The ConfigMap is left unchanged; reportPatches reports the failure.
+		r.Recorder.Event(policy, corev1.EventTypeWarning, "PatchFailed", err.Error())
+		return nil
+	}
+	if err := r.apply(ctx, policy, configMap); err != nil && !conflictIgnored(err) {
+		log.Error(err, "failed to apply furiosa device plugin configmap")
 		return err
 	}
-
-	log.Info("NVIDIA device plugin daemonset ensured")
 	return nil
 }
 
-// -- ensureFuriosaDevicePlugin creates a DaemonSet for Furiosa
-func (r *NPUClusterPolicyReconciler) ensureFuriosaDevicePlugin(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
-	log := logf.FromContext(ctx)
-
-	// 1. Create ConfigMap
-	configMap := &corev1.ConfigMap{
+// -- furiosaConfigMap builds the ConfigMap of the Furiosa device plugin
+func furiosaConfigMap(policy *npuv1alpha1.NPUClusterPolicy) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      policy.Spec.Furiosa.ConfigMapName,
-			Namespace: "kube-system",
+			Namespace: componentNamespace(policy),
+			Labels:    policyLabels(policy),
 		},
 		Data: map[string]string{
-			"config.yaml": `defaultPe: Fusion
-disabledDevices: []
-interval: 10`,
+			"config.yaml": furiosaPluginConfig(&policy.Spec.Furiosa),
 		},
 	}
-	if err := r.Client.Create(ctx, configMap); err != nil && !apierrors.IsAlreadyExists(err) {
-		log.Error(err, "failed to create furiosa device plugin configmap")
-		return err
+}
+
+// -- furiosaPluginConfig renders the device plugin configuration. Unless set otherwise, the PEs of a
+// card are fused and the card is advertised whole without partitioning; with it, single PEs are
+// grouped into partitions.
+func furiosaPluginConfig(spec *npuv1alpha1.FuriosaSpec) string {
+	config := furiosaPluginConfigFile{
+		DefaultPE:       npuv1alpha1.FuriosaPEFusion,
+		DisabledDevices: []string{},
+		Interval:        10,
+	}
+	if p := spec.Partitioning; p != nil {
+		config.DefaultPE = npuv1alpha1.FuriosaPESingle
+		config.PEsPerPartition = p.PEsPerPartition
+		config.ResourceName = npuv1alpha1.FuriosaPartitionResource(p.PEsPerPartition)
 	}
+	if c := spec.Config; c != nil {
+		if c.DefaultPE != "" {
+			config.DefaultPE = c.DefaultPE
+		}
+		if c.Interval > 0 {
+			config.Interval = c.Interval
+		}
+		if len(c.DisabledDevices) > 0 {
+			config.DisabledDevices = c.DisabledDevices
+		}
+	}
+	// Marshalling a struct of strings, numbers and a string list cannot fail.
+	raw, _ := yaml.Marshal(config)
+	return string(raw)
+}
 
-	// 2. Create DaemonSet
+// furiosaPluginConfigFile is the config.yaml read by the Furiosa device plugin.
+type furiosaPluginConfigFile struct {
+	DefaultPE       npuv1alpha1.FuriosaPEMode `json:"defaultPe"`
+	PEsPerPartition int32                     `json:"pesPerPartition,omitempty"`
+	ResourceName    string                    `json:"resourceName,omitempty"`
+	DisabledDevices []string                  `json:"disabledDevices"`
+	Interval        int32                     `json:"interval"`
+}
+
+// -- furiosaDevicePluginDaemonSet builds the DaemonSet of the Furiosa device plugin
+func furiosaDevicePluginDaemonSet(policy *npuv1alpha1.NPUClusterPolicy, image string) *appsv1.DaemonSet {
 	labels := map[string]string{
-		"app.kubernetes.io/name": "furiosa-device-plugin",
+		"app.kubernetes.io/name": furiosaDevicePluginName,
 	}
-	ds := &appsv1.DaemonSet{
+	return withDisabledDevices(withDevicePreferences(&appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "furiosa-device-plugin",
-			Namespace: "kube-system",
-			Labels:    labels,
+			Name:      furiosaDevicePluginName,
+			Namespace: componentNamespace(policy),
+			Labels:    mergeLabels(labels, policyLabels(policy)),
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{Labels: labels},
 				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{"furiosa": "true"},
+					NodeSelector: furiosaNodeLabels,
 					Containers: []corev1.Container{
 						{
 							Name:            "furiosa-device-plugin",
-							Image:           policy.Spec.Furiosa.DevicePluginImage,
+							Image:           image,
 							ImagePullPolicy: corev1.PullAlways,
 							Command:         []string{"/usr/bin/k8s-device-plugin"},
 							Args:            []string{"--config-file", "/etc/furiosa/config.yaml"},
@@ -236,26 +680,63 @@ interval: 10`,
 				},
 			},
 		},
-	}
-
-	if err := r.Client.Create(ctx, ds); err != nil && !apierrors.IsAlreadyExists(err) {
-		log.Error(err, "failed to create furiosa device plugin daemonset")
-		return err
-	}
-
-	log.Info("Furiosa device plugin daemonset ensured")
-	return nil
+	}, "furiosa"), "furiosa")
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NPUClusterPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &npuv1alpha1.NPUClusterPolicy{},
+		extraManifestsIndex, extraManifestNames); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &npuv1alpha1.NPUClusterPolicy{},
+		catalogIndex, catalogName); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&npuv1alpha1.NPUClusterPolicy{}).
+		For(&npuv1alpha1.NPUClusterPolicy{}, builder.WithPredicates(specOrMetadataChanged)).
+		// Managed objects are mapped to their policy by its labels rather than owned, as owner
+		// references cannot point from the component namespace to policies in other namespaces.
+		Watches(&appsv1.DaemonSet{}, handler.Funcs{UpdateFunc: r.onDaemonSetChanged, DeleteFunc: r.onDaemonSetDeleted},
+			builder.WithPredicates(managedOrForeignPlugin)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.policiesForConfigMap),
+			builder.WithPredicates(configMapContentChanged)).
+		Watches(&npuv1alpha1.NPUClusterPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policiesInConflict),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&npuv1alpha1.NPUComponentCatalog{}, handler.EnqueueRequestsFromMapFunc(r.policiesForCatalog),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&npuv1alpha1.NPUNode{}, handler.EnqueueRequestsFromMapFunc(r.policiesForNPUNode),
+			builder.WithPredicates(npuNodeResourcesChanged)).
+		Watches(&npuv1alpha1.NPUNode{}, handler.EnqueueRequestsFromMapFunc(r.policiesForDisabledDevices),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&npuv1alpha1.NPUNode{}, handler.EnqueueRequestsFromMapFunc(r.policiesForUnmanagedNode),
+			builder.WithPredicates(npuNodeUnmanagedChanged)).
+		Watches(&apiextensionsv1.CustomResourceDefinition{}, handler.Funcs{CreateFunc: r.onIntegrationCRDCreated,
+			UpdateFunc: r.onIntegrationCRDUpdated, DeleteFunc: r.onIntegrationCRDDeleted},
+			builder.WithPredicates(integrationCRD)).
 		Named("npuclusterpolicy").
+		WithOptions(tierQueueOptions(mgr, r.Workers, r.RateLimiter, func() client.Object { return &npuv1alpha1.NPUClusterPolicy{} })).
 		Complete(r)
 }
 
-// -- Add
+// -- minRequeue returns the shorter of two requeue intervals, ignoring zero
+func minRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func mergeLabels(sets ...map[string]string) map[string]string {
+	out := map[string]string{}
+	for _, set := range sets {
+		for k, v := range set {
+			out[k] = v
+		}
+	}
+	return out
+}
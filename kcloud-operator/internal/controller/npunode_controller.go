@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/audit"
+	"npu-operator/internal/cloudevents"
+	"npu-operator/internal/silence"
+	"npu-operator/internal/statestore"
+)
+
+// podNodeNameIndex indexes pods by the node they are scheduled to.
+const podNodeNameIndex = "spec.nodeName"
+
+// acceleratorVendors maps an accelerator resource prefix to the vendor prefix of its component names.
+var acceleratorVendors = map[string]string{
+	"nvidia.com/": "nvidia",
+	"furiosa.ai/": "furiosa",
+}
+
+// vendorNames are the vendors of acceleratorVendors, sorted.
+var vendorNames = slices.Sorted(maps.Values(acceleratorVendors))
+
+// NPUNodeReconciler keeps an NPUNode per accelerator node and remediates nodes whose
+// accelerators stop being allocatable.
+type NPUNodeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	Remediation RemediationConfig
+
+	// Events receives the nodes that remediation gave up on as CloudEvents. Nil disables delivery.
+	Events cloudevents.Emitter
+
+	// Silencer suppresses the alerts of nodes rebooted by remediation. Nil leaves alerts alone.
+	Silencer silence.Silencer
+
+	// Retention is how long the NPUNode of a removed node is kept. Zero keeps it forever.
+	Retention time.Duration
+
+	// State persists the remediation history across NPUNode recreation. Nil disables it.
+	State *statestore.Store
+
+	// BurnIn re-tests disabled devices and re-enables those that pass.
+	BurnIn BurnInConfig
+
+	// FailureDomains names the node labels of the racks and power zones of the nodes.
+	FailureDomains FailureDomainLabels
+
+	// Workers is the number of nodes reconciled concurrently. Zero uses the manager default.
+	Workers int
+
+	// RateLimiter configures the retries of failed nodes.
+	RateLimiter RateLimiterConfig
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npunodes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=npu.ai,resources=npunodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+
+// Reconcile mirrors the accelerator resources of a node into its NPUNode and drives remediation.
+func (r *NPUNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
+		if !apierrors.IsNotFound(err) || r.Retention == 0 {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		wait, err := r.collectStaleNPUNode(ctx, req.Name)
+		if err != nil {
+			logger.Error(err, "failed to collect stale NPUNode")
+		}
+		return ctrl.Result{RequeueAfter: wait}, err
+	}
+	if !isAcceleratorNode(node) {
+		return ctrl.Result{}, nil
+	}
+	ctx = audit.WithCause(ctx, "Node", node)
+
+	npuNode := &npuv1alpha1.NPUNode{}
+	created := false
+	if err := r.Get(ctx, req.NamespacedName, npuNode); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		npuNode = &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: node.Name}}
+		if err := r.Create(ctx, npuNode); err != nil {
+			logger.Error(err, "failed to create NPUNode")
+			return ctrl.Result{}, err
+		}
+		created = true
+	}
+	before := npuNode.Status.DeepCopy()
+
+	// Unmanaged nodes are only observed: they are neither revalidated, burned in nor remediated.
+	unmanaged := unmanagedNode(node)
+	// Revalidation patches the objects first, so the patches do not reset the status computed below.
+	burnInRequested := false
+	if !unmanaged {
+		if err := r.revalidate(ctx, node, npuNode); err != nil {
+			logger.Error(err, "failed to revalidate node")
+			return ctrl.Result{}, err
+		}
+		var err error
+		burnInRequested, err = r.burnInRequested(ctx, npuNode)
+		if err != nil {
+			logger.Error(err, "failed to process burn-in request")
+			return ctrl.Result{}, err
+		}
+	}
+	if created {
+		if err := r.restoreNodeState(ctx, npuNode); err != nil {
+			logger.Error(err, "failed to restore state")
+			return ctrl.Result{}, err
+		}
+	}
+
+	npuNode.Status.NodeRemovedTime = nil
+	npuNode.Status.Unmanaged = unmanaged
+	npuNode.Status.Capacity = acceleratorResources(node.Status.Capacity)
+	npuNode.Status.Allocatable = acceleratorResources(node.Status.Allocatable)
+	if err := r.summarizeNode(ctx, node, npuNode); err != nil {
+		logger.Error(err, "failed to summarize node")
+		return ctrl.Result{}, err
+	}
+
+	var result ctrl.Result
+	if r.Remediation.Enabled && !unmanaged {
+		wait, err := r.remediate(ctx, node, npuNode)
+		if err != nil {
+			logger.Error(err, "failed to remediate node")
+			return ctrl.Result{}, err
+		}
+		result.RequeueAfter = wait
+	}
+	if !unmanaged {
+		wait, err := r.burnIn(ctx, node, npuNode, burnInRequested)
+		if err != nil {
+			logger.Error(err, "failed to burn in excluded devices")
+			return ctrl.Result{}, err
+		}
+		result.RequeueAfter = minRequeue(result.RequeueAfter, wait)
+	}
+	npuNode.Status.Health = nodeHealth(npuNode)
+	setNodeConditions(npuNode)
+
+	if !equality.Semantic.DeepEqual(before, &npuNode.Status) {
+		if err := r.Status().Update(ctx, npuNode); err != nil {
+			logger.Error(err, "failed to update NPUNode status")
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.labelNPUNode(ctx, npuNode); err != nil {
+		logger.Error(err, "failed to label NPUNode")
+		return ctrl.Result{}, err
+	}
+	if err := r.saveNodeState(ctx, npuNode); err != nil {
+		logger.Error(err, "failed to save state")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// -- isAcceleratorNode reports whether the node carries a vendor label or accelerator capacity
+func isAcceleratorNode(node *corev1.Node) bool {
+	for _, set := range []map[string]string{nvidiaNodeLabels, furiosaNodeLabels} {
+		for k, v := range set {
+			if node.Labels[k] == v {
+				return true
+			}
+		}
+	}
+	return len(acceleratorResources(node.Status.Capacity)) > 0
+}
+
+// -- acceleratorResources filters a resource list down to accelerator resources
+func acceleratorResources(list corev1.ResourceList) corev1.ResourceList {
+	var out corev1.ResourceList
+	for name, q := range list {
+		if acceleratorVendor(string(name)) == "" {
+			continue
+		}
+		if out == nil {
+			out = corev1.ResourceList{}
+		}
+		out[name] = q
+	}
+	return out
+}
+
+func acceleratorVendor(resource string) string {
+	for prefix, vendor := range acceleratorVendors {
+		if strings.HasPrefix(resource, prefix) {
+			return vendor
+		}
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NPUNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameIndex,
+		func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return isAcceleratorNode(obj.(*corev1.Node))
+		}))).
+		Watches(&npuv1alpha1.NPUNode{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(specOrMetadataChanged)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(nodeForPod)).
+		Named("npunode").
+		WithOptions(tierQueueOptions(mgr, r.Workers, r.RateLimiter, func() client.Object { return &corev1.Node{} })).
+		Complete(r)
+}
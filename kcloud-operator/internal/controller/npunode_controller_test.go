@@ -0,0 +1,438 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/cloudevents"
+	"npu-operator/internal/statestore"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("NPUNode Controller", func() {
+	const nodeName = "gpu-node-wedged"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: nodeName}
+	podKey := types.NamespacedName{Name: "nvidia-device-plugin-wedged", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"nvidia.com/gpu.present": "true"},
+		}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		node.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("0")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "nvidia-device-plugin"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "nvidia-device-plugin", Image: "busybox"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+	})
+
+	It("should restart the device plugin and record the recovery", func() {
+		controllerReconciler := &NPUNodeReconciler{
+			Client:      k8sClient,
+			Scheme:      k8sClient.Scheme(),
+			Recorder:    record.NewFakeRecorder(10),
+			Remediation: RemediationConfig{Enabled: true, StepTimeout: time.Minute},
+		}
+
+		By("detecting the allocatable drop while the plugin is running")
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.Capacity).To(HaveKey(corev1.ResourceName("nvidia.com/gpu")))
+		Expect(npuNode.Status.Remediation).NotTo(BeNil())
+		Expect(npuNode.Status.Remediation.Step).To(Equal(npuv1alpha1.RemediationRestartPlugin))
+		pod := &corev1.Pod{}
+		err = k8sClient.Get(ctx, podKey, pod)
+		Expect(err != nil || pod.DeletionTimestamp != nil).To(BeTrue())
+
+		By("recording the recovery once the allocatable is back")
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, key, node)).To(Succeed())
+		node.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.Remediation).To(BeNil())
+		Expect(npuNode.Status.RemediationHistory).To(HaveLen(2))
+		Expect(npuNode.Status.RemediationHistory[1].Result).To(Equal("Recovered"))
+	})
+
+	It("should report the node as quarantined once remediation is exhausted", func() {
+		emitter := &recordingEmitter{}
+		controllerReconciler := &NPUNodeReconciler{
+			Client:      k8sClient,
+			Scheme:      k8sClient.Scheme(),
+			Recorder:    record.NewFakeRecorder(10),
+			Events:      emitter,
+			Remediation: RemediationConfig{Enabled: true},
+		}
+
+		By("stepping through the sequence without a reboot window")
+		for range 3 {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.Remediation).NotTo(BeNil())
+		Expect(npuNode.Status.Remediation.Step).To(Equal(npuv1alpha1.RemediationExhausted))
+		Expect(emitter.events).To(HaveLen(1))
+		Expect(emitter.events[0].Type).To(Equal(cloudevents.TypeNodeQuarantined))
+		Expect(emitter.events[0].Subject).To(Equal(nodeName))
+
+		By("not reporting it again while it stays exhausted")
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(emitter.events).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Node revalidation", func() {
+	const nodeName = "furiosa-node-swapped"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: nodeName}
+	podKey := types.NamespacedName{Name: "furiosa-validator-swapped", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        nodeName,
+			Labels:      furiosaNodeLabels,
+			Annotations: map[string]string{npuv1alpha1.RevalidateAnnotation: "true"},
+		}})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "furiosa-validator"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "furiosa-validator", Image: "busybox"}},
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+	})
+
+	It("should restart the validators of an annotated node and remove the annotation", func() {
+		controllerReconciler := &NPUNodeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, key, node)).To(Succeed())
+		Expect(node.Annotations).NotTo(HaveKey(npuv1alpha1.RevalidateAnnotation))
+		pod := &corev1.Pod{}
+		err = k8sClient.Get(ctx, podKey, pod)
+		Expect(err != nil || pod.DeletionTimestamp != nil).To(BeTrue())
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.LastRevalidationTime).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("Node summary", func() {
+	const nodeName = "gpu-node-summary"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: nodeName}
+	podKey := types.NamespacedName{Name: "training-summary", Namespace: "default"}
+
+	BeforeEach(func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Labels: map[string]string{
+				"nvidia.com/gpu.present":              "true",
+				"nvidia.com/gpu.product":              "NVIDIA-A100-SXM4-80GB",
+				"nvidia.com/cuda.driver-version.full": "550.54.15",
+				npuv1alpha1.RackLabel:                 "r12",
+			},
+		}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		node.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podKey.Name, Namespace: podKey.Namespace},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name:  "train",
+					Image: "busybox",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+					},
+				}},
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: podKey.Name, Namespace: podKey.Namespace,
+		}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+	})
+
+	It("should summarize the accelerators and label the NPUNode by model", func() {
+		controllerReconciler := &NPUNodeReconciler{
+			Client:         k8sClient,
+			Scheme:         k8sClient.Scheme(),
+			Recorder:       record.NewFakeRecorder(10),
+			FailureDomains: DefaultFailureDomainLabels,
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.Model).To(Equal("NVIDIA-A100-SXM4-80GB"))
+		Expect(npuNode.Status.Count).To(Equal(int64(8)))
+		Expect(npuNode.Status.Allocated).To(Equal(int64(2)))
+		Expect(npuNode.Status.DriverVersion).To(Equal("550.54.15"))
+		Expect(npuNode.Status.Health).To(Equal(npuv1alpha1.NodeHealthy))
+		Expect(conditions.NPUNode.IsReady(npuNode)).To(BeTrue())
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.ModelLabel, "A100"))
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.VendorLabel, "nvidia"))
+		Expect(npuNode.Status.Rack).To(Equal("r12"))
+		Expect(npuNode.Status.PowerZone).To(BeEmpty())
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.RackLabel, "r12"))
+		Expect(npuNode.Labels).NotTo(HaveKey(npuv1alpha1.PowerZoneLabel))
+	})
+
+	It("should summarize each vendor of a node with accelerators of several vendors", func() {
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, key, node)).To(Succeed())
+		node.Labels["furiosa"] = "true"
+		node.Labels["furiosa.ai/npu.product"] = "RNGD"
+		node.Labels["furiosa.ai/driver.version"] = "2024.2.0"
+		Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		node.Status.Capacity["furiosa.ai/rngd"] = resource.MustParse("2")
+		node.Status.Allocatable["furiosa.ai/rngd"] = resource.MustParse("2")
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		controllerReconciler := &NPUNodeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.Vendors).To(Equal([]npuv1alpha1.VendorSummary{
+			{Vendor: "furiosa", Model: "RNGD", Count: 2, DriverVersion: "2024.2.0"},
+			{Vendor: "nvidia", Model: "NVIDIA-A100-SXM4-80GB", Count: 8, DriverVersion: "550.54.15"},
+		}))
+		Expect(npuNode.Status.Count).To(Equal(int64(10)))
+		Expect(npuNode.Status.Health).To(Equal(npuv1alpha1.NodeHealthy))
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.VendorLabel, npuv1alpha1.MultiVendor))
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.VendorLabelPrefix+"nvidia", "true"))
+		Expect(npuNode.Labels).To(HaveKeyWithValue(npuv1alpha1.VendorLabelPrefix+"furiosa", "true"))
+		Expect(npuNodeVendors(npuNode)).To(Equal([]string{"furiosa", "nvidia"}))
+	})
+
+	It("should shorten product names to the model", func() {
+		Expect(shortModel("NVIDIA-H100-80GB-HBM3")).To(Equal("H100"))
+		Expect(shortModel("Tesla-V100-SXM2-16GB")).To(Equal("V100"))
+		Expect(shortModel("RNGD")).To(Equal("RNGD"))
+		Expect(shortModel("")).To(BeEmpty())
+	})
+
+	It("should set the conditions of the NPUNode from its capacity and remediation", func() {
+		npuNode := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+		setNodeConditions(npuNode)
+		Expect(conditions.Get(npuNode, conditions.Ready).Status).To(Equal(metav1.ConditionUnknown))
+
+		npuNode.Status.Capacity = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		npuNode.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("7")}
+		npuNode.Status.Health = nodeHealth(npuNode)
+		setNodeConditions(npuNode)
+		Expect(conditions.IsTrue(npuNode, conditions.Degraded)).To(BeTrue())
+		Expect(conditions.Get(npuNode, conditions.Degraded).Message).To(Equal("nvidia.com/gpu: 7 of 8 allocatable"))
+		Expect(conditions.IsFalse(npuNode, conditions.Ready)).To(BeTrue())
+		Expect(conditions.NPUNode.IsReady(npuNode)).To(BeFalse())
+
+		npuNode.Status.Remediation = &npuv1alpha1.RemediationState{
+			Resource: "nvidia.com/gpu", Step: npuv1alpha1.RemediationRestartPlugin,
+		}
+		npuNode.Status.Health = nodeHealth(npuNode)
+		setNodeConditions(npuNode)
+		Expect(conditions.IsTrue(npuNode, conditions.Remediating)).To(BeTrue())
+		Expect(conditions.Get(npuNode, conditions.Ready).Reason).To(Equal(conditions.ReasonRemediating))
+
+		npuNode.Status.Remediation = nil
+		npuNode.Status.Allocatable = npuNode.Status.Capacity
+		npuNode.Status.Health = nodeHealth(npuNode)
+		setNodeConditions(npuNode)
+		Expect(conditions.NPUNode.IsReady(npuNode)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Stale NPUNode cleanup", func() {
+	const nodeName = "gpu-node-removed"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: nodeName}
+
+	BeforeEach(func() {
+		npuNode := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		Expect(k8sClient.Create(ctx, npuNode)).To(Succeed())
+		npuNode.Status.Model = "NVIDIA-A100-SXM4-80GB"
+		Expect(k8sClient.Status().Update(ctx, npuNode)).To(Succeed())
+	})
+
+	It("should retain the NPUNode of a removed node and delete it with a tombstone later", func() {
+		state := statestore.New(k8sClient, "kube-system", "npu-operator-state-gc")
+		controllerReconciler := &NPUNodeReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Recorder:  record.NewFakeRecorder(10),
+			Retention: time.Hour,
+			State:     state,
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+
+		npuNode := &npuv1alpha1.NPUNode{}
+		Expect(k8sClient.Get(ctx, key, npuNode)).To(Succeed())
+		Expect(npuNode.Status.NodeRemovedTime).NotTo(BeNil())
+
+		By("deleting it once the retention expired")
+		controllerReconciler.Retention = time.Nanosecond
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.Get(ctx, key, npuNode)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		var tombstone nodeTombstone
+		found, err := state.Load(ctx, tombstoneKey(nodeName), &tombstone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(tombstone.Model).To(Equal("NVIDIA-A100-SXM4-80GB"))
+	})
+})
+
+var _ = Describe("Rack maintenance", func() {
+	ctx := context.Background()
+
+	It("should hold back reboots in other racks while a rack is in maintenance", func() {
+		rebooting := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node-rack-a"}}
+		Expect(k8sClient.Create(ctx, rebooting)).To(Succeed())
+		rebooting.Status.Rack = "rack-a"
+		rebooting.Status.Remediation = &npuv1alpha1.RemediationState{
+			Resource:    "nvidia.com/gpu",
+			Step:        npuv1alpha1.RemediationReboot,
+			StartedTime: metav1.Now(),
+			StepTime:    metav1.Now(),
+		}
+		Expect(k8sClient.Status().Update(ctx, rebooting)).To(Succeed())
+		controllerReconciler := &NPUNodeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		for rack, blocking := range map[string]string{"rack-a": "", "rack-b": "rack-a", "": "rack-a"} {
+			npuNode := &npuv1alpha1.NPUNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-node-other"},
+				Status:     npuv1alpha1.NPUNodeStatus{Rack: rack},
+			}
+			Expect(controllerReconciler.rackInMaintenance(ctx, npuNode)).To(Equal(blocking), "rack %q", rack)
+		}
+		Expect(controllerReconciler.rackInMaintenance(ctx, rebooting)).To(BeEmpty())
+
+		Expect(k8sClient.Delete(ctx, rebooting)).To(Succeed())
+	})
+})
+
+var _ = Describe("Maintenance window", func() {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	It("should contain times inside a window that wraps midnight", func() {
+		window, err := ParseMaintenanceWindow("23:00-01:30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(window.Contains(at(23, 30))).To(BeTrue())
+		Expect(window.Contains(at(1, 0))).To(BeTrue())
+		Expect(window.Contains(at(2, 0))).To(BeFalse())
+		Expect(window.Until(at(22, 0))).To(Equal(time.Hour))
+		Expect(window.Until(at(2, 0))).To(Equal(21 * time.Hour))
+	})
+
+	It("should reject malformed windows", func() {
+		_, err := ParseMaintenanceWindow("25:00-01:00")
+		Expect(err).To(HaveOccurred())
+		_, err = ParseMaintenanceWindow("nightly")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/audit"
+	"npu-operator/pkg/conditions"
+)
+
+const templateRefIndex = "spec.templateRef"
+
+var placeholderPattern = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// NPUPolicyParameterSetReconciler renders NPUClusterPolicyTemplates into NPUClusterPolicies
+type NPUPolicyParameterSetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npupolicyparametersets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=npu.ai,resources=npupolicyparametersets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=npu.ai,resources=npuclusterpolicytemplates,verbs=get;list;watch
+
+// Reconcile renders the referenced template with the values of the parameter set and
+// creates or updates the resulting NPUClusterPolicy.
+func (r *NPUPolicyParameterSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	//-- Get CR
+	var set npuv1alpha1.NPUPolicyParameterSet
+	if err := r.Get(ctx, req.NamespacedName, &set); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	ctx = audit.WithCause(ctx, "NPUPolicyParameterSet", &set)
+
+	var tmpl npuv1alpha1.NPUClusterPolicyTemplate
+	if err := r.Get(ctx, types.NamespacedName{Name: set.Spec.TemplateRef}, &tmpl); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to fetch NPUClusterPolicyTemplate", "template", set.Spec.TemplateRef)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.renderFailed(ctx, &set, fmt.Errorf("template %s not found", set.Spec.TemplateRef))
+	}
+
+	spec, err := renderPolicySpec(&tmpl, &set)
+	if err != nil {
+		return ctrl.Result{}, r.renderFailed(ctx, &set, err)
+	}
+
+	name := set.Spec.PolicyName
+	if name == "" {
+		name = set.Name
+	}
+	policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: set.Namespace}}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if !policy.CreationTimestamp.IsZero() && !metav1.IsControlledBy(policy, &set) {
+			return fmt.Errorf("policy %s exists and is not managed by this parameter set", name)
+		}
+		policy.Labels = mergeLabels(policy.Labels, map[string]string{npuv1alpha1.PolicyTemplateLabel: tmpl.Name})
+		policy.Spec = *spec
+		return controllerutil.SetControllerReference(&set, policy, r.Scheme)
+	})
+	if err != nil {
+		logger.Error(err, "failed to apply rendered NPUClusterPolicy", "policy", name)
+		return ctrl.Result{}, r.renderFailed(ctx, &set, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		r.Recorder.Eventf(&set, corev1.EventTypeNormal, "PolicyRendered",
+			"Rendered template %s (generation %d) into policy %s", tmpl.Name, tmpl.Generation, name)
+	}
+
+	set.Status.ObservedGeneration = set.Generation
+	set.Status.TemplateGeneration = tmpl.Generation
+	conditions.MarkTrue(&set, conditions.Rendered, conditions.ReasonReconciled,
+		fmt.Sprintf("Rendered into policy %s", name))
+	return ctrl.Result{}, r.Status().Update(ctx, &set)
+}
+
+// -- renderFailed reports a rendering error in the status of the parameter set
+func (r *NPUPolicyParameterSetReconciler) renderFailed(ctx context.Context, set *npuv1alpha1.NPUPolicyParameterSet, cause error) error {
+	r.Recorder.Event(set, corev1.EventTypeWarning, "RenderFailed", cause.Error())
+	set.Status.ObservedGeneration = set.Generation
+	conditions.MarkFalse(set, conditions.Rendered, conditions.ReasonRenderFailed, cause.Error())
+	return r.Status().Update(ctx, set)
+}
+
+// -- renderPolicySpec substitutes the parameter values into the template and applies the patch of the set
+func renderPolicySpec(tmpl *npuv1alpha1.NPUClusterPolicyTemplate,
+	set *npuv1alpha1.NPUPolicyParameterSet) (*npuv1alpha1.NPUClusterPolicySpec, error) {
+	values := map[string]string{}
+	declared := map[string]bool{}
+	for _, p := range tmpl.Spec.Parameters {
+		declared[p.Name] = true
+		if p.Default != nil {
+			values[p.Name] = *p.Default
+		}
+	}
+	for k, v := range set.Spec.Values {
+		if !declared[k] {
+			return nil, fmt.Errorf("parameter %s is not declared by template %s", k, tmpl.Name)
+		}
+		values[k] = v
+	}
+	for _, p := range tmpl.Spec.Parameters {
+		if _, ok := values[p.Name]; !ok {
+			return nil, fmt.Errorf("parameter %s is required by template %s", p.Name, tmpl.Name)
+		}
+	}
+
+	raw, err := json.Marshal(tmpl.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	doc, err = substitutePlaceholders(doc, values)
+	if err != nil {
+		return nil, err
+	}
+	if raw, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+	if set.Spec.Patch != nil {
+		if raw, err = jsonpatch.MergePatch(raw, set.Spec.Patch.Raw); err != nil {
+			return nil, fmt.Errorf("invalid patch: %w", err)
+		}
+	}
+
+	spec := &npuv1alpha1.NPUClusterPolicySpec{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("rendered spec is invalid: %w", err)
+	}
+	return spec, nil
+}
+
+// -- substitutePlaceholders replaces ${name} in every string of a decoded JSON document
+func substitutePlaceholders(doc interface{}, values map[string]string) (interface{}, error) {
+	switch v := doc.(type) {
+	case string:
+		var missing string
+		out := placeholderPattern.ReplaceAllStringFunc(v, func(m string) string {
+			name := placeholderPattern.FindStringSubmatch(m)[1]
+			value, ok := values[name]
+			if !ok {
+				missing = name
+			}
+			return value
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("placeholder ${%s} is not a declared parameter", missing)
+		}
+		return out, nil
+	case map[string]interface{}:
+		for k, item := range v {
+			out, err := substitutePlaceholders(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = out
+		}
+	case []interface{}:
+		for i, item := range v {
+			out, err := substitutePlaceholders(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = out
+		}
+	}
+	return doc, nil
+}
+
+// -- parameterSetsForTemplate maps a template to the parameter sets that render it
+func (r *NPUPolicyParameterSetReconciler) parameterSetsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	sets := &npuv1alpha1.NPUPolicyParameterSetList{}
+	if err := r.List(ctx, sets, client.MatchingFields{templateRefIndex: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list parameter sets for template", "template", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(sets.Items))
+	for _, s := range sets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&s)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NPUPolicyParameterSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &npuv1alpha1.NPUPolicyParameterSet{},
+		templateRefIndex, func(obj client.Object) []string {
+			return []string{obj.(*npuv1alpha1.NPUPolicyParameterSet).Spec.TemplateRef}
+		}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&npuv1alpha1.NPUPolicyParameterSet{}).
+		Owns(&npuv1alpha1.NPUClusterPolicy{}).
+		Watches(&npuv1alpha1.NPUClusterPolicyTemplate{}, handler.EnqueueRequestsFromMapFunc(r.parameterSetsForTemplate)).
+		Named("npupolicyparameterset").
+		Complete(r)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+var _ = Describe("NPUPolicyParameterSet Controller", func() {
+	const resourceName = "edge-01"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	policyKey := types.NamespacedName{Name: "rendered-policy", Namespace: "default"}
+
+	template := func() *npuv1alpha1.NPUClusterPolicyTemplate {
+		return &npuv1alpha1.NPUClusterPolicyTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "fleet"},
+			Spec: npuv1alpha1.NPUClusterPolicyTemplateSpec{
+				Parameters: []npuv1alpha1.TemplateParameter{
+					{Name: "registry"},
+					{Name: "pluginVersion", Default: strPtr("v0.17.1")},
+				},
+				Template: npuv1alpha1.NPUClusterPolicySpec{
+					Nvidia: npuv1alpha1.NvidiaSpec{
+						Enabled: true,
+						VendorComponents: npuv1alpha1.VendorComponents{
+							DevicePlugin: npuv1alpha1.ComponentSpec{
+								Image:   "${registry}/nvidia/k8s-device-plugin",
+								Version: "${pluginVersion}",
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(k8sClient.Create(ctx, template())).To(Succeed())
+		set := &npuv1alpha1.NPUPolicyParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUPolicyParameterSetSpec{
+				TemplateRef: "fleet",
+				PolicyName:  policyKey.Name,
+				Values:      map[string]string{"registry": "registry.edge-01.example.com"},
+				Patch:       &apiextensionsv1.JSON{Raw: []byte(`{"furiosa":{"enabled":true,"configMapName":"furiosa"}}`)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, set)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		set := &npuv1alpha1.NPUPolicyParameterSet{}
+		Expect(k8sClient.Get(ctx, key, set)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, set)).To(Succeed())
+		tmpl := &npuv1alpha1.NPUClusterPolicyTemplate{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "fleet"}, tmpl)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, tmpl)).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		if err := k8sClient.Get(ctx, policyKey, policy); err == nil {
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		}
+	})
+
+	It("should render the template into a policy", func() {
+		controllerReconciler := &NPUPolicyParameterSetReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, policyKey, policy)).To(Succeed())
+		Expect(policy.Spec.Nvidia.DevicePlugin.Image).To(Equal("registry.edge-01.example.com/nvidia/k8s-device-plugin"))
+		Expect(policy.Spec.Nvidia.DevicePlugin.Version).To(Equal("v0.17.1"))
+		Expect(policy.Spec.Furiosa.Enabled).To(BeTrue())
+		Expect(policy.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyTemplateLabel, "fleet"))
+		Expect(policy.OwnerReferences).To(HaveLen(1))
+
+		set := &npuv1alpha1.NPUPolicyParameterSet{}
+		Expect(k8sClient.Get(ctx, key, set)).To(Succeed())
+		Expect(conditions.IsTrue(set, conditions.Rendered)).To(BeTrue())
+	})
+
+	It("should reject missing and undeclared parameters", func() {
+		tmpl := template()
+
+		_, err := renderPolicySpec(tmpl, &npuv1alpha1.NPUPolicyParameterSet{})
+		Expect(err).To(MatchError(ContainSubstring("parameter registry is required")))
+
+		_, err = renderPolicySpec(tmpl, &npuv1alpha1.NPUPolicyParameterSet{
+			Spec: npuv1alpha1.NPUPolicyParameterSetSpec{Values: map[string]string{"registry": "r", "region": "eu"}},
+		})
+		Expect(err).To(MatchError(ContainSubstring("parameter region is not declared")))
+
+		tmpl.Spec.Template.Catalog = "${catalog}"
+		_, err = renderPolicySpec(tmpl, &npuv1alpha1.NPUPolicyParameterSet{
+			Spec: npuv1alpha1.NPUPolicyParameterSetSpec{Values: map[string]string{"registry": "r"}},
+		})
+		Expect(err).To(MatchError(ContainSubstring("placeholder ${catalog}")))
+	})
+})
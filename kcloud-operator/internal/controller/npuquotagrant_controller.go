@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// NPUQuotaGrantReconciler applies the extra quota of active NPUQuotaGrants to the
+// ResourceQuotas of their namespace and reverts it once they expire or are deleted.
+type NPUQuotaGrantReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npuquotagrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=npu.ai,resources=npuquotagrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;patch
+
+// Reconcile syncs the ResourceQuotas of the namespace of the grant with all of its grants,
+// so a deleted grant is reverted like an expired one.
+func (r *NPUQuotaGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	//-- Grants of the namespace
+	grants := &npuv1alpha1.NPUQuotaGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	var requeue time.Duration
+	extra := map[string]corev1.ResourceList{}
+	statuses := make([]npuv1alpha1.NPUQuotaGrantStatus, len(grants.Items))
+	for i := range grants.Items {
+		grant := &grants.Items[i]
+		expiry := metav1.NewTime(grant.CreationTimestamp.Add(grant.Spec.Duration.Duration))
+		status := npuv1alpha1.NPUQuotaGrantStatus{ExpiryTime: &expiry, QuotaName: grantQuota(grant, quotas.Items)}
+		switch {
+		case !now.Before(expiry.Time):
+			status.Phase = npuv1alpha1.QuotaGrantExpired
+		case status.QuotaName == "":
+			status.Phase = npuv1alpha1.QuotaGrantUnapplied
+		default:
+			status.Phase = npuv1alpha1.QuotaGrantActive
+			key := quotaKey(grant.Spec.Resource)
+			if extra[status.QuotaName] == nil {
+				extra[status.QuotaName] = corev1.ResourceList{}
+			}
+			sum := extra[status.QuotaName][key]
+			sum.Add(grant.Spec.Amount)
+			extra[status.QuotaName][key] = sum
+			requeue = minRequeue(requeue, expiry.Sub(now))
+		}
+		if status.Phase == npuv1alpha1.QuotaGrantExpired {
+			// Keep the quota of the record, it is the one that was reverted.
+			status.QuotaName = grant.Status.QuotaName
+		}
+		statuses[i] = status
+	}
+
+	//-- Quotas
+	for i := range quotas.Items {
+		if err := r.applyGrants(ctx, &quotas.Items[i], extra[quotas.Items[i].Name]); err != nil {
+			logger.Error(err, "failed to apply quota grants", "quota", quotas.Items[i].Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	//-- Status and audit events
+	for i := range grants.Items {
+		grant := &grants.Items[i]
+		if equality.Semantic.DeepEqual(grant.Status, statuses[i]) {
+			continue
+		}
+		r.recordTransition(grant, statuses[i])
+		grant.Status = statuses[i]
+		if err := r.Status().Update(ctx, grant); err != nil {
+			logger.Error(err, "failed to update NPUQuotaGrant status", "grant", grant.Name)
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// -- recordTransition emits the audit event of a grant changing phase
+func (r *NPUQuotaGrantReconciler) recordTransition(grant *npuv1alpha1.NPUQuotaGrant, status npuv1alpha1.NPUQuotaGrantStatus) {
+	if grant.Status.Phase == status.Phase {
+		return
+	}
+	amount := grant.Spec.Amount.String()
+	switch status.Phase {
+	case npuv1alpha1.QuotaGrantActive:
+		r.Recorder.Eventf(grant, corev1.EventTypeNormal, "QuotaGranted",
+			"Granted %s %s on ResourceQuota %s until %s: %s", amount, grant.Spec.Resource, status.QuotaName,
+			status.ExpiryTime.UTC().Format(time.RFC3339), grant.Spec.Reason)
+	case npuv1alpha1.QuotaGrantExpired:
+		if grant.Status.Phase == npuv1alpha1.QuotaGrantActive {
+			r.Recorder.Eventf(grant, corev1.EventTypeNormal, "QuotaReverted",
+				"Reverted %s %s on ResourceQuota %s after %s", amount, grant.Spec.Resource, status.QuotaName,
+				grant.Spec.Duration.Duration)
+		}
+	case npuv1alpha1.QuotaGrantUnapplied:
+		r.Recorder.Eventf(grant, corev1.EventTypeWarning, "QuotaNotFound",
+			"No ResourceQuota of namespace %s limits %s", grant.Namespace, quotaKey(grant.Spec.Resource))
+	}
+}
+
+// -- applyGrants sets the quota to its base, as set by its owner, plus the extra of active grants.
+// The applied extra is recorded in an annotation, so changes of the owner to the base are kept.
+func (r *NPUQuotaGrantReconciler) applyGrants(ctx context.Context, quota *corev1.ResourceQuota, extra corev1.ResourceList) error {
+	granted := corev1.ResourceList{}
+	if v := quota.Annotations[npuv1alpha1.GrantedQuotaAnnotation]; v != "" {
+		if err := json.Unmarshal([]byte(v), &granted); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", npuv1alpha1.GrantedQuotaAnnotation, err)
+		}
+	}
+	if sameResources(granted, extra) {
+		return nil
+	}
+
+	patch := client.MergeFrom(quota.DeepCopy())
+	if quota.Spec.Hard == nil {
+		quota.Spec.Hard = corev1.ResourceList{}
+	}
+	for _, key := range resourceKeys(granted, extra) {
+		hard := quota.Spec.Hard[key].DeepCopy()
+		hard.Sub(granted[key])
+		hard.Add(extra[key])
+		quota.Spec.Hard[key] = hard
+	}
+	if len(extra) == 0 {
+		delete(quota.Annotations, npuv1alpha1.GrantedQuotaAnnotation)
+	} else {
+		raw, err := json.Marshal(extra)
+		if err != nil {
+			return err
+		}
+		if quota.Annotations == nil {
+			quota.Annotations = map[string]string{}
+		}
+		quota.Annotations[npuv1alpha1.GrantedQuotaAnnotation] = string(raw)
+	}
+	logf.FromContext(ctx).Info("Applying quota grants", "quota", quota.Name, "extra", extra)
+	return r.Patch(ctx, quota, patch)
+}
+
+// -- grantQuota returns the ResourceQuota a grant applies to, or empty without one
+func grantQuota(grant *npuv1alpha1.NPUQuotaGrant, quotas []corev1.ResourceQuota) string {
+	key := quotaKey(grant.Spec.Resource)
+	var names []string
+	for _, q := range quotas {
+		if grant.Spec.QuotaName != "" && q.Name != grant.Spec.QuotaName {
+			continue
+		}
+		if _, ok := q.Spec.Hard[key]; ok || grant.Spec.QuotaName != "" {
+			names = append(names, q.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+func quotaKey(resourceName string) corev1.ResourceName {
+	return corev1.ResourceName(corev1.DefaultResourceRequestsPrefix + resourceName)
+}
+
+func sameResources(a, b corev1.ResourceList) bool {
+	for _, key := range resourceKeys(a, b) {
+		qa, qb := a[key], b[key]
+		if qa.Cmp(qb) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceKeys(lists ...corev1.ResourceList) []corev1.ResourceName {
+	set := map[corev1.ResourceName]bool{}
+	for _, l := range lists {
+		for k := range l {
+			set[k] = true
+		}
+	}
+	keys := make([]corev1.ResourceName, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// -- grantsForQuota maps a ResourceQuota to a grant of its namespace, which syncs all of them
+func (r *NPUQuotaGrantReconciler) grantsForQuota(ctx context.Context, obj client.Object) []reconcile.Request {
+	grants := &npuv1alpha1.NPUQuotaGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list quota grants", "namespace", obj.GetNamespace())
+		return nil
+	}
+	if len(grants.Items) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(&grants.Items[0])}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NPUQuotaGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&npuv1alpha1.NPUQuotaGrant{}).
+		Watches(&corev1.ResourceQuota{}, handler.EnqueueRequestsFromMapFunc(r.grantsForQuota)).
+		Named("npuquotagrant").
+		Complete(r)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("NPUQuotaGrant Controller", func() {
+	const quotaName = "accelerators"
+
+	ctx := context.Background()
+	quotaKey := types.NamespacedName{Name: quotaName, Namespace: "default"}
+	grantKey := types.NamespacedName{Name: "deadline-burst", Namespace: "default"}
+
+	hard := func() int64 {
+		quota := &corev1.ResourceQuota{}
+		Expect(k8sClient.Get(ctx, quotaKey, quota)).To(Succeed())
+		q := quota.Spec.Hard["requests.nvidia.com/gpu"]
+		return q.Value()
+	}
+
+	BeforeEach(func() {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: quotaName, Namespace: "default"},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("4")},
+			},
+		}
+		Expect(k8sClient.Create(ctx, quota)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{
+			Name: quotaName, Namespace: "default"}})).To(Succeed())
+	})
+
+	It("should raise the quota while the grant is active and revert it afterwards", func() {
+		grant := &npuv1alpha1.NPUQuotaGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: grantKey.Name, Namespace: grantKey.Namespace},
+			Spec: npuv1alpha1.NPUQuotaGrantSpec{
+				Resource: "nvidia.com/gpu",
+				Amount:   resource.MustParse("2"),
+				Duration: metav1.Duration{Duration: time.Hour},
+				Reason:   "Paper deadline",
+			},
+		}
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUQuotaGrantReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: grantKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(hard()).To(Equal(int64(6)))
+		Expect(recorder.Events).To(Receive(ContainSubstring("QuotaGranted")))
+
+		Expect(k8sClient.Get(ctx, grantKey, grant)).To(Succeed())
+		Expect(grant.Status.Phase).To(Equal(npuv1alpha1.QuotaGrantActive))
+		Expect(grant.Status.QuotaName).To(Equal(quotaName))
+
+		By("keeping changes of the quota owner to the base")
+		quota := &corev1.ResourceQuota{}
+		Expect(k8sClient.Get(ctx, quotaKey, quota)).To(Succeed())
+		quota.Spec.Hard["requests.nvidia.com/gpu"] = resource.MustParse("10")
+		Expect(k8sClient.Update(ctx, quota)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: grantKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hard()).To(Equal(int64(10)))
+
+		By("reverting the extra quota once the grant is deleted")
+		Expect(k8sClient.Delete(ctx, grant)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: grantKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hard()).To(Equal(int64(8)))
+		Expect(k8sClient.Get(ctx, quotaKey, quota)).To(Succeed())
+		Expect(quota.Annotations).NotTo(HaveKey(npuv1alpha1.GrantedQuotaAnnotation))
+	})
+
+	It("should not apply a grant that already expired", func() {
+		grant := &npuv1alpha1.NPUQuotaGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: grantKey.Name, Namespace: grantKey.Namespace},
+			Spec: npuv1alpha1.NPUQuotaGrantSpec{
+				Resource: "nvidia.com/gpu",
+				Amount:   resource.MustParse("2"),
+				Duration: metav1.Duration{Duration: time.Millisecond},
+			},
+		}
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+		time.Sleep(time.Second)
+
+		controllerReconciler := &NPUQuotaGrantReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: grantKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hard()).To(Equal(int64(4)))
+
+		Expect(k8sClient.Get(ctx, grantKey, grant)).To(Succeed())
+		Expect(grant.Status.Phase).To(Equal(npuv1alpha1.QuotaGrantExpired))
+		Expect(k8sClient.Delete(ctx, grant)).To(Succeed())
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/audit"
+	"npu-operator/internal/reservation"
+	"npu-operator/pkg/conditions"
+)
+
+// reservationPollInterval is how often active reservations recount the accelerators of their pool.
+const reservationPollInterval = time.Minute
+
+// NPUReservationReconciler reports the pool of each NPUReservation and moves it through its
+// phases. The reservation itself is enforced by the pod admission webhook.
+type NPUReservationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// FailureDomains names the node labels of the racks and power zones the pool is broken down by.
+	FailureDomains FailureDomainLabels
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npureservations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=npu.ai,resources=npureservations/status,verbs=get;update;patch
+
+// Reconcile counts the accelerators of the pool of the reservation and how many of them it holds.
+func (r *NPUReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	//-- Get CR
+	var res npuv1alpha1.NPUReservation
+	if err := r.Get(ctx, req.NamespacedName, &res); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	ctx = audit.WithCause(ctx, "NPUReservation", &res)
+	before := res.Status.DeepCopy()
+
+	//-- Phase
+	phase, next := reservation.Phase(&res, time.Now())
+	if phase != res.Status.Phase {
+		switch phase {
+		case npuv1alpha1.ReservationActive:
+			r.Recorder.Eventf(&res, corev1.EventTypeNormal, "ReservationStarted",
+				"Holding %d %s for %v", res.Spec.Count, res.Spec.Resource, res.Spec.Namespaces)
+		case npuv1alpha1.ReservationExpired:
+			r.Recorder.Eventf(&res, corev1.EventTypeNormal, "ReservationExpired",
+				"Released %d %s held for %v", res.Spec.Count, res.Spec.Resource, res.Spec.Namespaces)
+		}
+	}
+	res.Status.Phase = phase
+
+	//-- Pool
+	if err := r.countPool(ctx, &res); err != nil {
+		logger.Error(err, "failed to count the reservation pool")
+		return ctrl.Result{}, err
+	}
+
+	if !equality.Semantic.DeepEqual(before, &res.Status) {
+		if err := r.Status().Update(ctx, &res); err != nil {
+			logger.Error(err, "failed to update NPUReservation status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	switch phase {
+	case npuv1alpha1.ReservationPending:
+		return ctrl.Result{RequeueAfter: next}, nil
+	case npuv1alpha1.ReservationActive:
+		return ctrl.Result{RequeueAfter: minRequeue(next, reservationPollInterval)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// -- countPool sets the capacity, allocation and held accelerators of the pool in the status
+func (r *NPUReservationReconciler) countPool(ctx context.Context, res *npuv1alpha1.NPUReservation) error {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return err
+	}
+	models, err := reservation.NodeModels(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		return err
+	}
+	allocated := reservation.Allocated(pods.Items, res.Spec.Resource)
+
+	pool := map[string]bool{}
+	type domainKey struct{ rack, powerZone string }
+	domains := map[domainKey]*npuv1alpha1.FailureDomainCapacity{}
+	res.Status.Nodes, res.Status.Capacity, res.Status.Allocated, res.Status.Used = 0, 0, 0, 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !reservation.InPool(res, node, models[node.Name]) {
+			continue
+		}
+		pool[node.Name] = true
+		capacity := reservation.Allocatable(node, res.Spec.Resource)
+		res.Status.Nodes++
+		res.Status.Capacity += capacity
+		res.Status.Allocated += allocated[node.Name]
+
+		var key domainKey
+		key.rack, key.powerZone = r.FailureDomains.of(node)
+		domain := domains[key]
+		if domain == nil {
+			domain = &npuv1alpha1.FailureDomainCapacity{Rack: key.rack, PowerZone: key.powerZone}
+			domains[key] = domain
+		}
+		domain.Nodes++
+		domain.Capacity += capacity
+		domain.Allocated += allocated[node.Name]
+	}
+	res.Status.FailureDomains = nil
+	for _, domain := range domains {
+		res.Status.FailureDomains = append(res.Status.FailureDomains, *domain)
+	}
+	slices.SortFunc(res.Status.FailureDomains, func(a, b npuv1alpha1.FailureDomainCapacity) int {
+		return cmp.Or(cmp.Compare(a.Rack, b.Rack), cmp.Compare(a.PowerZone, b.PowerZone))
+	})
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pool[pod.Spec.NodeName] && reservation.Holds(res, pod.Namespace) &&
+			pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			res.Status.Used += reservation.Request(pod, res.Spec.Resource)
+		}
+	}
+
+	res.Status.Held = 0
+	if res.Status.Phase == npuv1alpha1.ReservationActive {
+		res.Status.Held = max(0, res.Spec.Count-res.Status.Used)
+	}
+	available := res.Status.Capacity - (res.Status.Allocated - res.Status.Used)
+	if available >= res.Spec.Count {
+		conditions.MarkTrue(res, conditions.Satisfiable, conditions.ReasonReconciled,
+			fmt.Sprintf("%d of %d %s in the pool are available to the reservation", available, res.Status.Capacity, res.Spec.Resource))
+	} else {
+		conditions.MarkFalse(res, conditions.Satisfiable, conditions.ReasonNoCapacity,
+			fmt.Sprintf("Only %d of %d %s in the pool are available to the reservation of %d",
+				max(0, available), res.Status.Capacity, res.Spec.Resource, res.Spec.Count))
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NPUReservationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&npuv1alpha1.NPUReservation{}).
+		Named("npureservation").
+		Complete(r)
+}
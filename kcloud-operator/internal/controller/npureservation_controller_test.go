@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("NPUReservation Controller", func() {
+	const (
+		nodeName = "gpu-node-reserved"
+		resName  = "launch-week"
+	)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resName}
+
+	BeforeEach(func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"pool": "inference", npuv1alpha1.RackLabel: "r7", npuv1alpha1.PowerZoneLabel: "pz-2"},
+		}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		node.Status.Allocatable = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "launch-server", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name:  "server",
+					Image: "busybox",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("3")},
+						Limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("3")},
+					},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "launch-server", Namespace: "default"}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &npuv1alpha1.NPUReservation{ObjectMeta: metav1.ObjectMeta{Name: resName}})).To(Succeed())
+	})
+
+	It("should count the pool and hold the unused part of the reservation", func() {
+		end := metav1.NewTime(time.Now().Add(time.Hour))
+		res := &npuv1alpha1.NPUReservation{
+			ObjectMeta: metav1.ObjectMeta{Name: resName},
+			Spec: npuv1alpha1.NPUReservationSpec{
+				Resource:     "nvidia.com/gpu",
+				Count:        6,
+				NodeSelector: map[string]string{"pool": "inference"},
+				Namespaces:   []string{"default"},
+				End:          &end,
+			},
+		}
+		Expect(k8sClient.Create(ctx, res)).To(Succeed())
+
+		controllerReconciler := &NPUReservationReconciler{
+			Client:         k8sClient,
+			Scheme:         k8sClient.Scheme(),
+			Recorder:       record.NewFakeRecorder(10),
+			FailureDomains: DefaultFailureDomainLabels,
+		}
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(reservationPollInterval))
+
+		Expect(k8sClient.Get(ctx, key, res)).To(Succeed())
+		Expect(res.Status.Phase).To(Equal(npuv1alpha1.ReservationActive))
+		Expect(res.Status.Nodes).To(Equal(int32(1)))
+		Expect(res.Status.Capacity).To(Equal(int64(8)))
+		Expect(res.Status.Used).To(Equal(int64(3)))
+		Expect(res.Status.Held).To(Equal(int64(3)))
+		Expect(res.Status.FailureDomains).To(Equal([]npuv1alpha1.FailureDomainCapacity{
+			{Rack: "r7", PowerZone: "pz-2", Nodes: 1, Capacity: 8, Allocated: 3},
+		}))
+		Expect(conditions.IsTrue(res, conditions.Satisfiable)).To(BeTrue())
+
+		By("releasing the accelerators once the reservation expired")
+		past := metav1.NewTime(time.Now().Add(-time.Minute))
+		res.Spec.End = &past
+		Expect(k8sClient.Update(ctx, res)).To(Succeed())
+
+		result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, key, res)).To(Succeed())
+		Expect(res.Status.Phase).To(Equal(npuv1alpha1.ReservationExpired))
+		Expect(res.Status.Held).To(BeZero())
+	})
+})
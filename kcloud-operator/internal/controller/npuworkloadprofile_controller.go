@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// NPUWorkloadProfileReconciler resolves the accelerator requests of NPUWorkloadProfiles to the
+// resources advertised by the accelerator nodes, which the pod webhook sets on their pods.
+type NPUWorkloadProfileReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npuworkloadprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=npu.ai,resources=npuworkloadprofiles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=npu.ai,resources=npunodes,verbs=get;list;watch
+
+// Reconcile maps the requests of the profile to the resources advertised in the cluster.
+func (r *NPUWorkloadProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	//-- Get CR
+	profile := &npuv1alpha1.NPUWorkloadProfile{}
+	if err := r.Get(ctx, req.NamespacedName, profile); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	//-- Inheritance from the parent profiles
+	before := profile.Status.DeepCopy()
+	previous := conditions.Get(profile, conditions.Resolved).DeepCopy()
+	spec, parents, err := r.inheritedSpec(ctx, profile)
+	profile.Status.InheritedFrom = parents
+	var unresolved *parentUnresolvedError
+	switch {
+	case errors.As(err, &unresolved):
+		// The pod webhook rejects the pods of the profile until the chain is fixed.
+		conditions.MarkFalse(profile, conditions.Resolved, conditions.ReasonParentUnresolved, unresolved.message)
+	case err != nil:
+		return ctrl.Result{}, err
+	default:
+		//-- Advertised resources
+		nodes := &npuv1alpha1.NPUNodeList{}
+		if err := r.List(ctx, nodes); err != nil {
+			return ctrl.Result{}, err
+		}
+		inherited := profile.DeepCopy()
+		inherited.Spec = spec
+		resolveProfile(inherited, nodes.Items)
+		renderPlacement(inherited)
+		profile.Status = inherited.Status
+	}
+
+	//-- Status
+	if equality.Semantic.DeepEqual(before, &profile.Status) {
+		return ctrl.Result{}, nil
+	}
+	resolved := conditions.Get(profile, conditions.Resolved)
+	switch {
+	case previous != nil && previous.Status == resolved.Status:
+	case resolved.Status == metav1.ConditionTrue:
+		r.Recorder.Event(profile, corev1.EventTypeNormal, "Resolved", resolved.Message)
+	default:
+		r.Recorder.Event(profile, corev1.EventTypeWarning, "Unresolved", resolved.Message)
+	}
+	if err := r.Status().Update(ctx, profile); err != nil {
+		logger.Error(err, "failed to update NPUWorkloadProfile status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// -- resolveProfile sets the resources of the profile from the Furiosa partitions advertised by the
+// nodes. PEs are requested in the largest partitions that divide them and that one node holds enough of.
+func resolveProfile(profile *npuv1alpha1.NPUWorkloadProfile, nodes []npuv1alpha1.NPUNode) {
+	furiosa := profile.Spec.Furiosa
+	if furiosa == nil {
+		profile.Status.Resources = nil
+		conditions.MarkTrue(profile, conditions.Resolved, conditions.ReasonReconciled, "The profile requests no accelerators")
+		return
+	}
+
+	// The most partitions of each size a single node advertises.
+	partitions := map[int32]int64{}
+	for _, node := range nodes {
+		for name, q := range node.Status.Capacity {
+			if size, ok := furiosaPartitionSize(string(name)); ok && q.Value() > partitions[size] {
+				partitions[size] = q.Value()
+			}
+		}
+	}
+	sizes := slices.Collect(maps.Keys(partitions))
+	slices.Sort(sizes)
+	slices.Reverse(sizes)
+	for _, size := range sizes {
+		count := furiosa.PEs / size
+		if furiosa.PEs%size != 0 || partitions[size] < int64(count) {
+			continue
+		}
+		name := npuv1alpha1.FuriosaPartitionResource(size)
+		profile.Status.Resources = corev1.ResourceList{corev1.ResourceName(name): *resource.NewQuantity(int64(count), resource.DecimalSI)}
+		conditions.MarkTrue(profile, conditions.Resolved, conditions.ReasonReconciled,
+			fmt.Sprintf("%d PEs are requested as %d %s", furiosa.PEs, count, name))
+		return
+	}
+	profile.Status.Resources = nil
+	conditions.MarkFalse(profile, conditions.Resolved, conditions.ReasonNoPartition,
+		fmt.Sprintf("No node advertises %d PEs as Furiosa partitions of a size dividing them", furiosa.PEs))
+}
+
+// -- renderPlacement renders the placement of the profile into the affinity and topology spread
+// constraints set on its pods. Pods of a group select each other by the profile label and the
+// value of the group label of the incoming pod.
+func renderPlacement(profile *npuv1alpha1.NPUWorkloadProfile) {
+	profile.Status.Affinity = nil
+	profile.Status.TopologySpreadConstraints = nil
+	placement := profile.Spec.Placement
+	if placement == nil {
+		return
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{npuv1alpha1.WorkloadProfileLabel: profile.Name}}
+	var groupKeys []string
+	if placement.GroupLabel != "" {
+		groupKeys = []string{placement.GroupLabel}
+	}
+
+	if placement.SameModel && profile.Spec.Furiosa != nil {
+		// The first pod of a group matches its own affinity term, so it may go to any model.
+		modelLabel := acceleratorProductLabels["furiosa.ai/"]
+		profile.Status.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: modelLabel, Operator: corev1.NodeSelectorOpExists},
+					}}},
+				},
+			},
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+					LabelSelector:  selector.DeepCopy(),
+					MatchLabelKeys: groupKeys,
+					TopologyKey:    modelLabel,
+				}},
+			},
+		}
+	}
+	if placement.SpreadAcross != "" {
+		profile.Status.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       placement.SpreadAcross,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     selector.DeepCopy(),
+			MatchLabelKeys:    groupKeys,
+		}}
+	}
+}
+
+// -- furiosaPartitionSize returns the PEs of a Furiosa partition resource, e.g. 2 for furiosa.ai/npu-2pe
+func furiosaPartitionSize(name string) (int32, bool) {
+	rest, ok := strings.CutPrefix(name, npuv1alpha1.FuriosaResource+"-")
+	if !ok {
+		return 0, false
+	}
+	rest, ok = strings.CutSuffix(rest, "pe")
+	if !ok {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(rest, 10, 32)
+	if err != nil || size < 1 {
+		return 0, false
+	}
+	return int32(size), true
+}
+
+// -- profilesForNPUNode maps an NPUNode to every profile, as any of them may map to its resources
+func (r *NPUWorkloadProfileReconciler) profilesForNPUNode(ctx context.Context, _ client.Object) []reconcile.Request {
+	profiles := &npuv1alpha1.NPUWorkloadProfileList{}
+	if err := r.List(ctx, profiles); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list workload profiles")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(profiles.Items))
+	for i := range profiles.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&profiles.Items[i])})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NPUWorkloadProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&npuv1alpha1.NPUWorkloadProfile{}).
+		Watches(&npuv1alpha1.NPUNode{}, handler.EnqueueRequestsFromMapFunc(r.profilesForNPUNode),
+			builder.WithPredicates(npuNodeResourcesChanged)).
+		Watches(&npuv1alpha1.NPUWorkloadProfile{}, handler.EnqueueRequestsFromMapFunc(r.profilesForParent)).
+		Named("npuworkloadprofile").
+		Complete(r)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("NPUWorkloadProfile Controller", func() {
+	ctx := context.Background()
+
+	partitionedNode := func(name string, capacity corev1.ResourceList) npuv1alpha1.NPUNode {
+		return npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     npuv1alpha1.NPUNodeStatus{Capacity: capacity},
+		}
+	}
+	pesProfile := func(pes int32) *npuv1alpha1.NPUWorkloadProfile {
+		return &npuv1alpha1.NPUWorkloadProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "inference", Namespace: "default"},
+			Spec:       npuv1alpha1.NPUWorkloadProfileSpec{Furiosa: &npuv1alpha1.FuriosaWorkload{PEs: pes}},
+		}
+	}
+
+	It("should render the partitioning into the device plugin configuration", func() {
+		Expect(furiosaPluginConfig(&npuv1alpha1.FuriosaSpec{})).To(ContainSubstring("defaultPe: Fusion"))
+		config := furiosaPluginConfig(&npuv1alpha1.FuriosaSpec{
+			Partitioning: &npuv1alpha1.FuriosaPartitioning{PEsPerPartition: 2},
+		})
+		Expect(config).To(ContainSubstring("defaultPe: Single"))
+		Expect(config).To(ContainSubstring("resourceName: furiosa.ai/npu-2pe"))
+	})
+
+	It("should render the configured settings into the device plugin configuration", func() {
+		Expect(furiosaPluginConfig(&npuv1alpha1.FuriosaSpec{})).To(Equal(
+			"defaultPe: Fusion\ndisabledDevices: []\ninterval: 10\n"))
+		Expect(furiosaPluginConfig(&npuv1alpha1.FuriosaSpec{Config: &npuv1alpha1.FuriosaPluginConfig{
+			DefaultPE:       npuv1alpha1.FuriosaPESingle,
+			Interval:        30,
+			DisabledDevices: []string{"3", "npu:0f1e"},
+		}})).To(Equal("defaultPe: Single\ndisabledDevices:\n- \"3\"\n- npu:0f1e\ninterval: 30\n"))
+	})
+
+	It("should request PEs in the largest partitions dividing them", func() {
+		nodes := []npuv1alpha1.NPUNode{
+			partitionedNode("rngd-a", corev1.ResourceList{"furiosa.ai/npu-2pe": resource.MustParse("16")}),
+			partitionedNode("rngd-b", corev1.ResourceList{"furiosa.ai/npu-4pe": resource.MustParse("1")}),
+		}
+		profile := pesProfile(4)
+		resolveProfile(profile, nodes)
+		Expect(conditions.IsTrue(profile, conditions.Resolved)).To(BeTrue())
+		Expect(profile.Status.Resources).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu-4pe"), resource.MustParse("1")))
+
+		profile = pesProfile(8)
+		resolveProfile(profile, nodes)
+		Expect(profile.Status.Resources).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu-2pe"), resource.MustParse("4")))
+
+		profile = pesProfile(3)
+		resolveProfile(profile, nodes)
+		Expect(conditions.Get(profile, conditions.Resolved).Reason).To(Equal(conditions.ReasonNoPartition))
+		Expect(profile.Status.Resources).To(BeEmpty())
+	})
+
+	It("should render the placement of the profile", func() {
+		profile := pesProfile(2)
+		profile.Spec.Placement = &npuv1alpha1.WorkloadPlacement{
+			SameModel:    true,
+			SpreadAcross: "topology.kubernetes.io/zone",
+			GroupLabel:   "job-name",
+		}
+		renderPlacement(profile)
+		terms := profile.Status.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		Expect(terms).To(HaveLen(1))
+		Expect(terms[0].TopologyKey).To(Equal("furiosa.ai/npu.product"))
+		Expect(terms[0].MatchLabelKeys).To(Equal([]string{"job-name"}))
+		Expect(terms[0].LabelSelector.MatchLabels).To(HaveKeyWithValue(npuv1alpha1.WorkloadProfileLabel, "inference"))
+		Expect(profile.Status.TopologySpreadConstraints).To(ConsistOf(HaveField("TopologyKey", "topology.kubernetes.io/zone")))
+
+		profile.Spec.Placement = nil
+		renderPlacement(profile)
+		Expect(profile.Status.Affinity).To(BeNil())
+		Expect(profile.Status.TopologySpreadConstraints).To(BeEmpty())
+	})
+
+	It("should resolve profiles against the NPUNodes of the cluster", func() {
+		node := partitionedNode("rngd-profile", nil)
+		Expect(k8sClient.Create(ctx, &node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"furiosa.ai/npu-2pe": resource.MustParse("4")}
+		Expect(k8sClient.Status().Update(ctx, &node)).To(Succeed())
+		profile := pesProfile(2)
+		Expect(k8sClient.Create(ctx, profile)).To(Succeed())
+
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUWorkloadProfileReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(profile)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(profile), profile)).To(Succeed())
+		Expect(conditions.IsTrue(profile, conditions.Resolved)).To(BeTrue())
+		Expect(profile.Status.Resources).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu-2pe"), resource.MustParse("1")))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Resolved")))
+
+		Expect(k8sClient.Delete(ctx, profile)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &node)).To(Succeed())
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	// nvidiaDevicePluginConfigName holds the configuration file of the NVIDIA device plugin.
+	nvidiaDevicePluginConfigName = "nvidia-device-plugin-config"
+	nvidiaDevicePluginConfigDir  = "/etc/nvidia-device-plugin"
+)
+
+// nvidiaDevicePluginConfigFile is the configuration file read by the NVIDIA device plugin.
+type nvidiaDevicePluginConfigFile struct {
+	Version string                     `json:"version"`
+	Sharing *npuv1alpha1.NvidiaSharing `json:"sharing,omitempty"`
+}
+
+// -- ensureNvidiaDevicePluginConfig applies the configuration file of the NVIDIA device plugin, or
+// deletes it once spec.nvidia.devicePluginConfig is unset
+func (r *NPUClusterPolicyReconciler) ensureNvidiaDevicePluginConfig(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+
+	if policy.Spec.Nvidia.DevicePluginConfig == nil {
+		return r.deleteRenderedConfigMap(ctx, policy, nvidiaDevicePluginConfigName)
+	}
+	configMap, err := nvidiaDevicePluginConfigMap(policy)
+	if err != nil {
+		return err
+	}
+	if err := r.setOwner(policy, configMap); err != nil {
+		return err
+	}
+	if err := patchObject(policy, patchKindConfigMap, configMap.Name, configMap); err != nil {
+		// The ConfigMap is left unchanged; reportPatches reports the failure.
+		r.Recorder.Event(policy, corev1.EventTypeWarning, "PatchFailed", err.Error())
+		return nil
+	}
+	if err := r.apply(ctx, policy, configMap); err != nil && !conflictIgnored(err) {
+		log.Error(err, "failed to apply nvidia device plugin configmap")
+		return err
+	}
+	return nil
+}
+
+// -- nvidiaDevicePluginConfigMap builds the ConfigMap of the configuration file of the NVIDIA device plugin
+func nvidiaDevicePluginConfigMap(policy *npuv1alpha1.NPUClusterPolicy) (*corev1.ConfigMap, error) {
+	config := policy.Spec.Nvidia.DevicePluginConfig
+	data := config.Raw
+	if config.Sharing != nil {
+		raw, err := yaml.Marshal(nvidiaDevicePluginConfigFile{Version: "v1", Sharing: config.Sharing})
+		if err != nil {
+			return nil, err
+		}
+		data = string(raw)
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nvidiaDevicePluginConfigName,
+			Namespace: componentNamespace(policy),
+			Labels:    policyLabels(policy),
+		},
+		Data: map[string]string{"config.yaml": data},
+	}, nil
+}
+
+// -- withNvidiaDevicePluginConfig mounts the configuration file into the NVIDIA device plugin
+func withNvidiaDevicePluginConfig(ds *appsv1.DaemonSet) *appsv1.DaemonSet {
+	pod := &ds.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "device-plugin-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: nvidiaDevicePluginConfigName},
+			},
+		},
+	})
+	container := &pod.Containers[0]
+	container.Args = append(container.Args, "--config-file="+nvidiaDevicePluginConfigDir+"/config.yaml")
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name: "device-plugin-config", MountPath: nvidiaDevicePluginConfigDir, ReadOnly: true,
+	})
+	return ds
+}
+
+// -- deleteRenderedConfigMap deletes a ConfigMap the policy rendered, leaving ConfigMaps of the
+// same name owned by others alone
+func (r *NPUClusterPolicyReconciler) deleteRenderedConfigMap(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, name string) error {
+	var configMap corev1.ConfigMap
+	err := r.Get(ctx, client.ObjectKey{Namespace: componentNamespace(policy), Name: name}, &configMap)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if key, ok := policyKeyFromLabels(&configMap); !ok || key != client.ObjectKeyFromObject(policy) {
+		return nil
+	}
+	if err := r.Delete(ctx, &configMap); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("NVIDIA device plugin config", func() {
+	policy := func(config *npuv1alpha1.NvidiaDevicePluginConfig) *npuv1alpha1.NPUClusterPolicy {
+		p := &npuv1alpha1.NPUClusterPolicy{}
+		p.Name = "cluster"
+		p.Namespace = "default"
+		p.Spec.Nvidia.Enabled = true
+		p.Spec.Nvidia.DevicePluginConfig = config
+		return p
+	}
+
+	It("should render the sharing settings in the format of the device plugin", func() {
+		configMap, err := nvidiaDevicePluginConfigMap(policy(&npuv1alpha1.NvidiaDevicePluginConfig{
+			Sharing: &npuv1alpha1.NvidiaSharing{TimeSlicing: &npuv1alpha1.NvidiaReplicas{
+				RenameByDefault: true,
+				Resources:       []npuv1alpha1.NvidiaReplicatedResource{{Name: "nvidia.com/gpu", Replicas: 4}},
+			}},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Name).To(Equal(nvidiaDevicePluginConfigName))
+		Expect(configMap.Namespace).To(Equal(npuv1alpha1.DefaultComponentNamespace))
+		Expect(configMap.Data).To(Equal(map[string]string{"config.yaml": `sharing:
+  timeSlicing:
+    renameByDefault: true
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 4
+version: v1
+`}))
+
+		raw := "version: v1\nflags:\n  migStrategy: mixed\n"
+		configMap, err = nvidiaDevicePluginConfigMap(policy(&npuv1alpha1.NvidiaDevicePluginConfig{Raw: raw}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data).To(HaveKeyWithValue("config.yaml", raw))
+	})
+
+	It("should mount the configuration into the device plugin only when set", func() {
+		ds := nvidiaDevicePluginDaemonSet(policy(nil), "nvcr.io/nvidia/k8s-device-plugin:v0.17.1")
+		Expect(ds.Spec.Template.Spec.Containers[0].Args).To(BeEmpty())
+
+		ds = nvidiaDevicePluginDaemonSet(policy(&npuv1alpha1.NvidiaDevicePluginConfig{Raw: "version: v1"}),
+			"nvcr.io/nvidia/k8s-device-plugin:v0.17.1")
+		container := ds.Spec.Template.Spec.Containers[0]
+		Expect(container.Args).To(Equal([]string{"--config-file=/etc/nvidia-device-plugin/config.yaml"}))
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name: "device-plugin-config", MountPath: nvidiaDevicePluginConfigDir, ReadOnly: true,
+		}))
+		Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("ConfigMap.Name", nvidiaDevicePluginConfigName)))
+	})
+
+	It("should roll the device plugin when the configuration changes", func() {
+		p := policy(&npuv1alpha1.NvidiaDevicePluginConfig{Raw: "version: v1"})
+		Expect(renderedConfig(p)).To(HaveKey(nvidiaDevicePluginConfigName))
+		p.Spec.Nvidia.DevicePluginConfig = nil
+		Expect(renderedConfig(p)).NotTo(HaveKey(nvidiaDevicePluginConfigName))
+	})
+})
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	patchKindDaemonSet = "DaemonSet"
+	patchKindConfigMap = "ConfigMap"
+)
+
+// -- patchObject applies the operations of spec.patches targeting the object, in place
+func patchObject[T any](policy *npuv1alpha1.NPUClusterPolicy, kind, name string, obj *T) error {
+	var ops []npuv1alpha1.JSONPatchOperation
+	for _, p := range policy.Spec.Patches {
+		if p.Kind == kind && p.Name == name {
+			ops = append(ops, p.Operations...)
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.DecodePatch(raw)
+	if err != nil {
+		return fmt.Errorf("invalid patch of %s %s: %w", kind, name, err)
+	}
+	doc, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if doc, err = patch.Apply(doc); err != nil {
+		return fmt.Errorf("failed to patch %s %s: %w", kind, name, err)
+	}
+
+	var patched T
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return fmt.Errorf("patched %s %s is invalid: %w", kind, name, err)
+	}
+	*obj = patched
+	return nil
+}
+
+// -- reportPatches sets the PatchesApplied condition from the patches that matched no
+// rendered object or failed to apply during this reconcile
+func reportPatches(policy *npuv1alpha1.NPUClusterPolicy) {
+	if len(policy.Spec.Patches) == 0 {
+		conditions.Remove(policy, conditions.PatchesApplied)
+		return
+	}
+
+	var failures []string
+	targets := map[string]bool{}
+	for _, c := range enabledComponents(policy) {
+		targets[patchKindDaemonSet+"/"+c.name] = true
+		if cond := conditions.Get(policy, conditions.ComponentReady(c.name)); cond != nil &&
+			cond.Reason == conditions.ReasonPatchFailed {
+			failures = append(failures, cond.Message)
+		}
+	}
+	if policy.Spec.Nvidia.Enabled && policy.Spec.Nvidia.DevicePluginConfig != nil {
+		targets[patchKindConfigMap+"/"+nvidiaDevicePluginConfigName] = true
+		if configMap, err := nvidiaDevicePluginConfigMap(policy); err != nil {
+			failures = append(failures, err.Error())
+		} else if err := patchObject(policy, patchKindConfigMap, configMap.Name, configMap); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if policy.Spec.Furiosa.Enabled {
+		name := policy.Spec.Furiosa.ConfigMapName
+		targets[patchKindConfigMap+"/"+name] = true
+		if err := patchObject(policy, patchKindConfigMap, name, furiosaConfigMap(policy)); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	for _, p := range policy.Spec.Patches {
+		if !targets[p.Kind+"/"+p.Name] {
+			failures = append(failures, fmt.Sprintf("no rendered %s is named %s", p.Kind, p.Name))
+		}
+	}
+
+	if len(failures) > 0 {
+		conditions.MarkFalse(policy, conditions.PatchesApplied, conditions.ReasonPatchFailed, strings.Join(failures, "; "))
+		return
+	}
+	conditions.MarkTrue(policy, conditions.PatchesApplied, conditions.ReasonReconciled,
+		fmt.Sprintf("Applied %d patches", len(policy.Spec.Patches)))
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Object patches", func() {
+	const resourceName = "patches"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.1"},
+					},
+				},
+				Patches: []npuv1alpha1.ObjectPatch{{
+					Kind: "DaemonSet",
+					Name: nvidiaDevicePluginName,
+					Operations: []npuv1alpha1.JSONPatchOperation{{
+						Op:    "add",
+						Path:  "/spec/template/spec/priorityClassName",
+						Value: &apiextensionsv1.JSON{Raw: []byte(`"system-node-critical"`)},
+					}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should apply patches to the rendered objects and report failures", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		plugin := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, plugin)).To(Succeed())
+		Expect(plugin.Spec.Template.Spec.PriorityClassName).To(Equal("system-node-critical"))
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsTrue(policy, conditions.PatchesApplied)).To(BeTrue())
+
+		By("removing a field that does not exist")
+		policy.Spec.Patches[0].Operations = append(policy.Spec.Patches[0].Operations,
+			npuv1alpha1.JSONPatchOperation{Op: "remove", Path: "/spec/template/spec/missing"})
+		policy.Spec.Patches = append(policy.Spec.Patches, npuv1alpha1.ObjectPatch{
+			Kind:       "ConfigMap",
+			Name:       "unknown",
+			Operations: []npuv1alpha1.JSONPatchOperation{{Op: "remove", Path: "/data"}},
+		})
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsFalse(policy, conditions.ComponentReady(nvidiaDevicePluginName))).To(BeTrue())
+		patched := conditions.Get(policy, conditions.PatchesApplied)
+		Expect(patched).NotTo(BeNil())
+		Expect(patched.Status).To(Equal(metav1.ConditionFalse))
+		Expect(patched.Message).To(ContainSubstring("no rendered ConfigMap is named unknown"))
+	})
+})
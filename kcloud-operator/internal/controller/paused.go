@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// -- reconcilePaused only reports the status of a paused policy: the health of its DaemonSets
+// and the summary conditions. No object is created, updated or deleted.
+func (r *NPUClusterPolicyReconciler) reconcilePaused(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	tally *applyTally, before []metav1.Condition) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	statusBefore := policy.Status.DeepCopy()
+
+	if !conditions.IsTrue(policy, conditions.Paused) {
+		r.Recorder.Event(policy, corev1.EventTypeNormal, "Paused", "Reconciliation is paused, components are left as they are")
+	}
+	conditions.MarkTrue(policy, conditions.Paused, conditions.ReasonPaused, "spec.paused is set, components are not changed")
+
+	progress, requeue, err := r.observeDaemonSets(ctx, policy)
+	if err != nil {
+		log.Error(err, "failed to observe component DaemonSets")
+		return ctrl.Result{}, err
+	}
+	summarizeConditions(policy, progress)
+	summarizeReconcile(policy, tally, nil)
+
+	if !equality.Semantic.DeepEqual(statusBefore, &policy.Status) {
+		if err := r.Status().Update(ctx, policy); err != nil {
+			log.Error(err, "failed to update NPUClusterPolicy status")
+			return ctrl.Result{}, err
+		}
+	}
+	r.notifyTransitions(ctx, policy, before)
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// -- resume clears the Paused condition once spec.paused is unset
+func (r *NPUClusterPolicyReconciler) resume(policy *npuv1alpha1.NPUClusterPolicy) {
+	if conditions.Get(policy, conditions.Paused) == nil {
+		return
+	}
+	conditions.Remove(policy, conditions.Paused)
+	r.Recorder.Event(policy, corev1.EventTypeNormal, "Resumed", "Reconciliation resumed")
+}
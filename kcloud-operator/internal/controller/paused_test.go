@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Paused policies", func() {
+	const resourceName = "paused"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	pluginKey := types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "kube-system"}
+
+	It("should leave manual changes alone while paused", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "paused-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		recorder := record.NewFakeRecorder(20)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("pausing the policy and changing the device plugin by hand")
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Paused = true
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		ds := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, ds)).To(Succeed())
+		ds.Spec.Template.Spec.Containers[0].Image = "debug/k8s-device-plugin:dev"
+		Expect(k8sClient.Update(ctx, ds)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, pluginKey, ds)).To(Succeed())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("debug/k8s-device-plugin:dev"))
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.IsTrue(policy, conditions.Paused)).To(BeTrue())
+		Expect(findComponentStatus(policy, furiosaDevicePluginName).Pods).NotTo(BeNil())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Paused")))
+
+		By("resuming the policy")
+		policy.Spec.Paused = false
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, pluginKey, ds)).To(Succeed())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("ghcr.io/furiosa-ai/k8s-device-plugin:0.10.1"))
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(conditions.Get(policy, conditions.Paused)).To(BeNil())
+
+		deletePolicy(ctx, policy)
+	})
+})
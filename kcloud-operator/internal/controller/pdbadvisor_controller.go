@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// acceleratorResourcePrefixes are the extended resource domains of the supported vendors.
+var acceleratorResourcePrefixes = []string{"nvidia.com/", "furiosa.ai/"}
+
+// PDBAdvisorReconciler warns about accelerator workloads that run on nodes subject to
+// managed disruptions without a PodDisruptionBudget, and optionally creates one.
+type PDBAdvisorReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	// NodeSelector selects the nodes whose pools are drained by managed maintenance.
+	NodeSelector labels.Selector
+}
+
+// +kubebuilder:rbac:groups="",resources=pods;nodes;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets;deployments;statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create
+
+// Reconcile checks that the workload owning an accelerator pod is covered by a PodDisruptionBudget.
+func (r *PDBAdvisorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if pod.Spec.NodeName == "" || !requestsAccelerator(pod) {
+		return ctrl.Result{}, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !r.NodeSelector.Matches(labels.Set(node.Labels)) {
+		return ctrl.Result{}, nil
+	}
+
+	workload, selector, err := r.owningWorkload(ctx, pod)
+	if err != nil || workload == nil {
+		return ctrl.Result{}, err
+	}
+
+	covered, err := r.hasPDB(ctx, pod)
+	if err != nil || covered {
+		return ctrl.Result{}, err
+	}
+
+	autoCreate, err := r.autoPDBEnabled(ctx, workload)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !autoCreate {
+		r.Recorder.Eventf(workload, corev1.EventTypeWarning, "MissingPodDisruptionBudget",
+			"Accelerator pods run on node %s which is subject to managed disruptions, but no PodDisruptionBudget covers them",
+			node.Name)
+		return ctrl.Result{}, nil
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workload.GetName() + "-npu",
+			Namespace: workload.GetNamespace(),
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "npu-operator"},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       selector,
+		},
+	}
+	if err := controllerutil.SetControllerReference(workload, pdb, r.Scheme()); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, pdb); err != nil && !apierrors.IsAlreadyExists(err) {
+		log.Error(err, "failed to create poddisruptionbudget", "workload", client.ObjectKeyFromObject(workload))
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(workload, corev1.EventTypeNormal, "PodDisruptionBudgetCreated",
+		"Created PodDisruptionBudget %s with maxUnavailable=1", pdb.Name)
+	return ctrl.Result{}, nil
+}
+
+// -- owningWorkload resolves the Deployment or StatefulSet that manages the pod
+func (r *PDBAdvisorReconciler) owningWorkload(ctx context.Context, pod *corev1.Pod) (client.Object, *metav1.LabelSelector, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil, nil, nil
+	}
+	switch ref.Kind {
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, sts); err != nil {
+			return nil, nil, client.IgnoreNotFound(err)
+		}
+		return sts, sts.Spec.Selector, nil
+	case "ReplicaSet":
+		rs := &appsv1.ReplicaSet{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, rs); err != nil {
+			return nil, nil, client.IgnoreNotFound(err)
+		}
+		rsRef := metav1.GetControllerOf(rs)
+		if rsRef == nil || rsRef.Kind != "Deployment" {
+			return nil, nil, nil
+		}
+		deploy := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: rsRef.Name}, deploy); err != nil {
+			return nil, nil, client.IgnoreNotFound(err)
+		}
+		return deploy, deploy.Spec.Selector, nil
+	}
+	// Jobs and bare pods are not meant to be protected by budgets.
+	return nil, nil, nil
+}
+
+// -- hasPDB reports whether any PodDisruptionBudget in the namespace selects the pod
+func (r *PDBAdvisorReconciler) hasPDB(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := r.List(ctx, pdbs, client.InNamespace(pod.Namespace)); err != nil {
+		return false, err
+	}
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// -- autoPDBEnabled checks the auto-pdb opt-in on the workload and on its namespace
+func (r *PDBAdvisorReconciler) autoPDBEnabled(ctx context.Context, workload client.Object) (bool, error) {
+	if workload.GetLabels()[npuv1alpha1.AutoPDBLabel] == "true" {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: workload.GetNamespace()}, ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return ns.Labels[npuv1alpha1.AutoPDBLabel] == "true", nil
+}
+
+// -- requestsAccelerator reports whether any container of the pod requests an accelerator resource
+func requestsAccelerator(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		for name := range c.Resources.Limits {
+			for _, prefix := range acceleratorResourcePrefixes {
+				if strings.HasPrefix(string(name), prefix) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PDBAdvisorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			pod, ok := o.(*corev1.Pod)
+			return ok && requestsAccelerator(pod)
+		}))).
+		Named("pdbadvisor").
+		Complete(r)
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// devicePluginDir is the kubelet directory holding the registration sockets of device plugins.
+const devicePluginDir = "/var/lib/kubelet/device-plugins"
+
+// -- pluginRegistrationFree reports whether the device plugin may be applied. It is held back when a
+// DaemonSet the operator does not manage already registers the same resource on the same nodes, as
+// the kubelet would keep replacing one plugin's registration with the other's, unless the policy
+// adopts existing plugins.
+func (r *NPUClusterPolicyReconciler) pluginRegistrationFree(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	desired *appsv1.DaemonSet) (bool, error) {
+	log := logf.FromContext(ctx)
+	if desired.Name != nvidiaDevicePluginName && desired.Name != furiosaDevicePluginName {
+		return true, nil
+	}
+	vendor, _, _ := strings.Cut(desired.Name, "-")
+	suffix := pluginSuffix(&desired.Spec.Template.Spec)
+
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, daemonSets); err != nil {
+		return false, err
+	}
+	existing := &appsv1.DaemonSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		existing = nil
+	}
+
+	for i := range daemonSets.Items {
+		other := &daemonSets.Items[i]
+		if foreignPluginVendor(other) != vendor || pluginSuffix(&other.Spec.Template.Spec) != suffix ||
+			!nodeSelectorsOverlap(desired.Spec.Template.Spec.NodeSelector, other.Spec.Template.Spec.NodeSelector) {
+			continue
+		}
+		if policy.Spec.AdoptExisting {
+			if err := r.adoptPlugin(ctx, policy, desired, other); err != nil {
+				return false, err
+			}
+			continue
+		}
+		message := fmt.Sprintf("DaemonSet %s registers the same %s resources as %s on the same nodes",
+			client.ObjectKeyFromObject(other), vendor, desired.Name)
+		if existing != nil && existing.CreationTimestamp.Before(&other.CreationTimestamp) {
+			// The other plugin came second; it is not ours to stop, so it is only reported.
+			r.Recorder.Event(policy, corev1.EventTypeWarning, "PluginConflict", message+"; remove it to stop registration flapping")
+			continue
+		}
+		log.Info("Device plugin conflicts with an existing plugin, holding it back", "component", desired.Name,
+			"daemonSet", client.ObjectKeyFromObject(other))
+		if cond := conditions.Get(policy, conditions.ComponentReady(desired.Name)); cond == nil ||
+			cond.Reason != conditions.ReasonPluginConflict {
+			r.Recorder.Event(policy, corev1.EventTypeWarning, "PluginConflict",
+				message+"; the plugin is not applied unless spec.adoptExisting is set")
+		}
+		conditions.MarkFalse(policy, conditions.ComponentReady(desired.Name), conditions.ReasonPluginConflict, message)
+		return false, nil
+	}
+	return true, nil
+}
+
+// -- adoptPlugin takes over a device plugin DaemonSet installed by other means. A DaemonSet with the
+// name and selector of the desired one is relabeled and given the desired pod template; any other
+// is deleted and replaced by the desired one.
+func (r *NPUClusterPolicyReconciler) adoptPlugin(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	desired, other *appsv1.DaemonSet) error {
+	log := logf.FromContext(ctx)
+	key := client.ObjectKeyFromObject(other)
+	if key == client.ObjectKeyFromObject(desired) && equality.Semantic.DeepEqual(other.Spec.Selector, desired.Spec.Selector) {
+		log.Info("Adopting existing device plugin", "daemonSet", key)
+		// The pod template is replaced as a whole, as applying it would merge in the containers
+		// and volumes the other installer set.
+		other.Labels = mergeLabels(other.Labels, policyLabels(policy))
+		other.Spec.Template = *desired.Spec.Template.DeepCopy()
+		if err := r.Update(ctx, other, client.FieldOwner(fieldOwner)); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "PluginAdopted", "Adopted DaemonSet %s, it is reconciled to the policy", key)
+		return nil
+	}
+
+	log.Info("Replacing existing device plugin", "daemonSet", key, "component", desired.Name)
+	if err := r.Delete(ctx, other, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "PluginAdopted", "Deleted DaemonSet %s, it is replaced by %s",
+		key, client.ObjectKeyFromObject(desired))
+	return nil
+}
+
+// -- reportPluginConflicts sets PluginConflict from the device plugins held back by another plugin
+func reportPluginConflicts(policy *npuv1alpha1.NPUClusterPolicy) {
+	var conflicts []string
+	for _, c := range enabledComponents(policy) {
+		if cond := conditions.Get(policy, conditions.ComponentReady(c.name)); cond != nil &&
+			cond.Reason == conditions.ReasonPluginConflict {
+			conflicts = append(conflicts, cond.Message)
+		}
+	}
+	if len(conflicts) == 0 {
+		conditions.Remove(policy, conditions.PluginConflict)
+		return
+	}
+	message := strings.Join(conflicts, "; ")
+	conditions.MarkTrue(policy, conditions.PluginConflict, conditions.ReasonPluginConflict, message)
+	conditions.MarkTrue(policy, conditions.Degraded, conditions.ReasonPluginConflict, message)
+}
+
+// -- foreignPluginVendor returns the vendor whose resources a DaemonSet not managed by the operator
+// registers, or "" when it is no device plugin. Plugins are told by the kubelet device plugin
+// directory they mount and their vendor by the images they run.
+func foreignPluginVendor(ds *appsv1.DaemonSet) string {
+	if ds.Labels["app.kubernetes.io/managed-by"] == "npu-operator" {
+		return ""
+	}
+	spec := &ds.Spec.Template.Spec
+	mounted := false
+	for _, v := range spec.Volumes {
+		if v.HostPath == nil {
+			continue
+		}
+		dir := path.Clean(v.HostPath.Path)
+		if dir == devicePluginDir || (dir != "/" && strings.HasPrefix(devicePluginDir, dir+"/")) {
+			mounted = true
+			break
+		}
+	}
+	if !mounted {
+		return ""
+	}
+	for _, c := range spec.Containers {
+		for _, vendor := range acceleratorVendors {
+			if strings.Contains(strings.ToLower(c.Image), vendor) {
+				return vendor
+			}
+		}
+	}
+	return ""
+}
+
+// -- pluginSuffix returns the suffix the plugin appends to its resource and socket names
+func pluginSuffix(spec *corev1.PodSpec) string {
+	for _, c := range spec.Containers {
+		for _, env := range c.Env {
+			if env.Name == resourceSuffixEnv {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
+
+// -- nodeSelectorsOverlap reports whether a node may match both selectors, which fails only when
+// they require different values of the same label
+func nodeSelectorsOverlap(a, b map[string]string) bool {
+	for key, value := range a {
+		if other, ok := b[key]; ok && other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// -- enqueuePluginConflicts enqueues the policies holding back a device plugin, so it is applied
+// once the other plugin is removed or changed
+func (r *NPUClusterPolicyReconciler) enqueuePluginConflicts(ctx context.Context, obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	ds, ok := obj.(*appsv1.DaemonSet)
+	if !ok || foreignPluginVendor(ds) == "" {
+		return
+	}
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list policies for device plugin conflicts")
+		return
+	}
+	for i := range policies.Items {
+		if conditions.IsTrue(&policies.Items[i], conditions.PluginConflict) {
+			q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policies.Items[i])})
+		}
+	}
+}
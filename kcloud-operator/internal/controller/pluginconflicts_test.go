@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Device plugin conflicts", func() {
+	ctx := context.Background()
+
+	foreignPlugin := func(image, hostPath string, env ...corev1.EnvVar) *appsv1.DaemonSet {
+		labels := map[string]string{"app": "foreign-device-plugin"}
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "foreign-device-plugin", Namespace: "default"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "plugin", Image: image, Env: env}},
+						Volumes: []corev1.Volume{{
+							Name:         "device-plugin",
+							VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: hostPath}},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	nvidiaPolicy := func(name string) *npuv1alpha1.NPUClusterPolicy {
+		return &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "nvcr.io/nvidia/k8s-device-plugin", Version: "v0.17.0"},
+					},
+				},
+			},
+		}
+	}
+
+	It("should recognize plugins of other installations", func() {
+		Expect(foreignPluginVendor(foreignPlugin("nvcr.io/nvidia/k8s-device-plugin:v0.17.0", devicePluginDir))).
+			To(Equal("nvidia"))
+		Expect(foreignPluginVendor(foreignPlugin("ghcr.io/furiosa-ai/k8s-device-plugin", "/var/lib/kubelet/"))).
+			To(Equal("furiosa"))
+		Expect(foreignPluginVendor(foreignPlugin("nvcr.io/nvidia/dcgm-exporter", "/run/nvidia"))).To(BeEmpty())
+		Expect(foreignPluginVendor(foreignPlugin("nvcr.io/nvidia/toolkit", "/"))).To(BeEmpty())
+
+		managed := foreignPlugin("nvcr.io/nvidia/k8s-device-plugin", devicePluginDir)
+		managed.Labels = map[string]string{"app.kubernetes.io/managed-by": "npu-operator"}
+		Expect(foreignPluginVendor(managed)).To(BeEmpty())
+
+		Expect(nodeSelectorsOverlap(nvidiaNodeLabels, nil)).To(BeTrue())
+		Expect(nodeSelectorsOverlap(map[string]string{"pool": "a"}, map[string]string{"pool": "b"})).To(BeFalse())
+	})
+
+	It("should hold back a plugin registering the same resource as an existing plugin", func() {
+		foreign := foreignPlugin("nvcr.io/nvidia/k8s-device-plugin:v0.17.0", devicePluginDir)
+		Expect(k8sClient.Create(ctx, foreign)).To(Succeed())
+		policy := nvidiaPolicy("plugin-conflict")
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		recorder := record.NewFakeRecorder(50)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		reconcilePolicy := func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		}
+		pluginKey := types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"}
+
+		reconcilePolicy()
+		Expect(conditions.IsTrue(policy, conditions.PluginConflict)).To(BeTrue())
+		Expect(conditions.Get(policy, conditions.PluginConflict).Message).To(ContainSubstring("default/foreign-device-plugin"))
+		Expect(conditions.Get(policy, conditions.ComponentReady(nvidiaDevicePluginName)).Reason).
+			To(Equal(conditions.ReasonPluginConflict))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("PluginConflict")))
+		err := k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		By("configuring the other plugin with a resource suffix")
+		foreign.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: resourceSuffixEnv, Value: "-shared"}}
+		Expect(k8sClient.Update(ctx, foreign)).To(Succeed())
+		reconcilePolicy()
+		Expect(conditions.Get(policy, conditions.PluginConflict)).To(BeNil())
+		Expect(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, foreign)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should let the plugins of different vendors share nodes", func() {
+		furiosa := foreignPlugin("ghcr.io/furiosa-ai/k8s-device-plugin:latest", devicePluginDir)
+		Expect(k8sClient.Create(ctx, furiosa)).To(Succeed())
+		policy := nvidiaPolicy("plugin-other-vendor")
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(50),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(conditions.Get(policy, conditions.PluginConflict)).To(BeNil())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nvidiaDevicePluginName, Namespace: "kube-system"},
+			&appsv1.DaemonSet{})).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, furiosa)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should adopt existing plugins when requested", func() {
+		sameName := foreignPlugin("nvcr.io/nvidia/k8s-device-plugin:v0.14.0", devicePluginDir)
+		sameName.ObjectMeta = metav1.ObjectMeta{Name: nvidiaDevicePluginName, Namespace: "kube-system",
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "Helm"}}
+		labels := map[string]string{"app.kubernetes.io/name": nvidiaDevicePluginName}
+		sameName.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		sameName.Spec.Template.Labels = labels
+		Expect(k8sClient.Create(ctx, sameName)).To(Succeed())
+		other := foreignPlugin("nvcr.io/nvidia/k8s-device-plugin:v0.14.0", devicePluginDir)
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+
+		policy := nvidiaPolicy("plugin-adoption")
+		policy.Spec.AdoptExisting = true
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		recorder := record.NewFakeRecorder(50)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("PluginAdopted")))
+
+		adopted := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sameName), adopted)).To(Succeed())
+		Expect(adopted.UID).To(Equal(sameName.UID))
+		Expect(adopted.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyNameLabel, "plugin-adoption"))
+		Expect(adopted.Spec.Template.Spec.Containers).To(HaveLen(1))
+		Expect(adopted.Spec.Template.Spec.Containers[0].Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.0"))
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(other), &appsv1.DaemonSet{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(conditions.Get(policy, conditions.PluginConflict)).To(BeNil())
+		deletePolicy(ctx, policy)
+	})
+})
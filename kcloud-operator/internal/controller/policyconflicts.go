@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// -- olderPolicy orders policies by creation, then by namespace and name, so exactly one wins a conflict
+func olderPolicy(a, b *npuv1alpha1.NPUClusterPolicy) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return client.ObjectKeyFromObject(a).String() < client.ObjectKeyFromObject(b).String()
+}
+
+// -- conflictingPolicy returns the oldest other policy managing any DaemonSet the policy manages,
+// with the DaemonSets they share, or nil when the policy is the oldest of them
+func (r *NPUClusterPolicyReconciler) conflictingPolicy(ctx context.Context,
+	policy *npuv1alpha1.NPUClusterPolicy) (*npuv1alpha1.NPUClusterPolicy, []string, error) {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return nil, nil, err
+	}
+	names := r.managedDaemonSetNames(policy)
+
+	var winner *npuv1alpha1.NPUClusterPolicy
+	var shared []string
+	for i := range policies.Items {
+		other := &policies.Items[i]
+		if other.UID == policy.UID || !other.DeletionTimestamp.IsZero() || !olderPolicy(other, policy) {
+			continue
+		}
+		var common []string
+		for _, name := range r.managedDaemonSetNames(other) {
+			if slices.Contains(names, name) {
+				common = append(common, name)
+			}
+		}
+		if len(common) > 0 && (winner == nil || olderPolicy(other, winner)) {
+			winner, shared = other, common
+		}
+	}
+	return winner, shared, nil
+}
+
+// -- markConflicted reports that an older policy manages the same DaemonSets, which this policy leaves alone
+func (r *NPUClusterPolicyReconciler) markConflicted(policy, winner *npuv1alpha1.NPUClusterPolicy, shared []string) {
+	message := fmt.Sprintf("NPUClusterPolicy %s is older and manages the same DaemonSets: %s",
+		client.ObjectKeyFromObject(winner), strings.Join(shared, ", "))
+	if !conditions.IsTrue(policy, conditions.Conflicted) {
+		r.Recorder.Event(policy, corev1.EventTypeWarning, "PolicyConflict", message+"; this policy is not applied")
+	}
+	conditions.MarkTrue(policy, conditions.Conflicted, conditions.ReasonPolicyConflict, message)
+	conditions.MarkTrue(policy, conditions.Degraded, conditions.ReasonPolicyConflict, message)
+	conditions.MarkFalse(policy, conditions.Ready, conditions.ReasonPolicyConflict, "The policy conflicts with an older policy")
+	policy.Status.Phase = npuv1alpha1.PolicyDegraded
+}
+
+// -- policiesInConflict maps a changed or deleted policy to the policies that lost a conflict, as
+// the change may resolve their conflict
+func (r *NPUClusterPolicyReconciler) policiesInConflict(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list conflicting policies")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		if p.UID != obj.GetUID() && conditions.IsTrue(p, conditions.Conflicted) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(p)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Conflicting policies", func() {
+	ctx := context.Background()
+
+	newPolicy := func(name string) *npuv1alpha1.NPUClusterPolicy {
+		return &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "conflict-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+	}
+
+	It("should only apply the oldest of the policies managing the same DaemonSets", func() {
+		older, newer := newPolicy("conflict-a"), newPolicy("conflict-b")
+		Expect(k8sClient.Create(ctx, older)).To(Succeed())
+		Expect(k8sClient.Create(ctx, newer)).To(Succeed())
+		recorder := record.NewFakeRecorder(20)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		reconcilePolicy := func(policy *npuv1alpha1.NPUClusterPolicy) {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		}
+		pluginKey := types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "kube-system"}
+
+		reconcilePolicy(older)
+		reconcilePolicy(newer)
+		Expect(conditions.IsTrue(older, conditions.Conflicted)).To(BeFalse())
+		Expect(conditions.IsTrue(newer, conditions.Conflicted)).To(BeTrue())
+		Expect(conditions.Get(newer, conditions.Conflicted).Message).To(ContainSubstring("default/conflict-a"))
+		Expect(conditions.IsTrue(newer, conditions.Degraded)).To(BeTrue())
+		Expect(newer.Status.Phase).To(Equal(npuv1alpha1.PolicyDegraded))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("PolicyConflict")))
+
+		ds := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, pluginKey, ds)).To(Succeed())
+		Expect(ds.Labels[npuv1alpha1.PolicyNameLabel]).To(Equal("conflict-a"))
+
+		By("deleting the older policy")
+		deletePolicy(ctx, older)
+		reconcilePolicy(newer)
+		Expect(conditions.Get(newer, conditions.Conflicted)).To(BeNil())
+		Expect(k8sClient.Get(ctx, pluginKey, ds)).To(Succeed())
+		Expect(ds.Labels[npuv1alpha1.PolicyNameLabel]).To(Equal("conflict-b"))
+
+		deletePolicy(ctx, newer)
+	})
+})
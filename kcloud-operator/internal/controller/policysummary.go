@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// vendorConditions maps the vendor condition of a policy to its components, and its device
+// plugin to the status field counting the nodes it is ready on.
+var vendorConditions = []struct {
+	condition  conditions.ConditionType
+	display    string
+	enabled    func(policy *npuv1alpha1.NPUClusterPolicy) bool
+	components func(policy *npuv1alpha1.NPUClusterPolicy) []component
+	plugin     string
+	ready      func(status *npuv1alpha1.NPUClusterPolicyStatus) *string
+}{
+	{conditions.NvidiaReady, "NVIDIA", func(p *npuv1alpha1.NPUClusterPolicy) bool { return p.Spec.Nvidia.Enabled }, nvidiaComponents,
+		nvidiaDevicePluginName, func(s *npuv1alpha1.NPUClusterPolicyStatus) *string { return &s.NvidiaReady }},
+	{conditions.FuriosaReady, "Furiosa", func(p *npuv1alpha1.NPUClusterPolicy) bool { return p.Spec.Furiosa.Enabled }, furiosaComponents,
+		furiosaDevicePluginName, func(s *npuv1alpha1.NPUClusterPolicyStatus) *string { return &s.FuriosaReady }},
+}
+
+// -- pluginReady formats the ready nodes of the device plugin out of its desired nodes, empty while
+// the pods of the plugin were not observed
+func pluginReady(policy *npuv1alpha1.NPUClusterPolicy, plugin string) string {
+	status := findComponentStatus(policy, plugin)
+	if status == nil || status.Pods == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", status.Pods.Ready, status.Pods.Desired)
+}
+
+// -- summarizeConditions derives the vendor, Degraded, Progressing and Ready conditions, the phase and
+// the ready device plugins from the component conditions set during this reconcile, the progress of the
+// components not ready yet and the observed pods
+func summarizeConditions(policy *npuv1alpha1.NPUClusterPolicy, progress []string) {
+	rollingOut := len(progress) > 0
+	var failures []string
+	for _, v := range vendorConditions {
+		*v.ready(&policy.Status) = ""
+		if !v.enabled(policy) {
+			conditions.MarkFalse(policy, v.condition, conditions.ReasonDisabled,
+				fmt.Sprintf("%s components are disabled", v.display))
+			continue
+		}
+		*v.ready(&policy.Status) = pluginReady(policy, v.plugin)
+		var failed []string
+		reason := conditions.ReasonReconciled
+		for _, c := range v.components(policy) {
+			if !c.enabled {
+				continue
+			}
+			cond := conditions.Get(policy, conditions.ComponentReady(c.name))
+			if cond == nil || cond.Status != metav1.ConditionFalse {
+				continue
+			}
+			if len(failed) == 0 {
+				reason = cond.Reason
+			}
+			failed = append(failed, fmt.Sprintf("%s: %s", c.name, cond.Message))
+		}
+		if len(failed) > 0 {
+			conditions.MarkFalse(policy, v.condition, reason, strings.Join(failed, "; "))
+			failures = append(failures, failed...)
+			continue
+		}
+		conditions.MarkTrue(policy, v.condition, conditions.ReasonReconciled,
+			fmt.Sprintf("%s components are deployed", v.display))
+	}
+
+	if len(failures) > 0 {
+		conditions.MarkTrue(policy, conditions.Degraded, conditions.ReasonReconcileFailed, strings.Join(failures, "; "))
+	} else {
+		conditions.MarkFalse(policy, conditions.Degraded, conditions.ReasonReconciled, "")
+	}
+	if rollingOut {
+		conditions.MarkTrue(policy, conditions.Progressing, conditions.ReasonRollingOut,
+			"Waiting for "+strings.Join(progress, "; "))
+	} else {
+		conditions.MarkFalse(policy, conditions.Progressing, conditions.ReasonReconciled, "")
+	}
+
+	switch {
+	case len(failures) > 0:
+		policy.Status.Phase = npuv1alpha1.PolicyDegraded
+		conditions.MarkFalse(policy, conditions.Ready, conditions.ReasonReconcileFailed, "Components failed, see the Degraded condition")
+	case conditions.IsTrue(policy, conditions.SafeMode), conditions.IsTrue(policy, conditions.Disconnected):
+		policy.Status.Phase = npuv1alpha1.PolicyDegraded
+		conditions.MarkFalse(policy, conditions.Ready, conditions.ReasonReconcileFailed, "Components are in safe mode or disconnected")
+	case rollingOut:
+		policy.Status.Phase = npuv1alpha1.PolicyProgressing
+		conditions.MarkFalse(policy, conditions.Ready, conditions.ReasonRollingOut, "Components are rolling out")
+	default:
+		policy.Status.Phase = npuv1alpha1.PolicyReady
+		conditions.MarkTrue(policy, conditions.Ready, conditions.ReasonReconciled, "All enabled components are deployed and rolled out")
+	}
+}
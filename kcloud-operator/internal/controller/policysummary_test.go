@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Summary conditions", func() {
+	var policy *npuv1alpha1.NPUClusterPolicy
+
+	BeforeEach(func() {
+		policy = &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "summary", Generation: 3}}
+		policy.Spec.Nvidia.Enabled = true
+	})
+
+	It("should be Ready when every enabled component reconciled", func() {
+		summarizeConditions(policy, nil)
+		Expect(conditions.IsTrue(policy, conditions.Ready)).To(BeTrue())
+		Expect(conditions.IsTrue(policy, conditions.NvidiaReady)).To(BeTrue())
+		Expect(conditions.Get(policy, conditions.FuriosaReady).Reason).To(Equal(conditions.ReasonDisabled))
+		Expect(conditions.IsFalse(policy, conditions.Degraded)).To(BeTrue())
+		Expect(conditions.Get(policy, conditions.Ready).ObservedGeneration).To(Equal(int64(3)))
+		Expect(policy.Status.Phase).To(Equal(npuv1alpha1.PolicyReady))
+	})
+
+	It("should be Progressing while components roll out", func() {
+		summarizeConditions(policy, []string{"nvidia-device-plugin 1/2 ready, 2/2 updated"})
+		Expect(conditions.IsTrue(policy, conditions.Progressing)).To(BeTrue())
+		Expect(conditions.Get(policy, conditions.Progressing).Message).To(ContainSubstring("nvidia-device-plugin 1/2 ready"))
+		Expect(conditions.Get(policy, conditions.Ready).Reason).To(Equal(conditions.ReasonRollingOut))
+		Expect(policy.Status.Phase).To(Equal(npuv1alpha1.PolicyProgressing))
+	})
+
+	It("should be Degraded when a component of an enabled vendor failed", func() {
+		conditions.MarkFalse(policy, conditions.ComponentReady(nvidiaDevicePluginName), conditions.ReasonImageUnresolved, "no image")
+		summarizeConditions(policy, []string{"validating rollouts"})
+		nvidia := conditions.Get(policy, conditions.NvidiaReady)
+		Expect(nvidia.Status).To(Equal(metav1.ConditionFalse))
+		Expect(nvidia.Reason).To(Equal(conditions.ReasonImageUnresolved))
+		Expect(nvidia.Message).To(ContainSubstring(nvidiaDevicePluginName))
+		Expect(conditions.IsTrue(policy, conditions.Degraded)).To(BeTrue())
+		Expect(conditions.IsFalse(policy, conditions.Ready)).To(BeTrue())
+		Expect(policy.Status.Phase).To(Equal(npuv1alpha1.PolicyDegraded))
+	})
+
+	It("should count the nodes the device plugins of the enabled vendors are ready on", func() {
+		policy.Status.FuriosaReady = "2/2"
+		summarizeConditions(policy, nil)
+		Expect(policy.Status.NvidiaReady).To(BeEmpty())
+		Expect(policy.Status.FuriosaReady).To(BeEmpty())
+
+		policy.Spec.Furiosa.Enabled = true
+		policy.Status.Components = []npuv1alpha1.ComponentStatus{
+			{Name: nvidiaDevicePluginName, Pods: &npuv1alpha1.ComponentPods{Desired: 4, Ready: 3, Available: 3, Updated: 4}},
+			{Name: furiosaDevicePluginName},
+		}
+		summarizeConditions(policy, []string{"nvidia-device-plugin 3/4 ready, 4/4 updated"})
+		Expect(policy.Status.NvidiaReady).To(Equal("3/4"))
+		Expect(policy.Status.FuriosaReady).To(BeEmpty())
+	})
+})
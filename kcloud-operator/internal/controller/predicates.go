@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// specOrMetadataChanged passes changes of the spec, labels or annotations of a custom resource,
+// so the status updates of a controller do not trigger its own reconciles again. Deletions
+// still pass, as setting the deletion timestamp bumps the generation.
+var specOrMetadataChanged = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+)
+
+// managedOrForeignPlugin passes DaemonSets labeled with a policy and device plugins deployed
+// outside the operator, which are the only DaemonSets the policies react to.
+var managedOrForeignPlugin = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := policyKeyFromLabels(obj); ok {
+		return true
+	}
+	ds, ok := obj.(*appsv1.DaemonSet)
+	return ok && foreignPluginVendor(ds) != ""
+})
+
+// configMapContentChanged passes every event of a ConfigMap labeled with a policy, so edits of
+// rendered ConfigMaps are corrected, but only data changes of other ConfigMaps, which may be
+// referenced as extra manifests. This skips ConfigMaps whose annotations are rewritten often,
+// e.g. by leader election.
+var configMapContentChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if _, ok := policyKeyFromLabels(e.ObjectNew); ok {
+			return true
+		}
+		oldCM, okOld := e.ObjectOld.(*corev1.ConfigMap)
+		newCM, okNew := e.ObjectNew.(*corev1.ConfigMap)
+		if !okOld || !okNew {
+			return true
+		}
+		return !maps.Equal(oldCM.Data, newCM.Data) || !equality.Semantic.DeepEqual(oldCM.BinaryData, newCM.BinaryData)
+	},
+}
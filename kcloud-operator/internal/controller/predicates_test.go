@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Event predicates", func() {
+	It("should skip status-only updates of a policy", func() {
+		old := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Generation: 1}}
+		updated := old.DeepCopy()
+		updated.Status.Phase = npuv1alpha1.PolicyDegraded
+		Expect(specOrMetadataChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeFalse())
+
+		updated.Generation = 2
+		Expect(specOrMetadataChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeTrue())
+
+		annotated := old.DeepCopy()
+		annotated.Annotations = map[string]string{npuv1alpha1.ApproveRecreateAnnotation: "true"}
+		Expect(specOrMetadataChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: annotated})).To(BeTrue())
+	})
+
+	It("should only pass managed DaemonSets and foreign device plugins", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+		managed := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "managed", Labels: policyLabels(policy)}}
+		Expect(managedOrForeignPlugin.Generic(event.GenericEvent{Object: managed})).To(BeTrue())
+
+		other := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy"}}
+		Expect(managedOrForeignPlugin.Generic(event.GenericEvent{Object: other})).To(BeFalse())
+	})
+
+	It("should skip metadata-only updates of unlabeled ConfigMaps", func() {
+		old := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "leader"}, Data: map[string]string{"a": "1"}}
+		renewed := old.DeepCopy()
+		renewed.Annotations = map[string]string{"control-plane.alpha.kubernetes.io/leader": "renewed"}
+		Expect(configMapContentChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: renewed})).To(BeFalse())
+
+		edited := old.DeepCopy()
+		edited.Data["a"] = "2"
+		Expect(configMapContentChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: edited})).To(BeTrue())
+
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+		rendered := renewed.DeepCopy()
+		rendered.Labels = policyLabels(policy)
+		old.Labels = policyLabels(policy)
+		Expect(configMapContentChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: rendered})).To(BeTrue())
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	defaultPrePullTimeout = 30 * time.Minute
+	prePullPollInterval   = 15 * time.Second
+	prePullPauseImage     = "registry.k8s.io/pause:3.10"
+)
+
+// -- prePulled pre-pulls the image of a component update and reports whether the update may proceed
+func (r *NPUClusterPolicyReconciler) prePulled(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	desired *appsv1.DaemonSet, image string) (bool, error) {
+	log := logf.FromContext(ctx)
+	status := componentStatus(policy, desired.Name)
+
+	spec := policy.Spec.PrePull
+	if spec == nil || !spec.Enabled || status.Image == "" || status.Image == image {
+		if status.PrePull != nil {
+			status.PrePull = nil
+			return true, r.deletePrePullDaemonSet(ctx, policy, desired.Name)
+		}
+		return true, nil
+	}
+
+	if status.PrePull == nil || status.PrePull.Image != image {
+		log.Info("Pre-pulling component image before the update", "component", desired.Name, "image", image)
+		status.PrePull = &npuv1alpha1.PrePullStatus{Image: image, StartTime: metav1.Now()}
+	}
+	ds := prePullDaemonSet(desired)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ds, func() error {
+		built := prePullDaemonSet(desired)
+		ds.Labels = built.Labels
+		ds.Spec = built.Spec
+		return r.setOwner(policy, ds)
+	}); err != nil {
+		return false, err
+	}
+
+	timeout := defaultPrePullTimeout
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+	elapsed := time.Since(status.PrePull.StartTime.Time)
+	switch {
+	case daemonSetRolledOut(ds):
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ImagePrePulled",
+			"Pre-pulled %s on %d nodes in %s", image, ds.Status.NumberReady, elapsed.Round(time.Second))
+	case elapsed >= timeout:
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "PrePullTimedOut",
+			"Pre-pulled %s on %d of %d nodes within %s, updating %s anyway",
+			image, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled, timeout, desired.Name)
+	default:
+		return false, nil
+	}
+	status.PrePull = nil
+	return true, r.deletePrePullDaemonSet(ctx, policy, desired.Name)
+}
+
+// -- prePullDaemonSet builds the DaemonSet pulling the images of a component on its nodes.
+// The images only run sh in init containers, the pod then idles on the pause image.
+func prePullDaemonSet(desired *appsv1.DaemonSet) *appsv1.DaemonSet {
+	name := prePullDaemonSetName(desired.Name)
+	labels := map[string]string{"app.kubernetes.io/name": name}
+
+	var initContainers []corev1.Container
+	for i, c := range desired.Spec.Template.Spec.Containers {
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           c.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sh", "-c", "exit 0"},
+		})
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: desired.Namespace,
+			// Not labeled with the policy, so removing it is not taken for an out-of-band deletion.
+			Labels: mergeLabels(labels, map[string]string{
+				"app.kubernetes.io/managed-by": "npu-operator",
+				"app.kubernetes.io/component":  "prepull",
+			}),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeSelector:     desired.Spec.Template.Spec.NodeSelector,
+					Affinity:         desired.Spec.Template.Spec.Affinity,
+					Tolerations:      desired.Spec.Template.Spec.Tolerations,
+					ImagePullSecrets: desired.Spec.Template.Spec.ImagePullSecrets,
+					InitContainers:   initContainers,
+					Containers: []corev1.Container{{
+						Name:            "pause",
+						Image:           prePullPauseImage,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (r *NPUClusterPolicyReconciler) deletePrePullDaemonSet(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, component string) error {
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: prePullDaemonSetName(component), Namespace: componentNamespace(policy)}}
+	if err := r.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func prePullDaemonSetName(component string) string {
+	return component + "-prepull"
+}
+
+// -- daemonSetRolledOut reports whether the current template is ready on every scheduled node
+func daemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberReady == ds.Status.DesiredNumberScheduled
+}
+
+// -- prePulling reports whether a component update waits for its pre-pull
+func prePulling(policy *npuv1alpha1.NPUClusterPolicy) bool {
+	for _, c := range policy.Status.Components {
+		if c.PrePull != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Image pre-pull", func() {
+	const resourceName = "prepull"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	validatorKey := types.NamespacedName{Name: "furiosa-validator", Namespace: "kube-system"}
+	prePullKey := types.NamespacedName{Name: "furiosa-validator-prepull", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "furiosa-device-plugin",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin:latest"},
+						Validator: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "ghcr.io/furiosa-ai/furiosa-validator",
+							Version: "v1",
+						},
+					},
+				},
+				PrePull: &npuv1alpha1.PrePullSpec{Enabled: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should update a component only once its new image is pre-pulled", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("starting the pre-pull of a new version instead of updating the component")
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Furiosa.Validator.Version = "v2"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("<=", prePullPollInterval))
+
+		validator := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, validatorKey, validator)).To(Succeed())
+		Expect(validator.Spec.Template.Spec.Containers[0].Image).To(Equal("ghcr.io/furiosa-ai/furiosa-validator:v1"))
+		prePull := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, prePullKey, prePull)).To(Succeed())
+		Expect(prePull.Spec.Template.Spec.InitContainers[0].Image).To(Equal("ghcr.io/furiosa-ai/furiosa-validator:v2"))
+
+		By("updating the component once the pre-pull DaemonSet is ready")
+		prePull.Status.ObservedGeneration = prePull.Generation
+		Expect(k8sClient.Status().Update(ctx, prePull)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, validatorKey, validator)).To(Succeed())
+		Expect(validator.Spec.Template.Spec.Containers[0].Image).To(Equal("ghcr.io/furiosa-ai/furiosa-validator:v2"))
+		err = k8sClient.Get(ctx, prePullKey, prePull)
+		Expect(apierrors.IsNotFound(err) || prePull.DeletionTimestamp != nil).To(BeTrue())
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(componentStatus(policy, "furiosa-validator").PrePull).To(BeNil())
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// productionPriority is added to the priority of requests for objects labeled
+// npu.ai/tier=production. It outweighs the low priority of the initial list, so after a
+// restart production objects are reconciled before the rest of the backlog.
+const productionPriority = -handler.LowPriority + 1
+
+// tierQueue is a priority queue that reconciles production objects first. The tier is read
+// from the labels of the object in the cache when a request is added.
+type tierQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	reader client.Reader
+	newObj func() client.Object
+}
+
+// -- tierQueueOptions returns controller options using a tierQueue over objects created by newObj.
+// Zero workers leaves the number of concurrent reconciles to the manager default.
+func tierQueueOptions(mgr ctrl.Manager, workers int, limits RateLimiterConfig,
+	newObj func() client.Object) controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: workers,
+		RateLimiter:             limits.rateLimiter(),
+		NewQueue: func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+			return &tierQueue{
+				PriorityQueue: priorityqueue.New(name, func(o *priorityqueue.Opts[reconcile.Request]) {
+					o.Log = mgr.GetLogger().WithValues("controller", name)
+					o.RateLimiter = rateLimiter
+				}),
+				reader: mgr.GetClient(),
+				newObj: newObj,
+			}
+		},
+	}
+}
+
+// AddWithOpts raises the priority of production objects.
+func (q *tierQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	for _, item := range items {
+		opts := o
+		opts.Priority += q.priority(item)
+		q.PriorityQueue.AddWithOpts(opts, item)
+	}
+}
+
+// Add adds the request with the priority of its tier.
+func (q *tierQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+// AddAfter adds the request with the priority of its tier after the duration.
+func (q *tierQueue) AddAfter(item reconcile.Request, after time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: after}, item)
+}
+
+// AddRateLimited adds the request with the priority of its tier once the rate limiter allows it.
+func (q *tierQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+func (q *tierQueue) priority(item reconcile.Request) int {
+	obj := q.newObj()
+	if err := q.reader.Get(context.Background(), item.NamespacedName, obj); err != nil {
+		return 0
+	}
+	if obj.GetLabels()[npuv1alpha1.TierLabel] == npuv1alpha1.TierProduction {
+		return productionPriority
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Tier queue", func() {
+	ctx := context.Background()
+	dev := types.NamespacedName{Name: "tier-dev", Namespace: "default"}
+	prod := types.NamespacedName{Name: "tier-prod", Namespace: "default"}
+
+	BeforeEach(func() {
+		for _, key := range []types.NamespacedName{dev, prod} {
+			policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+			if key == prod {
+				policy.Labels = map[string]string{npuv1alpha1.TierLabel: npuv1alpha1.TierProduction}
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		for _, key := range []types.NamespacedName{dev, prod} {
+			policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, policy))).To(Succeed())
+		}
+	})
+
+	It("hands out production policies of the initial list first", func() {
+		q := &tierQueue{
+			PriorityQueue: priorityqueue.New[reconcile.Request]("tier-test"),
+			reader:        k8sClient,
+			newObj:        func() client.Object { return &npuv1alpha1.NPUClusterPolicy{} },
+		}
+		defer q.ShutDown()
+
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: handler.LowPriority},
+			reconcile.Request{NamespacedName: dev}, reconcile.Request{NamespacedName: prod})
+
+		item, priority, _ := q.GetWithPriority()
+		Expect(item.NamespacedName).To(Equal(prod))
+		Expect(priority).To(BeNumerically(">", 0))
+		q.Done(item)
+		item, priority, _ = q.GetWithPriority()
+		Expect(item.NamespacedName).To(Equal(dev))
+		Expect(priority).To(Equal(handler.LowPriority))
+		q.Done(item)
+	})
+})
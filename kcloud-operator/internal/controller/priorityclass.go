@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const (
+	// defaultPriorityClassName keeps the component pods from being evicted under node pressure.
+	defaultPriorityClassName = "system-node-critical"
+	// createdPriorityClassValue is the highest value of a class other than the system ones.
+	createdPriorityClassValue int32 = 1000000000
+)
+
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create
+
+// -- priorityClassName returns the priority class of the component pods
+func priorityClassName(policy *npuv1alpha1.NPUClusterPolicy) string {
+	if policy.Spec.PriorityClassName != "" {
+		return policy.Spec.PriorityClassName
+	}
+	return defaultPriorityClassName
+}
+
+// -- ensurePriorityClass creates the priority class of the components when the policy asks for it.
+// The system classes always exist. The class is labeled but not owned by the policy, as other
+// workloads may use it.
+func (r *NPUClusterPolicyReconciler) ensurePriorityClass(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	name := priorityClassName(policy)
+	if !policy.Spec.CreatePriorityClass || strings.HasPrefix(name, "system-") {
+		return nil
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &schedulingv1.PriorityClass{}); !apierrors.IsNotFound(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Creating priority class", "priorityClass", name)
+	class := &schedulingv1.PriorityClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: name, Labels: policyLabels(policy)},
+		Value:       createdPriorityClassValue,
+		Description: "Accelerator components deployed by npu-operator",
+	}
+	if err := r.Create(ctx, class); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "PriorityClassCreated", "Created priority class %s for the components", name)
+	return nil
+}
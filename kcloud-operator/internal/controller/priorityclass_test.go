@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Component priority class", func() {
+	ctx := context.Background()
+
+	It("should run every component as node critical unless another class is set", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Validator.Enabled = boolPtr(true)
+		for _, c := range append(nvidiaComponents(policy), furiosaComponents(policy)...) {
+			ds, err := renderComponent(policy, c, "image", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ds.Spec.Template.Spec.PriorityClassName).To(Equal("system-node-critical"), c.name)
+		}
+
+		policy.Spec.PriorityClassName = "npu-critical"
+		plugin, err := renderComponent(policy, nvidiaComponents(policy)[1], "image", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.Spec.Template.Spec.PriorityClassName).To(Equal("npu-critical"))
+	})
+
+	It("should create a missing priority class when asked to", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "priority-class", Namespace: "default"},
+			Spec:       npuv1alpha1.NPUClusterPolicySpec{PriorityClassName: "npu-critical"},
+		}
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		key := types.NamespacedName{Name: "npu-critical"}
+
+		Expect(controllerReconciler.ensurePriorityClass(ctx, policy)).To(Succeed())
+		err := k8sClient.Get(ctx, key, &schedulingv1.PriorityClass{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		policy.Spec.CreatePriorityClass = true
+		Expect(controllerReconciler.ensurePriorityClass(ctx, policy)).To(Succeed())
+		class := &schedulingv1.PriorityClass{}
+		Expect(k8sClient.Get(ctx, key, class)).To(Succeed())
+		Expect(class.Value).To(Equal(createdPriorityClassValue))
+		Expect(class.Labels).To(HaveKeyWithValue(npuv1alpha1.PolicyNameLabel, "priority-class"))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("PriorityClassCreated")))
+
+		By("leaving the system classes alone")
+		policy.Spec.PriorityClassName = "system-cluster-critical"
+		Expect(controllerReconciler.ensurePriorityClass(ctx, policy)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, class)).To(Succeed())
+	})
+})
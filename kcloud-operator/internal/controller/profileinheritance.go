@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// maxProfileDepth bounds the parents of a profile, so long chains cannot stall reconciles.
+const maxProfileDepth = 8
+
+// parentUnresolvedError is a parent chain that cannot be followed, e.g. a missing parent or a cycle.
+type parentUnresolvedError struct {
+	message string
+}
+
+func (e *parentUnresolvedError) Error() string {
+	return e.message
+}
+
+// -- inheritedSpec returns the spec of the profile with the fields inherited from its parents, and
+// the parents as namespace/name, nearest first
+func (r *NPUWorkloadProfileReconciler) inheritedSpec(ctx context.Context,
+	profile *npuv1alpha1.NPUWorkloadProfile) (npuv1alpha1.NPUWorkloadProfileSpec, []string, error) {
+	chain := []*npuv1alpha1.NPUWorkloadProfile{profile}
+	var parents []string
+	visited := []client.ObjectKey{client.ObjectKeyFromObject(profile)}
+	for current := profile; current.Spec.Parent != nil; {
+		key := client.ObjectKey{Namespace: current.Spec.Parent.Namespace, Name: current.Spec.Parent.Name}
+		if key.Namespace == "" {
+			key.Namespace = current.Namespace
+		}
+		if slices.Contains(visited, key) {
+			return npuv1alpha1.NPUWorkloadProfileSpec{}, parents,
+				&parentUnresolvedError{fmt.Sprintf("Parent profiles form a cycle through %s", key)}
+		}
+		if len(parents) == maxProfileDepth {
+			return npuv1alpha1.NPUWorkloadProfileSpec{}, parents,
+				&parentUnresolvedError{fmt.Sprintf("The profile has more than %d parents", maxProfileDepth)}
+		}
+		parent := &npuv1alpha1.NPUWorkloadProfile{}
+		if err := r.Get(ctx, key, parent); err != nil {
+			if apierrors.IsNotFound(err) {
+				return npuv1alpha1.NPUWorkloadProfileSpec{}, parents,
+					&parentUnresolvedError{fmt.Sprintf("Parent profile %s does not exist", key)}
+			}
+			return npuv1alpha1.NPUWorkloadProfileSpec{}, parents, err
+		}
+		visited = append(visited, key)
+		parents = append(parents, key.String())
+		chain = append(chain, parent)
+		current = parent
+	}
+
+	spec := npuv1alpha1.NPUWorkloadProfileSpec{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		spec = inheritSpec(spec, chain[i].Spec)
+	}
+	return spec, parents, nil
+}
+
+// -- inheritSpec overrides the fields of the parent spec set in the child spec
+func inheritSpec(parent, child npuv1alpha1.NPUWorkloadProfileSpec) npuv1alpha1.NPUWorkloadProfileSpec {
+	spec := *parent.DeepCopy()
+	spec.Parent = nil
+	if child.Furiosa != nil {
+		spec.Furiosa = child.Furiosa.DeepCopy()
+	}
+	switch {
+	case child.Placement == nil:
+	case spec.Placement == nil:
+		spec.Placement = child.Placement.DeepCopy()
+	default:
+		spec.Placement.SameModel = spec.Placement.SameModel || child.Placement.SameModel
+		if child.Placement.SpreadAcross != "" {
+			spec.Placement.SpreadAcross = child.Placement.SpreadAcross
+		}
+		if child.Placement.GroupLabel != "" {
+			spec.Placement.GroupLabel = child.Placement.GroupLabel
+		}
+	}
+	return spec
+}
+
+// -- profilesForParent maps a profile to the profiles inheriting from it. Their own children are
+// reached through the status update of their reconcile.
+func (r *NPUWorkloadProfileReconciler) profilesForParent(ctx context.Context, obj client.Object) []reconcile.Request {
+	profiles := &npuv1alpha1.NPUWorkloadProfileList{}
+	if err := r.List(ctx, profiles); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list workload profiles")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, profile := range profiles.Items {
+		parent := profile.Spec.Parent
+		if parent == nil || parent.Name != obj.GetName() {
+			continue
+		}
+		if parent.Namespace == obj.GetNamespace() || (parent.Namespace == "" && profile.Namespace == obj.GetNamespace()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&profile)})
+		}
+	}
+	return requests
+}
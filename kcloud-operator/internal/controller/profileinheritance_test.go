@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Workload profile inheritance", func() {
+	ctx := context.Background()
+
+	It("should override the fields of the parent set on the child", func() {
+		parent := npuv1alpha1.NPUWorkloadProfileSpec{
+			Furiosa: &npuv1alpha1.FuriosaWorkload{PEs: 4},
+			Placement: &npuv1alpha1.WorkloadPlacement{
+				SpreadAcross: "topology.kubernetes.io/zone",
+				GroupLabel:   "job-name",
+			},
+		}
+		child := npuv1alpha1.NPUWorkloadProfileSpec{
+			Parent:    &npuv1alpha1.ProfileReference{Name: "base"},
+			Placement: &npuv1alpha1.WorkloadPlacement{SameModel: true, GroupLabel: "training-run"},
+		}
+		spec := inheritSpec(parent, child)
+		Expect(spec.Parent).To(BeNil())
+		Expect(spec.Furiosa).To(Equal(&npuv1alpha1.FuriosaWorkload{PEs: 4}))
+		Expect(spec.Placement).To(Equal(&npuv1alpha1.WorkloadPlacement{
+			SameModel:    true,
+			SpreadAcross: "topology.kubernetes.io/zone",
+			GroupLabel:   "training-run",
+		}))
+		Expect(parent.Placement.GroupLabel).To(Equal("job-name"))
+
+		child.Furiosa = &npuv1alpha1.FuriosaWorkload{PEs: 2}
+		Expect(inheritSpec(parent, child).Furiosa.PEs).To(Equal(int32(2)))
+	})
+
+	It("should resolve profiles through their parents of other namespaces", func() {
+		node := npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: "rngd-inheritance"}}
+		Expect(k8sClient.Create(ctx, &node)).To(Succeed())
+		node.Status.Capacity = corev1.ResourceList{"furiosa.ai/npu-2pe": resource.MustParse("4")}
+		Expect(k8sClient.Status().Update(ctx, &node)).To(Succeed())
+		base := &npuv1alpha1.NPUWorkloadProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "kube-system"},
+			Spec: npuv1alpha1.NPUWorkloadProfileSpec{
+				Furiosa:   &npuv1alpha1.FuriosaWorkload{PEs: 4},
+				Placement: &npuv1alpha1.WorkloadPlacement{SpreadAcross: "topology.kubernetes.io/zone"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, base)).To(Succeed())
+		team := &npuv1alpha1.NPUWorkloadProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+			Spec: npuv1alpha1.NPUWorkloadProfileSpec{
+				Parent:    &npuv1alpha1.ProfileReference{Name: "base", Namespace: "kube-system"},
+				Placement: &npuv1alpha1.WorkloadPlacement{SameModel: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, team)).To(Succeed())
+
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUWorkloadProfileReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		reconcileTeam := func() *npuv1alpha1.NPUWorkloadProfile {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(team)})
+			Expect(err).NotTo(HaveOccurred())
+			profile := &npuv1alpha1.NPUWorkloadProfile{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(team), profile)).To(Succeed())
+			return profile
+		}
+
+		profile := reconcileTeam()
+		Expect(conditions.IsTrue(profile, conditions.Resolved)).To(BeTrue())
+		Expect(profile.Status.InheritedFrom).To(Equal([]string{"kube-system/base"}))
+		Expect(profile.Status.Resources).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu-2pe"), resource.MustParse("2")))
+		Expect(profile.Status.Affinity).NotTo(BeNil())
+		Expect(profile.Status.TopologySpreadConstraints).To(ConsistOf(HaveField("TopologyKey", "topology.kubernetes.io/zone")))
+		Expect(controllerReconciler.profilesForParent(ctx, base)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(team)}))
+
+		By("reporting cycles")
+		base.Spec.Parent = &npuv1alpha1.ProfileReference{Name: "team", Namespace: "default"}
+		Expect(k8sClient.Update(ctx, base)).To(Succeed())
+		profile = reconcileTeam()
+		resolved := conditions.Get(profile, conditions.Resolved)
+		Expect(resolved.Status).To(Equal(metav1.ConditionFalse))
+		Expect(resolved.Reason).To(Equal(conditions.ReasonParentUnresolved))
+
+		By("reporting missing parents")
+		Expect(k8sClient.Delete(ctx, base)).To(Succeed())
+		profile = reconcileTeam()
+		Expect(conditions.Get(profile, conditions.Resolved).Message).To(ContainSubstring("does not exist"))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Unresolved")))
+
+		Expect(k8sClient.Delete(ctx, team)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &node)).To(Succeed())
+	})
+})
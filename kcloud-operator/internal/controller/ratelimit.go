@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RateLimiterConfig configures how fast failed requests are retried. Each request backs off
+// exponentially from BaseDelay up to MaxDelay, and all retries share a token bucket refilled
+// at QPS and holding Burst tokens. The zero value keeps the controller-runtime defaults.
+type RateLimiterConfig struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	QPS       float64
+	Burst     int
+}
+
+// DefaultRateLimiterConfig matches the default rate limiter of controller-runtime.
+var DefaultRateLimiterConfig = RateLimiterConfig{
+	BaseDelay: 5 * time.Millisecond,
+	MaxDelay:  1000 * time.Second,
+	QPS:       10,
+	Burst:     100,
+}
+
+// -- rateLimiter returns the rate limiter of the configuration, or nil for the defaults
+func (c RateLimiterConfig) rateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	if c == (RateLimiterConfig{}) {
+		return nil
+	}
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](c.BaseDelay, c.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(c.QPS), c.Burst)},
+	)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Rate limiter", func() {
+	It("should keep the controller-runtime defaults when unset", func() {
+		Expect(RateLimiterConfig{}.rateLimiter()).To(BeNil())
+	})
+
+	It("should back off from the base delay up to the max delay", func() {
+		limiter := RateLimiterConfig{BaseDelay: time.Second, MaxDelay: 3 * time.Second, QPS: 1000, Burst: 1000}.rateLimiter()
+		item := reconcile.Request{NamespacedName: types.NamespacedName{Name: "policy"}}
+		Expect(limiter.When(item)).To(Equal(time.Second))
+		Expect(limiter.When(item)).To(Equal(2 * time.Second))
+		Expect(limiter.When(item)).To(Equal(3 * time.Second))
+		limiter.Forget(item)
+		Expect(limiter.When(item)).To(Equal(time.Second))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// applyTally counts the outcome of the objects applied during one reconcile. Objects may be
+// applied concurrently.
+type applyTally struct {
+	start                       time.Time
+	created, updated, unchanged atomic.Int32
+
+	mu sync.Mutex
+	// conflicts are the field conflicts the applies met, one entry per object.
+	conflicts []string
+}
+
+type applyTallyKey struct{}
+
+// -- withApplyTally returns a context counting the objects applied from now on
+func withApplyTally(ctx context.Context) (context.Context, *applyTally) {
+	tally := &applyTally{start: time.Now()}
+	return context.WithValue(ctx, applyTallyKey{}, tally), tally
+}
+
+// -- recordApply counts an applied object in the tally of the context, if any
+func recordApply(ctx context.Context, result controllerutil.OperationResult) {
+	tally, ok := ctx.Value(applyTallyKey{}).(*applyTally)
+	if !ok {
+		return
+	}
+	switch result {
+	case controllerutil.OperationResultCreated:
+		tally.created.Add(1)
+	case controllerutil.OperationResultNone:
+		tally.unchanged.Add(1)
+	default:
+		tally.updated.Add(1)
+	}
+}
+
+// -- appliedResult tells from the server response of an apply whether it created or changed the object.
+// The API server skips no-op applies, so our managed fields keep an earlier timestamp.
+func appliedResult(obj client.Object, since time.Time) controllerutil.OperationResult {
+	since = since.Truncate(time.Second)
+	if !obj.GetCreationTimestamp().Time.Before(since) {
+		return controllerutil.OperationResultCreated
+	}
+	for _, f := range obj.GetManagedFields() {
+		if f.Manager == fieldOwner && f.Operation == metav1.ManagedFieldsOperationApply &&
+			f.Time != nil && !f.Time.Time.Before(since) {
+			return controllerutil.OperationResultUpdated
+		}
+	}
+	return controllerutil.OperationResultNone
+}
+
+// -- summarizeReconcile sets status.lastReconcile, unless the reconcile neither processed a new
+// generation, changed objects nor changed the errors, so the summary alone does not requeue the policy
+func summarizeReconcile(policy *npuv1alpha1.NPUClusterPolicy, tally *applyTally, errs []string) bool {
+	summary := &npuv1alpha1.ReconcileSummary{
+		ObservedGeneration: policy.Generation,
+		Time:               metav1.NewTime(tally.start),
+		Duration:           metav1.Duration{Duration: time.Since(tally.start).Round(time.Millisecond)},
+		Created:            tally.created.Load(),
+		Updated:            tally.updated.Load(),
+		Unchanged:          tally.unchanged.Load(),
+		Errors:             errs,
+	}
+	if last := policy.Status.LastReconcile; last != nil && last.ObservedGeneration == summary.ObservedGeneration &&
+		summary.Created == 0 && summary.Updated == 0 && slices.Equal(last.Errors, summary.Errors) {
+		return false
+	}
+	policy.Status.LastReconcile = summary
+	return true
+}
+
+// -- recordFailedReconcile patches status.lastReconcile with the error of a failed reconcile. Only the
+// summary is written, as the rest of the status may be half-updated.
+func (r *NPUClusterPolicyReconciler) recordFailedReconcile(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	tally *applyTally, reconcileErr error) {
+	if !summarizeReconcile(policy, tally, []string{reconcileErr.Error()}) {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"lastReconcile": policy.Status.LastReconcile},
+	})
+	if err == nil {
+		err = r.Status().Patch(ctx, policy, client.RawPatch(types.MergePatchType, patch))
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to record the failed reconcile")
+	}
+}
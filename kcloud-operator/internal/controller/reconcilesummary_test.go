@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Reconcile summary", func() {
+	It("should classify applies by the server response", func() {
+		since := time.Now()
+		earlier := metav1.NewTime(since.Add(-time.Hour))
+		now := metav1.NewTime(since)
+		obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: now}}
+		Expect(appliedResult(obj, since)).To(Equal(controllerutil.OperationResultCreated))
+
+		obj.CreationTimestamp = earlier
+		obj.ManagedFields = []metav1.ManagedFieldsEntry{
+			{Manager: fieldOwner, Operation: metav1.ManagedFieldsOperationApply, Time: &earlier},
+			{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, Time: &now},
+		}
+		Expect(appliedResult(obj, since)).To(Equal(controllerutil.OperationResultNone))
+		obj.ManagedFields[0].Time = &now
+		Expect(appliedResult(obj, since)).To(Equal(controllerutil.OperationResultUpdated))
+	})
+
+	It("should only change when there is something new to report", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+		ctx, tally := withApplyTally(context.Background())
+		recordApply(ctx, controllerutil.OperationResultNone)
+		Expect(summarizeReconcile(policy, tally, nil)).To(BeTrue())
+		Expect(policy.Status.LastReconcile.Unchanged).To(Equal(int32(1)))
+
+		_, tally = withApplyTally(context.Background())
+		Expect(summarizeReconcile(policy, tally, nil)).To(BeFalse())
+		Expect(summarizeReconcile(policy, tally, []string{"boom"})).To(BeTrue())
+		Expect(summarizeReconcile(policy, tally, nil)).To(BeTrue())
+
+		policy.Generation = 3
+		Expect(summarizeReconcile(policy, tally, nil)).To(BeTrue())
+		Expect(policy.Status.LastReconcile.ObservedGeneration).To(Equal(int64(3)))
+	})
+
+	It("should report the objects applied for the latest generation", func() {
+		ctx := context.Background()
+		key := types.NamespacedName{Name: "summary", Namespace: "default"}
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "summary-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(20),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		summary := policy.Status.LastReconcile
+		Expect(summary).NotTo(BeNil())
+		Expect(summary.ObservedGeneration).To(Equal(policy.Generation))
+		Expect(summary.Created).To(BeNumerically(">", 0))
+		Expect(summary.Errors).To(BeEmpty())
+
+		deletePolicy(ctx, policy)
+	})
+})
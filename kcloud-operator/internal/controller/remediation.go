@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/cloudevents"
+)
+
+const (
+	maxRemediationHistory = 20
+
+	resultRecovered = "Recovered"
+	resultDeferred  = "Deferred"
+	resultFailed    = "Failed"
+)
+
+// RemediationConfig configures the remediation of nodes whose accelerator allocatable
+// dropped to zero while the device plugin is still running, typically a wedged driver.
+type RemediationConfig struct {
+	Enabled bool
+	// StepTimeout is how long to wait for the allocatable to recover after each step.
+	StepTimeout time.Duration
+	// RebootWindow allows requesting a node reboot as the last step. Nil never reboots.
+	RebootWindow *MaintenanceWindow
+}
+
+// MaintenanceWindow is a daily time window in UTC.
+type MaintenanceWindow struct {
+	Start, End time.Duration
+}
+
+// ParseMaintenanceWindow parses a daily window in UTC such as "02:00-04:00".
+// Windows may wrap midnight, e.g. "23:00-01:00".
+func ParseMaintenanceWindow(s string) (*MaintenanceWindow, error) {
+	var sh, sm, eh, em int
+	if _, err := fmt.Sscanf(s, "%d:%d-%d:%d", &sh, &sm, &eh, &em); err != nil {
+		return nil, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM: %w", s, err)
+	}
+	if sh > 23 || eh > 23 || sm > 59 || em > 59 || sh < 0 || eh < 0 || sm < 0 || em < 0 {
+		return nil, fmt.Errorf("invalid maintenance window %q", s)
+	}
+	return &MaintenanceWindow{
+		Start: time.Duration(sh)*time.Hour + time.Duration(sm)*time.Minute,
+		End:   time.Duration(eh)*time.Hour + time.Duration(em)*time.Minute,
+	}, nil
+}
+
+// Contains reports whether t falls into the window.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// Until returns how long until the window opens next, or zero while it is open.
+func (w *MaintenanceWindow) Until(t time.Time) time.Duration {
+	if w.Contains(t) {
+		return 0
+	}
+	wait := w.Start - sinceMidnight(t)
+	if wait < 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.UTC()
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// -- remediate advances the remediation sequence of the node and returns when to check again
+func (r *NPUNodeReconciler) remediate(ctx context.Context, node *corev1.Node, npuNode *npuv1alpha1.NPUNode) (time.Duration, error) {
+	state := npuNode.Status.Remediation
+	if state == nil {
+		resource := zeroAllocatableResource(node)
+		if resource == "" {
+			return 0, nil
+		}
+		running, err := r.componentRunning(ctx, node, acceleratorVendor(resource)+"-device-plugin")
+		if err != nil || !running {
+			// Without a running plugin the drop is expected and handled by the DaemonSet.
+			return 0, err
+		}
+		npuNode.Status.Remediation = &npuv1alpha1.RemediationState{
+			Resource:    resource,
+			StartedTime: metav1.Now(),
+		}
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "AllocatableDropped",
+			"Allocatable %s dropped to zero while the device plugin is running, starting remediation", resource)
+		return r.remediationStep(ctx, node, npuNode, npuv1alpha1.RemediationRestartPlugin)
+	}
+
+	if !isZero(node.Status.Allocatable, state.Resource) {
+		appendRemediation(npuNode, state.Step, resultRecovered, "Allocatable recovered")
+		r.Recorder.Eventf(node, corev1.EventTypeNormal, "RemediationSucceeded",
+			"Allocatable %s recovered after %s", state.Resource, state.Step)
+		npuNode.Status.Remediation = nil
+		return 0, r.clearRebootRequest(ctx, node)
+	}
+	if state.Step == npuv1alpha1.RemediationExhausted {
+		return 0, nil
+	}
+	if elapsed := time.Since(state.StepTime.Time); elapsed < r.Remediation.StepTimeout {
+		return r.Remediation.StepTimeout - elapsed, nil
+	}
+
+	switch state.Step {
+	case npuv1alpha1.RemediationRestartPlugin:
+		return r.remediationStep(ctx, node, npuNode, npuv1alpha1.RemediationCheckDriver)
+	case npuv1alpha1.RemediationCheckDriver:
+		return r.remediationStep(ctx, node, npuNode, npuv1alpha1.RemediationReboot)
+	default:
+		return r.remediationStep(ctx, node, npuNode, npuv1alpha1.RemediationExhausted)
+	}
+}
+
+// -- remediationStep takes one step of the sequence and records it in the history
+func (r *NPUNodeReconciler) remediationStep(ctx context.Context, node *corev1.Node, npuNode *npuv1alpha1.NPUNode,
+	step npuv1alpha1.RemediationStep) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	state := npuNode.Status.Remediation
+	vendor := acceleratorVendor(state.Resource)
+
+	var message string
+	switch step {
+	case npuv1alpha1.RemediationRestartPlugin:
+		plugin := vendor + "-device-plugin"
+		if err := r.deleteComponentPods(ctx, node, plugin); err != nil {
+			return 0, err
+		}
+		message = fmt.Sprintf("Restarted %s", plugin)
+
+	case npuv1alpha1.RemediationCheckDriver:
+		driver := vendor + "-driver"
+		ready, err := r.componentRunning(ctx, node, driver)
+		if err != nil {
+			return 0, err
+		}
+		if ready {
+			message = fmt.Sprintf("%s is ready", driver)
+		} else {
+			if err := r.deleteComponentPods(ctx, node, driver); err != nil {
+				return 0, err
+			}
+			message = fmt.Sprintf("%s is not ready, restarted it", driver)
+		}
+
+	case npuv1alpha1.RemediationReboot:
+		window := r.Remediation.RebootWindow
+		if window == nil {
+			return r.remediationStep(ctx, node, npuNode, npuv1alpha1.RemediationExhausted)
+		}
+		if wait := window.Until(time.Now()); wait > 0 {
+			if last := lastRemediation(npuNode); last == nil || last.Result != resultDeferred {
+				appendRemediation(npuNode, step, resultDeferred, "Reboot deferred to the maintenance window")
+			}
+			return wait, nil
+		}
+		rack, err := r.rackInMaintenance(ctx, npuNode)
+		if err != nil {
+			return 0, err
+		}
+		if rack != "" {
+			deferred := fmt.Sprintf("Reboot deferred while rack %s is in maintenance", rack)
+			if last := lastRemediation(npuNode); last == nil || last.Message != deferred {
+				appendRemediation(npuNode, step, resultDeferred, deferred)
+			}
+			return r.Remediation.StepTimeout, nil
+		}
+		patch := client.MergeFrom(node.DeepCopy())
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		silenceNode(ctx, r.Silencer, node.Name, fmt.Sprintf("Reboot requested by remediation of %s", state.Resource))
+		node.Annotations[npuv1alpha1.RebootRequiredAnnotation] = "true"
+		if err := r.Patch(ctx, node, patch); err != nil {
+			return 0, err
+		}
+		message = "Requested a node reboot"
+
+	case npuv1alpha1.RemediationExhausted:
+		appendRemediation(npuNode, step, resultFailed, "Remediation did not recover the allocatable")
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RemediationFailed",
+			"Allocatable %s is still zero after remediation; manual intervention is required", state.Resource)
+		r.emitQuarantined(ctx, node, state)
+		state.Step = step
+		state.StepTime = metav1.Now()
+		return 0, r.clearRebootRequest(ctx, node)
+	}
+
+	log.Info("Remediation step taken", "resource", state.Resource, "step", step, "message", message)
+	state.Step = step
+	state.StepTime = metav1.Now()
+	appendRemediation(npuNode, step, "", message)
+	return r.Remediation.StepTimeout, nil
+}
+
+// -- emitQuarantined reports a node left to manual intervention as a CloudEvent
+func (r *NPUNodeReconciler) emitQuarantined(ctx context.Context, node *corev1.Node, state *npuv1alpha1.RemediationState) {
+	if r.Events == nil {
+		return
+	}
+	event := cloudevents.Event{
+		Type:    cloudevents.TypeNodeQuarantined,
+		Source:  fmt.Sprintf("/apis/%s/npunodes/%s", npuv1alpha1.GroupVersion.String(), node.Name),
+		Subject: node.Name,
+		Data: map[string]interface{}{
+			"reason":      "RemediationFailed",
+			"resource":    state.Resource,
+			"startedTime": state.StartedTime,
+		},
+	}
+	if err := r.Events.Emit(ctx, event); err != nil {
+		// Delivery is best effort and must not block remediation.
+		logf.FromContext(ctx).Error(err, "failed to emit cloudevent", "type", event.Type)
+	}
+}
+
+// -- componentRunning reports whether a ready pod of the component runs on the node
+func (r *NPUNodeReconciler) componentRunning(ctx context.Context, node *corev1.Node, component string) (bool, error) {
+	pods, err := r.componentPods(ctx, node, component)
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *NPUNodeReconciler) deleteComponentPods(ctx context.Context, node *corev1.Node, component string) error {
+	pods, err := r.componentPods(ctx, node, component)
+	if err != nil {
+		return err
+	}
+	for i := range pods {
+		if err := r.Delete(ctx, &pods[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *NPUNodeReconciler) componentPods(ctx context.Context, node *corev1.Node, component string) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingFields{podNodeNameIndex: node.Name},
+		client.MatchingLabels{"app.kubernetes.io/name": component}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// -- clearRebootRequest removes a pending reboot request so a recovered node is not rebooted again
+func (r *NPUNodeReconciler) clearRebootRequest(ctx context.Context, node *corev1.Node) error {
+	if _, ok := node.Annotations[npuv1alpha1.RebootRequiredAnnotation]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	delete(node.Annotations, npuv1alpha1.RebootRequiredAnnotation)
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return err
+	}
+	unsilenceNode(ctx, r.Silencer, node.Name)
+	return nil
+}
+
+// -- zeroAllocatableResource returns an accelerator resource with capacity but nothing allocatable
+func zeroAllocatableResource(node *corev1.Node) string {
+	for name, capacity := range acceleratorResources(node.Status.Capacity) {
+		if !capacity.IsZero() && isZero(node.Status.Allocatable, string(name)) {
+			return string(name)
+		}
+	}
+	return ""
+}
+
+func isZero(list corev1.ResourceList, name string) bool {
+	q, ok := list[corev1.ResourceName(name)]
+	return !ok || q.IsZero()
+}
+
+func appendRemediation(npuNode *npuv1alpha1.NPUNode, step npuv1alpha1.RemediationStep, result, message string) {
+	history := append(npuNode.Status.RemediationHistory, npuv1alpha1.RemediationRecord{
+		Time:     metav1.Now(),
+		Resource: npuNode.Status.Remediation.Resource,
+		Step:     step,
+		Result:   result,
+		Message:  message,
+	})
+	if len(history) > maxRemediationHistory {
+		history = history[len(history)-maxRemediationHistory:]
+	}
+	npuNode.Status.RemediationHistory = history
+}
+
+func lastRemediation(npuNode *npuv1alpha1.NPUNode) *npuv1alpha1.RemediationRecord {
+	if n := len(npuNode.Status.RemediationHistory); n > 0 {
+		return &npuNode.Status.RemediationHistory[n-1]
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// -- revalidate restarts the validators on a node annotated for revalidation and removes the annotation
+func (r *NPUNodeReconciler) revalidate(ctx context.Context, node *corev1.Node, npuNode *npuv1alpha1.NPUNode) error {
+	log := logf.FromContext(ctx)
+
+	requested := false
+	for _, obj := range []client.Object{node, npuNode} {
+		if obj.GetAnnotations()[npuv1alpha1.RevalidateAnnotation] != "true" {
+			continue
+		}
+		requested = true
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		delete(annotations, npuv1alpha1.RevalidateAnnotation)
+		obj.SetAnnotations(annotations)
+		if err := r.Patch(ctx, obj, patch); err != nil {
+			return err
+		}
+	}
+	if !requested {
+		return nil
+	}
+
+	var validators []string
+	for _, vendor := range nodeVendors(node) {
+		validator := vendor + "-validator"
+		if err := r.deleteComponentPods(ctx, node, validator); err != nil {
+			return err
+		}
+		validators = append(validators, validator)
+	}
+	log.Info("Revalidation requested, restarted validators", "validators", validators)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "RevalidationRequested",
+		"Restarted %s to revalidate the accelerators", strings.Join(validators, ", "))
+	now := metav1.Now()
+	npuNode.Status.LastRevalidationTime = &now
+	return nil
+}
+
+// -- nodeVendors returns the accelerator vendors of a node from its labels and capacity
+func nodeVendors(node *corev1.Node) []string {
+	var vendors []string
+	add := func(vendor string) {
+		if !slices.Contains(vendors, vendor) {
+			vendors = append(vendors, vendor)
+		}
+	}
+	for vendor, labels := range map[string]map[string]string{"nvidia": nvidiaNodeLabels, "furiosa": furiosaNodeLabels} {
+		for k, v := range labels {
+			if node.Labels[k] == v {
+				add(vendor)
+			}
+		}
+	}
+	for name := range acceleratorResources(node.Status.Capacity) {
+		add(acceleratorVendor(string(name)))
+	}
+	slices.Sort(vendors)
+	return vendors
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// Rollout phases, used as the phase label of the duration metric.
+const (
+	phasePrePull    = "prepull"
+	phaseApply      = "apply"
+	phaseReady      = "ready"
+	phaseValidation = "validation"
+	phaseTotal      = "total"
+)
+
+const rolloutPollInterval = 15 * time.Second
+
+// Components that are not ready on every node are polled with a backoff between these intervals.
+const (
+	healthPollMinInterval = 5 * time.Second
+	healthPollMaxInterval = 2 * time.Minute
+)
+
+var rolloutPhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "npu_operator_rollout_phase_duration_seconds",
+	Help:    "Duration of the phases of component image rollouts.",
+	Buckets: prometheus.ExponentialBuckets(0.5, 2, 16),
+}, []string{"component", "phase"})
+
+func init() {
+	metrics.Registry.MustRegister(rolloutPhaseDuration)
+}
+
+// -- startRollout records a new image rollout of a component once its DaemonSet was applied
+func startRollout(policy *npuv1alpha1.NPUClusterPolicy, name, previous, image string, prePullStart *metav1.Time,
+	applyStart time.Time) {
+	now := metav1.Now()
+	rollout := &npuv1alpha1.RolloutStatus{
+		Image:         image,
+		PreviousImage: previous,
+		StartTime:     metav1.NewTime(applyStart),
+		Apply:         observePhase(name, phaseApply, now.Sub(applyStart)),
+	}
+	if prePullStart != nil {
+		rollout.StartTime = *prePullStart
+		rollout.PrePull = observePhase(name, phasePrePull, applyStart.Sub(prePullStart.Time))
+	}
+	componentStatus(policy, name).LastRollout = rollout
+}
+
+// -- trackRollouts advances the rollouts in progress and reports whether any is still running
+func (r *NPUClusterPolicyReconciler) trackRollouts(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (bool, error) {
+	enabled := map[string]bool{}
+	for _, c := range enabledComponents(policy) {
+		enabled[c.name] = true
+	}
+
+	running := false
+	for i := range policy.Status.Components {
+		c := &policy.Status.Components[i]
+		rollout := c.LastRollout
+		if rollout == nil || rollout.CompletionTime != nil || !enabled[c.Name] {
+			continue
+		}
+		now := metav1.Now()
+
+		if rollout.Ready == nil {
+			ready, err := r.rolledOut(ctx, policy, c.Name)
+			if err != nil {
+				return false, err
+			}
+			if !ready {
+				running = true
+				continue
+			}
+			applied := rollout.StartTime.Add(durationOf(rollout.PrePull) + durationOf(rollout.Apply))
+			rollout.Ready = observePhase(c.Name, phaseReady, now.Sub(applied))
+		}
+
+		if rollout.Validation == nil {
+			vendor, _, _ := strings.Cut(c.Name, "-")
+			validator := vendor + "-validator"
+			validation := time.Duration(0)
+			if enabled[validator] && validator != c.Name {
+				ready, err := r.rolledOut(ctx, policy, validator)
+				if err != nil {
+					return false, err
+				}
+				if !ready {
+					running = true
+					continue
+				}
+				readyAt := rollout.StartTime.Add(durationOf(rollout.PrePull) + durationOf(rollout.Apply) + rollout.Ready.Duration)
+				validation = now.Sub(readyAt)
+			}
+			rollout.Validation = observePhase(c.Name, phaseValidation, validation)
+		}
+
+		good := true
+		if hooks := componentHooks(policy, c.Name); hooks != nil && rollout.PreviousImage != "" {
+			passed, err := r.runHook(ctx, policy, c.Name, hookPostUpgrade, hooks.PostUpgrade, rollout.PreviousImage, rollout.Image)
+			if err != nil {
+				return false, err
+			}
+			if !passed && c.UpgradeHook.Result == hookRunning {
+				running = true
+				continue
+			}
+			good = passed
+		}
+		rollout.Total = observePhase(c.Name, phaseTotal, now.Sub(rollout.StartTime.Time))
+		rollout.CompletionTime = &now
+		if !good {
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, "RolloutUnverified",
+				"%s; the post-upgrade hook failed, keeping %s as the last known good image",
+				rolloutSummary(c.Name, rollout), c.LastKnownGoodImage)
+			continue
+		}
+		c.LastKnownGoodImage = rollout.Image
+		r.Recorder.Event(policy, corev1.EventTypeNormal, "RolloutCompleted", rolloutSummary(c.Name, rollout))
+		if err := r.deleteHookJobs(ctx, policy, c.Name, ""); err != nil {
+			return false, err
+		}
+	}
+	return running, nil
+}
+
+// -- rolledOut reports whether the DaemonSet of a component is ready on every node
+func (r *NPUClusterPolicyReconciler) rolledOut(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, name string) (bool, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: componentNamespace(policy)}, ds); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return daemonSetRolledOut(ds), nil
+}
+
+// -- observeDaemonSets records the pod counts and the rolled out image of the DaemonSet of each enabled
+// component. It returns the progress of the components not ready on every node yet, and when to check
+// them again: the interval doubles with the time they have been waiting, up to healthPollMaxInterval.
+func (r *NPUClusterPolicyReconciler) observeDaemonSets(ctx context.Context,
+	policy *npuv1alpha1.NPUClusterPolicy) (progress []string, wait time.Duration, err error) {
+	now := metav1.Now()
+	for _, c := range enabledComponents(policy) {
+		ds := &appsv1.DaemonSet{}
+		if err := r.Get(ctx, types.NamespacedName{Name: c.name, Namespace: componentNamespace(policy)}, ds); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, 0, err
+			}
+			if status := findComponentStatus(policy, c.name); status != nil {
+				status.Pods = nil
+				status.NotReadySince = nil
+			}
+			continue
+		}
+		status := componentStatus(policy, c.name)
+		pods := daemonSetPods(ds)
+		status.Pods = &pods
+		if daemonSetRolledOut(ds) {
+			status.NotReadySince = nil
+			if len(ds.Spec.Template.Spec.Containers) > 0 {
+				status.RolledOutImage = ds.Spec.Template.Spec.Containers[0].Image
+			}
+			continue
+		}
+
+		if status.NotReadySince == nil {
+			status.NotReadySince = &now
+		}
+		progress = append(progress, fmt.Sprintf("%s %d/%d ready, %d/%d updated",
+			c.name, pods.Ready, pods.Desired, pods.Updated, pods.Desired))
+		wait = minRequeue(wait, healthPollInterval(now.Sub(status.NotReadySince.Time)))
+	}
+	return progress, wait, nil
+}
+
+// -- healthPollInterval backs off the polling of a component that has not been ready for the given time
+func healthPollInterval(waiting time.Duration) time.Duration {
+	return min(max(waiting, healthPollMinInterval), healthPollMaxInterval)
+}
+
+func daemonSetPods(ds *appsv1.DaemonSet) npuv1alpha1.ComponentPods {
+	return npuv1alpha1.ComponentPods{
+		Desired:   ds.Status.DesiredNumberScheduled,
+		Ready:     ds.Status.NumberReady,
+		Available: ds.Status.NumberAvailable,
+		Updated:   ds.Status.UpdatedNumberScheduled,
+	}
+}
+
+func rolloutSummary(name string, rollout *npuv1alpha1.RolloutStatus) string {
+	return fmt.Sprintf("Rolled out %s to %s in %s (pre-pull %s, apply %s, ready %s, validation %s)",
+		rollout.Image, name, durationOf(rollout.Total), durationOf(rollout.PrePull), durationOf(rollout.Apply),
+		durationOf(rollout.Ready), durationOf(rollout.Validation))
+}
+
+func observePhase(component, phase string, d time.Duration) *metav1.Duration {
+	d = d.Round(time.Millisecond)
+	rolloutPhaseDuration.WithLabelValues(component, phase).Observe(d.Seconds())
+	return &metav1.Duration{Duration: d}
+}
+
+func durationOf(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
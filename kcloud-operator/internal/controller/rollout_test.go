@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Rollout timing", func() {
+	const resourceName = "rollout-timing"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	gfdKey := types.NamespacedName{Name: "nvidia-gpu-feature-discovery", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia: npuv1alpha1.NvidiaSpec{
+					Enabled: true,
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Enabled: boolPtr(false)},
+						GFD: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "nvcr.io/nvidia/k8s-device-plugin",
+							Version: "v0.17.1",
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should record the phases of a rollout until the DaemonSet is ready", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(healthPollMinInterval), "the DaemonSet is polled until it is ready")
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		rollout := componentStatus(policy, "nvidia-gpu-feature-discovery").LastRollout
+		Expect(rollout).NotTo(BeNil())
+		Expect(rollout.Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+		Expect(rollout.Apply).NotTo(BeNil())
+		Expect(rollout.CompletionTime).To(BeNil())
+		Expect(componentStatus(policy, "nvidia-gpu-feature-discovery").NotReadySince).NotTo(BeNil())
+		Expect(conditions.Get(policy, conditions.Progressing).Message).To(ContainSubstring("nvidia-gpu-feature-discovery 0/0 ready"))
+
+		By("completing the rollout once the DaemonSet is ready")
+		gfd := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, gfdKey, gfd)).To(Succeed())
+		gfd.Status.ObservedGeneration = gfd.Generation
+		gfd.Status.DesiredNumberScheduled = 2
+		gfd.Status.NumberReady = 2
+		gfd.Status.NumberAvailable = 2
+		gfd.Status.UpdatedNumberScheduled = 2
+		Expect(k8sClient.Status().Update(ctx, gfd)).To(Succeed())
+
+		result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		rollout = componentStatus(policy, "nvidia-gpu-feature-discovery").LastRollout
+		Expect(rollout.CompletionTime).NotTo(BeNil())
+		Expect(rollout.Ready).NotTo(BeNil())
+		Expect(rollout.Total).NotTo(BeNil())
+		Expect(rollout.PrePull).To(BeNil())
+
+		By("reporting the pods and the image of the DaemonSet")
+		status := componentStatus(policy, "nvidia-gpu-feature-discovery")
+		Expect(status.Pods).To(Equal(&npuv1alpha1.ComponentPods{Desired: 2, Ready: 2, Available: 2, Updated: 2}))
+		Expect(status.RolledOutImage).To(Equal("nvcr.io/nvidia/k8s-device-plugin:v0.17.1"))
+		Expect(status.NotReadySince).To(BeNil())
+		Expect(conditions.IsFalse(policy, conditions.Progressing)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Health polling", func() {
+	It("should back off with the time a component has not been ready", func() {
+		Expect(healthPollInterval(0)).To(Equal(healthPollMinInterval))
+		Expect(healthPollInterval(20 * time.Second)).To(Equal(20 * time.Second))
+		Expect(healthPollInterval(time.Hour)).To(Equal(healthPollMaxInterval))
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	defaultSafeModeWindow = 10 * time.Minute
+	safeModePollInterval  = 30 * time.Second
+)
+
+// -- componentStatus returns the status entry of a component, adding it when missing
+func componentStatus(policy *npuv1alpha1.NPUClusterPolicy, name string) *npuv1alpha1.ComponentStatus {
+	for i := range policy.Status.Components {
+		if policy.Status.Components[i].Name == name {
+			return &policy.Status.Components[i]
+		}
+	}
+	policy.Status.Components = append(policy.Status.Components, npuv1alpha1.ComponentStatus{Name: name})
+	return &policy.Status.Components[len(policy.Status.Components)-1]
+}
+
+// -- findComponentStatus returns the status entry of a component, or nil when missing
+func findComponentStatus(policy *npuv1alpha1.NPUClusterPolicy, name string) *npuv1alpha1.ComponentStatus {
+	for i := range policy.Status.Components {
+		if policy.Status.Components[i].Name == name {
+			return &policy.Status.Components[i]
+		}
+	}
+	return nil
+}
+
+// -- markChanged records that the operator just applied a change to a component
+func markChanged(policy *npuv1alpha1.NPUClusterPolicy, name string) {
+	now := metav1.Now()
+	componentStatus(policy, name).LastChangeTime = &now
+}
+
+// -- inSafeMode reports whether changes to the component are frozen
+func inSafeMode(policy *npuv1alpha1.NPUClusterPolicy, name string) bool {
+	for _, c := range policy.Status.Components {
+		if c.Name == name {
+			return c.SafeMode
+		}
+	}
+	return false
+}
+
+// -- acknowledgeSafeMode releases frozen components named by the acknowledge annotation
+func (r *NPUClusterPolicyReconciler) acknowledgeSafeMode(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	value, ok := policy.Annotations[npuv1alpha1.AcknowledgeSafeModeAnnotation]
+	if !ok {
+		return nil
+	}
+	patch := client.MergeFrom(policy.DeepCopy())
+	delete(policy.Annotations, npuv1alpha1.AcknowledgeSafeModeAnnotation)
+	if err := r.Patch(ctx, policy, patch); err != nil {
+		return err
+	}
+
+	var released []string
+	for i := range policy.Status.Components {
+		c := &policy.Status.Components[i]
+		if c.SafeMode && (value == "true" || value == c.Name) {
+			c.SafeMode = false
+			released = append(released, c.Name)
+		}
+	}
+	if len(released) == 0 {
+		return nil
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeNormal, "SafeModeAcknowledged",
+		"Safe mode acknowledged, resuming changes to %s", strings.Join(released, ", "))
+	r.updateSafeModeCondition(policy)
+	return nil
+}
+
+// -- checkSafeMode freezes components whose pods crash loop within the window after an operator change.
+// It returns how long to wait before checking again while a window is still open.
+func (r *NPUClusterPolicyReconciler) checkSafeMode(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	if !policy.Spec.SafeMode.Enabled {
+		return 0, nil
+	}
+	window := defaultSafeModeWindow
+	if policy.Spec.SafeMode.Window != nil {
+		window = policy.Spec.SafeMode.Window.Duration
+	}
+
+	var requeue time.Duration
+	for i := range policy.Status.Components {
+		c := &policy.Status.Components[i]
+		if c.SafeMode || c.LastChangeTime == nil || time.Since(c.LastChangeTime.Time) > window {
+			continue
+		}
+		requeue = safeModePollInterval
+
+		crashing, err := r.crashLoopingPod(ctx, policy, c.Name)
+		if err != nil {
+			return 0, err
+		}
+		if crashing == "" {
+			continue
+		}
+		c.SafeMode = true
+		log.Info("Component crash loops after an operator change, entering safe mode", "component", c.Name, "pod", crashing)
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "SafeModeEntered",
+			"Pod %s of %s is in CrashLoopBackOff within %s of an operator change; further changes are frozen until "+
+				"the policy is annotated with %s=%s", crashing, c.Name, window, npuv1alpha1.AcknowledgeSafeModeAnnotation, c.Name)
+	}
+	r.updateSafeModeCondition(policy)
+	return requeue, nil
+}
+
+// -- crashLoopingPod returns the name of a pod of the component that is in CrashLoopBackOff
+func (r *NPUClusterPolicyReconciler) crashLoopingPod(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy, component string) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(componentNamespace(policy)),
+		client.MatchingLabels{"app.kubernetes.io/name": component}); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				return pod.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (r *NPUClusterPolicyReconciler) updateSafeModeCondition(policy *npuv1alpha1.NPUClusterPolicy) {
+	var frozen []string
+	for _, c := range policy.Status.Components {
+		if c.SafeMode {
+			frozen = append(frozen, c.Name)
+		}
+	}
+	if len(frozen) == 0 {
+		if conditions.Get(policy, conditions.SafeMode) != nil {
+			conditions.MarkFalse(policy, conditions.SafeMode, conditions.ReasonAcknowledged, "")
+		}
+		return
+	}
+	conditions.MarkTrue(policy, conditions.SafeMode, conditions.ReasonCrashLoop,
+		fmt.Sprintf("Changes are frozen for %s", strings.Join(frozen, ", ")))
+}
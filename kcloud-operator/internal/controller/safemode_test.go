@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Safe mode", func() {
+	const resourceName = "safe-mode"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	podKey := types.NamespacedName{Name: "furiosa-device-plugin-crashing", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:           true,
+					DevicePluginImage: "ghcr.io/furiosa-ai/k8s-device-plugin:latest",
+					ConfigMapName:     "furiosa-device-plugin",
+				},
+				SafeMode: npuv1alpha1.SafeModeSpec{Enabled: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": furiosaDevicePluginName},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "furiosa-device-plugin", Image: "busybox"}}},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "furiosa-device-plugin",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		pod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, podKey, pod)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, pod)).To(Succeed())
+	})
+
+	It("should freeze a component that crash loops after a change until acknowledged", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		By("recording a recent operator change to the Furiosa device plugin")
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		markChanged(policy, furiosaDevicePluginName)
+		Expect(k8sClient.Status().Update(ctx, policy)).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(inSafeMode(policy, furiosaDevicePluginName)).To(BeTrue())
+		Expect(conditions.IsTrue(policy, conditions.SafeMode)).To(BeTrue())
+
+		By("acknowledging safe mode through the annotation")
+		policy.Annotations = map[string]string{npuv1alpha1.AcknowledgeSafeModeAnnotation: furiosaDevicePluginName}
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		pod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, podKey, pod)).To(Succeed())
+		pod.Status.ContainerStatuses = nil
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Annotations).NotTo(HaveKey(npuv1alpha1.AcknowledgeSafeModeAnnotation))
+		Expect(inSafeMode(policy, furiosaDevicePluginName)).To(BeFalse())
+		Expect(conditions.IsFalse(policy, conditions.SafeMode)).To(BeTrue())
+	})
+})
@@ -0,0 +1,372 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	schedulerComponentLabel  = "npu-scheduler"
+	schedulerConfigKey       = "config.yaml"
+	schedulerConfigHashLabel = "npu.ai/scheduler-config-hash"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;rolebindings,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames="system:kube-scheduler";"system:volume-scheduler"
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=bind,resourceNames=extension-apiserver-authentication-reader
+// +kubebuilder:rbac:groups=npu.ai,resources=npunodes,verbs=get;list;watch
+
+// -- ensureScheduler deploys the accelerator-aware scheduler and taints the hot nodes it avoids
+func (r *NPUClusterPolicyReconciler) ensureScheduler(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+
+	spec := policy.Spec.Scheduler
+	if spec == nil {
+		removed, err := r.removeScheduler(ctx, policy, "")
+		if err != nil || !removed {
+			return err
+		}
+		return r.syncThermalTaints(ctx, nil)
+	}
+	name := spec.SchedulerName
+	condition := conditions.ComponentReady(name)
+	if _, err := r.removeScheduler(ctx, policy, name); err != nil {
+		return err
+	}
+
+	resources, err := r.acceleratorResourceNames(ctx)
+	if err != nil {
+		return err
+	}
+	config, err := schedulerConfig(spec, resources)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(config)
+	hash := hex.EncodeToString(sum[:8])
+	image := spec.Image + ":" + spec.Version
+
+	labels := mergeLabels(map[string]string{
+		"app.kubernetes.io/name":      name,
+		"app.kubernetes.io/component": schedulerComponentLabel,
+	}, policyLabels(policy))
+	objects := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: componentNamespace(policy), Labels: labels}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: componentNamespace(policy), Labels: labels},
+			Data:       map[string]string{schedulerConfigKey: string(config)},
+		},
+		schedulerClusterRoleBinding(name, componentNamespace(policy), "system:kube-scheduler", labels),
+		schedulerClusterRoleBinding(name, componentNamespace(policy), "system:volume-scheduler", labels),
+		&rbacv1.RoleBinding{
+			// The authentication reader Role is only bound in kube-system.
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-auth-reader", Namespace: "kube-system", Labels: labels},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName, Kind: "Role", Name: "extension-apiserver-authentication-reader",
+			},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: componentNamespace(policy)}},
+		},
+		schedulerDeployment(name, componentNamespace(policy), image, hash, labels, imagePullSecrets(policy)),
+	}
+	for _, desired := range objects {
+		if err := r.applySchedulerObject(ctx, policy, desired); err != nil {
+			log.Error(err, "failed to apply scheduler object", "kind", fmt.Sprintf("%T", desired), "name", desired.GetName())
+			conditions.MarkFalse(policy, condition, conditions.ReasonReconcileFailed, err.Error())
+			return err
+		}
+	}
+	conditions.MarkTrue(policy, condition, conditions.ReasonReconciled,
+		fmt.Sprintf("Deployment %s/%s runs %s", componentNamespace(policy), name, image))
+
+	var hot []string
+	if spec.TemperatureWeight > 0 {
+		for _, s := range policy.Status.Thermal {
+			hot = append(hot, s.Node)
+		}
+	}
+	return r.syncThermalTaints(ctx, hot)
+}
+
+// -- applySchedulerObject creates the object or replaces the spec of an existing one
+func (r *NPUClusterPolicyReconciler) applySchedulerObject(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	desired client.Object) error {
+	obj := desired.DeepCopyObject().(client.Object)
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		obj.SetLabels(desired.GetLabels())
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			o.Data = desired.(*corev1.ConfigMap).Data
+		case *rbacv1.ClusterRoleBinding:
+			o.Subjects = desired.(*rbacv1.ClusterRoleBinding).Subjects
+		case *rbacv1.RoleBinding:
+			o.Subjects = desired.(*rbacv1.RoleBinding).Subjects
+		case *appsv1.Deployment:
+			o.Spec = desired.(*appsv1.Deployment).Spec
+		}
+		return r.setOwner(policy, obj)
+	})
+	return err
+}
+
+// -- removeScheduler deletes the schedulers of the policy other than keep and reports whether there were any
+func (r *NPUClusterPolicyReconciler) removeScheduler(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	keep string) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(componentNamespace(policy)), client.MatchingLabels(mergeLabels(
+		map[string]string{"app.kubernetes.io/component": schedulerComponentLabel}, policyLabels(policy)))); err != nil {
+		return false, err
+	}
+	removed := false
+	for _, d := range deployments.Items {
+		if d.Name == keep {
+			continue
+		}
+		logf.FromContext(ctx).Info("Removing scheduler", "name", d.Name)
+		for _, obj := range []client.Object{
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: d.Name, Namespace: componentNamespace(policy)}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: d.Name, Namespace: componentNamespace(policy)}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: d.Name, Namespace: componentNamespace(policy)}},
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: d.Name + "-auth-reader", Namespace: "kube-system"}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: d.Name + "-kube-scheduler"}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: d.Name + "-volume-scheduler"}},
+		} {
+			if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+		conditions.Remove(policy, conditions.ComponentReady(d.Name))
+		removed = true
+	}
+	return removed, nil
+}
+
+// -- syncThermalTaints puts the thermal pressure taint on the hot nodes and lifts it from all other
+// managed nodes
+func (r *NPUClusterPolicyReconciler) syncThermalTaints(ctx context.Context, hot []string) error {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if unmanagedNode(node) {
+			continue
+		}
+		want := slices.Contains(hot, node.Name)
+		has := slices.ContainsFunc(node.Spec.Taints, isThermalPressureTaint)
+		if want == has {
+			continue
+		}
+		patch := client.MergeFrom(node.DeepCopy())
+		if want {
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+				Key:    npuv1alpha1.ThermalPressureTaint,
+				Value:  "true",
+				Effect: corev1.TaintEffectPreferNoSchedule,
+			})
+		} else {
+			node.Spec.Taints = slices.DeleteFunc(node.Spec.Taints, isThermalPressureTaint)
+		}
+		if err := r.Patch(ctx, node, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isThermalPressureTaint(t corev1.Taint) bool {
+	return t.Key == npuv1alpha1.ThermalPressureTaint
+}
+
+// -- acceleratorResourceNames returns the accelerator resources advertised by any NPUNode
+func (r *NPUClusterPolicyReconciler) acceleratorResourceNames(ctx context.Context) ([]string, error) {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := r.List(ctx, npuNodes); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, n := range npuNodes.Items {
+		for name := range n.Status.Capacity {
+			if !slices.Contains(names, string(name)) {
+				names = append(names, string(name))
+			}
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// -- schedulerConfig renders the KubeSchedulerConfiguration of the scheduler profile. Accelerator
+// requests are scored by RequestedToCapacityRatio, preferring nodes whose accelerators are most
+// used, and hot nodes are scored down through their PreferNoSchedule taint.
+func schedulerConfig(spec *npuv1alpha1.SchedulerSpec, resources []string) ([]byte, error) {
+	score := map[string]interface{}{
+		"enabled": []interface{}{
+			map[string]interface{}{"name": "NodeResourcesFit", "weight": spec.BinPackingWeight},
+		},
+	}
+	if spec.TemperatureWeight > 0 {
+		score["enabled"] = append(score["enabled"].([]interface{}),
+			map[string]interface{}{"name": "TaintToleration", "weight": spec.TemperatureWeight})
+	} else {
+		score["disabled"] = []interface{}{map[string]interface{}{"name": "TaintToleration"}}
+	}
+	profile := map[string]interface{}{
+		"schedulerName": spec.SchedulerName,
+		"plugins":       map[string]interface{}{"score": score},
+	}
+	if len(resources) > 0 {
+		var weighted []interface{}
+		for _, name := range resources {
+			weighted = append(weighted, map[string]interface{}{"name": name, "weight": 1})
+		}
+		profile["pluginConfig"] = []interface{}{
+			map[string]interface{}{
+				"name": "NodeResourcesFit",
+				"args": map[string]interface{}{
+					"scoringStrategy": map[string]interface{}{
+						"type":      "RequestedToCapacityRatio",
+						"resources": weighted,
+						"requestedToCapacityRatio": map[string]interface{}{
+							"shape": []interface{}{
+								map[string]interface{}{"utilization": 0, "score": 0},
+								map[string]interface{}{"utilization": 100, "score": 10},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion":     "kubescheduler.config.k8s.io/v1",
+		"kind":           "KubeSchedulerConfiguration",
+		"leaderElection": map[string]interface{}{"leaderElect": false},
+		"profiles":       []interface{}{profile},
+	})
+}
+
+func schedulerClusterRoleBinding(name, namespace, role string, labels map[string]string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-" + role[len("system:"):], Labels: labels},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}},
+	}
+}
+
+// -- schedulerDeployment builds the Deployment of the scheduler. The configuration hash in the
+// pod template restarts the scheduler when its configuration changes.
+func schedulerDeployment(name, namespace, image, hash string, labels map[string]string,
+	pullSecrets []corev1.LocalObjectReference) *appsv1.Deployment {
+	selector := map[string]string{"app.kubernetes.io/name": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptrTo(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: mergeLabels(selector, map[string]string{schedulerConfigHashLabel: hash}),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: name,
+					PriorityClassName:  "system-cluster-critical",
+					ImagePullSecrets:   pullSecrets,
+					Containers: []corev1.Container{{
+						Name:            "kube-scheduler",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"kube-scheduler", "--config=/etc/npu-scheduler/" + schedulerConfigKey},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "config", MountPath: "/etc/npu-scheduler", ReadOnly: true},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							Privileged:               boolPtr(false),
+							AllowPrivilegeEscalation: boolPtr(false),
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: name},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// -- policiesForNPUNode maps an NPUNode to the policies deploying a scheduler, whose
+// configuration lists the accelerator resources of all NPUNodes, or labeling device preference pools
+func (r *NPUClusterPolicyReconciler) policiesForNPUNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list policies for NPUNode", "node", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policies.Items {
+		if p.Spec.Scheduler != nil || len(p.Spec.DevicePreferences) > 0 {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+		}
+	}
+	return requests
+}
+
+// npuNodeResourcesChanged passes NPUNode events that change the set of advertised resources.
+var npuNodeResourcesChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		before := e.ObjectOld.(*npuv1alpha1.NPUNode).Status.Capacity
+		after := e.ObjectNew.(*npuv1alpha1.NPUNode).Status.Capacity
+		if len(before) != len(after) {
+			return true
+		}
+		for name := range after {
+			if _, ok := before[name]; !ok {
+				return true
+			}
+		}
+		return false
+	},
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Accelerator-aware scheduler", func() {
+	const resourceName = "scheduler"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	schedulerKey := types.NamespacedName{Name: "npu-scheduler", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Scheduler: &npuv1alpha1.SchedulerSpec{Version: "v1.33.0", TemperatureWeight: 3},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should deploy the scheduler and remove it once disabled", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, schedulerKey, deployment)).To(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.k8s.io/kube-scheduler:v1.33.0"))
+		Expect(k8sClient.Get(ctx, schedulerKey, &corev1.ConfigMap{})).To(Succeed())
+
+		By("disabling the scheduler")
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		policy.Spec.Scheduler = nil
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.Get(ctx, schedulerKey, &appsv1.Deployment{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Scheduler configuration", func() {
+	It("should pack the accelerator resources and score hot nodes down", func() {
+		spec := &npuv1alpha1.SchedulerSpec{SchedulerName: "npu-scheduler", BinPackingWeight: 5, TemperatureWeight: 3}
+		raw, err := schedulerConfig(spec, []string{"furiosa.ai/npu", "nvidia.com/gpu"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).To(ContainSubstring("type: RequestedToCapacityRatio"))
+		Expect(string(raw)).To(ContainSubstring("name: nvidia.com/gpu"))
+
+		var config struct {
+			Profiles []struct {
+				SchedulerName string `json:"schedulerName"`
+				Plugins       struct {
+					Score struct {
+						Enabled []struct {
+							Name   string `json:"name"`
+							Weight int32  `json:"weight"`
+						} `json:"enabled"`
+					} `json:"score"`
+				} `json:"plugins"`
+			} `json:"profiles"`
+		}
+		Expect(yaml.Unmarshal(raw, &config)).To(Succeed())
+		Expect(config.Profiles).To(HaveLen(1))
+		Expect(config.Profiles[0].SchedulerName).To(Equal("npu-scheduler"))
+		Expect(config.Profiles[0].Plugins.Score.Enabled).To(HaveLen(2))
+		Expect(config.Profiles[0].Plugins.Score.Enabled[1].Name).To(Equal("TaintToleration"))
+		Expect(config.Profiles[0].Plugins.Score.Enabled[1].Weight).To(Equal(int32(3)))
+	})
+
+	It("should leave taint scoring disabled without a temperature weight", func() {
+		spec := &npuv1alpha1.SchedulerSpec{SchedulerName: "npu-scheduler", BinPackingWeight: 5}
+		raw, err := schedulerConfig(spec, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).To(ContainSubstring("disabled:"))
+		Expect(string(raw)).NotTo(ContainSubstring("RequestedToCapacityRatio"))
+	})
+})
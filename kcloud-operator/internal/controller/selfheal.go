@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+const defaultSelfHealingInterval = 30 * time.Second
+
+// deletionTracker remembers managed DaemonSets deleted since the owning policy was
+// last reconciled. The value tells whether the deletion was already counted.
+type deletionTracker struct {
+	mu      sync.Mutex
+	pending map[types.NamespacedName]map[string]bool
+}
+
+func (t *deletionTracker) record(key types.NamespacedName, name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending == nil {
+		t.pending = map[types.NamespacedName]map[string]bool{}
+	}
+	if t.pending[key] == nil {
+		t.pending[key] = map[string]bool{}
+	}
+	t.pending[key][name] = false
+}
+
+// -- take returns the deletions not counted yet and marks them as counted
+func (t *deletionTracker) take(key types.NamespacedName) (uncounted []string, pending bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name, counted := range t.pending[key] {
+		if !counted {
+			uncounted = append(uncounted, name)
+			t.pending[key][name] = true
+		}
+	}
+	return uncounted, len(t.pending[key]) > 0
+}
+
+func (t *deletionTracker) clear(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, key)
+}
+
+// -- onDaemonSetDeleted records the deletion of a managed DaemonSet and enqueues its policy
+func (r *NPUClusterPolicyReconciler) onDaemonSetDeleted(ctx context.Context, e event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	key, ok := policyKeyFromLabels(e.Object)
+	if !ok {
+		r.enqueuePluginConflicts(ctx, e.Object, q)
+		return
+	}
+	r.deletions.record(key, e.Object.GetName())
+	q.Add(reconcile.Request{NamespacedName: key})
+}
+
+// -- onDaemonSetChanged enqueues the policy of a managed DaemonSet whose spec or pod counts changed,
+// so edits are corrected and the status follows the rollout without waiting for the policy to change
+func (r *NPUClusterPolicyReconciler) onDaemonSetChanged(ctx context.Context, e event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldDS, okOld := e.ObjectOld.(*appsv1.DaemonSet)
+	newDS, okNew := e.ObjectNew.(*appsv1.DaemonSet)
+	if e.ObjectOld.GetGeneration() == e.ObjectNew.GetGeneration() &&
+		(!okOld || !okNew || daemonSetPods(oldDS) == daemonSetPods(newDS)) {
+		return
+	}
+	if key, ok := policyKeyFromLabels(e.ObjectNew); ok {
+		q.Add(reconcile.Request{NamespacedName: key})
+		return
+	}
+	r.enqueuePluginConflicts(ctx, e.ObjectNew, q)
+}
+
+// -- healDeletedComponents accounts for out-of-band deletions before the components are ensured.
+// It returns blocked when recreation needs approval, or a delay when recreation is rate limited.
+func (r *NPUClusterPolicyReconciler) healDeletedComponents(ctx context.Context,
+	policy *npuv1alpha1.NPUClusterPolicy) (blocked bool, wait time.Duration, err error) {
+	log := logf.FromContext(ctx)
+	key := client.ObjectKeyFromObject(policy)
+
+	healing := policy.Status.SelfHealing
+	if healing == nil {
+		healing = &npuv1alpha1.SelfHealingStatus{}
+	}
+	before := *healing
+	now := metav1.Now()
+
+	if policy.Annotations[npuv1alpha1.ApproveRecreateAnnotation] == "true" {
+		patch := client.MergeFrom(policy.DeepCopy())
+		delete(policy.Annotations, npuv1alpha1.ApproveRecreateAnnotation)
+		if err := r.Patch(ctx, policy, patch); err != nil {
+			return false, 0, err
+		}
+		healing.UnexpectedDeletions = 0
+		healing.ApprovalRequired = false
+		r.Recorder.Event(policy, corev1.EventTypeNormal, "RecreationApproved",
+			"Recreation of deleted components was approved, resetting the deletion counter")
+	}
+
+	expected := map[string]bool{}
+	for _, name := range r.managedDaemonSetNames(policy) {
+		expected[name] = true
+	}
+	deleted, pending := r.deletions.take(key)
+	for _, name := range deleted {
+		if !expected[name] {
+			continue
+		}
+		healing.UnexpectedDeletions++
+		healing.LastDeletionTime = &now
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "ComponentDeleted",
+			"DaemonSet %s was deleted out-of-band (%d unexpected deletions)", name, healing.UnexpectedDeletions)
+	}
+
+	limit := policy.Spec.SelfHealing.MaxUnexpectedDeletions
+	if limit > 0 && healing.UnexpectedDeletions >= limit && !healing.ApprovalRequired {
+		healing.ApprovalRequired = true
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "RecreationBlocked",
+			"%d unexpected deletions reached the limit; annotate the policy with %s=true to resume",
+			healing.UnexpectedDeletions, npuv1alpha1.ApproveRecreateAnnotation)
+	}
+
+	switch {
+	case healing.ApprovalRequired:
+		r.deletions.clear(key)
+		blocked = true
+	case pending:
+		interval := defaultSelfHealingInterval
+		if policy.Spec.SelfHealing.MinInterval != nil {
+			interval = policy.Spec.SelfHealing.MinInterval.Duration
+		}
+		if last := healing.LastRecreationTime; last != nil && now.Sub(last.Time) < interval {
+			wait = interval - now.Sub(last.Time)
+			log.Info("Rate limiting recreation of deleted components", "after", wait)
+			break
+		}
+		r.deletions.clear(key)
+		healing.LastRecreationTime = &now
+	}
+
+	if before != *healing {
+		policy.Status.SelfHealing = healing
+		if err := r.Status().Update(ctx, policy); err != nil {
+			return false, 0, err
+		}
+	}
+	return blocked, wait, nil
+}
+
+// -- managedDaemonSetNames lists the DaemonSets the policy currently wants to exist
+func (r *NPUClusterPolicyReconciler) managedDaemonSetNames(policy *npuv1alpha1.NPUClusterPolicy) []string {
+	var names []string
+	for _, c := range enabledComponents(policy) {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// -- componentNamespace returns the namespace the components of the policy are deployed to
+func componentNamespace(policy *npuv1alpha1.NPUClusterPolicy) string {
+	if policy.Spec.Namespace != "" {
+		return policy.Spec.Namespace
+	}
+	return npuv1alpha1.DefaultComponentNamespace
+}
+
+// -- policyLabels returns the labels that point a managed object back at its policy
+func policyLabels(policy *npuv1alpha1.NPUClusterPolicy) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by":   "npu-operator",
+		npuv1alpha1.PolicyNameLabel:      policy.Name,
+		npuv1alpha1.PolicyNamespaceLabel: policy.Namespace,
+	}
+}
+
+// -- setOwner makes the policy the controller of an object in its own namespace, so the
+// object is garbage-collected with the policy. Owner references cannot cross namespaces,
+// so objects elsewhere are only linked to the policy by its labels.
+func (r *NPUClusterPolicyReconciler) setOwner(policy *npuv1alpha1.NPUClusterPolicy, obj client.Object) error {
+	if obj.GetNamespace() != policy.Namespace {
+		return nil
+	}
+	return controllerutil.SetControllerReference(policy, obj, r.Scheme)
+}
+
+func policyKeyFromLabels(obj client.Object) (types.NamespacedName, bool) {
+	labels := obj.GetLabels()
+	name, ok := labels[npuv1alpha1.PolicyNameLabel]
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: labels[npuv1alpha1.PolicyNamespaceLabel], Name: name}, true
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Self-healing of deleted components", func() {
+	const resourceName = "self-healing"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Nvidia:      npuv1alpha1.NvidiaSpec{Enabled: true, DevicePluginImage: "nvcr.io/nvidia/k8s-device-plugin:v0.17.1"},
+				SelfHealing: npuv1alpha1.SelfHealingSpec{MaxUnexpectedDeletions: 1},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+	})
+
+	It("should require approval after too many unexpected deletions", func() {
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		By("recording an out-of-band deletion of the NVIDIA device plugin")
+		controllerReconciler.deletions.record(key, nvidiaDevicePluginName)
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.SelfHealing).NotTo(BeNil())
+		Expect(policy.Status.SelfHealing.UnexpectedDeletions).To(Equal(int32(1)))
+		Expect(policy.Status.SelfHealing.ApprovalRequired).To(BeTrue())
+
+		By("approving recreation through the annotation")
+		policy.Annotations = map[string]string{npuv1alpha1.ApproveRecreateAnnotation: "true"}
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Annotations).NotTo(HaveKey(npuv1alpha1.ApproveRecreateAnnotation))
+		Expect(policy.Status.SelfHealing.ApprovalRequired).To(BeFalse())
+		Expect(policy.Status.SelfHealing.UnexpectedDeletions).To(BeZero())
+	})
+})
+
+var _ = Describe("Changes to managed DaemonSets", func() {
+	It("should enqueue the policy when the spec or pods of its DaemonSet change", func() {
+		r := &NPUClusterPolicyReconciler{}
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "edited", Namespace: "default"}}
+		old := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name: nvidiaDevicePluginName, Namespace: "kube-system", Generation: 1, Labels: policyLabels(policy),
+		}}
+		statusOnly := old.DeepCopy()
+		r.onDaemonSetChanged(context.Background(), event.UpdateEvent{ObjectOld: old, ObjectNew: statusOnly}, q)
+		Expect(q.Len()).To(BeZero())
+
+		edited := old.DeepCopy()
+		edited.Generation = 2
+		r.onDaemonSetChanged(context.Background(), event.UpdateEvent{ObjectOld: old, ObjectNew: edited}, q)
+		Expect(q.Len()).To(Equal(1))
+		item, _ := q.Get()
+		Expect(item.NamespacedName).To(Equal(client.ObjectKeyFromObject(policy)))
+		q.Done(item)
+
+		By("enqueueing the policy when the pod counts change")
+		progressed := old.DeepCopy()
+		progressed.Status.NumberReady = 1
+		r.onDaemonSetChanged(context.Background(), event.UpdateEvent{ObjectOld: old, ObjectNew: progressed}, q)
+		Expect(q.Len()).To(Equal(1))
+	})
+})
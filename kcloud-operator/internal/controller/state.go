@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// policyState is the bookkeeping of a policy kept in the state store: the component
+// statuses with their rollout progress and last-known-good images.
+type policyState struct {
+	Components []npuv1alpha1.ComponentStatus `json:"components,omitempty"`
+}
+
+// nodeState is the bookkeeping of an NPUNode kept in the state store.
+type nodeState struct {
+	Remediation        *npuv1alpha1.RemediationState   `json:"remediation,omitempty"`
+	RemediationHistory []npuv1alpha1.RemediationRecord `json:"remediationHistory,omitempty"`
+	BurnInHistory      []npuv1alpha1.BurnInRecord      `json:"burnInHistory,omitempty"`
+}
+
+func policyStateKey(key types.NamespacedName) string {
+	return "policy." + key.Namespace + "." + key.Name
+}
+
+func nodeStateKey(name string) string {
+	return "node." + name
+}
+
+// -- restorePolicyState restores component statuses missing from the policy status from the store
+func (r *NPUClusterPolicyReconciler) restorePolicyState(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if r.State == nil {
+		return nil
+	}
+	var state policyState
+	found, err := r.State.Load(ctx, policyStateKey(types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}), &state)
+	if err != nil || !found {
+		return err
+	}
+	var restored []string
+	for _, saved := range state.Components {
+		if componentImage(policy, saved.Name) != "" {
+			continue
+		}
+		*componentStatus(policy, saved.Name) = saved
+		restored = append(restored, saved.Name)
+	}
+	if len(restored) > 0 {
+		logf.FromContext(ctx).Info("Restored component bookkeeping from the state store", "components", restored)
+	}
+	return nil
+}
+
+// -- savePolicyState stores the component statuses of the policy when they changed
+func (r *NPUClusterPolicyReconciler) savePolicyState(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	if r.State == nil {
+		return nil
+	}
+	key := policyStateKey(types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name})
+	var saved policyState
+	if _, err := r.State.Load(ctx, key, &saved); err != nil {
+		return err
+	}
+	state := policyState{Components: policy.Status.Components}
+	if equality.Semantic.DeepEqual(saved, state) {
+		return nil
+	}
+	return r.State.Save(ctx, key, state)
+}
+
+// -- restoreNodeState restores the remediation and burn-in bookkeeping of a recreated NPUNode from the store
+func (r *NPUNodeReconciler) restoreNodeState(ctx context.Context, npuNode *npuv1alpha1.NPUNode) error {
+	if r.State == nil {
+		return nil
+	}
+	var state nodeState
+	found, err := r.State.Load(ctx, nodeStateKey(npuNode.Name), &state)
+	if err != nil || !found {
+		return err
+	}
+	npuNode.Status.Remediation = state.Remediation
+	npuNode.Status.RemediationHistory = state.RemediationHistory
+	npuNode.Status.BurnInHistory = state.BurnInHistory
+	return nil
+}
+
+// -- saveNodeState stores the remediation and burn-in bookkeeping of the NPUNode when it changed
+func (r *NPUNodeReconciler) saveNodeState(ctx context.Context, npuNode *npuv1alpha1.NPUNode) error {
+	if r.State == nil {
+		return nil
+	}
+	key := nodeStateKey(npuNode.Name)
+	var saved nodeState
+	if _, err := r.State.Load(ctx, key, &saved); err != nil {
+		return err
+	}
+	state := nodeState{
+		Remediation:        npuNode.Status.Remediation,
+		RemediationHistory: npuNode.Status.RemediationHistory,
+		BurnInHistory:      npuNode.Status.BurnInHistory,
+	}
+	if equality.Semantic.DeepEqual(saved, state) {
+		return nil
+	}
+	return r.State.Save(ctx, key, state)
+}
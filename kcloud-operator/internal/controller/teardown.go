@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+// -- teardown deletes the components of a policy being deleted and then releases its finalizer.
+// The components are found by their policy labels, as owner references cannot reach them.
+func (r *NPUClusterPolicyReconciler) teardown(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+	if !controllerutil.ContainsFinalizer(policy, npuv1alpha1.PolicyFinalizer) {
+		return nil
+	}
+
+	for _, c := range policy.Status.Components {
+		if err := r.deleteGreenDaemonSet(ctx, policy, c.Name); err != nil {
+			return err
+		}
+		if err := r.deletePrePullDaemonSet(ctx, policy, c.Name); err != nil {
+			return err
+		}
+	}
+	removed, err := r.removeScheduler(ctx, policy, "")
+	if err != nil {
+		return err
+	}
+	if removed {
+		if err := r.syncThermalTaints(ctx, nil); err != nil {
+			return err
+		}
+	}
+	for _, list := range []client.ObjectList{&appsv1.DaemonSetList{}, &corev1.ConfigMapList{}, &batchv1.JobList{},
+		&corev1.ServiceList{}, &corev1.SecretList{}} {
+		if err := r.List(ctx, list, client.InNamespace(componentNamespace(policy)), client.MatchingLabels(policyLabels(policy))); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			log.Info("Deleting component object", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+				!apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	r.Recorder.Event(policy, corev1.EventTypeNormal, "ComponentsDeleted", "Deleted the components of the policy")
+
+	controllerutil.RemoveFinalizer(policy, npuv1alpha1.PolicyFinalizer)
+	return r.Update(ctx, policy)
+}
+
+// -- pruneDisabledComponents deletes the components the policy deployed before their vendor or the
+// component itself was disabled. Deployed components are found by their policy labels.
+func (r *NPUClusterPolicyReconciler) pruneDisabledComponents(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) error {
+	log := logf.FromContext(ctx)
+
+	disabled := map[string]bool{}
+	for _, c := range append(nvidiaComponents(policy), furiosaComponents(policy)...) {
+		disabled[c.name] = true
+	}
+	for _, c := range enabledComponents(policy) {
+		delete(disabled, c.name)
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := r.List(ctx, &daemonSets, client.InNamespace(componentNamespace(policy)), client.MatchingLabels(policyLabels(policy))); err != nil {
+		return err
+	}
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		if !disabled[ds.Name] {
+			continue
+		}
+		log.Info("Deleting disabled component", "component", ds.Name)
+		if err := r.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			return err
+		}
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ComponentRemoved", "Deleted DaemonSet %s of the disabled component", ds.Name)
+	}
+
+	var services corev1.ServiceList
+	if err := r.List(ctx, &services, client.InNamespace(componentNamespace(policy)), client.MatchingLabels(policyLabels(policy))); err != nil {
+		return err
+	}
+	for i := range services.Items {
+		if !disabled[services.Items[i].Name] {
+			continue
+		}
+		if err := r.Delete(ctx, &services.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if !policy.Spec.Furiosa.Enabled && policy.Spec.Furiosa.ConfigMapName != "" {
+		if err := r.deleteRenderedConfigMap(ctx, policy, policy.Spec.Furiosa.ConfigMapName); err != nil {
+			return err
+		}
+	}
+	if !policy.Spec.Nvidia.Enabled {
+		if err := r.deleteRenderedConfigMap(ctx, policy, nvidiaDevicePluginConfigName); err != nil {
+			return err
+		}
+	}
+
+	// The blue-green and pre-pull DaemonSets and the status of a component follow its main DaemonSet.
+	components := policy.Status.Components[:0]
+	for _, c := range policy.Status.Components {
+		if !disabled[c.Name] {
+			components = append(components, c)
+			continue
+		}
+		if err := r.deleteGreenDaemonSet(ctx, policy, c.Name); err != nil {
+			return err
+		}
+		if err := r.deletePrePullDaemonSet(ctx, policy, c.Name); err != nil {
+			return err
+		}
+		conditions.Remove(policy, conditions.ComponentReady(c.Name))
+	}
+	policy.Status.Components = components
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// deletePolicy deletes a policy and reconciles it, so its finalizer is released.
+func deletePolicy(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) {
+	Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+	controllerReconciler := &NPUClusterPolicyReconciler{
+		Client:   k8sClient,
+		Scheme:   k8sClient.Scheme(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+	_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy))).To(BeTrue())
+}
+
+var _ = Describe("Teardown", func() {
+	const resourceName = "teardown"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+	It("should delete the components in kube-system before the policy is removed", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "teardown-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Finalizers).To(ContainElement(npuv1alpha1.PolicyFinalizer))
+		pluginKey := types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "kube-system"}
+		configKey := types.NamespacedName{Name: "teardown-furiosa-config", Namespace: "kube-system"}
+		Expect(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})).To(Succeed())
+		Expect(k8sClient.Get(ctx, configKey, &corev1.ConfigMap{})).To(Succeed())
+
+		deletePolicy(ctx, policy)
+
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{}))).To(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, configKey, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+	It("should delete the components of a vendor once it is disabled", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName + "-disable", Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "teardown-disable-furiosa-config",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin", Version: "0.10.1"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		policyKey := client.ObjectKeyFromObject(policy)
+		recorder := record.NewFakeRecorder(10)
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: policyKey})
+		Expect(err).NotTo(HaveOccurred())
+		pluginKey := types.NamespacedName{Name: furiosaDevicePluginName, Namespace: "kube-system"}
+		configKey := types.NamespacedName{Name: "teardown-disable-furiosa-config", Namespace: "kube-system"}
+		Expect(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{})).To(Succeed())
+		drainEvents(recorder)
+
+		Expect(k8sClient.Get(ctx, policyKey, policy)).To(Succeed())
+		policy.Spec.Furiosa.Enabled = false
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: policyKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, pluginKey, &appsv1.DaemonSet{}))).To(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, configKey, &corev1.ConfigMap{}))).To(BeTrue())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("ComponentRemoved")))
+		Expect(k8sClient.Get(ctx, policyKey, policy)).To(Succeed())
+		Expect(findComponentStatus(policy, furiosaDevicePluginName)).To(BeNil())
+		Expect(policy.Status.SelfHealing == nil || policy.Status.SelfHealing.UnexpectedDeletions == 0).To(BeTrue())
+
+		deletePolicy(ctx, policy)
+	})
+})
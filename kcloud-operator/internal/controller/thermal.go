@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/cloudevents"
+	"npu-operator/pkg/conditions"
+)
+
+const (
+	defaultThermalFor   = 5 * time.Minute
+	thermalPollInterval = 30 * time.Second
+)
+
+// MetricsScraper reads the samples of every metric served at a Prometheus endpoint.
+type MetricsScraper interface {
+	Scrape(ctx context.Context, url string) (map[string][]float64, error)
+}
+
+// HTTPMetricsScraper scrapes Prometheus text endpoints over HTTP.
+type HTTPMetricsScraper struct {
+	Client *http.Client
+}
+
+// Scrape fetches and parses the metrics served at url.
+func (s *HTTPMetricsScraper) Scrape(ctx context.Context, url string) (map[string][]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scraping %s: unexpected status %s", url, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	samples := map[string][]float64{}
+	for name, family := range families {
+		for _, m := range family.GetMetric() {
+			samples[name] = append(samples[name], sampleValue(m))
+		}
+	}
+	return samples, nil
+}
+
+func sampleValue(m *dto.Metric) float64 {
+	switch {
+	case m.Gauge != nil:
+		return m.GetGauge().GetValue()
+	case m.Counter != nil:
+		return m.GetCounter().GetValue()
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// -- checkThermal scrapes the exporters, tracks hot nodes and takes or reverts the thermal actions
+func (r *NPUClusterPolicyReconciler) checkThermal(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	spec := policy.Spec.Thermal
+	if spec == nil {
+		for _, s := range policy.Status.Thermal {
+			if err := r.revertThermal(ctx, policy, s.Node, nil); err != nil {
+				return 0, err
+			}
+		}
+		policy.Status.Thermal = nil
+		conditions.Remove(policy, conditions.MetricsAvailable)
+		return 0, nil
+	}
+	hold := defaultThermalFor
+	if spec.For != nil {
+		hold = spec.For.Duration
+	}
+
+	hot, scraped, missing, err := r.scrapeThermal(ctx, policy, spec)
+	if err != nil {
+		return 0, err
+	}
+	// Nodes without metrics keep their last known state: they are neither responded to nor
+	// recovered until their exporter can be scraped again.
+	if len(missing) > 0 {
+		conditions.MarkFalse(policy, conditions.MetricsAvailable, conditions.ReasonInsufficientData,
+			fmt.Sprintf("No metrics from the exporters of %d nodes: %s", len(missing), strings.Join(missing, ", ")))
+	} else {
+		conditions.MarkTrue(policy, conditions.MetricsAvailable, conditions.ReasonReconciled,
+			fmt.Sprintf("Metrics of %d nodes are available", len(scraped)))
+	}
+
+	var next []npuv1alpha1.NodeThermalStatus
+	tracked := map[string]bool{}
+	for _, s := range policy.Status.Thermal {
+		tracked[s.Node] = true
+		sample, isHot := hot[s.Node]
+		switch {
+		case isHot:
+			s.Metric, s.Value = sample.metric, sample.value
+		case scraped[s.Node]:
+			if s.Responded {
+				if err := r.revertThermal(ctx, policy, s.Node, spec.Actions); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+		if isHot && !s.Responded && time.Since(s.Since.Time) >= hold {
+			log.Info("Node is thermally throttled, responding", "node", s.Node, "metric", s.Metric, "value", s.Value)
+			if err := r.respondThermal(ctx, policy, &s, spec); err != nil {
+				return 0, err
+			}
+			s.Responded = true
+		}
+		next = append(next, s)
+	}
+	for node, sample := range hot {
+		if !tracked[node] {
+			next = append(next, npuv1alpha1.NodeThermalStatus{
+				Node: node, Since: metav1.Now(), Metric: sample.metric, Value: sample.value,
+			})
+		}
+	}
+	slices.SortFunc(next, func(a, b npuv1alpha1.NodeThermalStatus) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+	policy.Status.Thermal = next
+	return thermalPollInterval, nil
+}
+
+type thermalSample struct {
+	metric, value string
+}
+
+// -- scrapeThermal returns the nodes above a threshold, every node whose exporter was scraped, and
+// the sorted nodes whose exporter failed or whose circuit is open
+func (r *NPUClusterPolicyReconciler) scrapeThermal(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	spec *npuv1alpha1.ThermalSpec) (map[string]thermalSample, map[string]bool, []string, error) {
+	log := logf.FromContext(ctx)
+
+	var exporters []component
+	for _, c := range enabledComponents(policy) {
+		if c.metricsPort != 0 {
+			exporters = append(exporters, c)
+		}
+	}
+
+	hot := map[string]thermalSample{}
+	scraped := map[string]bool{}
+	failed := map[string]bool{}
+	for _, c := range exporters {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(componentNamespace(policy)),
+			client.MatchingLabels{"app.kubernetes.io/name": c.name}); err != nil {
+			return nil, nil, nil, err
+		}
+		scraper, scheme, scraperErr := r.exporterScraper(ctx, policy, c)
+		if scraperErr != nil {
+			log.Error(scraperErr, "failed to set up scraping of exporter", "component", c.name)
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.Spec.NodeName == "" {
+				continue
+			}
+			breaker := c.name + "/" + pod.Spec.NodeName
+			if scraperErr != nil || !r.breakers.allow(breaker, time.Now()) {
+				failed[pod.Spec.NodeName] = true
+				continue
+			}
+			samples, err := scraper.Scrape(ctx, fmt.Sprintf("%s://%s:%d/metrics", scheme, pod.Status.PodIP, c.metricsPort))
+			r.breakers.record(breaker, err, time.Now())
+			if err != nil {
+				log.Error(err, "failed to scrape exporter", "pod", pod.Name, "node", pod.Spec.NodeName)
+				failed[pod.Spec.NodeName] = true
+				continue
+			}
+			scraped[pod.Spec.NodeName] = true
+			if sample, ok := exceededThreshold(spec.Thresholds, samples); ok {
+				hot[pod.Spec.NodeName] = sample
+			}
+		}
+	}
+	var missing []string
+	for node := range failed {
+		if !scraped[node] {
+			missing = append(missing, node)
+		}
+	}
+	slices.Sort(missing)
+	return hot, scraped, missing, nil
+}
+
+// -- exceededThreshold returns the first threshold with a sample above its value
+func exceededThreshold(thresholds []npuv1alpha1.MetricThreshold, samples map[string][]float64) (thermalSample, bool) {
+	for _, t := range thresholds {
+		limit := t.Value.AsApproximateFloat64()
+		for _, v := range samples[t.Metric] {
+			if v > limit {
+				return thermalSample{metric: t.Metric, value: strconv.FormatFloat(v, 'f', -1, 64)}, true
+			}
+		}
+	}
+	return thermalSample{}, false
+}
+
+// -- respondThermal takes the configured actions for a hot node, unless it is unmanaged
+func (r *NPUClusterPolicyReconciler) respondThermal(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	status *npuv1alpha1.NodeThermalStatus, spec *npuv1alpha1.ThermalSpec) error {
+	actions := spec.Actions
+	if len(actions) == 0 {
+		actions = []npuv1alpha1.ThermalAction{npuv1alpha1.ThermalActionNotify}
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: status.Node}, node); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if unmanagedNode(node) {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	if slices.Contains(actions, npuv1alpha1.ThermalActionPowerCap) && spec.PowerLimitWatts != nil {
+		node.Annotations[npuv1alpha1.PowerLimitAnnotation] = strconv.Itoa(int(*spec.PowerLimitWatts))
+	}
+	cordoned := false
+	if slices.Contains(actions, npuv1alpha1.ThermalActionCordon) && !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		node.Annotations[npuv1alpha1.ThermalCordonAnnotation] = "true"
+		cordoned = true
+	}
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return err
+	}
+
+	source := fmt.Sprintf("/apis/%s/namespaces/%s/npuclusterpolicies/%s",
+		npuv1alpha1.GroupVersion.String(), policy.Namespace, policy.Name)
+	if cordoned && r.Events != nil {
+		// The quarantine is reported even without Notify, as it takes capacity away.
+		event := cloudevents.Event{
+			Type:    cloudevents.TypeNodeQuarantined,
+			Source:  source,
+			Subject: status.Node,
+			Data: map[string]interface{}{
+				"reason": "ThermalThrottling",
+				"metric": status.Metric,
+				"value":  status.Value,
+			},
+		}
+		if err := r.Events.Emit(ctx, event); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to emit cloudevent", "type", event.Type)
+		}
+	}
+
+	if !slices.Contains(actions, npuv1alpha1.ThermalActionNotify) {
+		return nil
+	}
+	message := fmt.Sprintf("Node %s is thermally throttled: %s=%s since %s",
+		status.Node, status.Metric, status.Value, status.Since.Format(time.RFC3339))
+	r.Recorder.Event(policy, corev1.EventTypeWarning, "ThermalThrottling", message)
+	r.Recorder.Event(node, corev1.EventTypeWarning, "ThermalThrottling", message)
+	if r.Events != nil {
+		event := cloudevents.Event{
+			Type:    cloudevents.TypeNodeThrottled,
+			Source:  source,
+			Subject: status.Node,
+			Data: map[string]interface{}{
+				"metric":  status.Metric,
+				"value":   status.Value,
+				"since":   status.Since,
+				"actions": actions,
+			},
+		}
+		if err := r.Events.Emit(ctx, event); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to emit cloudevent", "type", event.Type)
+		}
+	}
+	return nil
+}
+
+// -- revertThermal lifts the power limit and the cordon the operator applied to a node
+func (r *NPUClusterPolicyReconciler) revertThermal(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy,
+	name string, actions []npuv1alpha1.ThermalAction) error {
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	delete(node.Annotations, npuv1alpha1.PowerLimitAnnotation)
+	if _, ok := node.Annotations[npuv1alpha1.ThermalCordonAnnotation]; ok {
+		node.Spec.Unschedulable = false
+		delete(node.Annotations, npuv1alpha1.ThermalCordonAnnotation)
+	}
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return err
+	}
+	if len(actions) == 0 || slices.Contains(actions, npuv1alpha1.ThermalActionNotify) {
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ThermalRecovered", "Node %s cooled down", name)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/cloudevents"
+	"npu-operator/pkg/conditions"
+)
+
+// fakeScraper serves the same samples for every exporter.
+type fakeScraper struct {
+	samples map[string][]float64
+}
+
+func (s *fakeScraper) Scrape(context.Context, string) (map[string][]float64, error) {
+	return s.samples, nil
+}
+
+// recordingEmitter keeps the CloudEvents it is given.
+type recordingEmitter struct {
+	events []cloudevents.Event
+}
+
+func (e *recordingEmitter) Emit(_ context.Context, event cloudevents.Event) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+// types returns the types of the recorded events.
+func (e *recordingEmitter) types() []string {
+	var types []string
+	for _, event := range e.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// failingScraper fails every scrape and counts them.
+type failingScraper struct {
+	scrapes int
+}
+
+func (s *failingScraper) Scrape(context.Context, string) (map[string][]float64, error) {
+	s.scrapes++
+	return nil, errors.New("connection refused")
+}
+
+var _ = Describe("Thermal responses", func() {
+	const (
+		resourceName = "thermal"
+		nodeName     = "hot-node"
+	)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	podKey := types.NamespacedName{Name: "furiosa-metrics-exporter-hot", Namespace: "kube-system"}
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: npuv1alpha1.NPUClusterPolicySpec{
+				Furiosa: npuv1alpha1.FuriosaSpec{
+					Enabled:       true,
+					ConfigMapName: "furiosa-device-plugin",
+					VendorComponents: npuv1alpha1.VendorComponents{
+						DevicePlugin: npuv1alpha1.ComponentSpec{Image: "ghcr.io/furiosa-ai/k8s-device-plugin:latest"},
+						Exporter: npuv1alpha1.ComponentSpec{
+							Enabled: boolPtr(true),
+							Image:   "ghcr.io/furiosa-ai/furiosa-metrics-exporter:latest",
+						},
+					},
+				},
+				Thermal: &npuv1alpha1.ThermalSpec{
+					Thresholds: []npuv1alpha1.MetricThreshold{
+						{Metric: "furiosa_npu_hw_temperature", Value: resource.MustParse("90")},
+					},
+					For:     &metav1.Duration{},
+					Actions: []npuv1alpha1.ThermalAction{npuv1alpha1.ThermalActionCordon, npuv1alpha1.ThermalActionNotify},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "furiosa-metrics-exporter"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "furiosa-metrics-exporter", Image: "busybox"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = "10.0.0.10"
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		deletePolicy(ctx, policy)
+		Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: podKey.Name, Namespace: podKey.Namespace,
+		}})).To(Succeed())
+	})
+
+	It("should cordon a hot node and uncordon it once it cools down", func() {
+		scraper := &fakeScraper{samples: map[string][]float64{"furiosa_npu_hw_temperature": {70, 95}}}
+		emitter := &recordingEmitter{}
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+			Events:   emitter,
+			Metrics:  scraper,
+		}
+
+		By("tracking the node on the first hot sample and responding once it stays hot")
+		for range 2 {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.Thermal).To(HaveLen(1))
+		Expect(policy.Status.Thermal[0].Responded).To(BeTrue())
+		Expect(policy.Status.Thermal[0].Value).To(Equal("95"))
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(node.Annotations).To(HaveKey(npuv1alpha1.ThermalCordonAnnotation))
+		Expect(emitter.types()).To(ContainElements(cloudevents.TypeNodeQuarantined, cloudevents.TypeNodeThrottled))
+		for _, event := range emitter.events {
+			if event.Type == cloudevents.TypeNodeQuarantined {
+				Expect(event.Subject).To(Equal(nodeName))
+			}
+		}
+
+		By("uncordoning the node once it cools down")
+		scraper.samples = map[string][]float64{"furiosa_npu_hw_temperature": {70, 75}}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.Thermal).To(BeEmpty())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(node.Annotations).NotTo(HaveKey(npuv1alpha1.ThermalCordonAnnotation))
+	})
+
+	It("should report insufficient data and stop scraping an exporter that keeps failing", func() {
+		scraper := &failingScraper{}
+		controllerReconciler := &NPUClusterPolicyReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(20),
+			Metrics:  scraper,
+		}
+
+		for range metricsBreakerThreshold + 2 {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(scraper.scrapes).To(Equal(metricsBreakerThreshold))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(k8sClient.Get(ctx, key, policy)).To(Succeed())
+		Expect(policy.Status.Thermal).To(BeEmpty())
+		condition := conditions.Get(policy, conditions.MetricsAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(conditions.ReasonInsufficientData))
+		Expect(condition.Message).To(ContainSubstring(nodeName))
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// -- unmanagedNode reports whether the node is excluded from the actions of the operator
+func unmanagedNode(node *corev1.Node) bool {
+	return node.Annotations[npuv1alpha1.UnmanagedAnnotation] == "true"
+}
+
+// -- unmanagedNodes returns the sorted names of the nodes whose NPUNode is marked unmanaged
+func (r *NPUClusterPolicyReconciler) unmanagedNodes(ctx context.Context) ([]string, error) {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := r.List(ctx, npuNodes); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, n := range npuNodes.Items {
+		if n.Status.Unmanaged {
+			names = append(names, n.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// -- excludeNodes keeps the pods off the nodes by a required node affinity on their names, which
+// is added to every term of the affinity of the pod
+func excludeNodes(pod *corev1.PodSpec, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   slices.Clone(names),
+	}
+	// The affinity may be shared with the policy spec.
+	pod.Affinity = pod.Affinity.DeepCopy()
+	if pod.Affinity == nil {
+		pod.Affinity = &corev1.Affinity{}
+	}
+	if pod.Affinity.NodeAffinity == nil {
+		pod.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		required = &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{}}}
+		pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	}
+	for i := range required.NodeSelectorTerms {
+		term := &required.NodeSelectorTerms[i]
+		term.MatchFields = append(term.MatchFields, requirement)
+	}
+}
+
+// -- policiesForUnmanagedNode maps an NPUNode to the policies whose components run on it
+func (r *NPUClusterPolicyReconciler) policiesForUnmanagedNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.policiesForDisabledDevices(ctx, obj)
+}
+
+// npuNodeUnmanagedChanged passes NPUNode events that mark the node unmanaged or managed again.
+var npuNodeUnmanagedChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.(*npuv1alpha1.NPUNode).Status.Unmanaged
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.(*npuv1alpha1.NPUNode).Status.Unmanaged != e.ObjectNew.(*npuv1alpha1.NPUNode).Status.Unmanaged
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return e.Object.(*npuv1alpha1.NPUNode).Status.Unmanaged
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
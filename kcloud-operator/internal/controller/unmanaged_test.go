@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Unmanaged nodes", func() {
+	notIn := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"lab-1", "lab-2"},
+	}
+
+	It("should only treat nodes annotated true as unmanaged", func() {
+		node := &corev1.Node{}
+		Expect(unmanagedNode(node)).To(BeFalse())
+		node.Annotations = map[string]string{npuv1alpha1.UnmanagedAnnotation: "false"}
+		Expect(unmanagedNode(node)).To(BeFalse())
+		node.Annotations[npuv1alpha1.UnmanagedAnnotation] = "true"
+		Expect(unmanagedNode(node)).To(BeTrue())
+	})
+
+	It("should keep component pods off the unmanaged nodes", func() {
+		pod := &corev1.PodSpec{}
+		excludeNodes(pod, nil)
+		Expect(pod.Affinity).To(BeNil())
+
+		excludeNodes(pod, []string{"lab-1", "lab-2"})
+		Expect(pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal(
+			[]corev1.NodeSelectorTerm{{MatchFields: []corev1.NodeSelectorRequirement{notIn}}}))
+	})
+
+	It("should add the exclusion to every term without changing the shared affinity", func() {
+		shared := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}}},
+			}},
+		}}
+		original := shared.DeepCopy()
+		pod := &corev1.PodSpec{Affinity: shared}
+
+		excludeNodes(pod, []string{"lab-1", "lab-2"})
+		Expect(shared).To(Equal(original))
+		terms := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms).To(HaveLen(2))
+		for i, term := range terms {
+			Expect(term.MatchExpressions).To(Equal(original.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[i].MatchExpressions))
+			Expect(term.MatchFields).To(Equal([]corev1.NodeSelectorRequirement{notIn}))
+		}
+	})
+
+	It("should requeue the policies only when a node becomes unmanaged or managed again", func() {
+		managed := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: "lab-1"}}
+		unmanaged := managed.DeepCopy()
+		unmanaged.Status.Unmanaged = true
+
+		Expect(npuNodeUnmanagedChanged.Create(event.CreateEvent{Object: managed})).To(BeFalse())
+		Expect(npuNodeUnmanagedChanged.Create(event.CreateEvent{Object: unmanaged})).To(BeTrue())
+		Expect(npuNodeUnmanagedChanged.Update(event.UpdateEvent{ObjectOld: managed, ObjectNew: managed})).To(BeFalse())
+		Expect(npuNodeUnmanagedChanged.Update(event.UpdateEvent{ObjectOld: managed, ObjectNew: unmanaged})).To(BeTrue())
+		Expect(npuNodeUnmanagedChanged.Update(event.UpdateEvent{ObjectOld: unmanaged, ObjectNew: managed})).To(BeTrue())
+		Expect(npuNodeUnmanagedChanged.Delete(event.DeleteEvent{Object: unmanaged})).To(BeTrue())
+	})
+
+	It("should list the unmanaged nodes in the fleet inventory", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Enabled = true
+		nvidia := []npuv1alpha1.VendorSummary{{Vendor: "nvidia", DriverVersion: "570.86"}}
+		nodes := []npuv1alpha1.NPUNode{
+			{ObjectMeta: metav1.ObjectMeta{Name: "lab-2"}, Status: npuv1alpha1.NPUNodeStatus{Vendors: nvidia, Unmanaged: true}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1"}, Status: npuv1alpha1.NPUNodeStatus{Vendors: nvidia}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "lab-1"}, Status: npuv1alpha1.NPUNodeStatus{Vendors: nvidia, Unmanaged: true}},
+		}
+
+		inventory := fleetInventory(policy, nodes, nil)
+		Expect(inventory.Nodes).To(Equal(int32(3)))
+		Expect(inventory.Unmanaged).To(Equal([]string{"lab-1", "lab-2"}))
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export writes snapshots of a policy and the objects it manages into versioned
+// bundles, for disaster recovery and change records. Bundles are stored in a directory,
+// typically a mounted PersistentVolumeClaim, or uploaded to object storage over HTTP.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// BundleVersion identifies the format of the bundles written by this package.
+const BundleVersion = "npu.ai/export/v1"
+
+// Bundle is a snapshot of a policy, including its status, and the objects it manages.
+type Bundle struct {
+	Version string      `json:"version"`
+	Time    metav1.Time `json:"time"`
+	// Policy is the policy with its spec and status.
+	Policy *npuv1alpha1.NPUClusterPolicy `json:"policy"`
+	// Objects are the managed objects as found in the cluster, without managed fields.
+	Objects []unstructured.Unstructured `json:"objects"`
+}
+
+// Name returns the path of the bundle below the storage location, unique per policy and
+// time, e.g. default/cluster/20250102T030405Z-gen7.json.
+func (b *Bundle) Name() string {
+	return fmt.Sprintf("%s/%s/%s-gen%d.json", b.Policy.Namespace, b.Policy.Name,
+		b.Time.UTC().Format("20060102T150405Z"), b.Policy.Generation)
+}
+
+// Sink stores bundles.
+type Sink interface {
+	// Write stores the data under name and returns where it was stored.
+	Write(ctx context.Context, name string, data []byte) (string, error)
+}
+
+// NewSink returns the sink of a location, an http(s) URL of an object storage bucket or
+// prefix that accepts PUT requests, or a local directory.
+func NewSink(location string) (Sink, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		u, err := url.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid export location %q: %w", location, err)
+		}
+		return &HTTPSink{URL: u, Client: &http.Client{Timeout: 30 * time.Second}}, nil
+	}
+	if !filepath.IsAbs(location) {
+		return nil, fmt.Errorf("export location %q must be an absolute directory or an http(s) URL", location)
+	}
+	return &DirSink{Dir: location}, nil
+}
+
+// DirSink writes bundles below a directory.
+type DirSink struct {
+	Dir string
+}
+
+// Write writes the bundle atomically, so a crash never leaves a partial bundle behind.
+func (s *DirSink) Write(_ context.Context, name string, data []byte) (string, error) {
+	path := filepath.Join(s.Dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp, path)
+}
+
+// HTTPSink uploads bundles with PUT requests below a URL, e.g. a pre-authorized bucket URL.
+type HTTPSink struct {
+	URL    *url.URL
+	Client *http.Client
+}
+
+// Write uploads the bundle to <URL>/<name>.
+func (s *HTTPSink) Write(ctx context.Context, name string, data []byte) (string, error) {
+	target := s.URL.JoinPath(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("upload to %s failed: %s", target.Redacted(), resp.Status)
+	}
+	return target.Redacted(), nil
+}
+
+// Encode returns the JSON of the bundle. Managed fields are dropped from the bundle.
+func Encode(b *Bundle) ([]byte, error) {
+	b.Version = BundleVersion
+	b.Policy.ManagedFields = nil
+	for i := range b.Objects {
+		b.Objects[i].SetManagedFields(nil)
+	}
+	return json.MarshalIndent(b, "", "  ")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Export", func() {
+	var bundle *Bundle
+
+	BeforeEach(func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster", Namespace: "default", Generation: 7,
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		}}
+		object := unstructured.Unstructured{}
+		object.SetAPIVersion("apps/v1")
+		object.SetKind("DaemonSet")
+		object.SetName("nvidia-device-plugin")
+		object.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "npu-operator"}})
+		bundle = &Bundle{
+			Time:    metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Policy:  policy,
+			Objects: []unstructured.Unstructured{object},
+		}
+	})
+
+	It("names bundles by policy, time and generation and drops managed fields", func() {
+		Expect(bundle.Name()).To(Equal("default/cluster/20250102T030405Z-gen7.json"))
+		data, err := Encode(bundle)
+		Expect(err).NotTo(HaveOccurred())
+
+		decoded := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded["version"]).To(Equal(BundleVersion))
+		Expect(string(data)).NotTo(ContainSubstring("managedFields"))
+	})
+
+	It("writes bundles below a directory", func() {
+		dir := GinkgoT().TempDir()
+		sink, err := NewSink(dir)
+		Expect(err).NotTo(HaveOccurred())
+
+		location, err := sink.Write(context.Background(), bundle.Name(), []byte("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location).To(Equal(filepath.Join(dir, "default", "cluster", "20250102T030405Z-gen7.json")))
+		Expect(os.ReadFile(location)).To(Equal([]byte("{}")))
+	})
+
+	It("uploads bundles to object storage", func() {
+		var path, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPut))
+			data, _ := io.ReadAll(r.Body)
+			path, body = r.URL.Path, string(data)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(server.URL + "/bucket/exports")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = sink.Write(context.Background(), bundle.Name(), []byte("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/bucket/exports/default/cluster/20250102T030405Z-gen7.json"))
+		Expect(body).To(Equal("{}"))
+	})
+
+	It("rejects relative directories", func() {
+		_, err := NewSink("exports")
+		Expect(err).To(HaveOccurred())
+	})
+})
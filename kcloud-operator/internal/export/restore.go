@@ -0,0 +1,283 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/health"
+	"npu-operator/pkg/conditions"
+)
+
+// maxDiffsPerObject bounds the differences reported for a single object.
+const maxDiffsPerObject = 10
+
+// Decode parses a bundle written by Encode.
+func Decode(data []byte) (*Bundle, error) {
+	b := &Bundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	if b.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %q, expected %q", b.Version, BundleVersion)
+	}
+	if b.Policy == nil {
+		return nil, fmt.Errorf("bundle has no policy")
+	}
+	return b, nil
+}
+
+// LoadBundles reads a bundle file, or every bundle below a directory keeping the latest
+// bundle of each policy. The bundles are returned ordered by policy.
+func LoadBundles(path string) ([]*Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.HasSuffix(p, ".json") {
+				files = append(files, p)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
+	}
+
+	latest := map[string]*Bundle{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		b, err := Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		key := client.ObjectKeyFromObject(b.Policy).String()
+		if prev, ok := latest[key]; !ok || prev.Time.Before(&b.Time) {
+			latest[key] = b
+		}
+	}
+	keys := make([]string, 0, len(latest))
+	for k := range latest {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	bundles := make([]*Bundle, 0, len(keys))
+	for _, k := range keys {
+		bundles = append(bundles, latest[k])
+	}
+	return bundles, nil
+}
+
+// Restorer bootstraps a replacement cluster from bundles: it recreates the policies that
+// do not exist, waits for them to become Ready and reports how the objects the operator
+// manages differ from the bundle. It runs once when the manager starts.
+type Restorer struct {
+	client.Client
+	Recorder record.EventRecorder
+	Bundles  []*Bundle
+	// Timeout is how long to wait for a restored policy to become Ready.
+	Timeout time.Duration
+	// Interval is how often readiness is checked.
+	Interval time.Duration
+	// Fatal receives the policies that could not be restored, failing readiness. Nil only logs them.
+	Fatal *health.Fatal
+}
+
+// NeedLeaderElection makes only the leader restore, so replicas do not race on creation.
+func (r *Restorer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start restores every bundle. Failures are reported and do not stop the manager.
+func (r *Restorer) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("restore")
+	for _, b := range r.Bundles {
+		key := client.ObjectKeyFromObject(b.Policy)
+		if err := r.restore(ctx, b); err != nil {
+			log.Error(err, "failed to restore policy", "policy", key)
+			if r.Fatal != nil {
+				r.Fatal.Report("restore of policy "+key.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// -- restore recreates the policy of the bundle, waits for it to converge and reports the differences
+func (r *Restorer) restore(ctx context.Context, b *Bundle) error {
+	log := logf.FromContext(ctx).WithName("restore")
+	key := client.ObjectKeyFromObject(b.Policy)
+
+	policy := &npuv1alpha1.NPUClusterPolicy{}
+	err := r.Get(ctx, key, policy)
+	switch {
+	case apierrors.IsNotFound(err):
+		policy = restoredPolicy(b.Policy)
+		if err := r.Create(ctx, policy); err != nil {
+			return err
+		}
+		log.Info("Restored policy from bundle", "policy", key, "bundle", b.Name())
+	case err != nil:
+		return err
+	default:
+		log.Info("Policy exists, verifying it against the bundle", "policy", key, "bundle", b.Name())
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, r.Interval, r.Timeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, key, policy); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		ready := conditions.Get(policy, conditions.Ready)
+		return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == policy.Generation, nil
+	})
+	if err != nil {
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "RestoreNotReady",
+			"Policy did not become Ready within %s after restoring bundle %s", r.Timeout, b.Name())
+		return fmt.Errorf("policy did not become Ready: %w", err)
+	}
+
+	diffs, err := Diff(ctx, r.Client, b)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		log.Info("Policy converged to the bundle", "policy", key)
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, "Restored", "Policy converged to bundle %s", b.Name())
+		return nil
+	}
+	for _, d := range diffs {
+		log.Info("Cluster differs from the bundle", "policy", key, "difference", d)
+	}
+	r.Recorder.Eventf(policy, corev1.EventTypeWarning, "RestoreDiff",
+		"Policy is Ready but %d differences to bundle %s remain: %s", len(diffs), b.Name(), strings.Join(diffs, "; "))
+	return nil
+}
+
+// -- restoredPolicy copies the policy of a bundle without server-populated metadata and status
+func restoredPolicy(in *npuv1alpha1.NPUClusterPolicy) *npuv1alpha1.NPUClusterPolicy {
+	out := &npuv1alpha1.NPUClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        in.Name,
+			Namespace:   in.Namespace,
+			Labels:      in.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec: *in.Spec.DeepCopy(),
+	}
+	for k, v := range in.Annotations {
+		// An export request in the bundle would otherwise be replayed on the new cluster.
+		if k != npuv1alpha1.ExportAnnotation {
+			out.Annotations[k] = v
+		}
+	}
+	return out
+}
+
+// Diff compares the objects of the bundle with the cluster and returns the differences.
+// Metadata and status are ignored, as they are owned by the new cluster.
+func Diff(ctx context.Context, c client.Reader, b *Bundle) ([]string, error) {
+	var diffs []string
+	for i := range b.Objects {
+		want := &b.Objects[i]
+		ref := fmt.Sprintf("%s %s", want.GetKind(), client.ObjectKeyFromObject(want))
+		got := &unstructured.Unstructured{}
+		got.SetGroupVersionKind(want.GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(want), got); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			diffs = append(diffs, ref+" is missing")
+			continue
+		}
+		var paths []string
+		for field, value := range want.Object {
+			if field == "metadata" || field == "status" || field == "apiVersion" || field == "kind" {
+				continue
+			}
+			paths = diffPaths(paths, field, value, got.Object[field])
+		}
+		sort.Strings(paths)
+		if len(paths) > maxDiffsPerObject {
+			paths = append(paths[:maxDiffsPerObject], "...")
+		}
+		if len(paths) > 0 {
+			diffs = append(diffs, fmt.Sprintf("%s differs at %s", ref, strings.Join(paths, ", ")))
+		}
+	}
+	return diffs, nil
+}
+
+// -- diffPaths appends the paths below path at which the decoded JSON values differ
+func diffPaths(paths []string, path string, want, got interface{}) []string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return append(paths, path)
+		}
+		for k, v := range w {
+			paths = diffPaths(paths, path+"."+k, v, g[k])
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				paths = append(paths, path+"."+k)
+			}
+		}
+		return paths
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return append(paths, path)
+		}
+		for i := range w {
+			paths = diffPaths(paths, fmt.Sprintf("%s[%d]", path, i), w[i], g[i])
+		}
+		return paths
+	default:
+		if !equality.Semantic.DeepEqual(want, got) {
+			return append(paths, path)
+		}
+		return paths
+	}
+}
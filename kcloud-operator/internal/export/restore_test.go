@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/pkg/conditions"
+)
+
+var _ = Describe("Restore", func() {
+	var (
+		scheme *runtime.Scheme
+		bundle *Bundle
+		ds     *appsv1.DaemonSet
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		ds = &appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin", Namespace: "kube-system"},
+			Spec: appsv1.DaemonSetSpec{
+				MinReadySeconds: 10,
+				Selector:        &metav1.LabelSelector{MatchLabels: map[string]string{"app": "plugin"}},
+			},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ds)
+		Expect(err).NotTo(HaveOccurred())
+		bundle = &Bundle{
+			Time: metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Policy: &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster", Namespace: "default", Generation: 2,
+				Annotations: map[string]string{npuv1alpha1.ExportAnnotation: "true", "team": "ml"},
+			}},
+			Objects: []unstructured.Unstructured{{Object: content}},
+		}
+	})
+
+	It("loads the latest bundle of each policy from a directory", func() {
+		dir := GinkgoT().TempDir()
+		sink := &DirSink{Dir: dir}
+		for _, gen := range []int64{2, 3} {
+			bundle.Policy.Generation = gen
+			bundle.Time = metav1.NewTime(bundle.Time.Add(time.Hour))
+			data, err := Encode(bundle)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sink.Write(context.Background(), bundle.Name(), data)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(os.WriteFile(filepath.Join(dir, "other.json"), []byte(`{"version":"v0"}`), 0o600)).To(Succeed())
+
+		_, err := LoadBundles(dir)
+		Expect(err).To(MatchError(ContainSubstring("unsupported bundle version")))
+
+		Expect(os.Remove(filepath.Join(dir, "other.json"))).To(Succeed())
+		bundles, err := LoadBundles(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bundles).To(HaveLen(1))
+		Expect(bundles[0].Policy.Generation).To(Equal(int64(3)))
+	})
+
+	It("reports objects that differ from the bundle or are missing", func() {
+		live := ds.DeepCopy()
+		live.Spec.MinReadySeconds = 30
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(live).Build()
+
+		missing := bundle.Objects[0].DeepCopy()
+		missing.SetName("furiosa-device-plugin")
+		bundle.Objects = append(bundle.Objects, *missing)
+
+		diffs, err := Diff(context.Background(), c, bundle)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(ConsistOf(
+			"DaemonSet kube-system/nvidia-device-plugin differs at spec.minReadySeconds",
+			"DaemonSet kube-system/furiosa-device-plugin is missing",
+		))
+	})
+
+	It("verifies an existing Ready policy against the bundle", func() {
+		policy := bundle.Policy.DeepCopy()
+		conditions.MarkTrue(policy, conditions.Ready, conditions.ReasonReconciled, "")
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy, ds.DeepCopy()).Build()
+		recorder := record.NewFakeRecorder(10)
+
+		r := &Restorer{Client: c, Recorder: recorder, Bundles: []*Bundle{bundle},
+			Timeout: time.Second, Interval: 10 * time.Millisecond}
+		Expect(r.Start(context.Background())).To(Succeed())
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Restored")))
+	})
+
+	It("recreates a missing policy without replaying its export request", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		recorder := record.NewFakeRecorder(10)
+
+		r := &Restorer{Client: c, Recorder: recorder, Bundles: []*Bundle{bundle},
+			Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+		Expect(r.Start(context.Background())).To(Succeed())
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning RestoreNotReady")))
+
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(bundle.Policy), policy)).To(Succeed())
+		Expect(policy.Annotations).To(Equal(map[string]string{"team": "ml"}))
+	})
+})
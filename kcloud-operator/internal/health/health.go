@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health provides the checks behind the healthz and readyz endpoints of the manager,
+// so orchestration detects an operator that runs but cannot do its work, and a diagnostics
+// handler reporting the result of every check as JSON.
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// Checks is a named set of checks. It serves them as JSON diagnostics.
+type Checks struct {
+	names  []string
+	checks map[string]healthz.Checker
+}
+
+// Add registers a check under a name.
+func (c *Checks) Add(name string, check healthz.Checker) {
+	if c.checks == nil {
+		c.checks = map[string]healthz.Checker{}
+	}
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Each calls fn for every check in the order they were added.
+func (c *Checks) Each(fn func(name string, check healthz.Checker) error) error {
+	for _, name := range c.names {
+		if err := fn(name, c.checks[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of one check in the diagnostics.
+type Result struct {
+	Name     string `json:"name"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Diagnostics is the JSON document served by Checks.
+type Diagnostics struct {
+	Healthy bool     `json:"healthy"`
+	Checks  []Result `json:"checks"`
+}
+
+// Run runs every check.
+func (c *Checks) Run(req *http.Request) Diagnostics {
+	d := Diagnostics{Healthy: true}
+	for _, name := range c.names {
+		start := time.Now()
+		err := c.checks[name](req)
+		result := Result{Name: name, Healthy: err == nil, Duration: time.Since(start).Round(time.Millisecond).String()}
+		if err != nil {
+			result.Error = err.Error()
+			d.Healthy = false
+		}
+		d.Checks = append(d.Checks, result)
+	}
+	return d
+}
+
+// ServeHTTP serves the diagnostics, with status 503 when a check fails.
+func (c *Checks) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	d := c.Run(req)
+	w.Header().Set("Content-Type", "application/json")
+	if !d.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(d)
+}
+
+// InformersSynced fails until the informers of the cache have synced.
+func InformersSynced(c cache.Cache, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informers have not synced")
+		}
+		return nil
+	}
+}
+
+// APIServer fails while the API server cannot be reached.
+func APIServer(d discovery.ServerVersionInterface) healthz.Checker {
+	return func(_ *http.Request) error {
+		if _, err := d.ServerVersion(); err != nil {
+			return fmt.Errorf("API server is unreachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// CertificateValid fails when the first certificate of the PEM file cannot be read, is not
+// yet valid or expires within the margin. The file is read on every check, so rotated
+// certificates are picked up.
+func CertificateValid(certFile string, margin time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		raw, err := os.ReadFile(certFile)
+		if err != nil {
+			return err
+		}
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return fmt.Errorf("%s contains no PEM certificate", certFile)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		switch {
+		case now.Before(cert.NotBefore):
+			return fmt.Errorf("certificate is not valid before %s", cert.NotBefore.Format(time.RFC3339))
+		case now.Add(margin).After(cert.NotAfter):
+			return fmt.Errorf("certificate expires at %s", cert.NotAfter.Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// Fatal collects failures the operator cannot recover from by itself, such as a backup
+// that could not be restored. Its check fails while any is reported.
+type Fatal struct {
+	mu     sync.Mutex
+	errors map[string]error
+}
+
+// Report records a fatal failure of a source, replacing its previous one.
+func (f *Fatal) Report(source string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.errors == nil {
+		f.errors = map[string]error{}
+	}
+	f.errors[source] = err
+}
+
+// Resolve clears the failure of a source.
+func (f *Fatal) Resolve(source string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.errors, source)
+}
+
+// Check fails while a fatal failure is reported.
+func (f *Fatal) Check(_ *http.Request) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for source, err := range f.errors {
+		messages = append(messages, source+": "+err.Error())
+	}
+	slices.Sort(messages)
+	return errors.New(strings.Join(messages, "; "))
+}
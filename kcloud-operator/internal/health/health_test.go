@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// writeCertificate writes a self-signed certificate valid until notAfter and returns its path.
+func writeCertificate(notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	path := filepath.Join(GinkgoT().TempDir(), "tls.crt")
+	Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+	return path
+}
+
+var _ = Describe("Checks", func() {
+	It("should report every check as JSON and fail when one fails", func() {
+		checks := &Checks{}
+		checks.Add("ping", func(*http.Request) error { return nil })
+		checks.Add("apiserver", func(*http.Request) error { return errors.New("connection refused") })
+
+		rec := httptest.NewRecorder()
+		checks.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/diagnostics", nil))
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		var d Diagnostics
+		Expect(json.Unmarshal(rec.Body.Bytes(), &d)).To(Succeed())
+		Expect(d.Healthy).To(BeFalse())
+		Expect(d.Checks).To(HaveLen(2))
+		Expect(d.Checks[0]).To(HaveField("Healthy", true))
+		Expect(d.Checks[1]).To(HaveField("Error", "connection refused"))
+	})
+
+	It("should fail for expired certificates", func() {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		Expect(CertificateValid(writeCertificate(time.Now().Add(time.Hour)), 0)(req)).To(Succeed())
+		Expect(CertificateValid(writeCertificate(time.Now().Add(time.Hour)), 2*time.Hour)(req)).NotTo(Succeed())
+		Expect(CertificateValid(writeCertificate(time.Now().Add(-time.Minute)), 0)(req)).To(MatchError(ContainSubstring("expires")))
+		Expect(CertificateValid(filepath.Join(GinkgoT().TempDir(), "missing.crt"), 0)(req)).NotTo(Succeed())
+	})
+
+	It("should fail while a fatal failure is reported", func() {
+		var fatal Fatal
+		Expect(fatal.Check(nil)).To(Succeed())
+		fatal.Report("restore of policy default/gpu", errors.New("policy did not become Ready"))
+		Expect(fatal.Check(nil)).To(MatchError("restore of policy default/gpu: policy did not become Ready"))
+		fatal.Resolve("restore of policy default/gpu")
+		Expect(fatal.Check(nil)).To(Succeed())
+	})
+})
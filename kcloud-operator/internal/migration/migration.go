@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration rewrites stored policies whose spec still sets fields renamed in a later
+// revision of the v1alpha1 API, e.g. spec.nvidia.devicePluginImage moved to
+// spec.nvidia.devicePlugin.image and version. The deprecated fields stay served so existing
+// manifests keep applying, but the operator moves them to their replacement once, on start.
+// It then rewrites the policies stored in a version other than the storage version of their
+// CRD, so the served versions can be removed from the CRD later.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/health"
+)
+
+// Migration moves a deprecated field of the policy spec to its replacement.
+type Migration struct {
+	// Field is the path of the deprecated field below the spec, e.g. nvidia.devicePluginImage.
+	Field string
+	// Migrate moves the field of the spec and reports whether it was set. It must not change
+	// what the operator renders from the spec.
+	Migrate func(spec *npuv1alpha1.NPUClusterPolicySpec) bool
+}
+
+// Migrations are applied in order, so a field renamed twice is migrated in steps.
+var Migrations = []Migration{
+	{Field: "nvidia.devicePluginImage", Migrate: func(spec *npuv1alpha1.NPUClusterPolicySpec) bool {
+		return migrateDevicePluginImage(&spec.Nvidia.DevicePluginImage, &spec.Nvidia.DevicePlugin, spec.Catalog)
+	}},
+	{Field: "furiosa.devicePluginImage", Migrate: func(spec *npuv1alpha1.NPUClusterPolicySpec) bool {
+		return migrateDevicePluginImage(&spec.Furiosa.DevicePluginImage, &spec.Furiosa.DevicePlugin, spec.Catalog)
+	}},
+}
+
+// migrateDevicePluginImage splits the legacy image into devicePlugin.image and version. The
+// legacy image is only used without a catalog and devicePlugin.image, otherwise it is dropped.
+func migrateDevicePluginImage(legacy *string, plugin *npuv1alpha1.ComponentSpec, catalog string) bool {
+	if *legacy == "" {
+		return false
+	}
+	if plugin.Image == "" && catalog == "" {
+		plugin.Image, plugin.Version = splitImage(*legacy)
+	}
+	*legacy = ""
+	return true
+}
+
+// splitImage splits an image reference into its repository and tag. References without a
+// tag or pinned by digest are kept whole, e.g. registry:5000/plugin@sha256:... has no version.
+func splitImage(ref string) (string, string) {
+	if strings.Contains(ref, "@") {
+		return ref, ""
+	}
+	i := strings.LastIndex(ref, ":")
+	if i < 0 || strings.Contains(ref[i:], "/") {
+		return ref, ""
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// Spec applies the migrations to the spec and returns the migrated fields.
+func Spec(spec *npuv1alpha1.NPUClusterPolicySpec) []string {
+	var fields []string
+	for _, m := range Migrations {
+		if m.Migrate(spec) {
+			fields = append(fields, m.Field)
+		}
+	}
+	return fields
+}
+
+// +kubebuilder:rbac:groups=npu.ai,resources=npuclusterpolicies;npuclusterpolicytemplates,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update
+
+// Migrator migrates the policies and policy templates stored in the cluster. It runs once
+// when the manager starts, i.e. after an upgrade of the operator.
+type Migrator struct {
+	client.Client
+	Recorder record.EventRecorder
+	// Fatal receives the objects that could not be migrated, failing readiness. Nil only logs them.
+	Fatal *health.Fatal
+}
+
+// NeedLeaderElection makes only the leader migrate, so replicas do not race on updates.
+func (m *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start migrates every policy and template, then the stored versions of the policies, and
+// logs the completion. Failures are reported and do not stop the manager.
+func (m *Migrator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("migration")
+
+	policies := &npuv1alpha1.NPUClusterPolicyList{}
+	if err := m.List(ctx, policies); err != nil {
+		m.fail(ctx, "policies", err)
+		return nil
+	}
+	templates := &npuv1alpha1.NPUClusterPolicyTemplateList{}
+	if err := m.List(ctx, templates); err != nil {
+		m.fail(ctx, "policy templates", err)
+		return nil
+	}
+
+	var objects []migratable
+	for i := range policies.Items {
+		objects = append(objects, migratable{&policies.Items[i], &policies.Items[i].Spec})
+	}
+	for i := range templates.Items {
+		objects = append(objects, migratable{&templates.Items[i], &templates.Items[i].Spec.Template})
+	}
+	var migrated, failed int
+	for _, o := range objects {
+		ok, err := m.migrate(ctx, o)
+		switch {
+		case err != nil:
+			failed++
+		case ok:
+			migrated++
+		}
+	}
+	log.Info("Field migration complete", "checked", len(objects), "migrated", migrated, "failed", failed)
+
+	// An object failing to migrate may fail to rewrite as well, keep the stored versions then.
+	if failed > 0 {
+		return nil
+	}
+	if err := m.migrateStorageVersion(ctx, policies.Items); err != nil {
+		log.Error(err, "failed to migrate the stored versions", "crd", policyCRD)
+		if m.Fatal != nil {
+			m.Fatal.Report("storage version migration", err)
+		}
+	}
+	return nil
+}
+
+// policyCRD is the name of the CRD of NPUClusterPolicy.
+var policyCRD = "npuclusterpolicies." + npuv1alpha1.GroupVersion.Group
+
+// migrateStorageVersion rewrites the policies while the CRD records versions other than its
+// storage version as stored, then drops them from the stored versions. The API server stores
+// an object in the storage version on every write, so an empty patch is enough to move it.
+func (m *Migrator) migrateStorageVersion(ctx context.Context, policies []npuv1alpha1.NPUClusterPolicy) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Get(ctx, client.ObjectKey{Name: policyCRD}, crd); err != nil {
+		return err
+	}
+	var storage string
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			storage = v.Name
+		}
+	}
+	if slices.Equal(crd.Status.StoredVersions, []string{storage}) {
+		return nil
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		if err := m.Patch(ctx, policy, client.RawPatch(types.MergePatchType, []byte("{}"))); client.IgnoreNotFound(err) != nil {
+			m.Recorder.Eventf(policy, corev1.EventTypeWarning, "MigrationFailed",
+				"Policy could not be rewritten in storage version %s: %v", storage, err)
+			return fmt.Errorf("rewriting %s: %w", client.ObjectKeyFromObject(policy), err)
+		}
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.Get(ctx, client.ObjectKey{Name: policyCRD}, crd); err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storage}
+		return m.Status().Update(ctx, crd)
+	})
+	if err != nil {
+		return err
+	}
+	logf.FromContext(ctx).WithName("migration").Info("Storage version migration complete",
+		"policies", len(policies), "version", storage)
+	return nil
+}
+
+// migratable is a stored object and the policy spec it holds.
+type migratable struct {
+	obj  client.Object
+	spec *npuv1alpha1.NPUClusterPolicySpec
+}
+
+// migrate rewrites the deprecated fields of the object, retrying on conflicts, and reports
+// whether it had any. The migrated fields are recorded in its MigratedFieldsAnnotation.
+func (m *Migrator) migrate(ctx context.Context, o migratable) (bool, error) {
+	log := logf.FromContext(ctx).WithName("migration")
+	obj := o.obj
+	var fields []string
+	attempt := 0
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if attempt++; attempt > 1 {
+			// Clear what the previous attempt changed, decoding does not reset omitted fields.
+			*o.spec = npuv1alpha1.NPUClusterPolicySpec{}
+			obj.SetAnnotations(nil)
+			if err := m.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+		}
+		fields = Spec(o.spec)
+		if len(fields) == 0 {
+			return nil
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		recorded := fields
+		if prev := annotations[npuv1alpha1.MigratedFieldsAnnotation]; prev != "" {
+			recorded = append(strings.Split(prev, ","), fields...)
+			slices.Sort(recorded)
+			recorded = slices.Compact(recorded)
+		}
+		annotations[npuv1alpha1.MigratedFieldsAnnotation] = strings.Join(recorded, ",")
+		obj.SetAnnotations(annotations)
+		return m.Update(ctx, obj)
+	})
+	name := client.ObjectKeyFromObject(obj).String()
+	if err != nil {
+		log.Error(err, "failed to migrate deprecated fields", "object", name, "fields", fields)
+		m.Recorder.Eventf(obj, corev1.EventTypeWarning, "MigrationFailed",
+			"Deprecated fields %s could not be migrated: %v", strings.Join(fields, ", "), err)
+		if m.Fatal != nil {
+			m.Fatal.Report("migration of "+name, err)
+		}
+		return false, err
+	}
+	if len(fields) == 0 {
+		return false, nil
+	}
+	log.Info("Migrated deprecated fields", "object", name, "fields", fields)
+	m.Recorder.Eventf(obj, corev1.EventTypeNormal, "FieldsMigrated",
+		"Deprecated fields %s were moved to their replacement", strings.Join(fields, ", "))
+	return true, nil
+}
+
+// fail reports that the objects could not be listed, so none of them was migrated.
+func (m *Migrator) fail(ctx context.Context, what string, err error) {
+	logf.FromContext(ctx).WithName("migration").Error(err, "failed to list objects to migrate", "objects", what)
+	if m.Fatal != nil {
+		m.Fatal.Report("migration of "+what, err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Field migration", func() {
+	ctx := context.Background()
+
+	It("should split the legacy device plugin image into image and version", func() {
+		spec := &npuv1alpha1.NPUClusterPolicySpec{}
+		spec.Nvidia.DevicePluginImage = "registry.local:5000/nvidia/k8s-device-plugin:v0.17.0"
+		spec.Furiosa.DevicePluginImage = "registry.local:5000/furiosa/device-plugin"
+
+		Expect(Spec(spec)).To(Equal([]string{"nvidia.devicePluginImage", "furiosa.devicePluginImage"}))
+		Expect(spec.Nvidia.DevicePluginImage).To(BeEmpty())
+		Expect(spec.Nvidia.DevicePlugin.Image).To(Equal("registry.local:5000/nvidia/k8s-device-plugin"))
+		Expect(spec.Nvidia.DevicePlugin.Version).To(Equal("v0.17.0"))
+		Expect(spec.Furiosa.DevicePlugin.Image).To(Equal("registry.local:5000/furiosa/device-plugin"))
+		Expect(spec.Furiosa.DevicePlugin.Version).To(BeEmpty())
+
+		Expect(Spec(spec)).To(BeEmpty())
+	})
+
+	It("should drop legacy images that are ignored", func() {
+		spec := &npuv1alpha1.NPUClusterPolicySpec{}
+		spec.Nvidia.DevicePluginImage = "nvidia/k8s-device-plugin:v0.16.0"
+		spec.Nvidia.DevicePlugin.Image = "nvcr.io/nvidia/k8s-device-plugin"
+		spec.Furiosa.DevicePluginImage = "furiosa/device-plugin@sha256:0123"
+		spec.Catalog = "stable"
+
+		Expect(Spec(spec)).To(HaveLen(2))
+		Expect(spec.Nvidia.DevicePlugin.Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin"))
+		Expect(spec.Nvidia.DevicePlugin.Version).To(BeEmpty())
+		Expect(spec.Furiosa.DevicePlugin.Image).To(BeEmpty())
+	})
+
+	It("should keep image references pinned by digest whole", func() {
+		Expect(splitImage("furiosa/device-plugin@sha256:0123")).To(Equal("furiosa/device-plugin@sha256:0123"))
+		image, version := splitImage("registry.local:5000/plugin")
+		Expect(image).To(Equal("registry.local:5000/plugin"))
+		Expect(version).To(BeEmpty())
+	})
+
+	It("should migrate the stored policies and templates once and record the fields", func() {
+		scheme := runtime.NewScheme()
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		policy.Spec.Nvidia.DevicePluginImage = "nvidia/k8s-device-plugin:v0.17.0"
+		template := &npuv1alpha1.NPUClusterPolicyTemplate{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"}}
+		template.Spec.Template.Furiosa.DevicePluginImage = "${registry}/furiosa/device-plugin:${version}"
+		current := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "default"}}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy, template, current).Build()
+		recorder := record.NewFakeRecorder(10)
+
+		migrator := &Migrator{Client: c, Recorder: recorder}
+		Expect(migrator.Start(ctx)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.Spec.Nvidia.DevicePluginImage).To(BeEmpty())
+		Expect(policy.Spec.Nvidia.DevicePlugin.Image).To(Equal("nvidia/k8s-device-plugin"))
+		Expect(policy.Annotations).To(HaveKeyWithValue(npuv1alpha1.MigratedFieldsAnnotation, "nvidia.devicePluginImage"))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(template), template)).To(Succeed())
+		Expect(template.Spec.Template.Furiosa.DevicePlugin.Image).To(Equal("${registry}/furiosa/device-plugin"))
+		Expect(template.Spec.Template.Furiosa.DevicePlugin.Version).To(Equal("${version}"))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(current), current)).To(Succeed())
+		Expect(current.Annotations).NotTo(HaveKey(npuv1alpha1.MigratedFieldsAnnotation))
+		Expect(recorder.Events).To(HaveLen(2))
+
+		version := policy.ResourceVersion
+		Expect(migrator.Start(ctx)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.ResourceVersion).To(Equal(version))
+	})
+
+	It("should rewrite the policies and drop stored versions other than the storage version", func() {
+		scheme := runtime.NewScheme()
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "npuclusterpolicies.npu.ai"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+				{Name: "v1beta1", Served: true},
+			}},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1beta1", "v1alpha1"}},
+		}
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd, policy).WithStatusSubresource(crd).Build()
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		version := policy.ResourceVersion
+
+		migrator := &Migrator{Client: c, Recorder: record.NewFakeRecorder(10)}
+		Expect(migrator.Start(ctx)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(crd), crd)).To(Succeed())
+		Expect(crd.Status.StoredVersions).To(Equal([]string{"v1alpha1"}))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.ResourceVersion).NotTo(Equal(version))
+
+		version = policy.ResourceVersion
+		Expect(migrator.Start(ctx)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.ResourceVersion).To(Equal(version))
+	})
+})
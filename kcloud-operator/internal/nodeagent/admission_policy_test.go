@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/google/cel-go/cel"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// fieldSpec is the part of a kustomize transformer configuration the admission policy relies on.
+type fieldSpec struct {
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+}
+
+// kustomizeConfig is the transformer configuration of config/node-agent.
+type kustomizeConfig struct {
+	NameReference []struct {
+		Kind       string      `json:"kind"`
+		FieldSpecs []fieldSpec `json:"fieldSpecs"`
+	} `json:"nameReference"`
+	Namespace []fieldSpec `json:"namespace"`
+}
+
+// evaluate evaluates a CEL expression of the policy on an admission request.
+func evaluate(expression string, vars map[string]any) any {
+	env, err := cel.NewEnv(
+		cel.Variable("request", cel.DynType),
+		cel.Variable("oldObject", cel.DynType),
+		cel.Variable("params", cel.DynType),
+		cel.Variable("variables", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	ast, issues := env.Compile(expression)
+	Expect(issues.Err()).NotTo(HaveOccurred(), expression)
+	program, err := env.Program(ast)
+	Expect(err).NotTo(HaveOccurred())
+	out, _, err := program.Eval(vars)
+	Expect(err).NotTo(HaveOccurred(), expression)
+	return out.Value()
+}
+
+var _ = Describe("Node agent admission policy", func() {
+	var (
+		policy  admissionregistrationv1.ValidatingAdmissionPolicy
+		binding admissionregistrationv1.ValidatingAdmissionPolicyBinding
+		account corev1.ServiceAccount
+		config  kustomizeConfig
+	)
+
+	BeforeEach(func() {
+		dir := filepath.Join("..", "..", "config", "node-agent")
+		data, err := os.ReadFile(filepath.Join(dir, "admission_policy.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		docs := bytes.Split(data, []byte("\n---\n"))
+		Expect(docs).To(HaveLen(2))
+		Expect(yaml.Unmarshal(docs[0], &policy)).To(Succeed())
+		Expect(yaml.Unmarshal(docs[1], &binding)).To(Succeed())
+		data, err = os.ReadFile(filepath.Join(dir, "service_account.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(yaml.Unmarshal(data, &account)).To(Succeed())
+		data, err = os.ReadFile(filepath.Join(dir, "kustomizeconfig.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(yaml.Unmarshal(data, &config)).To(Succeed())
+	})
+
+	It("should bind the policy by the name kustomize gives it", func() {
+		Expect(binding.Spec.PolicyName).To(Equal(policy.Name))
+		Expect(config.NameReference).To(ContainElement(HaveField("Kind", "ValidatingAdmissionPolicy")))
+	})
+
+	It("should pass the ServiceAccount of the node agent as param, as kustomize names it", func() {
+		Expect(policy.Spec.ParamKind).NotTo(BeNil())
+		Expect(policy.Spec.ParamKind.Kind).To(Equal("ServiceAccount"))
+		Expect(binding.Spec.ParamRef).NotTo(BeNil())
+		Expect(binding.Spec.ParamRef.Name).To(Equal(account.Name))
+		Expect(binding.Spec.ParamRef.Namespace).To(Equal(account.Namespace))
+
+		// Without these, a name prefix or namespace of the overlay leaves the param dangling and
+		// the policy inert.
+		var references []fieldSpec
+		for _, r := range config.NameReference {
+			if r.Kind == "ServiceAccount" {
+				references = append(references, r.FieldSpecs...)
+			}
+		}
+		Expect(references).To(ContainElement(fieldSpec{Kind: "ValidatingAdmissionPolicyBinding", Path: "spec/paramRef/name"}))
+		Expect(config.Namespace).To(ContainElement(fieldSpec{Kind: "ValidatingAdmissionPolicyBinding", Path: "spec/paramRef/namespace"}))
+	})
+
+	It("should take the identity of the node agent from its ServiceAccount", func() {
+		Expect(policy.Spec.MatchConditions).To(HaveLen(1))
+		expression := policy.Spec.MatchConditions[0].Expression
+		params := map[string]any{"metadata": map[string]any{"namespace": "npu-system", "name": "npu-node-agent"}}
+
+		agent := map[string]any{"userInfo": map[string]any{"username": "system:serviceaccount:npu-system:npu-node-agent"}}
+		Expect(evaluate(expression, map[string]any{"request": agent, "params": params})).To(BeTrue())
+		other := map[string]any{"userInfo": map[string]any{"username": "system:serviceaccount:npu-system:npu-operator"}}
+		Expect(evaluate(expression, map[string]any{"request": other, "params": params})).To(BeFalse())
+		elsewhere := map[string]any{"userInfo": map[string]any{"username": "system:serviceaccount:default:npu-node-agent"}}
+		Expect(evaluate(expression, map[string]any{"request": elsewhere, "params": params})).To(BeFalse())
+	})
+
+	It("should only let the node agent write its own node and the pods on it", func() {
+		Expect(policy.Spec.Variables).To(HaveLen(1))
+		request := func(resource string) map[string]any {
+			return map[string]any{
+				"resource": map[string]any{"resource": resource},
+				"userInfo": map[string]any{"extra": map[string]any{
+					"authentication.kubernetes.io/node-name": []any{"node-a"},
+				}},
+			}
+		}
+		allowed := func(resource string, oldObject map[string]any) bool {
+			vars := map[string]any{"request": request(resource), "oldObject": oldObject}
+			vars["variables"] = map[string]any{policy.Spec.Variables[0].Name: evaluate(policy.Spec.Variables[0].Expression, vars)}
+			for _, v := range policy.Spec.Validations {
+				if evaluate(v.Expression, vars) != true {
+					return false
+				}
+			}
+			return true
+		}
+
+		Expect(allowed("nodes", map[string]any{"metadata": map[string]any{"name": "node-a"}})).To(BeTrue())
+		Expect(allowed("nodes", map[string]any{"metadata": map[string]any{"name": "node-b"}})).To(BeFalse())
+		Expect(allowed("npunodes", map[string]any{"metadata": map[string]any{"name": "node-b"}})).To(BeFalse())
+		Expect(allowed("pods", map[string]any{"spec": map[string]any{"nodeName": "node-a"}})).To(BeTrue())
+		Expect(allowed("pods", map[string]any{"spec": map[string]any{"nodeName": "node-b"}})).To(BeFalse())
+
+		unbound := request("nodes")
+		unbound["userInfo"] = map[string]any{"extra": map[string]any{}}
+		vars := map[string]any{"request": unbound, "oldObject": map[string]any{"metadata": map[string]any{"name": "node-a"}}}
+		Expect(evaluate(policy.Spec.Variables[0].Expression, vars)).To(BeEmpty())
+	})
+})
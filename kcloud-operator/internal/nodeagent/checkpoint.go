@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// DefaultCheckpointPath is where the kubelet device manager persists device assignments.
+const DefaultCheckpointPath = "/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"
+
+// devicePlugins maps an accelerator resource prefix to the app.kubernetes.io/name of its device plugin.
+var devicePlugins = map[string]string{
+	"nvidia.com/": "nvidia-device-plugin",
+	"furiosa.ai/": "furiosa-device-plugin",
+}
+
+// Checkpoint is the kubelet device manager checkpoint file.
+type Checkpoint struct {
+	Data struct {
+		PodDeviceEntries  []PodDevicesEntry   `json:"PodDeviceEntries"`
+		RegisteredDevices map[string][]string `json:"RegisteredDevices"`
+	} `json:"Data"`
+}
+
+// PodDevicesEntry is one container device assignment in the checkpoint.
+type PodDevicesEntry struct {
+	PodUID        string `json:"PodUID"`
+	ContainerName string `json:"ContainerName"`
+	ResourceName  string `json:"ResourceName"`
+	// DeviceIDs maps a NUMA node to the device IDs assigned from it.
+	DeviceIDs map[string][]string `json:"DeviceIDs"`
+}
+
+// ReadCheckpoint reads the kubelet device manager checkpoint.
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// CheckpointVerifier compares the kubelet device manager checkpoint with the devices
+// actually registered and the pods actually running, once per node boot. Assignments of
+// devices that no longer exist restart the vendor's device plugin so it re-registers;
+// assignments of pods that no longer exist request revalidation of the node.
+type CheckpointVerifier struct {
+	client.Client
+	NodeName       string
+	CheckpointPath string
+	Devices        AllocatableLister
+	Recorder       record.EventRecorder
+}
+
+// Verify checks the checkpoint if the node rebooted since the last verification.
+func (v *CheckpointVerifier) Verify(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := v.Get(ctx, types.NamespacedName{Name: v.NodeName}, node); err != nil {
+		return err
+	}
+	bootID := node.Status.NodeInfo.BootID
+	if bootID == "" || node.Annotations[npuv1alpha1.CheckpointVerifiedAnnotation] == bootID {
+		return nil
+	}
+
+	cp, err := ReadCheckpoint(v.CheckpointPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	revalidate := false
+	if cp != nil {
+		staleDevices, stalePods, err := v.findStale(ctx, cp)
+		if err != nil {
+			return err
+		}
+		for _, plugin := range staleDevices {
+			log.Info("Checkpoint assigns devices that are not registered, restarting device plugin", "plugin", plugin)
+			v.Recorder.Eventf(node, corev1.EventTypeWarning, "StaleDeviceCheckpoint",
+				"Kubelet checkpoint assigns devices that are not registered; restarting %s", plugin)
+			if err := v.restartPlugin(ctx, plugin); err != nil {
+				return err
+			}
+		}
+		if len(stalePods) > 0 {
+			log.Info("Checkpoint assigns devices to pods that no longer exist, requesting revalidation", "pods", stalePods)
+			v.Recorder.Eventf(node, corev1.EventTypeWarning, "StalePodCheckpoint",
+				"Kubelet checkpoint assigns devices to %d pods that no longer exist; requesting revalidation", len(stalePods))
+			revalidate = true
+		}
+	}
+	return v.markVerified(ctx, node, bootID, revalidate)
+}
+
+// -- findStale returns the device plugins with stale device assignments and the UIDs of pods that no longer exist
+func (v *CheckpointVerifier) findStale(ctx context.Context, cp *Checkpoint) (plugins []string, podUIDs []string, err error) {
+	allocatable, err := v.Devices.Allocatable(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	registered := map[string]bool{}
+	for _, d := range allocatable {
+		for _, id := range d.GetDeviceIds() {
+			registered[d.GetResourceName()+"/"+id] = true
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := v.List(ctx, pods, client.MatchingFields{"spec.nodeName": v.NodeName}); err != nil {
+		return nil, nil, err
+	}
+	running := map[string]bool{}
+	for _, p := range pods.Items {
+		running[string(p.UID)] = true
+	}
+
+	stalePlugins := map[string]bool{}
+	stalePods := map[string]bool{}
+	for _, e := range cp.Data.PodDeviceEntries {
+		plugin := pluginForResource(e.ResourceName)
+		if plugin == "" {
+			continue
+		}
+		if !running[e.PodUID] {
+			stalePods[e.PodUID] = true
+			continue
+		}
+		for _, ids := range e.DeviceIDs {
+			for _, id := range ids {
+				if !registered[e.ResourceName+"/"+id] {
+					stalePlugins[plugin] = true
+				}
+			}
+		}
+	}
+	return sortedSet(stalePlugins), sortedSet(stalePods), nil
+}
+
+// -- restartPlugin deletes the device plugin pod on this node so its DaemonSet recreates it
+func (v *CheckpointVerifier) restartPlugin(ctx context.Context, plugin string) error {
+	pods := &corev1.PodList{}
+	if err := v.List(ctx, pods, client.MatchingFields{"spec.nodeName": v.NodeName},
+		client.MatchingLabels{"app.kubernetes.io/name": plugin}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		if err := v.Delete(ctx, &pods.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// -- markVerified records the verified boot ID and optionally requests revalidation
+func (v *CheckpointVerifier) markVerified(ctx context.Context, node *corev1.Node, bootID string, revalidate bool) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[npuv1alpha1.CheckpointVerifiedAnnotation] = bootID
+	if revalidate {
+		node.Annotations[npuv1alpha1.RevalidateAnnotation] = "true"
+	}
+	return v.Patch(ctx, node, patch)
+}
+
+func pluginForResource(name string) string {
+	for prefix, plugin := range devicePlugins {
+		if strings.HasPrefix(name, prefix) {
+			return plugin
+		}
+	}
+	return ""
+}
+
+func sortedSet(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
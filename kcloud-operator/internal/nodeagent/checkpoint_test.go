@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+type staticAllocatable []*podresourcesv1.ContainerDevices
+
+func (l staticAllocatable) Allocatable(context.Context) ([]*podresourcesv1.ContainerDevices, error) {
+	return l, nil
+}
+
+const testCheckpoint = `{
+  "Data": {
+    "PodDeviceEntries": [
+      {"PodUID": "uid-trainer", "ContainerName": "main", "ResourceName": "nvidia.com/gpu",
+       "DeviceIDs": {"0": ["GPU-0", "GPU-7"]}},
+      {"PodUID": "uid-gone", "ContainerName": "main", "ResourceName": "nvidia.com/gpu",
+       "DeviceIDs": {"0": ["GPU-1"]}}
+    ],
+    "RegisteredDevices": {"nvidia.com/gpu": ["GPU-0", "GPU-1", "GPU-7"]}
+  },
+  "Checksum": 1234
+}`
+
+var _ = Describe("CheckpointVerifier", func() {
+	const nodeName = "gpu-node-1"
+
+	ctx := context.Background()
+
+	It("restarts the plugin and requests revalidation for stale assignments", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "kubelet_internal_checkpoint")
+		Expect(os.WriteFile(path, []byte(testCheckpoint), 0o600)).To(Succeed())
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{BootID: "boot-2"}},
+		}
+		trainer := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "trainer", Namespace: "default", UID: "uid-trainer"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+		plugin := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-device-plugin-x7k2p",
+				Namespace: "kube-system",
+				UID:       "uid-plugin",
+				Labels:    map[string]string{"app.kubernetes.io/name": "nvidia-device-plugin"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(node, trainer, plugin).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		verifier := &CheckpointVerifier{
+			Client:         c,
+			NodeName:       nodeName,
+			CheckpointPath: path,
+			// GPU-7 fell off the bus during the crash and is no longer registered.
+			Devices:  staticAllocatable{{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"GPU-0", "GPU-1"}}},
+			Recorder: record.NewFakeRecorder(10),
+		}
+		Expect(verifier.Verify(ctx)).To(Succeed())
+
+		err := c.Get(ctx, client.ObjectKeyFromObject(plugin), &corev1.Pod{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the device plugin pod should be restarted")
+
+		Expect(c.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(node.Annotations).To(HaveKeyWithValue(npuv1alpha1.CheckpointVerifiedAnnotation, "boot-2"))
+		Expect(node.Annotations).To(HaveKeyWithValue(npuv1alpha1.RevalidateAnnotation, "true"))
+
+		By("skipping verification until the next boot")
+		Expect(os.Remove(path)).To(Succeed())
+		Expect(verifier.Verify(ctx)).To(Succeed())
+	})
+})
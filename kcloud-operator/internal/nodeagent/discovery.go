@@ -0,0 +1,272 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// Discovery backends.
+const (
+	DiscoveryPCI   = "pci"
+	DiscoverySMI   = "smi"
+	DiscoveryCloud = "cloud"
+)
+
+// DefaultDiscovery is the order in which discovery backends are tried.
+var DefaultDiscovery = []string{DiscoveryPCI, DiscoverySMI, DiscoveryCloud}
+
+// ErrDiscoveryUnavailable is returned by a backend that cannot run on this node, e.g.
+// without access to /sys. The next backend is tried then.
+var ErrDiscoveryUnavailable = errors.New("discovery backend unavailable")
+
+// Discoverer finds the accelerators of a node.
+type Discoverer interface {
+	Discover(ctx context.Context, node *corev1.Node) ([]npuv1alpha1.DiscoveredDevice, error)
+}
+
+// DeviceDiscovery publishes the accelerators of the node in the status of its NPUNode,
+// using the first discovery backend that is available and finds devices.
+type DeviceDiscovery struct {
+	client.Client
+	NodeName string
+	Backends map[string]Discoverer
+	// Order of the backends, unless the node sets the npu.ai/discovery label.
+	Order []string
+}
+
+// Sync discovers the devices of the node and updates its NPUNode.
+func (d *DeviceDiscovery) Sync(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := d.Get(ctx, types.NamespacedName{Name: d.NodeName}, node); err != nil {
+		return err
+	}
+	npuNode := &npuv1alpha1.NPUNode{}
+	if err := d.Get(ctx, types.NamespacedName{Name: d.NodeName}, npuNode); err != nil {
+		// The operator creates the NPUNode once the node is known as an accelerator node.
+		return client.IgnoreNotFound(err)
+	}
+
+	order := d.Order
+	if v := node.Labels[npuv1alpha1.DiscoveryLabel]; v != "" {
+		order = strings.Split(v, ",")
+	}
+	var devices []npuv1alpha1.DiscoveredDevice
+	var backend string
+	for _, name := range order {
+		name = strings.TrimSpace(name)
+		discoverer, ok := d.Backends[name]
+		if !ok {
+			log.Info("Unknown discovery backend, skipping", "backend", name)
+			continue
+		}
+		found, err := discoverer.Discover(ctx, node)
+		if errors.Is(err, ErrDiscoveryUnavailable) {
+			log.V(1).Info("Discovery backend unavailable", "backend", name, "reason", err.Error())
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s discovery: %w", name, err)
+		}
+		backend, devices = name, found
+		if len(found) > 0 {
+			break
+		}
+	}
+
+	if npuNode.Status.DiscoveredBy == backend && equality.Semantic.DeepEqual(npuNode.Status.Devices, devices) {
+		return nil
+	}
+	log.Info("Discovered devices", "backend", backend, "count", len(devices))
+	patch := client.MergeFrom(npuNode.DeepCopy())
+	npuNode.Status.Devices = devices
+	npuNode.Status.DiscoveredBy = backend
+	return d.Status().Patch(ctx, npuNode, patch)
+}
+
+// pciVendors maps PCI vendor IDs to accelerator vendors.
+var pciVendors = map[string]string{
+	"0x10de": "nvidia",
+	"0x1ed2": "furiosa",
+}
+
+// PCIDiscoverer scans the PCI devices in sysfs for display controllers and processing
+// accelerators of known vendors.
+type PCIDiscoverer struct {
+	// Root of sysfs, /sys unless set.
+	Root string
+}
+
+// Discover lists the accelerators on the PCI bus.
+func (p *PCIDiscoverer) Discover(_ context.Context, _ *corev1.Node) ([]npuv1alpha1.DiscoveredDevice, error) {
+	root := p.Root
+	if root == "" {
+		root = "/sys"
+	}
+	dir := filepath.Join(root, "bus", "pci", "devices")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("%w: %v", ErrDiscoveryUnavailable, err)
+		}
+		return nil, err
+	}
+	var devices []npuv1alpha1.DiscoveredDevice
+	for _, entry := range entries {
+		read := func(name string) string {
+			data, _ := os.ReadFile(filepath.Join(dir, entry.Name(), name))
+			return strings.TrimSpace(string(data))
+		}
+		vendor, ok := pciVendors[read("vendor")]
+		// 0x03 are display controllers, 0x12 processing accelerators.
+		class := read("class")
+		if !ok || !(strings.HasPrefix(class, "0x03") || strings.HasPrefix(class, "0x12")) {
+			continue
+		}
+		device := npuv1alpha1.DiscoveredDevice{
+			ID:     entry.Name(),
+			Vendor: vendor,
+			Model:  strings.TrimPrefix(read("vendor"), "0x") + ":" + strings.TrimPrefix(read("device"), "0x"),
+		}
+		if numa, err := strconv.ParseInt(read("numa_node"), 10, 32); err == nil && numa >= 0 {
+			device.NUMANode = ptrTo(int32(numa))
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// CommandRunner runs a command and returns its standard output.
+type CommandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func execCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// SMIDiscoverer queries the vendor management tools available in the agent image.
+// Only nvidia-smi is supported so far.
+type SMIDiscoverer struct {
+	// Run runs the tools, executing them unless set.
+	Run CommandRunner
+}
+
+// Discover lists the GPUs reported by nvidia-smi.
+func (s *SMIDiscoverer) Discover(ctx context.Context, _ *corev1.Node) ([]npuv1alpha1.DiscoveredDevice, error) {
+	run := s.Run
+	if run == nil {
+		run = execCommand
+	}
+	out, err := run(ctx, "nvidia-smi", "--query-gpu=uuid,name,pci.bus_id", "--format=csv,noheader")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrDiscoveryUnavailable, err)
+		}
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(out))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected nvidia-smi output: %w", err)
+	}
+	var devices []npuv1alpha1.DiscoveredDevice
+	for _, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output: %q", strings.Join(record, ","))
+		}
+		devices = append(devices, npuv1alpha1.DiscoveredDevice{ID: record[0], Vendor: "nvidia", Model: record[1]})
+	}
+	return devices, nil
+}
+
+// cloudInstance is the accelerators of a cloud instance type.
+type cloudInstance struct {
+	vendor, model string
+	count         int
+}
+
+// cloudInstances maps well-known accelerator instance types to their accelerators.
+var cloudInstances = map[string]cloudInstance{
+	"p4d.24xlarge":          {"nvidia", "A100", 8},
+	"p4de.24xlarge":         {"nvidia", "A100", 8},
+	"p5.48xlarge":           {"nvidia", "H100", 8},
+	"g5.xlarge":             {"nvidia", "A10G", 1},
+	"g5.12xlarge":           {"nvidia", "A10G", 4},
+	"g5.48xlarge":           {"nvidia", "A10G", 8},
+	"a2-highgpu-1g":         {"nvidia", "A100", 1},
+	"a2-highgpu-8g":         {"nvidia", "A100", 8},
+	"a3-highgpu-8g":         {"nvidia", "H100", 8},
+	"Standard_ND96asr_v4":   {"nvidia", "A100", 8},
+	"Standard_ND96isr_H100": {"nvidia", "H100", 8},
+}
+
+// CloudDiscoverer derives the accelerators from the instance type the cloud provider
+// reported for the node, for nodes where neither sysfs nor the vendor tools are usable.
+type CloudDiscoverer struct{}
+
+// Discover lists the accelerators of the instance type of the node.
+func (CloudDiscoverer) Discover(_ context.Context, node *corev1.Node) ([]npuv1alpha1.DiscoveredDevice, error) {
+	instanceType := node.Labels[corev1.LabelInstanceTypeStable]
+	if instanceType == "" {
+		return nil, fmt.Errorf("%w: node has no %s label", ErrDiscoveryUnavailable, corev1.LabelInstanceTypeStable)
+	}
+	instance, ok := cloudInstances[instanceType]
+	if !ok {
+		return nil, nil
+	}
+	devices := make([]npuv1alpha1.DiscoveredDevice, 0, instance.count)
+	for i := range instance.count {
+		devices = append(devices, npuv1alpha1.DiscoveredDevice{
+			ID:     fmt.Sprintf("%s/%d", instanceType, i),
+			Vendor: instance.vendor,
+			Model:  instance.model,
+		})
+	}
+	return devices, nil
+}
+
+// DiscoveryBackends returns the available discovery backends.
+func DiscoveryBackends(sysfsRoot string) map[string]Discoverer {
+	return map[string]Discoverer{
+		DiscoveryPCI:   &PCIDiscoverer{Root: sysfsRoot},
+		DiscoverySMI:   &SMIDiscoverer{},
+		DiscoveryCloud: CloudDiscoverer{},
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
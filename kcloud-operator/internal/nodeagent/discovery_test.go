@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+type staticDiscoverer struct {
+	devices []npuv1alpha1.DiscoveredDevice
+	err     error
+}
+
+func (d staticDiscoverer) Discover(context.Context, *corev1.Node) ([]npuv1alpha1.DiscoveredDevice, error) {
+	return d.devices, d.err
+}
+
+func writePCIDevice(root, address string, files map[string]string) {
+	dir := filepath.Join(root, "bus", "pci", "devices", address)
+	Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+	for name, content := range files {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o600)).To(Succeed())
+	}
+}
+
+var _ = Describe("Device discovery", func() {
+	const nodeName = "gpu-node-1"
+
+	ctx := context.Background()
+
+	It("scans sysfs for accelerators of known vendors", func() {
+		root := GinkgoT().TempDir()
+		writePCIDevice(root, "0000:07:00.0", map[string]string{
+			"vendor": "0x10de", "device": "0x20b2", "class": "0x030200", "numa_node": "1",
+		})
+		writePCIDevice(root, "0000:08:00.0", map[string]string{
+			"vendor": "0x10de", "device": "0x1af1", "class": "0x068000", "numa_node": "1",
+		})
+		writePCIDevice(root, "0000:09:00.0", map[string]string{
+			"vendor": "0x1ed2", "device": "0x0001", "class": "0x120000", "numa_node": "-1",
+		})
+
+		devices, err := (&PCIDiscoverer{Root: root}).Discover(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(devices).To(HaveLen(2))
+		Expect(devices[0].ID).To(Equal("0000:07:00.0"))
+		Expect(devices[0].Model).To(Equal("10de:20b2"))
+		Expect(*devices[0].NUMANode).To(Equal(int32(1)))
+		Expect(devices[1].Vendor).To(Equal("furiosa"))
+		Expect(devices[1].NUMANode).To(BeNil())
+
+		_, err = (&PCIDiscoverer{Root: filepath.Join(root, "missing")}).Discover(ctx, nil)
+		Expect(errors.Is(err, ErrDiscoveryUnavailable)).To(BeTrue())
+	})
+
+	It("parses nvidia-smi output", func() {
+		smi := &SMIDiscoverer{Run: func(context.Context, string, ...string) ([]byte, error) {
+			return []byte("GPU-1a2b, NVIDIA A100-SXM4-80GB, 00000000:07:00.0\n"), nil
+		}}
+		devices, err := smi.Discover(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(devices).To(ConsistOf(npuv1alpha1.DiscoveredDevice{
+			ID: "GPU-1a2b", Vendor: "nvidia", Model: "NVIDIA A100-SXM4-80GB",
+		}))
+
+		missing := &SMIDiscoverer{Run: func(context.Context, string, ...string) ([]byte, error) {
+			return nil, exec.ErrNotFound
+		}}
+		_, err = missing.Discover(ctx, nil)
+		Expect(errors.Is(err, ErrDiscoveryUnavailable)).To(BeTrue())
+	})
+
+	It("derives the accelerators from the cloud instance type", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{corev1.LabelInstanceTypeStable: "p5.48xlarge"},
+		}}
+		devices, err := CloudDiscoverer{}.Discover(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(devices).To(HaveLen(8))
+		Expect(devices[0].Model).To(Equal("H100"))
+	})
+
+	It("publishes the devices of the first available backend, in the order of the node label", func() {
+		s := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(s)).To(Succeed())
+		Expect(npuv1alpha1.AddToScheme(s)).To(Succeed())
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{npuv1alpha1.DiscoveryLabel: "pci,cloud"},
+		}}
+		npuNode := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		c := fake.NewClientBuilder().WithScheme(s).WithObjects(node, npuNode).
+			WithStatusSubresource(npuNode).Build()
+
+		discovery := &DeviceDiscovery{
+			Client:   c,
+			NodeName: nodeName,
+			Backends: map[string]Discoverer{
+				DiscoveryPCI: staticDiscoverer{err: ErrDiscoveryUnavailable},
+				DiscoverySMI: staticDiscoverer{devices: []npuv1alpha1.DiscoveredDevice{{ID: "GPU-0", Vendor: "nvidia"}}},
+				DiscoveryCloud: staticDiscoverer{devices: []npuv1alpha1.DiscoveredDevice{
+					{ID: "p5.48xlarge/0", Vendor: "nvidia", Model: "H100"},
+				}},
+			},
+			Order: DefaultDiscovery,
+		}
+		Expect(discovery.Sync(ctx)).To(Succeed())
+
+		Expect(c.Get(ctx, types.NamespacedName{Name: nodeName}, npuNode)).To(Succeed())
+		Expect(npuNode.Status.DiscoveredBy).To(Equal(DiscoveryCloud))
+		Expect(npuNode.Status.Devices).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// NodeConditionReporter mirrors the accelerator state of the node into Node conditions:
+// whether the driver pods run, whether the device plugins registered devices with the
+// kubelet, and whether every device is allocatable.
+type NodeConditionReporter struct {
+	client.Client
+	NodeName string
+	Devices  AllocatableLister
+}
+
+// Sync computes the conditions and patches the ones that changed.
+func (r *NodeConditionReporter) Sync(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: r.NodeName}, node); err != nil {
+		return err
+	}
+	resources := acceleratorCapacity(node)
+	if len(resources) == 0 {
+		return nil
+	}
+
+	driver, err := r.driverCondition(ctx, resources)
+	if err != nil {
+		return err
+	}
+	plugin, err := r.pluginCondition(ctx, resources)
+	if err != nil {
+		return err
+	}
+	desired := []corev1.NodeCondition{driver, plugin, deviceCondition(node, resources)}
+
+	patch := client.StrategicMergeFrom(node.DeepCopy())
+	changed := false
+	now := metav1.Now()
+	for _, want := range desired {
+		if setNodeCondition(node, want, now) {
+			log.Info("Node condition changed", "type", want.Type, "status", want.Status, "reason", want.Reason)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.Status().Patch(ctx, node, patch)
+}
+
+// -- driverCondition reports whether a ready driver pod runs for every vendor. Vendors
+// without driver pods are assumed to use a driver preinstalled on the host.
+func (r *NodeConditionReporter) driverCondition(ctx context.Context, resources []string) (corev1.NodeCondition, error) {
+	var missing, preinstalled []string
+	for _, vendor := range vendorsOf(resources) {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.MatchingFields{"spec.nodeName": r.NodeName},
+			client.MatchingLabels{"app.kubernetes.io/name": vendor + "-driver"}); err != nil {
+			return corev1.NodeCondition{}, err
+		}
+		if len(pods.Items) == 0 {
+			preinstalled = append(preinstalled, vendor)
+			continue
+		}
+		if !anyPodReady(pods.Items) {
+			missing = append(missing, vendor+"-driver")
+		}
+	}
+	switch {
+	case len(missing) > 0:
+		return nodeCondition(npuv1alpha1.NodeDriverReady, corev1.ConditionFalse, "DriverNotReady",
+			fmt.Sprintf("%s not ready", strings.Join(missing, ", "))), nil
+	case len(preinstalled) > 0:
+		return nodeCondition(npuv1alpha1.NodeDriverReady, corev1.ConditionTrue, "HostDriver",
+			fmt.Sprintf("No driver pod for %s, assuming a driver installed on the host", strings.Join(preinstalled, ", "))), nil
+	}
+	return nodeCondition(npuv1alpha1.NodeDriverReady, corev1.ConditionTrue, "DriverReady", "Driver pods are ready"), nil
+}
+
+// -- pluginCondition reports whether devices of every accelerator resource are registered with the kubelet
+func (r *NodeConditionReporter) pluginCondition(ctx context.Context, resources []string) (corev1.NodeCondition, error) {
+	allocatable, err := r.Devices.Allocatable(ctx)
+	if err != nil {
+		return corev1.NodeCondition{}, err
+	}
+	registered := map[string]bool{}
+	for _, d := range allocatable {
+		if len(d.GetDeviceIds()) > 0 {
+			registered[d.GetResourceName()] = true
+		}
+	}
+	var missing []string
+	for _, name := range resources {
+		if !registered[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nodeCondition(npuv1alpha1.NodePluginRegistered, corev1.ConditionFalse, "PluginNotRegistered",
+			fmt.Sprintf("No devices registered for %s", strings.Join(missing, ", "))), nil
+	}
+	return nodeCondition(npuv1alpha1.NodePluginRegistered, corev1.ConditionTrue, "PluginRegistered",
+		"Device plugins registered their devices"), nil
+}
+
+// -- deviceCondition reports whether every device is allocatable. The kubelet removes devices
+// the plugin reports unhealthy from the allocatable resources.
+func deviceCondition(node *corev1.Node, resources []string) corev1.NodeCondition {
+	var unhealthy []string
+	for _, name := range resources {
+		capacity := node.Status.Capacity[corev1.ResourceName(name)]
+		allocatable := node.Status.Allocatable[corev1.ResourceName(name)]
+		if allocatable.Cmp(capacity) < 0 {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %s of %s allocatable", name, allocatable.String(), capacity.String()))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return nodeCondition(npuv1alpha1.NodeDeviceHealthy, corev1.ConditionFalse, "DeviceUnhealthy",
+			strings.Join(unhealthy, ", "))
+	}
+	return nodeCondition(npuv1alpha1.NodeDeviceHealthy, corev1.ConditionTrue, "DeviceHealthy", "All devices are allocatable")
+}
+
+func nodeCondition(t string, status corev1.ConditionStatus, reason, message string) corev1.NodeCondition {
+	return corev1.NodeCondition{Type: corev1.NodeConditionType(t), Status: status, Reason: reason, Message: message}
+}
+
+// -- setNodeCondition sets the condition on the node and reports whether it changed. The
+// transition time only moves when the status does.
+func setNodeCondition(node *corev1.Node, want corev1.NodeCondition, now metav1.Time) bool {
+	for i := range node.Status.Conditions {
+		c := &node.Status.Conditions[i]
+		if c.Type != want.Type {
+			continue
+		}
+		if c.Status == want.Status && c.Reason == want.Reason && c.Message == want.Message {
+			return false
+		}
+		if c.Status != want.Status {
+			c.LastTransitionTime = now
+		}
+		c.Status, c.Reason, c.Message = want.Status, want.Reason, want.Message
+		c.LastHeartbeatTime = now
+		return true
+	}
+	want.LastTransitionTime = now
+	want.LastHeartbeatTime = now
+	node.Status.Conditions = append(node.Status.Conditions, want)
+	return true
+}
+
+// -- acceleratorCapacity returns the accelerator resources the node has capacity for
+func acceleratorCapacity(node *corev1.Node) []string {
+	var out []string
+	for name, q := range node.Status.Capacity {
+		if pluginForResource(string(name)) != "" && !q.IsZero() {
+			out = append(out, string(name))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func vendorsOf(resources []string) []string {
+	set := map[string]bool{}
+	for _, name := range resources {
+		vendor, _, _ := strings.Cut(pluginForResource(name), "-")
+		set[vendor] = true
+	}
+	return sortedSet(set)
+}
+
+func anyPodReady(pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("NodeConditionReporter", func() {
+	const nodeName = "gpu-node-1"
+
+	ctx := context.Background()
+
+	condition := func(node *corev1.Node, t string) *corev1.NodeCondition {
+		for i := range node.Status.Conditions {
+			if string(node.Status.Conditions[i].Type) == t {
+				return &node.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	It("mirrors the driver, plugin and device health into Node conditions", func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: corev1.NodeStatus{
+				Capacity:    corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+				Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("7")},
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"},
+				},
+			},
+		}
+		driver := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-driver-q8x2m",
+				Namespace: "kube-system",
+				Labels:    map[string]string{"app.kubernetes.io/name": "nvidia-driver"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(node, driver).
+			WithStatusSubresource(node).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		reporter := &NodeConditionReporter{
+			Client:   c,
+			NodeName: nodeName,
+			Devices:  staticAllocatable{{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"GPU-0", "GPU-1"}}},
+		}
+		Expect(reporter.Sync(ctx)).To(Succeed())
+
+		Expect(c.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(condition(node, string(corev1.NodeReady))).NotTo(BeNil(), "conditions of the kubelet are kept")
+		Expect(condition(node, npuv1alpha1.NodeDriverReady).Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition(node, npuv1alpha1.NodePluginRegistered).Status).To(Equal(corev1.ConditionTrue))
+		healthy := condition(node, npuv1alpha1.NodeDeviceHealthy)
+		Expect(healthy.Status).To(Equal(corev1.ConditionFalse))
+		Expect(healthy.Message).To(Equal("nvidia.com/gpu: 7 of 8 allocatable"))
+
+		By("reporting an unregistered plugin")
+		reporter.Devices = staticAllocatable{}
+		Expect(reporter.Sync(ctx)).To(Succeed())
+		Expect(c.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		Expect(condition(node, npuv1alpha1.NodePluginRegistered).Status).To(Equal(corev1.ConditionFalse))
+	})
+
+	It("assumes a host driver without driver pods", func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: corev1.NodeStatus{
+				Capacity:    corev1.ResourceList{"furiosa.ai/rngd": resource.MustParse("4")},
+				Allocatable: corev1.ResourceList{"furiosa.ai/rngd": resource.MustParse("4")},
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(node).
+			WithStatusSubresource(node).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		reporter := &NodeConditionReporter{
+			Client:   c,
+			NodeName: nodeName,
+			Devices: staticAllocatable{
+				{ResourceName: "furiosa.ai/rngd", DeviceIds: []string{"npu0", "npu1", "npu2", "npu3"}},
+			},
+		}
+		Expect(reporter.Sync(ctx)).To(Succeed())
+
+		Expect(c.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+		driver := condition(node, npuv1alpha1.NodeDriverReady)
+		Expect(driver.Status).To(Equal(corev1.ConditionTrue))
+		Expect(driver.Reason).To(Equal("HostDriver"))
+		Expect(condition(node, npuv1alpha1.NodeDeviceHealthy).Status).To(Equal(corev1.ConditionTrue))
+	})
+})
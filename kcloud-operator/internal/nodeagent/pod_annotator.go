@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// modelLabels maps an accelerator resource prefix to the node label carrying the device model.
+var modelLabels = map[string]string{
+	"nvidia.com/": "nvidia.com/gpu.product",
+	"furiosa.ai/": "furiosa.ai/npu.product",
+}
+
+// PodAnnotator stamps the accelerators the kubelet allocated to each pod of a node
+// onto the pod as npu.ai/accelerator-* annotations.
+type PodAnnotator struct {
+	client.Client
+	NodeName string
+	Devices  DeviceLister
+}
+
+// Sync annotates every pod on the node that has accelerators allocated.
+func (a *PodAnnotator) Sync(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := a.Get(ctx, types.NamespacedName{Name: a.NodeName}, node); err != nil {
+		return err
+	}
+
+	podResources, err := a.Devices.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// pod -> resource name -> device IDs
+	allocated := map[types.NamespacedName]map[string][]string{}
+	for _, pr := range podResources {
+		key := types.NamespacedName{Namespace: pr.GetNamespace(), Name: pr.GetName()}
+		for _, c := range pr.GetContainers() {
+			for _, d := range c.GetDevices() {
+				if !isAcceleratorResource(d.GetResourceName()) {
+					continue
+				}
+				if allocated[key] == nil {
+					allocated[key] = map[string][]string{}
+				}
+				allocated[key][d.GetResourceName()] = append(allocated[key][d.GetResourceName()], d.GetDeviceIds()...)
+			}
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := a.List(ctx, pods, client.MatchingFields{"spec.nodeName": a.NodeName}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		devices, ok := allocated[client.ObjectKeyFromObject(pod)]
+		if !ok {
+			continue
+		}
+		want := acceleratorAnnotations(node, devices)
+		if hasAnnotations(pod, want) {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		for k, v := range want {
+			pod.Annotations[k] = v
+		}
+		if err := a.Patch(ctx, pod, patch); err != nil {
+			log.Error(err, "failed to annotate pod", "pod", client.ObjectKeyFromObject(pod))
+			continue
+		}
+		log.V(1).Info("Annotated pod with allocated accelerators", "pod", client.ObjectKeyFromObject(pod))
+	}
+	return nil
+}
+
+// -- acceleratorAnnotations renders the annotations for the devices allocated to one pod
+func acceleratorAnnotations(node *corev1.Node, devices map[string][]string) map[string]string {
+	resources := make([]string, 0, len(devices))
+	for name := range devices {
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+
+	var models, ids []string
+	count := 0
+	for _, name := range resources {
+		models = append(models, deviceModel(node, name))
+		ids = append(ids, devices[name]...)
+		count += len(devices[name])
+	}
+	return map[string]string{
+		npuv1alpha1.AcceleratorResourceAnnotation: strings.Join(resources, ","),
+		npuv1alpha1.AcceleratorModelAnnotation:    strings.Join(models, ","),
+		npuv1alpha1.AcceleratorCountAnnotation:    strconv.Itoa(count),
+		npuv1alpha1.AcceleratorDevicesAnnotation:  strings.Join(ids, ","),
+	}
+}
+
+// -- deviceModel reads the device model from the node labels, falling back to the resource name
+func deviceModel(node *corev1.Node, resourceName string) string {
+	for prefix, label := range modelLabels {
+		if strings.HasPrefix(resourceName, prefix) {
+			if model, ok := node.Labels[label]; ok && model != "" {
+				return model
+			}
+		}
+	}
+	_, model, _ := strings.Cut(resourceName, "/")
+	return model
+}
+
+func isAcceleratorResource(name string) bool {
+	for prefix := range modelLabels {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnnotations(obj client.Object, want map[string]string) bool {
+	have := obj.GetAnnotations()
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
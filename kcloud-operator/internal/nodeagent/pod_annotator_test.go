@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+type staticDeviceLister []*podresourcesv1.PodResources
+
+func (l staticDeviceLister) List(context.Context) ([]*podresourcesv1.PodResources, error) {
+	return l, nil
+}
+
+var _ = Describe("PodAnnotator", func() {
+	const nodeName = "gpu-node-1"
+
+	ctx := context.Background()
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	It("annotates pods with the accelerators the kubelet allocated", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"},
+		}}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(node, newPod("trainer"), newPod("web")).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		annotator := &PodAnnotator{
+			Client:   c,
+			NodeName: nodeName,
+			Devices: staticDeviceLister{
+				{
+					Name:      "trainer",
+					Namespace: "default",
+					Containers: []*podresourcesv1.ContainerResources{{
+						Name: "main",
+						Devices: []*podresourcesv1.ContainerDevices{
+							{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"GPU-0", "GPU-1"}},
+						},
+					}},
+				},
+				{
+					Name:      "web",
+					Namespace: "default",
+					Containers: []*podresourcesv1.ContainerResources{{
+						Name: "main",
+						Devices: []*podresourcesv1.ContainerDevices{
+							{ResourceName: "hugepages-2Mi", DeviceIds: []string{"0"}},
+						},
+					}},
+				},
+			},
+		}
+		Expect(annotator.Sync(ctx)).To(Succeed())
+
+		trainer := &corev1.Pod{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trainer"}, trainer)).To(Succeed())
+		Expect(trainer.Annotations).To(HaveKeyWithValue(npuv1alpha1.AcceleratorResourceAnnotation, "nvidia.com/gpu"))
+		Expect(trainer.Annotations).To(HaveKeyWithValue(npuv1alpha1.AcceleratorModelAnnotation, "NVIDIA-A100-SXM4-80GB"))
+		Expect(trainer.Annotations).To(HaveKeyWithValue(npuv1alpha1.AcceleratorCountAnnotation, "2"))
+		Expect(trainer.Annotations).To(HaveKeyWithValue(npuv1alpha1.AcceleratorDevicesAnnotation, "GPU-0,GPU-1"))
+
+		web := &corev1.Pod{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "web"}, web)).To(Succeed())
+		Expect(web.Annotations).To(BeEmpty())
+	})
+
+	It("falls back to the resource name when the node has no model label", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		Expect(deviceModel(node, "furiosa.ai/rngd")).To(Equal("rngd"))
+	})
+})
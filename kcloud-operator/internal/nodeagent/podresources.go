@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+// DefaultPodResourcesSocket is where the kubelet serves the pod resources API.
+const DefaultPodResourcesSocket = "/var/lib/kubelet/pod-resources/kubelet.sock"
+
+// DeviceLister reports the devices the kubelet has assigned to the pods of this node.
+type DeviceLister interface {
+	List(ctx context.Context) ([]*podresourcesv1.PodResources, error)
+}
+
+// AllocatableLister reports the devices the kubelet can currently allocate on this node.
+type AllocatableLister interface {
+	Allocatable(ctx context.Context) ([]*podresourcesv1.ContainerDevices, error)
+}
+
+// KubeletDeviceLister reads device assignments from the kubelet pod resources API.
+type KubeletDeviceLister struct {
+	client  podresourcesv1.PodResourcesListerClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+}
+
+// NewKubeletDeviceLister connects to the kubelet pod resources socket.
+func NewKubeletDeviceLister(socket string) (*KubeletDeviceLister, error) {
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &KubeletDeviceLister{
+		client:  podresourcesv1.NewPodResourcesListerClient(conn),
+		conn:    conn,
+		timeout: 10 * time.Second,
+	}, nil
+}
+
+// List returns the devices assigned to every pod known to the kubelet.
+func (l *KubeletDeviceLister) List(ctx context.Context) ([]*podresourcesv1.PodResources, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	resp, err := l.client.List(ctx, &podresourcesv1.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPodResources(), nil
+}
+
+// Allocatable returns the devices registered with the kubelet by the device plugins.
+func (l *KubeletDeviceLister) Allocatable(ctx context.Context) ([]*podresourcesv1.ContainerDevices, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	resp, err := l.client.GetAllocatableResources(ctx, &podresourcesv1.AllocatableResourcesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetDevices(), nil
+}
+
+// Close releases the connection to the kubelet.
+func (l *KubeletDeviceLister) Close() error {
+	return l.conn.Close()
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reservation computes the pools of NPUReservations and the accelerators held for
+// them. It is shared by the reservation controller, which reports the pool in the status of
+// the reservation, and the pod admission webhook, which keeps held accelerators free.
+package reservation
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// Phase returns the phase of the reservation at the given time, and how long until it changes.
+// The duration is zero once the reservation expired or when it never ends.
+func Phase(res *npuv1alpha1.NPUReservation, now time.Time) (npuv1alpha1.ReservationPhase, time.Duration) {
+	if start := res.Spec.Start; start != nil && now.Before(start.Time) {
+		return npuv1alpha1.ReservationPending, start.Sub(now)
+	}
+	if end := res.Spec.End; end != nil {
+		if !now.Before(end.Time) {
+			return npuv1alpha1.ReservationExpired, 0
+		}
+		return npuv1alpha1.ReservationActive, end.Sub(now)
+	}
+	return npuv1alpha1.ReservationActive, 0
+}
+
+// InPool reports whether the node, whose NPUNode carries the given model label, belongs to the
+// pool of the reservation.
+func InPool(res *npuv1alpha1.NPUReservation, node *corev1.Node, model string) bool {
+	if Allocatable(node, res.Spec.Resource) == 0 {
+		return false
+	}
+	if res.Spec.Model != "" && res.Spec.Model != model {
+		return false
+	}
+	return labels.SelectorFromSet(res.Spec.NodeSelector).Matches(labels.Set(node.Labels))
+}
+
+// Holds reports whether the reservation is held for the namespace.
+func Holds(res *npuv1alpha1.NPUReservation, namespace string) bool {
+	return slices.Contains(res.Spec.Namespaces, namespace)
+}
+
+// Allocatable returns the allocatable count of the resource on the node.
+func Allocatable(node *corev1.Node, resource string) int64 {
+	q, ok := node.Status.Allocatable[corev1.ResourceName(resource)]
+	if !ok {
+		return 0
+	}
+	return q.Value()
+}
+
+// Request returns how many of the resource the containers of the pod request.
+func Request(pod *corev1.Pod, resource string) int64 {
+	var total int64
+	for _, c := range pod.Spec.Containers {
+		if q, ok := c.Resources.Requests[corev1.ResourceName(resource)]; ok {
+			total += q.Value()
+		}
+	}
+	return total
+}
+
+// Allocated returns how many of the resource the scheduled, unfinished pods request, per node.
+func Allocated(pods []corev1.Pod, resource string) map[string]int64 {
+	out := map[string]int64{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if n := Request(pod, resource); n > 0 {
+			out[pod.Spec.NodeName] += n
+		}
+	}
+	return out
+}
+
+// NodeModels maps node names to the npu.ai/model label of their NPUNode.
+func NodeModels(ctx context.Context, c client.Reader) (map[string]string, error) {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := c.List(ctx, npuNodes); err != nil {
+		return nil, err
+	}
+	models := map[string]string{}
+	for _, n := range npuNodes.Items {
+		models[n.Name] = n.Labels[npuv1alpha1.ModelLabel]
+	}
+	return models, nil
+}
+
+// Victim is a scheduled pod the scheduler would preempt to make room for a pod.
+type Victim struct {
+	Pod      *corev1.Pod
+	Count    int64
+	Priority int32
+}
+
+// String describes the victim and why it would be preempted, e.g.
+// "batch/trainer (4 on node-a, priority 0)".
+func (v Victim) String() string {
+	return fmt.Sprintf("%s/%s (%d on %s, priority %d)", v.Pod.Namespace, v.Pod.Name, v.Count, v.Pod.Spec.NodeName, v.Priority)
+}
+
+// Preemption simulates which scheduled pods on the given nodes the scheduler would preempt to
+// free need of the resource for the pod: the pods of lower priority that protected does not
+// exclude, lowest priority and most recently started first. It returns the victims and how
+// many they free, which is less than need when preemption cannot make enough room.
+func Preemption(pod *corev1.Pod, pods []corev1.Pod, resource string, nodes map[string]bool, need int64,
+	protected func(*corev1.Pod) bool) ([]Victim, int64) {
+	if pod.Spec.PreemptionPolicy != nil && *pod.Spec.PreemptionPolicy == corev1.PreemptNever {
+		return nil, 0
+	}
+	priority := podPriority(pod)
+	var candidates []Victim
+	for i := range pods {
+		p := &pods[i]
+		if !nodes[p.Spec.NodeName] || p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed ||
+			p.DeletionTimestamp != nil || podPriority(p) >= priority || protected(p) {
+			continue
+		}
+		if n := Request(p, resource); n > 0 {
+			candidates = append(candidates, Victim{Pod: p, Count: n, Priority: podPriority(p)})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b Victim) int {
+		if c := cmp.Compare(a.Priority, b.Priority); c != 0 {
+			return c
+		}
+		if c := startTime(b.Pod).Compare(startTime(a.Pod)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Pod.Namespace+"/"+a.Pod.Name, b.Pod.Namespace+"/"+b.Pod.Name)
+	})
+
+	var victims []Victim
+	var freed int64
+	for _, v := range candidates {
+		if freed >= need {
+			break
+		}
+		victims = append(victims, v)
+		freed += v.Count
+	}
+	return victims, freed
+}
+
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+func startTime(pod *corev1.Pod) time.Time {
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
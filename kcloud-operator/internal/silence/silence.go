@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package silence suppresses the alerts of nodes the operator disrupts on purpose, e.g.
+// reboots requested by remediation and driver upgrades, so planned work does not cause
+// alert storms. Silences are removed once the node is back.
+package silence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// createdBy marks the Alertmanager silences of the operator.
+const createdBy = "npu-operator"
+
+// Silencer suppresses and restores the alerts of nodes. Both calls are idempotent.
+type Silencer interface {
+	// Silence suppresses the alerts of the node, with a comment saying why.
+	Silence(ctx context.Context, node, comment string) error
+	// Unsilence restores the alerts of the node.
+	Unsilence(ctx context.Context, node string) error
+}
+
+// Alertmanager creates silences through the Alertmanager v2 API, matching the alerts whose
+// node label names the node.
+type Alertmanager struct {
+	URL string
+	// NodeLabel is the alert label naming the node, e.g. node or instance.
+	NodeLabel string
+	// Duration of the silences. A silence the operator fails to remove expires by itself.
+	Duration time.Duration
+	Client   *http.Client
+}
+
+// NewAlertmanager returns a silencer for the Alertmanager at url with the given request timeout.
+func NewAlertmanager(url, nodeLabel string, duration, timeout time.Duration) *Alertmanager {
+	return &Alertmanager{
+		URL:       strings.TrimSuffix(url, "/"),
+		NodeLabel: nodeLabel,
+		Duration:  duration,
+		Client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type silence struct {
+	ID        string    `json:"id,omitempty"`
+	Matchers  []matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+	Status    *struct {
+		State string `json:"state"`
+	} `json:"status,omitempty"`
+}
+
+// Silence creates a silence of the node unless one of the operator is still active.
+func (a *Alertmanager) Silence(ctx context.Context, node, comment string) error {
+	ids, err := a.silences(ctx, node)
+	if err != nil || len(ids) > 0 {
+		return err
+	}
+	now := time.Now().UTC()
+	body, err := json.Marshal(silence{
+		Matchers:  []matcher{{Name: a.NodeLabel, Value: node, IsEqual: true}},
+		StartsAt:  now,
+		EndsAt:    now.Add(a.Duration),
+		CreatedBy: createdBy,
+		Comment:   comment,
+	})
+	if err != nil {
+		return err
+	}
+	return a.do(ctx, http.MethodPost, "/api/v2/silences", body, nil)
+}
+
+// Unsilence expires the active silences the operator created for the node.
+func (a *Alertmanager) Unsilence(ctx context.Context, node string) error {
+	ids, err := a.silences(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := a.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -- silences returns the IDs of the active or pending silences the operator created for the node
+func (a *Alertmanager) silences(ctx context.Context, node string) ([]string, error) {
+	filter := url.Values{"filter": {fmt.Sprintf("%s=%q", a.NodeLabel, node)}}
+	var silences []silence
+	if err := a.do(ctx, http.MethodGet, "/api/v2/silences?"+filter.Encode(), nil, &silences); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, s := range silences {
+		if s.CreatedBy != createdBy || s.Status == nil || s.Status.State == "expired" {
+			continue
+		}
+		for _, m := range s.Matchers {
+			if m.Name == a.NodeLabel && m.Value == node && m.IsEqual && !m.IsRegex {
+				ids = append(ids, s.ID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (a *Alertmanager) do(ctx context.Context, method, path string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, a.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alertmanager %s %s returned %s", method, a.URL+path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// NodeLabel labels silenced nodes with npu.ai/alerts-silenced=true. Alerting rules suppress
+// the alerts of those nodes by joining kube_node_labels, e.g.
+//
+//	... unless on(node) kube_node_labels{label_npu_ai_alerts_silenced="true"}
+type NodeLabel struct {
+	Client client.Client
+}
+
+// Silence labels the node.
+func (l *NodeLabel) Silence(ctx context.Context, node, _ string) error {
+	return l.label(ctx, node, true)
+}
+
+// Unsilence removes the label from the node.
+func (l *NodeLabel) Unsilence(ctx context.Context, node string) error {
+	return l.label(ctx, node, false)
+}
+
+func (l *NodeLabel) label(ctx context.Context, name string, silenced bool) error {
+	node := &corev1.Node{}
+	if err := l.Client.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := node.Labels[npuv1alpha1.AlertsSilencedLabel]; ok == silenced {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	if silenced {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[npuv1alpha1.AlertsSilencedLabel] = "true"
+	} else {
+		delete(node.Labels, npuv1alpha1.AlertsSilencedLabel)
+	}
+	return l.Client.Patch(ctx, node, patch)
+}
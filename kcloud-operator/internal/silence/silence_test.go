@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// fakeAlertmanager keeps silences in memory and serves the parts of the v2 API the silencer uses.
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	silences []silence
+	filters  []string
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+		f.filters = append(f.filters, r.URL.Query().Get("filter"))
+		_ = json.NewEncoder(w).Encode(f.silences)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var s silence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.ID = fmt.Sprintf("silence-%d", len(f.silences))
+		s.Status = &struct {
+			State string `json:"state"`
+		}{State: "active"}
+		f.silences = append(f.silences, s)
+		_ = json.NewEncoder(w).Encode(map[string]string{"silenceID": s.ID})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		for i := range f.silences {
+			if f.silences[i].ID == strings.TrimPrefix(r.URL.Path, "/api/v2/silence/") {
+				f.silences[i].Status.State = "expired"
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeAlertmanager) active() []silence {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var active []silence
+	for _, s := range f.silences {
+		if s.Status.State == "active" {
+			active = append(active, s)
+		}
+	}
+	return active
+}
+
+var _ = Describe("Alertmanager", func() {
+	ctx := context.Background()
+
+	It("creates one silence per node and expires it once the node is back", func() {
+		am := &fakeAlertmanager{}
+		server := httptest.NewServer(am)
+		defer server.Close()
+		silencer := NewAlertmanager(server.URL+"/", "node", time.Hour, time.Second)
+
+		Expect(silencer.Silence(ctx, "gpu-1", "Reboot requested by remediation")).To(Succeed())
+		Expect(silencer.Silence(ctx, "gpu-1", "Reboot requested by remediation")).To(Succeed())
+		Expect(silencer.Silence(ctx, "gpu-2", "Upgrade of nvidia-driver")).To(Succeed())
+		active := am.active()
+		Expect(active).To(HaveLen(2))
+		Expect(active[0].Matchers).To(ConsistOf(matcher{Name: "node", Value: "gpu-1", IsEqual: true}))
+		Expect(active[0].CreatedBy).To(Equal("npu-operator"))
+		Expect(active[0].Comment).To(Equal("Reboot requested by remediation"))
+		Expect(active[0].EndsAt.Sub(active[0].StartsAt)).To(Equal(time.Hour))
+		Expect(am.filters).To(ContainElement(`node="gpu-1"`))
+
+		Expect(silencer.Unsilence(ctx, "gpu-1")).To(Succeed())
+		Expect(am.active()).To(HaveLen(1))
+		Expect(am.active()[0].Matchers[0].Value).To(Equal("gpu-2"))
+
+		By("leaving silences created by others alone")
+		am.silences = append(am.silences, silence{
+			ID:        "manual",
+			Matchers:  []matcher{{Name: "node", Value: "gpu-2", IsEqual: true}},
+			CreatedBy: "oncall",
+			Status: &struct {
+				State string `json:"state"`
+			}{State: "active"},
+		})
+		Expect(silencer.Unsilence(ctx, "gpu-2")).To(Succeed())
+		Expect(am.active()).To(HaveLen(1))
+		Expect(am.active()[0].ID).To(Equal("manual"))
+	})
+
+	It("fails when Alertmanager rejects the request", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewAlertmanager(server.URL, "node", time.Hour, time.Second).Silence(ctx, "gpu-1", "maintenance")
+		Expect(err).To(MatchError(ContainSubstring("503")))
+	})
+})
+
+var _ = Describe("NodeLabel", func() {
+	It("labels silenced nodes and removes the label afterwards", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1"}}).Build()
+		silencer := &NodeLabel{Client: c}
+
+		Expect(silencer.Silence(ctx, "gpu-1", "maintenance")).To(Succeed())
+		node := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "gpu-1"}, node)).To(Succeed())
+		Expect(node.Labels).To(HaveKeyWithValue(npuv1alpha1.AlertsSilencedLabel, "true"))
+
+		Expect(silencer.Unsilence(ctx, "gpu-1")).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "gpu-1"}, node)).To(Succeed())
+		Expect(node.Labels).NotTo(HaveKey(npuv1alpha1.AlertsSilencedLabel))
+
+		Expect(silencer.Silence(ctx, "removed", "maintenance")).To(Succeed())
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statestore persists small pieces of operator bookkeeping, such as rollout
+// progress, last-known-good versions and remediation history, in a ConfigMap, so they
+// survive operator restarts and the loss or recreation of the objects they describe.
+package statestore
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+
+// Store keeps JSON documents under keys of a single ConfigMap. Keys must be valid
+// ConfigMap keys, e.g. policy.default.cluster.
+type Store struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+}
+
+// New returns a store backed by the ConfigMap namespace/name.
+func New(c client.Client, namespace, name string) *Store {
+	return &Store{Client: c, Namespace: namespace, Name: name}
+}
+
+// Load decodes the document stored under key into v and reports whether it exists.
+func (s *Store) Load(ctx context.Context, key string, v interface{}) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	data, ok := cm.Data[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal([]byte(data), v)
+}
+
+// Save stores v under key, creating the ConfigMap when missing.
+func (s *Store) Save(ctx context.Context, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.update(ctx, func(cm *corev1.ConfigMap) {
+		cm.Data[key] = string(data)
+	})
+}
+
+// Delete removes the document stored under key.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.update(ctx, func(cm *corev1.ConfigMap) {
+		delete(cm.Data, key)
+	})
+}
+
+func (s *Store) update(ctx context.Context, mutate func(cm *corev1.ConfigMap)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.Name,
+					Namespace: s.Namespace,
+					Labels:    map[string]string{"app.kubernetes.io/managed-by": "npu-operator"},
+				},
+				Data: map[string]string{},
+			}
+			mutate(cm)
+			return s.Client.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		mutate(cm)
+		return s.Client.Update(ctx, cm)
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statestore
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type document struct {
+	Image string `json:"image"`
+}
+
+var _ = Describe("Store", func() {
+	var (
+		ctx   context.Context
+		c     client.Client
+		store *Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		store = New(c, "kube-system", "npu-operator-state")
+	})
+
+	It("reports missing documents without creating the ConfigMap", func() {
+		var doc document
+		found, err := store.Load(ctx, "policy.default.cluster", &doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("saves, loads and deletes documents under their keys", func() {
+		Expect(store.Save(ctx, "policy.default.cluster", document{Image: "plugin:v1"})).To(Succeed())
+		Expect(store.Save(ctx, "node.gpu-1", document{Image: "plugin:v2"})).To(Succeed())
+
+		var doc document
+		found, err := store.Load(ctx, "policy.default.cluster", &doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(doc.Image).To(Equal("plugin:v1"))
+
+		Expect(store.Delete(ctx, "policy.default.cluster")).To(Succeed())
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "npu-operator-state"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveLen(1))
+		Expect(cm.Data).To(HaveKey("node.gpu-1"))
+	})
+})
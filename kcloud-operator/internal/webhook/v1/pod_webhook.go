@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/reservation"
+	"npu-operator/pkg/conditions"
+)
+
+// podlog is for logging in this package.
+var podlog = logf.Log.WithName("pod-resource")
+
+// SetupPodWebhookWithManager registers the webhook for Pod in the manager.
+func SetupPodWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.Pod{}).
+		WithDefaulter(&PodCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&PodCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod-v1.npu.ai,admissionReviewVersions=v1
+
+// PodCustomDefaulter sets the accelerator requests of the NPUWorkloadProfile a pod is labeled
+// with on its accelerator containers: those requesting a resource of the vendor of the
+// profile, or the first container when none does. The placement of the profile is added to
+// the affinity and topology spread constraints of the pod. Requests and placement inherited
+// from the parents of the profile are included, as resolved into its status by the controller.
+type PodCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &PodCustomDefaulter{}
+
+// Default applies the resources and placement of the profile of the pod.
+func (d *PodCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+	name := pod.Labels[npuv1alpha1.WorkloadProfileLabel]
+	if name == "" || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	profile := &npuv1alpha1.NPUWorkloadProfile{}
+	if err := d.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: name}, profile); err != nil {
+		return fmt.Errorf("failed to get NPUWorkloadProfile %s: %w", name, err)
+	}
+	if c := conditions.Get(profile, conditions.Resolved); c == nil || c.Status != metav1.ConditionTrue {
+		return fmt.Errorf("NPUWorkloadProfile %s is not resolved to advertised resources", name)
+	}
+
+	if len(profile.Status.Resources) > 0 {
+		setProfileResources(pod, profile.Status.Resources)
+	}
+	addPlacement(&pod.Spec, profile)
+	podlog.Info("Set accelerator requests and placement of workload profile", "namespace", pod.Namespace,
+		"pod", pod.Name+pod.GenerateName, "profile", name, "inheritedFrom", profile.Status.InheritedFrom,
+		"resources", profile.Status.Resources)
+	return nil
+}
+
+// setProfileResources replaces the vendor requests of the accelerator containers with the resources of the profile.
+func setProfileResources(pod *corev1.Pod, profile corev1.ResourceList) {
+	vendors := map[string]bool{}
+	for resource := range profile {
+		domain, _, _ := strings.Cut(string(resource), "/")
+		vendors[domain] = true
+	}
+	isVendorResource := func(resource corev1.ResourceName) bool {
+		domain, _, _ := strings.Cut(string(resource), "/")
+		return vendors[domain]
+	}
+	var targets []int
+	for i, c := range pod.Spec.Containers {
+		for resource := range mergeResourceLists(c.Resources.Requests, c.Resources.Limits) {
+			if isVendorResource(resource) {
+				targets = append(targets, i)
+				break
+			}
+		}
+	}
+	if len(targets) == 0 {
+		targets = []int{0}
+	}
+	for _, i := range targets {
+		resources := &pod.Spec.Containers[i].Resources
+		resources.Requests = withProfileResources(resources.Requests, profile, isVendorResource)
+		resources.Limits = withProfileResources(resources.Limits, profile, isVendorResource)
+	}
+}
+
+// addPlacement adds the affinity and topology spread constraints of the profile to those of the pod.
+func addPlacement(spec *corev1.PodSpec, profile *npuv1alpha1.NPUWorkloadProfile) {
+	if affinity := profile.Status.Affinity; affinity != nil {
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		if required := affinity.NodeAffinity; required != nil && required.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+			if spec.Affinity.NodeAffinity == nil {
+				spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+			}
+			spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = andNodeSelectors(
+				spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+				required.RequiredDuringSchedulingIgnoredDuringExecution)
+		}
+		if affinity.PodAffinity != nil {
+			if spec.Affinity.PodAffinity == nil {
+				spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+			}
+			spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+				spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+				affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		}
+	}
+	for _, constraint := range profile.Status.TopologySpreadConstraints {
+		spread := false
+		for _, c := range spec.TopologySpreadConstraints {
+			spread = spread || c.TopologyKey == constraint.TopologyKey
+		}
+		if !spread {
+			spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, *constraint.DeepCopy())
+		}
+	}
+}
+
+// andNodeSelectors returns a selector matching nodes matched by both. Terms are ORed, so the
+// expressions of the profile are added to every term of the pod.
+func andNodeSelectors(pod, profile *corev1.NodeSelector) *corev1.NodeSelector {
+	if pod == nil || len(pod.NodeSelectorTerms) == 0 {
+		return profile.DeepCopy()
+	}
+	out := pod.DeepCopy()
+	for i := range out.NodeSelectorTerms {
+		for _, term := range profile.NodeSelectorTerms {
+			out.NodeSelectorTerms[i].MatchExpressions = append(out.NodeSelectorTerms[i].MatchExpressions, term.MatchExpressions...)
+		}
+	}
+	return out
+}
+
+// withProfileResources returns the list without the resources of the profile vendors, plus the profile resources.
+func withProfileResources(list, profile corev1.ResourceList, isVendorResource func(corev1.ResourceName) bool) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for name, q := range list {
+		if !isVendorResource(name) {
+			out[name] = q
+		}
+	}
+	for name, q := range profile {
+		out[name] = q.DeepCopy()
+	}
+	return out
+}
+
+func mergeResourceLists(lists ...corev1.ResourceList) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for _, l := range lists {
+		for name, q := range l {
+			out[name] = q
+		}
+	}
+	return out
+}
+
+// +kubebuilder:webhook:path=/validate--v1-pod,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=vpod-v1.npu.ai,admissionReviewVersions=v1
+
+// PodCustomValidator keeps the accelerators held by active NPUReservations free: a pod of a
+// namespace the reservation is not held for is rejected when its accelerator request exceeds
+// the free accelerators of the nodes it may run on, less the accelerators still held there.
+// Before rejecting, it simulates which pods of lower priority the scheduler would preempt for
+// the pod: when they free enough, the pod is admitted with a warning naming them, otherwise the
+// rejection names them and how many they free.
+type PodCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &PodCustomValidator{}
+
+// ValidateCreate rejects pods that would consume accelerators held for other namespaces.
+func (v *PodCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+
+	reservations := &npuv1alpha1.NPUReservationList{}
+	if err := v.Client.List(ctx, reservations); err != nil {
+		return nil, err
+	}
+	byResource := map[string][]*npuv1alpha1.NPUReservation{}
+	now := time.Now()
+	for i := range reservations.Items {
+		res := &reservations.Items[i]
+		if phase, _ := reservation.Phase(res, now); phase != npuv1alpha1.ReservationActive ||
+			reservation.Holds(res, pod.Namespace) || res.Status.Held == 0 {
+			continue
+		}
+		if reservation.Request(pod, res.Spec.Resource) > 0 {
+			byResource[res.Spec.Resource] = append(byResource[res.Spec.Resource], res)
+		}
+	}
+	if len(byResource) == 0 {
+		return nil, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := v.Client.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+	models, err := reservation.NodeModels(ctx, v.Client)
+	if err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := v.Client.List(ctx, pods); err != nil {
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(pod.Spec.NodeSelector)
+	var warnings admission.Warnings
+	for resource, held := range byResource {
+		allocated := reservation.Allocated(pods.Items, resource)
+		var free, holding int64
+		var names []string
+		counted := map[string]bool{}
+		eligible := map[string]bool{}
+		for i := range nodes.Items {
+			node := &nodes.Items[i]
+			capacity := reservation.Allocatable(node, resource)
+			if capacity == 0 || !selector.Matches(labels.Set(node.Labels)) {
+				continue
+			}
+			eligible[node.Name] = true
+			free += max(0, capacity-allocated[node.Name])
+			for _, res := range held {
+				if !counted[res.Name] && reservation.InPool(res, node, models[node.Name]) {
+					counted[res.Name] = true
+					holding += res.Status.Held
+					names = append(names, res.Name)
+				}
+			}
+		}
+		request := reservation.Request(pod, resource)
+		if holding == 0 || request <= free-holding {
+			continue
+		}
+		sort.Strings(names)
+		shortage := fmt.Sprintf("pod requests %d %s but only %d are free outside of NPUReservations %s",
+			request, resource, max(0, free-holding), strings.Join(names, ", "))
+
+		// The scheduler preempts lower-priority pods for the pod, the accelerators they free
+		// count as free unless their namespace consumes the reservations itself.
+		victims, freed := reservation.Preemption(pod, pods.Items, resource, eligible, request-(free-holding),
+			func(p *corev1.Pod) bool { return holdsAny(held, p.Namespace) })
+		if len(victims) > 0 && request <= free-holding+freed {
+			podlog.Info("Admitting pod that preempts lower-priority pods", "namespace", pod.Namespace,
+				"pod", pod.Name+pod.GenerateName, "resource", resource, "victims", describeVictims(victims))
+			warnings = append(warnings, fmt.Sprintf("%s, the scheduler is expected to preempt %s",
+				shortage, describeVictims(victims)))
+			continue
+		}
+		podlog.Info("Rejecting pod that would consume reserved accelerators", "namespace", pod.Namespace,
+			"pod", pod.Name+pod.GenerateName, "resource", resource, "reservations", names,
+			"victims", describeVictims(victims))
+		if len(victims) == 0 {
+			return warnings, fmt.Errorf("%s, and no pods of lower priority could be preempted", shortage)
+		}
+		return warnings, fmt.Errorf("%s, and preempting the pods of lower priority %s frees only %d",
+			shortage, describeVictims(victims), freed)
+	}
+	return warnings, nil
+}
+
+// holdsAny reports whether one of the reservations is held for the namespace.
+func holdsAny(reservations []*npuv1alpha1.NPUReservation, namespace string) bool {
+	for _, res := range reservations {
+		if reservation.Holds(res, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeVictims lists the pods the scheduler would preempt, with their accelerators and
+// priority.
+func describeVictims(victims []reservation.Victim) string {
+	out := make([]string, len(victims))
+	for i, v := range victims {
+		out[i] = v.String()
+	}
+	return strings.Join(out, ", ")
+}
+
+// ValidateUpdate allows every update, the accelerator requests of a pod are immutable.
+func (v *PodCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete allows every deletion.
+func (v *PodCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
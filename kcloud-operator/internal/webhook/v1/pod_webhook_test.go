@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Pod Webhook", func() {
+	ctx := context.Background()
+
+	gpuNode := func(name, model string, gpus string) (*corev1.Node, *npuv1alpha1.NPUNode) {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+			},
+		}, &npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{npuv1alpha1.ModelLabel: model}},
+		}
+	}
+	gpuPod := func(namespace, node string, gpus string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-" + namespace + node, Namespace: namespace},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+				Containers: []corev1.Container{{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+						Limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	var validator *PodCustomValidator
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		h100a, npuA := gpuNode("h100-a", "H100", "8")
+		h100b, npuB := gpuNode("h100-b", "H100", "8")
+		reservation := &npuv1alpha1.NPUReservation{
+			ObjectMeta: metav1.ObjectMeta{Name: "launch"},
+			Spec: npuv1alpha1.NPUReservationSpec{
+				Resource:   "nvidia.com/gpu",
+				Model:      "H100",
+				Count:      8,
+				Namespaces: []string{"inference"},
+			},
+			// The launch already runs on 2 of its 8 accelerators.
+			Status: npuv1alpha1.NPUReservationStatus{Phase: npuv1alpha1.ReservationActive, Used: 2, Held: 6},
+		}
+		objects := []client.Object{h100a, npuA, h100b, npuB, reservation,
+			gpuPod("inference", "h100-a", "2"), gpuPod("batch", "h100-b", "4")}
+		validator = &PodCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()}
+	})
+
+	It("admits pods of other namespaces within the unreserved free accelerators", func() {
+		// 16 allocatable, 6 allocated, 6 held: 4 free for other namespaces.
+		_, err := validator.ValidateCreate(ctx, gpuPod("batch", "", "4"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects pods of other namespaces that would consume held accelerators", func() {
+		_, err := validator.ValidateCreate(ctx, gpuPod("batch", "", "5"))
+		Expect(err).To(MatchError(ContainSubstring("only 4 are free outside of NPUReservations launch")))
+	})
+
+	It("admits pods that preempt lower-priority pods of other namespaces, naming them", func() {
+		pod := gpuPod("batch", "", "8")
+		pod.Spec.Priority = ptr.To[int32](1000)
+		warnings, err := validator.ValidateCreate(ctx, pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring(
+			"the scheduler is expected to preempt batch/worker-batchh100-b (4 on h100-b, priority 0)")))
+	})
+
+	It("names the lower-priority pods when preempting them does not free enough", func() {
+		pod := gpuPod("batch", "", "9")
+		pod.Spec.Priority = ptr.To[int32](1000)
+		// The pod of the reserved namespace is not a victim, it consumes the reservation itself.
+		_, err := validator.ValidateCreate(ctx, pod)
+		Expect(err).To(MatchError(HaveSuffix(
+			"preempting the pods of lower priority batch/worker-batchh100-b (4 on h100-b, priority 0) frees only 4")))
+
+		pod.Spec.PreemptionPolicy = ptr.To(corev1.PreemptNever)
+		_, err = validator.ValidateCreate(ctx, pod)
+		Expect(err).To(MatchError(ContainSubstring("no pods of lower priority could be preempted")))
+	})
+
+	It("admits pods of the reserved namespaces", func() {
+		_, err := validator.ValidateCreate(ctx, gpuPod("inference", "", "6"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("admits pods without accelerator requests", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "batch"}}
+		_, err := validator.ValidateCreate(ctx, pod)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("Pod workload profile defaulting", func() {
+	ctx := context.Background()
+
+	var defaulter *PodCustomDefaulter
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		resolved := &npuv1alpha1.NPUWorkloadProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "two-pes", Namespace: "inference"},
+			Spec:       npuv1alpha1.NPUWorkloadProfileSpec{Furiosa: &npuv1alpha1.FuriosaWorkload{PEs: 2}},
+			Status: npuv1alpha1.NPUWorkloadProfileStatus{
+				Resources: corev1.ResourceList{"furiosa.ai/npu-2pe": resource.MustParse("1")},
+				Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "furiosa.ai/npu.product", Operator: corev1.NodeSelectorOpExists}},
+					}}},
+				}},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone"}},
+				Conditions: []metav1.Condition{{Type: "Resolved", Status: metav1.ConditionTrue, Reason: "Reconciled",
+					LastTransitionTime: metav1.Now()}},
+			},
+		}
+		unresolved := &npuv1alpha1.NPUWorkloadProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "three-pes", Namespace: "inference"},
+			Spec:       npuv1alpha1.NPUWorkloadProfileSpec{Furiosa: &npuv1alpha1.FuriosaWorkload{PEs: 3}},
+		}
+		defaulter = &PodCustomDefaulter{Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(resolved, unresolved).Build()}
+	})
+
+	profilePod := func(profile string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "server", Namespace: "inference",
+				Labels: map[string]string{npuv1alpha1.WorkloadProfileLabel: profile}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "proxy"},
+				{Name: "server", Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{"furiosa.ai/npu": resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+				}},
+			}},
+		}
+	}
+
+	It("replaces the vendor requests of the accelerator containers", func() {
+		pod := profilePod("two-pes")
+		Expect(defaulter.Default(ctx, pod)).To(Succeed())
+		Expect(pod.Spec.Containers[0].Resources.Limits).To(BeEmpty())
+		limits := pod.Spec.Containers[1].Resources.Limits
+		Expect(limits).NotTo(HaveKey(corev1.ResourceName("furiosa.ai/npu")))
+		Expect(limits).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu-2pe"), resource.MustParse("1")))
+		Expect(limits).To(HaveKeyWithValue(corev1.ResourceMemory, resource.MustParse("8Gi")))
+		Expect(pod.Spec.Containers[1].Resources.Requests).To(HaveKey(corev1.ResourceName("furiosa.ai/npu-2pe")))
+	})
+
+	It("adds the placement of the profile to the pod", func() {
+		pod := profilePod("two-pes")
+		pod.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}}},
+			}},
+		}}
+		Expect(defaulter.Default(ctx, pod)).To(Succeed())
+		for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			Expect(term.MatchExpressions).To(ContainElement(HaveField("Key", "furiosa.ai/npu.product")))
+		}
+		Expect(pod.Spec.TopologySpreadConstraints).To(HaveLen(1))
+	})
+
+	It("rejects pods of unresolved or missing profiles", func() {
+		Expect(defaulter.Default(ctx, profilePod("three-pes"))).To(MatchError(ContainSubstring("is not resolved")))
+		Expect(defaulter.Default(ctx, profilePod("missing"))).To(HaveOccurred())
+	})
+
+	It("leaves pods without a profile alone", func() {
+		pod := profilePod("")
+		Expect(defaulter.Default(ctx, pod)).To(Succeed())
+		Expect(pod.Spec.Containers[1].Resources.Limits).To(HaveKey(corev1.ResourceName("furiosa.ai/npu")))
+	})
+})
@@ -0,0 +1,289 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// Image references as parsed by the container runtimes: a repository of an optional registry
+// host and slash-separated lowercase path components, an optional tag and an optional digest.
+const (
+	domainPattern     = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?`
+	pathPattern       = `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+	repositoryPattern = `(?:` + domainPattern + `/)?` + pathPattern + `(?:/` + pathPattern + `)*`
+	tagPattern        = `[\w][\w.-]{0,127}`
+	digestPattern     = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+)
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^` + repositoryPattern + `$`)
+	tagRegexp        = regexp.MustCompile(`^` + tagPattern + `$`)
+	imageRegexp      = regexp.MustCompile(`^` + repositoryPattern + `(?::` + tagPattern + `)?(?:@` + digestPattern + `)?$`)
+)
+
+// SetupNPUClusterPolicyWebhookWithManager registers the webhook for NPUClusterPolicy in the manager.
+func SetupNPUClusterPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&npuv1alpha1.NPUClusterPolicy{}).
+		WithDefaulter(&NPUClusterPolicyCustomDefaulter{}).
+		WithValidator(&NPUClusterPolicyCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-npu-ai-v1alpha1-npuclusterpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=npu.ai,resources=npuclusterpolicies,verbs=create;update,versions=v1alpha1,name=mnpuclusterpolicy-v1alpha1.npu.ai,admissionReviewVersions=v1
+
+// NPUClusterPolicyCustomDefaulter fills in what a policy leaves unset, so a policy only enabling
+// a vendor deploys it: the namespace of the components and, for each enabled vendor, the image
+// of the device plugin unless images are resolved from a catalog, and the ConfigMap of the
+// Furiosa device plugin. Tolerations are left to the controller, which lets device plugins
+// without tolerations tolerate every taint.
+type NPUClusterPolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &NPUClusterPolicyCustomDefaulter{}
+
+// Default sets the defaults of the policy.
+func (d *NPUClusterPolicyCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*npuv1alpha1.NPUClusterPolicy)
+	if !ok {
+		return fmt.Errorf("expected an NPUClusterPolicy object but got %T", obj)
+	}
+	spec := &policy.Spec
+	if spec.Namespace == "" {
+		spec.Namespace = npuv1alpha1.DefaultComponentNamespace
+	}
+	catalog := spec.Catalog != ""
+	if nvidia := &spec.Nvidia; nvidia.Enabled {
+		if !catalog && nvidia.DevicePluginImage == "" {
+			defaultImage(&nvidia.DevicePlugin, npuv1alpha1.DefaultNvidiaDevicePluginImage, npuv1alpha1.DefaultNvidiaDevicePluginVersion)
+		}
+	}
+	if furiosa := &spec.Furiosa; furiosa.Enabled {
+		if !catalog && furiosa.DevicePluginImage == "" {
+			defaultImage(&furiosa.DevicePlugin, npuv1alpha1.DefaultFuriosaDevicePluginImage, npuv1alpha1.DefaultFuriosaDevicePluginVersion)
+		}
+		if furiosa.ConfigMapName == "" {
+			furiosa.ConfigMapName = npuv1alpha1.DefaultFuriosaConfigMapName
+		}
+	}
+	return nil
+}
+
+// defaultImage sets the image of an enabled component without one.
+func defaultImage(c *npuv1alpha1.ComponentSpec, image, version string) {
+	if c.Enabled != nil && !*c.Enabled || c.Image != "" {
+		return
+	}
+	c.Image = image
+	if c.Version == "" {
+		c.Version = version
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-npu-ai-v1alpha1-npuclusterpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=npu.ai,resources=npuclusterpolicies,verbs=create;update,versions=v1alpha1,name=vnpuclusterpolicy-v1alpha1.npu.ai,admissionReviewVersions=v1
+
+// NPUClusterPolicyCustomValidator rejects policies the controller could not apply: enabled
+// components without an image or with an invalid image reference, Furiosa without the name of
+// its ConfigMap, and vendors whose node selector and node affinity select no node. Updates
+// leaving the spec unchanged are always admitted, so finalizers and annotations of stored
+// policies can be managed.
+type NPUClusterPolicyCustomValidator struct{}
+
+var _ webhook.CustomValidator = &NPUClusterPolicyCustomValidator{}
+
+// ValidateCreate rejects invalid policies.
+func (v *NPUClusterPolicyCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*npuv1alpha1.NPUClusterPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an NPUClusterPolicy object but got %T", obj)
+	}
+	return nil, invalid(policy, validatePolicy(policy))
+}
+
+// ValidateUpdate rejects updates of the spec leaving it invalid.
+func (v *NPUClusterPolicyCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*npuv1alpha1.NPUClusterPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an NPUClusterPolicy object but got %T", oldObj)
+	}
+	policy, ok := newObj.(*npuv1alpha1.NPUClusterPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an NPUClusterPolicy object but got %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(old.Spec, policy.Spec) {
+		return nil, nil
+	}
+	return nil, invalid(policy, validatePolicy(policy))
+}
+
+// ValidateDelete allows every deletion.
+func (v *NPUClusterPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func invalid(policy *npuv1alpha1.NPUClusterPolicy, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(npuv1alpha1.GroupVersion.WithKind("NPUClusterPolicy").GroupKind(), policy.Name, errs)
+}
+
+// validatePolicy validates the enabled vendors of the policy.
+func validatePolicy(policy *npuv1alpha1.NPUClusterPolicy) field.ErrorList {
+	var errs field.ErrorList
+	spec := &policy.Spec
+	catalog := spec.Catalog != ""
+	if spec.Nvidia.Enabled {
+		path := field.NewPath("spec", "nvidia")
+		errs = append(errs, validateLegacyImage(path.Child("devicePluginImage"), spec.Nvidia.DevicePluginImage)...)
+		errs = append(errs, validateComponents(path, &spec.Nvidia.VendorComponents, spec.Nvidia.DevicePluginImage != "", catalog)...)
+		errs = append(errs, validateNodeSelection(path, &spec.Nvidia.VendorPodSpec)...)
+	}
+	if spec.Furiosa.Enabled {
+		path := field.NewPath("spec", "furiosa")
+		errs = append(errs, validateLegacyImage(path.Child("devicePluginImage"), spec.Furiosa.DevicePluginImage)...)
+		errs = append(errs, validateComponents(path, &spec.Furiosa.VendorComponents, spec.Furiosa.DevicePluginImage != "", catalog)...)
+		errs = append(errs, validateNodeSelection(path, &spec.Furiosa.VendorPodSpec)...)
+		if name := spec.Furiosa.ConfigMapName; name == "" {
+			errs = append(errs, field.Required(path.Child("configMapName"), "the device plugin configuration needs a ConfigMap"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(name) {
+				errs = append(errs, field.Invalid(path.Child("configMapName"), name, msg))
+			}
+		}
+	}
+	return errs
+}
+
+func validateLegacyImage(path *field.Path, image string) field.ErrorList {
+	if image == "" || imageRegexp.MatchString(image) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, image, "must be an image reference, e.g. nvcr.io/nvidia/k8s-device-plugin:v0.17.1")}
+}
+
+// validateComponents checks that every enabled component has an image: its own repository and
+// tag, the deprecated devicePluginImage for the device plugin, or a version of the catalog.
+func validateComponents(path *field.Path, components *npuv1alpha1.VendorComponents, legacyImage, catalog bool) field.ErrorList {
+	var errs field.ErrorList
+	for _, c := range []struct {
+		name      string
+		spec      *npuv1alpha1.ComponentSpec
+		byDefault bool
+	}{
+		{"devicePlugin", &components.DevicePlugin, true},
+		{"exporter", &components.Exporter, false},
+		{"validator", &components.Validator, false},
+		{"driver", &components.Driver, false},
+		{"gfd", &components.GFD, false},
+	} {
+		if enabled := c.spec.Enabled; enabled == nil && !c.byDefault || enabled != nil && !*enabled {
+			continue
+		}
+		path := path.Child(c.name)
+		switch {
+		case catalog && c.spec.Image != "":
+			errs = append(errs, field.Forbidden(path.Child("image"), "images are resolved from spec.catalog"))
+		case catalog && c.spec.Version == "":
+			errs = append(errs, field.Required(path.Child("version"), "selects the image of the component in spec.catalog"))
+		case catalog:
+		case c.spec.Image == "" && !(c.name == "devicePlugin" && legacyImage):
+			errs = append(errs, field.Required(path.Child("image"), "an enabled component needs an image unless spec.catalog is set"))
+		case c.spec.Image != "" && !repositoryRegexp.MatchString(c.spec.Image):
+			errs = append(errs, field.Invalid(path.Child("image"), c.spec.Image,
+				"must be an image repository without tag, e.g. nvcr.io/nvidia/k8s-device-plugin"))
+		}
+		if !catalog && c.spec.Version != "" && !tagRegexp.MatchString(c.spec.Version) {
+			errs = append(errs, field.Invalid(path.Child("version"), c.spec.Version, "must be an image tag, e.g. v0.17.1"))
+		}
+	}
+	return errs
+}
+
+// validateNodeSelection rejects node selectors contradicted by every required term of the node
+// affinity, as no node could run the components.
+func validateNodeSelection(path *field.Path, pod *npuv1alpha1.VendorPodSpec) field.ErrorList {
+	if len(pod.NodeSelector) == 0 || pod.Affinity == nil || pod.Affinity.NodeAffinity == nil ||
+		pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	terms := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return nil
+	}
+	var conflicts []string
+	for _, term := range terms {
+		conflict := ""
+		for _, req := range term.MatchExpressions {
+			if value, ok := pod.NodeSelector[req.Key]; ok && !satisfies(req, value) {
+				conflict = req.Key
+				break
+			}
+		}
+		if conflict == "" {
+			return nil
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	slices.Sort(conflicts)
+	conflicts = slices.Compact(conflicts)
+	return field.ErrorList{field.Invalid(path.Child("nodeSelector"), pod.NodeSelector,
+		fmt.Sprintf("no node matches both nodeSelector and the required node affinity, whose terms exclude the labels %s",
+			strings.Join(conflicts, ", ")))}
+}
+
+// satisfies reports whether a node labeled key=value matches the requirement on the key.
+func satisfies(req corev1.NodeSelectorRequirement, value string) bool {
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		return slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpDoesNotExist:
+		return false
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(req.Values) != 1 {
+			return true
+		}
+		label, err1 := strconv.ParseInt(value, 10, 64)
+		bound, err2 := strconv.ParseInt(req.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return label > bound
+		}
+		return label < bound
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("NPUClusterPolicy Webhook", func() {
+	var (
+		validator *NPUClusterPolicyCustomValidator
+		policy    *npuv1alpha1.NPUClusterPolicy
+	)
+	disabled := false
+	enabled := true
+
+	causes := func(err error) []string {
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+		var fields []string
+		for _, cause := range err.(*apierrors.StatusError).ErrStatus.Details.Causes {
+			fields = append(fields, cause.Field)
+		}
+		return fields
+	}
+
+	BeforeEach(func() {
+		validator = &NPUClusterPolicyCustomValidator{}
+		policy = &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		policy.Spec.Nvidia.Enabled = true
+		policy.Spec.Nvidia.DevicePlugin.Image = "nvcr.io/nvidia/k8s-device-plugin"
+		policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1"
+		policy.Spec.Furiosa.Enabled = true
+		policy.Spec.Furiosa.DevicePluginImage = "registry.example.com:5000/furiosa-ai/k8s-device-plugin:0.10.1"
+		policy.Spec.Furiosa.ConfigMapName = "npu-device-plugin"
+	})
+
+	It("should admit a valid policy", func() {
+		Expect(validator.ValidateCreate(context.Background(), policy)).Error().NotTo(HaveOccurred())
+	})
+
+	It("should require an image for every enabled component", func() {
+		policy.Spec.Nvidia.DevicePlugin.Image = ""
+		policy.Spec.Furiosa.DevicePluginImage = ""
+		policy.Spec.Furiosa.Exporter.Enabled = &enabled
+		policy.Spec.Furiosa.Validator.Enabled = &disabled
+
+		_, err := validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf(
+			"spec.nvidia.devicePlugin.image",
+			"spec.furiosa.devicePlugin.image",
+			"spec.furiosa.exporter.image",
+		))
+
+		By("ignoring the components of disabled vendors")
+		policy.Spec.Nvidia.Enabled = false
+		policy.Spec.Furiosa.Enabled = false
+		Expect(validator.ValidateCreate(context.Background(), policy)).Error().NotTo(HaveOccurred())
+	})
+
+	It("should require catalog versions instead of images with a catalog", func() {
+		policy.Spec.Catalog = "stable"
+		policy.Spec.Furiosa.DevicePlugin.Version = "0.10.1"
+
+		_, err := validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf("spec.nvidia.devicePlugin.image"))
+
+		policy.Spec.Nvidia.DevicePlugin.Image = ""
+		policy.Spec.Nvidia.DevicePlugin.Version = ""
+		_, err = validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf("spec.nvidia.devicePlugin.version"))
+	})
+
+	It("should reject invalid image references", func() {
+		policy.Spec.Nvidia.DevicePlugin.Image = "nvcr.io/nvidia/k8s-device-plugin:v0.17.1"
+		policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1 "
+		policy.Spec.Furiosa.DevicePluginImage = "ghcr.io/FuriosaAI/k8s-device-plugin:0.10.1"
+
+		_, err := validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf(
+			"spec.nvidia.devicePlugin.image",
+			"spec.nvidia.devicePlugin.version",
+			"spec.furiosa.devicePluginImage",
+		))
+
+		By("admitting references pinned by digest")
+		policy.Spec.Nvidia.DevicePlugin.Image = "nvcr.io/nvidia/k8s-device-plugin"
+		policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1"
+		policy.Spec.Furiosa.DevicePluginImage = "ghcr.io/furiosa-ai/k8s-device-plugin@sha256:" +
+			"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		Expect(validator.ValidateCreate(context.Background(), policy)).Error().NotTo(HaveOccurred())
+	})
+
+	It("should require the ConfigMap name of Furiosa", func() {
+		policy.Spec.Furiosa.ConfigMapName = ""
+		_, err := validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf("spec.furiosa.configMapName"))
+
+		policy.Spec.Furiosa.ConfigMapName = "Device_Plugin"
+		_, err = validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf("spec.furiosa.configMapName"))
+	})
+
+	It("should reject node selectors the node affinity contradicts", func() {
+		term := func(op corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorTerm {
+			return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "pool", Operator: op, Values: values},
+			}}
+		}
+		policy.Spec.Nvidia.NodeSelector = map[string]string{"pool": "gpu"}
+		policy.Spec.Nvidia.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				term(corev1.NodeSelectorOpNotIn, "gpu"),
+				term(corev1.NodeSelectorOpDoesNotExist),
+			}},
+		}}
+		_, err := validator.ValidateCreate(context.Background(), policy)
+		Expect(causes(err)).To(ConsistOf("spec.nvidia.nodeSelector"))
+		Expect(err.Error()).To(ContainSubstring("exclude the labels pool"))
+
+		By("admitting them when any term matches")
+		terms := &policy.Spec.Nvidia.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		*terms = append(*terms, term(corev1.NodeSelectorOpIn, "gpu", "inference"))
+		Expect(validator.ValidateCreate(context.Background(), policy)).Error().NotTo(HaveOccurred())
+	})
+
+	It("should admit updates leaving an invalid spec unchanged", func() {
+		policy.Spec.Furiosa.ConfigMapName = ""
+		updated := policy.DeepCopy()
+		updated.Finalizers = []string{"npu.ai/finalizer"}
+		Expect(validator.ValidateUpdate(context.Background(), policy, updated)).Error().NotTo(HaveOccurred())
+
+		updated.Spec.Nvidia.Enabled = false
+		_, err := validator.ValidateUpdate(context.Background(), policy, updated)
+		Expect(causes(err)).To(ConsistOf("spec.furiosa.configMapName"))
+	})
+
+	Context("When defaulting", func() {
+		It("should complete a policy only enabling the vendors", func() {
+			policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+			policy.Spec.Nvidia.Enabled = true
+			policy.Spec.Furiosa.Enabled = true
+			Expect((&NPUClusterPolicyCustomDefaulter{}).Default(context.Background(), policy)).To(Succeed())
+
+			Expect(policy.Spec.Namespace).To(Equal("kube-system"))
+			Expect(policy.Spec.Nvidia.DevicePlugin.Image).To(Equal("nvcr.io/nvidia/k8s-device-plugin"))
+			Expect(policy.Spec.Nvidia.DevicePlugin.Version).To(Equal("v0.17.1"))
+			Expect(policy.Spec.Nvidia.Tolerations).To(BeEmpty(), "device plugins tolerate every taint without tolerations")
+			Expect(policy.Spec.Furiosa.DevicePlugin.Image).To(Equal("ghcr.io/furiosa-ai/k8s-device-plugin"))
+			Expect(policy.Spec.Furiosa.ConfigMapName).To(Equal("furiosa-device-plugin-config"))
+			Expect(policy.Spec.Furiosa.Tolerations).To(BeEmpty())
+			Expect(validator.ValidateCreate(context.Background(), policy)).Error().NotTo(HaveOccurred())
+		})
+
+		It("should keep what is set and leave disabled vendors alone", func() {
+			policy := &npuv1alpha1.NPUClusterPolicy{}
+			policy.Spec.Namespace = "npu-system"
+			policy.Spec.Nvidia.Enabled = true
+			policy.Spec.Nvidia.DevicePluginImage = "nvcr.io/nvidia/k8s-device-plugin:v0.16.2"
+			policy.Spec.Nvidia.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+			Expect((&NPUClusterPolicyCustomDefaulter{}).Default(context.Background(), policy)).To(Succeed())
+
+			Expect(policy.Spec.Namespace).To(Equal("npu-system"))
+			Expect(policy.Spec.Nvidia.DevicePlugin.Image).To(BeEmpty())
+			Expect(policy.Spec.Nvidia.Tolerations).To(Equal([]corev1.Toleration{{Operator: corev1.TolerationOpExists}}))
+			Expect(policy.Spec.Furiosa).To(Equal(npuv1alpha1.FuriosaSpec{}))
+
+			By("resolving images from the catalog")
+			policy.Spec.Catalog = "stable"
+			policy.Spec.Nvidia.DevicePluginImage = ""
+			policy.Spec.Nvidia.DevicePlugin.Version = "v0.17.1"
+			Expect((&NPUClusterPolicyCustomDefaulter{}).Default(context.Background(), policy)).To(Succeed())
+			Expect(policy.Spec.Nvidia.DevicePlugin.Image).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,31 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	npuv1beta1 "npu-operator/api/v1beta1"
+)
+
+// SetupNPUClusterPolicyWebhookWithManager registers the conversion webhook for NPUClusterPolicy
+// in the manager. Policies are converted through the v1alpha1 hub; admission requests of
+// v1beta1 are served by the v1alpha1 webhooks, which match equivalent versions.
+func SetupNPUClusterPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&npuv1beta1.NPUClusterPolicy{}).
+		Complete()
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package whatif simulates the accelerator capacity a policy change would leave, so capacity
+// planning sees the schedulable capacity of each pool, and the running workloads that would
+// no longer fit, before the change is applied. The simulation covers the enabled vendors, the
+// sharing of the NVIDIA GPUs and the partitioning of the Furiosa cards.
+package whatif
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+	"npu-operator/internal/reservation"
+)
+
+const (
+	nvidiaGPU       = "nvidia.com/gpu"
+	nvidiaSharedGPU = "nvidia.com/gpu.shared"
+	// maxBodySize bounds the policies posted to the handler.
+	maxBodySize = 1 << 20
+)
+
+// Simulation is the outcome of a simulated policy change.
+type Simulation struct {
+	// Policy is the simulated policy as namespace/name.
+	Policy string `json:"policy"`
+	// Pools are the accelerator models of the cluster, by the npu.ai/model label of their nodes.
+	Pools []Pool `json:"pools"`
+	// Unfit are the running pods whose requests the nodes would no longer advertise.
+	Unfit []Workload `json:"unfit,omitempty"`
+	// Notes explain the nodes whose capacity could not be simulated; they keep their current one.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// Pool is the schedulable accelerator capacity of the nodes of a model.
+type Pool struct {
+	Model    string              `json:"model"`
+	Nodes    int                 `json:"nodes"`
+	Current  corev1.ResourceList `json:"current"`
+	Proposed corev1.ResourceList `json:"proposed"`
+}
+
+// Workload is a running pod that would no longer fit on its node.
+type Workload struct {
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Node      string              `json:"node"`
+	Requests  corev1.ResourceList `json:"requests"`
+	Reason    string              `json:"reason"`
+}
+
+// Simulate computes the capacity the nodes would advertise under the policy, and the pods that
+// would no longer fit on their node. Pods keep their accelerators oldest first.
+func Simulate(policy *npuv1alpha1.NPUClusterPolicy, npuNodes []npuv1alpha1.NPUNode, pods []corev1.Pod) Simulation {
+	report := Simulation{Policy: policy.Namespace + "/" + policy.Name}
+	pools := map[string]*Pool{}
+	proposed := map[string]corev1.ResourceList{}
+	for _, npuNode := range npuNodes {
+		current := accelerators(npuNode.Status.Allocatable)
+		capacity, note := simulateNode(policy, &npuNode, current)
+		if note != "" {
+			report.Notes = append(report.Notes, fmt.Sprintf("%s: %s", npuNode.Name, note))
+		}
+		proposed[npuNode.Name] = capacity
+
+		model := npuNode.Labels[npuv1alpha1.ModelLabel]
+		if pools[model] == nil {
+			pools[model] = &Pool{Model: model, Current: corev1.ResourceList{}, Proposed: corev1.ResourceList{}}
+		}
+		pool := pools[model]
+		pool.Nodes++
+		addResources(pool.Current, current)
+		addResources(pool.Proposed, capacity)
+	}
+	for _, model := range slices.Sorted(maps.Keys(pools)) {
+		report.Pools = append(report.Pools, *pools[model])
+	}
+
+	running := slices.Clone(pods)
+	slices.SortStableFunc(running, func(a, b corev1.Pod) int {
+		return cmp.Or(a.CreationTimestamp.Compare(b.CreationTimestamp.Time), cmp.Compare(a.Name, b.Name))
+	})
+	free := map[string]map[corev1.ResourceName]int64{}
+	for node, capacity := range proposed {
+		free[node] = map[corev1.ResourceName]int64{}
+		for name, q := range capacity {
+			free[node][name] = q.Value()
+		}
+	}
+	for i := range running {
+		pod := &running[i]
+		if free[pod.Spec.NodeName] == nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests := accelerators(podRequests(pod))
+		if len(requests) == 0 {
+			continue
+		}
+		var short []string
+		for _, name := range slices.Sorted(maps.Keys(requests)) {
+			if n := requests[name]; n.Value() > free[pod.Spec.NodeName][name] {
+				short = append(short, fmt.Sprintf("%s: %d requested, %d left", name, n.Value(), free[pod.Spec.NodeName][name]))
+			}
+		}
+		if len(short) > 0 {
+			report.Unfit = append(report.Unfit, Workload{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Node:      pod.Spec.NodeName,
+				Requests:  requests,
+				Reason:    strings.Join(short, ", "),
+			})
+			continue
+		}
+		for name, n := range requests {
+			free[pod.Spec.NodeName][name] -= n.Value()
+		}
+	}
+	return report
+}
+
+// simulateNode returns the accelerators the node would advertise under the policy, with a note
+// when they cannot be told and the current ones are kept.
+func simulateNode(policy *npuv1alpha1.NPUClusterPolicy, npuNode *npuv1alpha1.NPUNode,
+	current corev1.ResourceList) (corev1.ResourceList, string) {
+	capacity := corev1.ResourceList{}
+	devices := map[string]int64{}
+	for _, d := range npuNode.Status.Devices {
+		devices[d.Vendor]++
+	}
+
+	var notes []string
+	if policy.Spec.Nvidia.Enabled {
+		nvidia, note := simulateNvidia(policy.Spec.Nvidia.DevicePluginConfig, current, devices["nvidia"])
+		maps.Copy(capacity, nvidia)
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if policy.Spec.Furiosa.Enabled {
+		furiosa, note := simulateFuriosa(policy.Spec.Furiosa.Partitioning, npuNode, current, devices["furiosa"])
+		maps.Copy(capacity, furiosa)
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	return capacity, strings.Join(notes, "; ")
+}
+
+// simulateNvidia returns the NVIDIA resources the node would advertise, its GPUs shared as
+// configured. GPUs are counted by the devices of the node; MIG resources keep their capacity.
+func simulateNvidia(config *npuv1alpha1.NvidiaDevicePluginConfig, current corev1.ResourceList,
+	devices int64) (corev1.ResourceList, string) {
+	nvidia := corev1.ResourceList{}
+	for name, q := range current {
+		if strings.HasPrefix(string(name), "nvidia.com/") {
+			nvidia[name] = q
+		}
+	}
+	_, whole := nvidia[nvidiaGPU]
+	_, shared := nvidia[nvidiaSharedGPU]
+	switch {
+	case devices == 0:
+		if config != nil && config.Sharing != nil {
+			return nvidia, "the GPUs of the node are unknown, their sharing cannot be simulated"
+		}
+		return nvidia, ""
+	case len(nvidia) > 0 && !whole && !shared:
+		// The GPUs are partitioned by MIG.
+		return nvidia, ""
+	case config != nil && config.Raw != "":
+		return nvidia, "the sharing of a raw device plugin configuration is not simulated"
+	}
+
+	delete(nvidia, nvidiaGPU)
+	delete(nvidia, nvidiaSharedGPU)
+	name, replicas := corev1.ResourceName(nvidiaGPU), int64(1)
+	if config != nil && config.Sharing != nil {
+		sharing := config.Sharing.TimeSlicing
+		if sharing == nil {
+			sharing = config.Sharing.MPS
+		}
+		for _, r := range sharing.Resources {
+			if r.Name != nvidiaGPU {
+				continue
+			}
+			replicas = int64(r.Replicas)
+			switch {
+			case r.Rename != "":
+				name = corev1.ResourceName(r.Rename)
+			case sharing.RenameByDefault:
+				name = nvidiaSharedGPU
+			}
+		}
+	}
+	nvidia[name] = *resource.NewQuantity(devices*replicas, resource.DecimalSI)
+	return nvidia, ""
+}
+
+// simulateFuriosa returns the Furiosa resources the node would advertise under the partitioning.
+func simulateFuriosa(partitioning *npuv1alpha1.FuriosaPartitioning, npuNode *npuv1alpha1.NPUNode,
+	current corev1.ResourceList, devices int64) (corev1.ResourceList, string) {
+	furiosa := corev1.ResourceList{}
+	// Whole cards and PEs the node holds, from what it advertises or else from its devices.
+	var cards, pes int64
+	for name, q := range current {
+		if string(name) == npuv1alpha1.FuriosaResource {
+			cards = q.Value()
+		} else if size, ok := furiosaPartitionSize(string(name)); ok {
+			pes += q.Value() * int64(size)
+		}
+	}
+	if cards == 0 && pes == 0 {
+		cards = devices
+	}
+	if cards == 0 && pes == 0 {
+		return furiosa, ""
+	}
+	cardPEs := furiosaCardPEs(furiosaModel(npuNode))
+	switch {
+	case pes == 0 && cardPEs > 0:
+		pes = cards * cardPEs
+	case cards == 0 && cardPEs > 0:
+		cards = pes / cardPEs
+	}
+
+	switch {
+	case partitioning == nil && cards > 0:
+		furiosa[npuv1alpha1.FuriosaResource] = *resource.NewQuantity(cards, resource.DecimalSI)
+	case partitioning != nil && pes > 0:
+		name := corev1.ResourceName(npuv1alpha1.FuriosaPartitionResource(partitioning.PEsPerPartition))
+		furiosa[name] = *resource.NewQuantity(pes/int64(partitioning.PEsPerPartition), resource.DecimalSI)
+	default:
+		for name, q := range current {
+			if strings.HasPrefix(string(name), "furiosa.ai/") {
+				furiosa[name] = q
+			}
+		}
+		return furiosa, fmt.Sprintf("the PEs of Furiosa model %q are unknown", furiosaModel(npuNode))
+	}
+	return furiosa, ""
+}
+
+// furiosaModel returns the model of the Furiosa cards of the node.
+func furiosaModel(npuNode *npuv1alpha1.NPUNode) string {
+	for _, v := range npuNode.Status.Vendors {
+		if v.Vendor == "furiosa" && v.Model != "" {
+			return v.Model
+		}
+	}
+	return npuNode.Status.Model
+}
+
+// furiosaCardPEs returns the processing elements of a Furiosa card model, or 0 when unknown.
+func furiosaCardPEs(model string) int64 {
+	switch model = strings.ToLower(model); {
+	case strings.Contains(model, "rngd"):
+		return 8
+	case strings.Contains(model, "warboy"):
+		return 2
+	}
+	return 0
+}
+
+// furiosaPartitionSize returns the PEs of a Furiosa partition resource, e.g. 2 for furiosa.ai/npu-2pe.
+func furiosaPartitionSize(name string) (int32, bool) {
+	var size int32
+	if _, err := fmt.Sscanf(name, npuv1alpha1.FuriosaResource+"-%dpe", &size); err != nil || size < 1 {
+		return 0, false
+	}
+	return size, npuv1alpha1.FuriosaPartitionResource(size) == name
+}
+
+// accelerators returns the accelerator resources of the list.
+func accelerators(list corev1.ResourceList) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for name, q := range list {
+		if strings.HasPrefix(string(name), "nvidia.com/") || strings.HasPrefix(string(name), "furiosa.ai/") {
+			out[name] = q
+		}
+	}
+	return out
+}
+
+// podRequests sums the requests of the containers of the pod.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name := range c.Resources.Requests {
+			requests[name] = *resource.NewQuantity(reservation.Request(pod, string(name)), resource.DecimalSI)
+		}
+	}
+	return requests
+}
+
+func addResources(total, list corev1.ResourceList) {
+	for name, q := range list {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
+
+// Handler simulates the NPUClusterPolicy posted as JSON or YAML against the NPUNodes and pods
+// of the cluster, and responds with the report as JSON.
+type Handler struct {
+	Client client.Reader
+}
+
+// ServeHTTP serves the simulation of the posted policy.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "post the NPUClusterPolicy to simulate", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	policy := &npuv1alpha1.NPUClusterPolicy{}
+	if err := yaml.UnmarshalStrict(body, policy); err != nil {
+		http.Error(w, fmt.Sprintf("invalid NPUClusterPolicy: %v", err), http.StatusBadRequest)
+		return
+	}
+	report, err := h.simulate(req.Context(), policy)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (h *Handler) simulate(ctx context.Context, policy *npuv1alpha1.NPUClusterPolicy) (Simulation, error) {
+	npuNodes := &npuv1alpha1.NPUNodeList{}
+	if err := h.Client.List(ctx, npuNodes); err != nil {
+		return Simulation{}, err
+	}
+	pods := &corev1.PodList{}
+	if err := h.Client.List(ctx, pods); err != nil {
+		return Simulation{}, err
+	}
+	return Simulate(policy, npuNodes.Items, pods.Items), nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package whatif
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("What-if simulation", func() {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rngdNode := func(name string, allocatable corev1.ResourceList) *npuv1alpha1.NPUNode {
+		return &npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{npuv1alpha1.ModelLabel: "RNGD"}},
+			Status: npuv1alpha1.NPUNodeStatus{
+				Model:       "RNGD",
+				Allocatable: allocatable,
+				Vendors:     []npuv1alpha1.VendorSummary{{Vendor: "furiosa", Model: "RNGD"}},
+			},
+		}
+	}
+	pod := func(name, node string, age int, requests corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ml",
+				CreationTimestamp: metav1.NewTime(start.Add(time.Duration(age) * time.Minute))},
+			Spec: corev1.PodSpec{NodeName: node, Containers: []corev1.Container{{
+				Name: "main", Resources: corev1.ResourceRequirements{Requests: requests},
+			}}},
+		}
+	}
+	cards := func(n string) corev1.ResourceList {
+		return corev1.ResourceList{"furiosa.ai/npu": resource.MustParse(n)}
+	}
+	values := func(list corev1.ResourceList) map[corev1.ResourceName]int64 {
+		out := map[corev1.ResourceName]int64{}
+		for name, q := range list {
+			out[name] = q.Value()
+		}
+		return out
+	}
+
+	It("should simulate partitioning the cards of a pool", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		policy.Spec.Furiosa.Enabled = true
+		policy.Spec.Furiosa.Partitioning = &npuv1alpha1.FuriosaPartitioning{PEsPerPartition: 4}
+		nodes := []npuv1alpha1.NPUNode{*rngdNode("rngd-a", cards("2")), *rngdNode("rngd-b", cards("1"))}
+		pods := []corev1.Pod{*pod("whole-card", "rngd-a", 0, cards("1"))}
+
+		report := Simulate(policy, nodes, pods)
+		Expect(report.Policy).To(Equal("default/cluster"))
+		Expect(report.Pools).To(HaveLen(1))
+		pool := report.Pools[0]
+		Expect(pool.Model).To(Equal("RNGD"))
+		Expect(pool.Nodes).To(Equal(2))
+		Expect(values(pool.Current)).To(HaveKeyWithValue(corev1.ResourceName("furiosa.ai/npu"), int64(3)))
+		Expect(values(pool.Proposed)).To(Equal(map[corev1.ResourceName]int64{"furiosa.ai/npu-4pe": 6}))
+		Expect(report.Unfit).To(ConsistOf(HaveField("Name", "whole-card")))
+		Expect(report.Notes).To(BeEmpty())
+	})
+
+	It("should keep the accelerators of the oldest pods when a node shrinks", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Furiosa.Enabled = true
+		policy.Spec.Furiosa.Partitioning = &npuv1alpha1.FuriosaPartitioning{PEsPerPartition: 2}
+		node := rngdNode("rngd-a", corev1.ResourceList{"furiosa.ai/npu-1pe": resource.MustParse("8")})
+		twoPEs := corev1.ResourceList{"furiosa.ai/npu-2pe": resource.MustParse("2")}
+		pods := []corev1.Pod{
+			*pod("newest", "rngd-a", 3, twoPEs),
+			*pod("oldest", "rngd-a", 1, twoPEs),
+			*pod("middle", "rngd-a", 2, twoPEs),
+		}
+
+		report := Simulate(policy, []npuv1alpha1.NPUNode{*node}, pods)
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"furiosa.ai/npu-2pe": 4}))
+		Expect(report.Unfit).To(HaveLen(1))
+		Expect(report.Unfit[0].Name).To(Equal("newest"))
+		Expect(report.Unfit[0].Reason).To(Equal("furiosa.ai/npu-2pe: 2 requested, 0 left"))
+
+		By("dropping the accelerators of disabled vendors")
+		policy.Spec.Furiosa.Enabled = false
+		report = Simulate(policy, []npuv1alpha1.NPUNode{*node}, pods)
+		Expect(report.Pools[0].Proposed).To(BeEmpty())
+		Expect(report.Unfit).To(HaveLen(3))
+	})
+
+	It("should keep the capacity of models of unknown PEs", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Furiosa.Enabled = true
+		policy.Spec.Furiosa.Partitioning = &npuv1alpha1.FuriosaPartitioning{PEsPerPartition: 2}
+		node := rngdNode("next-gen", cards("4"))
+		node.Status.Vendors[0].Model = "Next"
+
+		report := Simulate(policy, []npuv1alpha1.NPUNode{*node}, nil)
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"furiosa.ai/npu": 4}))
+		Expect(report.Notes).To(ConsistOf(ContainSubstring(`the PEs of Furiosa model "Next" are unknown`)))
+	})
+
+	It("should simulate sharing the GPUs of a node", func() {
+		policy := &npuv1alpha1.NPUClusterPolicy{}
+		policy.Spec.Nvidia.Enabled = true
+		policy.Spec.Nvidia.DevicePluginConfig = &npuv1alpha1.NvidiaDevicePluginConfig{
+			Sharing: &npuv1alpha1.NvidiaSharing{TimeSlicing: &npuv1alpha1.NvidiaReplicas{
+				Resources: []npuv1alpha1.NvidiaReplicatedResource{{Name: "nvidia.com/gpu", Replicas: 4}},
+			}},
+		}
+		gpus := func(n string) corev1.ResourceList {
+			return corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(n)}
+		}
+		node := npuv1alpha1.NPUNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "h100-a", Labels: map[string]string{npuv1alpha1.ModelLabel: "H100"}},
+			Status: npuv1alpha1.NPUNodeStatus{
+				Allocatable: gpus("2"),
+				Devices:     []npuv1alpha1.DiscoveredDevice{{Vendor: "nvidia"}, {Vendor: "nvidia"}},
+			},
+		}
+
+		report := Simulate(policy, []npuv1alpha1.NPUNode{node}, []corev1.Pod{*pod("training", "h100-a", 0, gpus("2"))})
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"nvidia.com/gpu": 8}))
+		Expect(report.Unfit).To(BeEmpty())
+
+		By("advertising the replicas under their shared name")
+		policy.Spec.Nvidia.DevicePluginConfig.Sharing.TimeSlicing.RenameByDefault = true
+		report = Simulate(policy, []npuv1alpha1.NPUNode{node}, []corev1.Pod{*pod("training", "h100-a", 0, gpus("2"))})
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"nvidia.com/gpu.shared": 8}))
+		Expect(report.Unfit).To(ConsistOf(HaveField("Reason", "nvidia.com/gpu: 2 requested, 0 left")))
+
+		By("keeping the capacity under a raw configuration")
+		policy.Spec.Nvidia.DevicePluginConfig = &npuv1alpha1.NvidiaDevicePluginConfig{Raw: "version: v1"}
+		report = Simulate(policy, []npuv1alpha1.NPUNode{node}, nil)
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"nvidia.com/gpu": 2}))
+		Expect(report.Notes).To(ConsistOf(ContainSubstring("raw device plugin configuration is not simulated")))
+	})
+
+	It("should serve the simulation of the posted policy", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(npuv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rngdNode("rngd-a", cards("2")), pod("whole-card", "rngd-a", 0, cards("2"))).Build()
+		handler := &Handler{Client: c}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/whatif", strings.NewReader(`
+apiVersion: npu.ai/v1alpha1
+kind: NPUClusterPolicy
+metadata:
+  name: cluster
+  namespace: default
+spec:
+  furiosa:
+    enabled: true
+    partitioning:
+      pesPerPartition: 1
+`)))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		report := Simulation{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &report)).To(Succeed())
+		Expect(values(report.Pools[0].Proposed)).To(Equal(map[corev1.ResourceName]int64{"furiosa.ai/npu-1pe": 16}))
+		Expect(report.Unfit).To(ConsistOf(HaveField("Name", "whole-card")))
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/whatif", strings.NewReader("spec: {furiosa: {enable: true}}")))
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whatif", nil))
+		Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("should be grantable to the callers of the secure metrics server", func() {
+		data, err := os.ReadFile(filepath.Join("..", "..", "config", "rbac", "whatif_role.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		role := rbacv1.ClusterRole{}
+		Expect(yaml.Unmarshal(data, &role)).To(Succeed())
+
+		// The authorization filter of the metrics server checks the lowercased HTTP method.
+		verb := strings.ToLower(http.MethodPost)
+		Expect(slices.ContainsFunc(role.Rules, func(rule rbacv1.PolicyRule) bool {
+			return slices.Contains(rule.NonResourceURLs, "/whatif") && slices.Contains(rule.Verbs, verb)
+		})).To(BeTrue(), "no rule grants %s on /whatif", verb)
+	})
+})
@@ -0,0 +1,279 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions provides typed status condition helpers for the npu.ai API
+// objects, so controllers and tests set and evaluate conditions consistently
+// instead of matching on strings.
+package conditions
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is the type of a status condition.
+type ConditionType string
+
+// Condition types set on NPUClusterPolicy.
+const (
+	// Ready is True when every enabled vendor component is deployed and available.
+	Ready ConditionType = "Ready"
+	// Progressing is True while components are being created or rolled out.
+	Progressing ConditionType = "Progressing"
+	// Degraded is True when a component failed and needs attention.
+	Degraded ConditionType = "Degraded"
+	// NvidiaReady reports the state of the NVIDIA components.
+	NvidiaReady ConditionType = "NvidiaReady"
+	// FuriosaReady reports the state of the Furiosa components.
+	FuriosaReady ConditionType = "FuriosaReady"
+	// SafeMode is True while a component is frozen after crash looping.
+	SafeMode ConditionType = "SafeMode"
+	// Disconnected is True while component pods cannot pull images from the registry.
+	Disconnected ConditionType = "Disconnected"
+	// PatchesApplied is False when a patch of spec.patches matched no object or failed to apply.
+	PatchesApplied ConditionType = "PatchesApplied"
+	// Paused is True while spec.paused stops the operator from changing the components.
+	Paused ConditionType = "Paused"
+	// DryRun is True while spec.dryRun publishes the rendered objects instead of applying them.
+	DryRun ConditionType = "DryRun"
+	// Conflicted is True while an older policy manages the same DaemonSets, so this one is not applied.
+	Conflicted ConditionType = "Conflicted"
+	// FieldConflict is True while applied objects have fields owned by another field manager.
+	// The message names each object, its conflict policy, the field paths and their managers.
+	FieldConflict ConditionType = "FieldConflict"
+	// PluginConflict is True while a device plugin is held back because another plugin already
+	// registers the same resource on the same nodes.
+	PluginConflict ConditionType = "PluginConflict"
+	// IntegrationsAvailable is False when the policy requests integrations whose APIs the cluster does not serve.
+	IntegrationsAvailable ConditionType = "IntegrationsAvailable"
+	// MetricsAvailable is False while exporters of nodes cannot be scraped, so the features
+	// depending on their metrics, e.g. thermal responses, lack data for those nodes.
+	MetricsAvailable ConditionType = "MetricsAvailable"
+)
+
+// Condition types set on NPUPolicyParameterSet.
+const (
+	// Rendered is True when the template was rendered into the policy.
+	Rendered ConditionType = "Rendered"
+)
+
+// Condition types set on NPUReservation.
+const (
+	// Satisfiable is True when the pool has enough accelerators not used by other namespaces.
+	Satisfiable ConditionType = "Satisfiable"
+)
+
+// Condition types set on NPUWorkloadProfile.
+const (
+	// Resolved is True when the accelerator requests of the profile map to advertised resources.
+	Resolved ConditionType = "Resolved"
+)
+
+// Condition types set on NPUNode, which also sets Ready and Degraded. Ready is True while
+// every accelerator of the node is allocatable, Degraded while some are not.
+const (
+	// Remediating is True while the operator takes remediation steps on the node.
+	Remediating ConditionType = "Remediating"
+)
+
+// ComponentReady returns the condition type reporting a single component, derived
+// from its name, e.g. nvidia-dcgm-exporter becomes NvidiaDcgmExporterReady.
+func ComponentReady(component string) ConditionType {
+	var b strings.Builder
+	for _, part := range strings.Split(component, "-") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Ready")
+	return ConditionType(b.String())
+}
+
+// Common condition reasons.
+const (
+	ReasonReconciled       = "Reconciled"
+	ReasonReconcileFailed  = "ReconcileFailed"
+	ReasonDisabled         = "Disabled"
+	ReasonRollingOut       = "RollingOut"
+	ReasonCrashLoop        = "CrashLoopAfterChange"
+	ReasonAcknowledged     = "Acknowledged"
+	ReasonImageUnresolved  = "ImageUnresolved"
+	ReasonRenderFailed     = "RenderFailed"
+	ReasonImagePullFailed  = "ImagePullFailed"
+	ReasonHookFailed       = "UpgradeHookFailed"
+	ReasonNoCapacity       = "InsufficientCapacity"
+	ReasonPatchFailed      = "PatchFailed"
+	ReasonFieldConflict    = "FieldConflict"
+	ReasonAPIUnavailable   = "APIUnavailable"
+	ReasonPolicyConflict   = "PolicyConflict"
+	ReasonPaused           = "Paused"
+	ReasonPluginConflict   = "PluginConflict"
+	ReasonDryRun           = "DryRun"
+	ReasonNoPartition      = "NoMatchingPartition"
+	ReasonInsufficientData = "InsufficientData"
+	ReasonParentUnresolved = "ParentUnresolved"
+	ReasonAdmissionRetry   = "AdmissionRetry"
+	ReasonAllocatable      = "Allocatable"
+	ReasonUnallocatable    = "Unallocatable"
+	ReasonNoAccelerators   = "NoAccelerators"
+	ReasonRemediating      = "Remediating"
+)
+
+// Object is an API object that carries metav1.Conditions in its status.
+type Object interface {
+	GetGeneration() int64
+	GetConditions() []metav1.Condition
+	SetConditions([]metav1.Condition)
+}
+
+// Set adds or updates a condition, stamping the object's current generation.
+func Set(obj Object, t ConditionType, status metav1.ConditionStatus, reason, message string) {
+	conditions := obj.GetConditions()
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               string(t),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+	})
+	obj.SetConditions(conditions)
+}
+
+// MarkTrue sets the condition to True.
+func MarkTrue(obj Object, t ConditionType, reason, message string) {
+	Set(obj, t, metav1.ConditionTrue, reason, message)
+}
+
+// MarkFalse sets the condition to False.
+func MarkFalse(obj Object, t ConditionType, reason, message string) {
+	Set(obj, t, metav1.ConditionFalse, reason, message)
+}
+
+// MarkUnknown sets the condition to Unknown.
+func MarkUnknown(obj Object, t ConditionType, reason, message string) {
+	Set(obj, t, metav1.ConditionUnknown, reason, message)
+}
+
+// Remove deletes the condition from the object.
+func Remove(obj Object, t ConditionType) {
+	conditions := obj.GetConditions()
+	meta.RemoveStatusCondition(&conditions, string(t))
+	obj.SetConditions(conditions)
+}
+
+// Get returns the condition of the given type, or nil.
+func Get(obj Object, t ConditionType) *metav1.Condition {
+	return meta.FindStatusCondition(obj.GetConditions(), string(t))
+}
+
+// IsTrue reports whether the condition is present and True.
+func IsTrue(obj Object, t ConditionType) bool {
+	return meta.IsStatusConditionTrue(obj.GetConditions(), string(t))
+}
+
+// IsFalse reports whether the condition is present and False.
+func IsFalse(obj Object, t ConditionType) bool {
+	return meta.IsStatusConditionFalse(obj.GetConditions(), string(t))
+}
+
+// Polarity tells whether True is the healthy state of a condition type.
+type Polarity int
+
+const (
+	// Positive conditions are healthy when True, e.g. Ready.
+	Positive Polarity = iota
+	// Negative conditions are healthy when False or absent, e.g. Degraded.
+	Negative
+)
+
+// Registry holds the condition types that decide whether an object is ready.
+// External controllers may register their own types to take part in readiness.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[ConditionType]Polarity
+}
+
+// NewRegistry returns a registry with the given positive condition types.
+func NewRegistry(positive ...ConditionType) *Registry {
+	r := &Registry{types: map[ConditionType]Polarity{}}
+	for _, t := range positive {
+		r.types[t] = Positive
+	}
+	return r
+}
+
+// Register adds a condition type with its polarity.
+func (r *Registry) Register(t ConditionType, p Polarity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[t] = p
+}
+
+// Types returns the registered condition types in a stable order.
+func (r *Registry) Types() []ConditionType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]ConditionType, 0, len(r.types))
+	for t := range r.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// IsReady reports whether every positive condition is True, no negative condition
+// is True, and all registered conditions were observed at the current generation.
+func (r *Registry) IsReady(obj Object) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for t, p := range r.types {
+		c := Get(obj, t)
+		if p == Negative {
+			if c != nil && c.Status == metav1.ConditionTrue {
+				return false
+			}
+			continue
+		}
+		if c == nil || c.Status != metav1.ConditionTrue || c.ObservedGeneration != obj.GetGeneration() {
+			return false
+		}
+	}
+	return true
+}
+
+// ClusterPolicy is the readiness registry of NPUClusterPolicy.
+var ClusterPolicy = func() *Registry {
+	r := NewRegistry(Ready)
+	r.Register(Degraded, Negative)
+	r.Register(SafeMode, Negative)
+	r.Register(Disconnected, Negative)
+	r.Register(Conflicted, Negative)
+	r.Register(PluginConflict, Negative)
+	return r
+}()
+
+// NPUNode is the readiness registry of NPUNode.
+var NPUNode = func() *Registry {
+	r := NewRegistry(Ready)
+	r.Register(Degraded, Negative)
+	r.Register(Remediating, Negative)
+	return r
+}()
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Conditions", func() {
+	var policy *npuv1alpha1.NPUClusterPolicy
+
+	BeforeEach(func() {
+		policy = &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	})
+
+	It("stamps the observed generation when setting a condition", func() {
+		MarkTrue(policy, Ready, ReasonReconciled, "all components available")
+		c := Get(policy, Ready)
+		Expect(c).NotTo(BeNil())
+		Expect(c.ObservedGeneration).To(Equal(int64(3)))
+		Expect(IsTrue(policy, Ready)).To(BeTrue())
+
+		Remove(policy, Ready)
+		Expect(Get(policy, Ready)).To(BeNil())
+	})
+
+	It("evaluates readiness from the registered condition types", func() {
+		Expect(ClusterPolicy.IsReady(policy)).To(BeFalse())
+
+		MarkTrue(policy, Ready, ReasonReconciled, "")
+		Expect(ClusterPolicy.IsReady(policy)).To(BeTrue())
+
+		MarkTrue(policy, Degraded, ReasonReconcileFailed, "daemonset unavailable")
+		Expect(ClusterPolicy.IsReady(policy)).To(BeFalse())
+		MarkFalse(policy, Degraded, ReasonReconciled, "")
+
+		policy.Generation = 4
+		Expect(ClusterPolicy.IsReady(policy)).To(BeFalse(), "conditions from an older generation are stale")
+	})
+
+	It("evaluates the readiness of NPUNodes", func() {
+		node := &npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+		Expect(NPUNode.Types()).To(Equal([]ConditionType{Degraded, Ready, Remediating}))
+		Expect(NPUNode.IsReady(node)).To(BeFalse())
+
+		MarkTrue(node, Ready, ReasonAllocatable, "")
+		Expect(NPUNode.IsReady(node)).To(BeTrue())
+		Expect(node.Status.Conditions).To(HaveLen(1))
+
+		MarkTrue(node, Remediating, string(npuv1alpha1.RemediationRestartPlugin), "")
+		Expect(NPUNode.IsReady(node)).To(BeFalse())
+		MarkFalse(node, Remediating, ReasonAllocatable, "")
+		MarkTrue(node, Degraded, ReasonUnallocatable, "1 of 8 nvidia.com/gpu not allocatable")
+		Expect(NPUNode.IsReady(node)).To(BeFalse())
+	})
+
+	It("lets callers register additional readiness gates", func() {
+		registry := NewRegistry(Ready)
+		registry.Register("ExporterReady", Positive)
+		Expect(registry.Types()).To(Equal([]ConditionType{"ExporterReady", Ready}))
+
+		MarkTrue(policy, Ready, ReasonReconciled, "")
+		Expect(registry.IsReady(policy)).To(BeFalse())
+		MarkTrue(policy, "ExporterReady", ReasonReconciled, "")
+		Expect(registry.IsReady(policy)).To(BeTrue())
+	})
+
+	It("derives component condition types from component names", func() {
+		Expect(ComponentReady("nvidia-dcgm-exporter")).To(Equal(ConditionType("NvidiaDcgmExporterReady")))
+		Expect(ComponentReady("furiosa-device-plugin")).To(Equal(ConditionType("FuriosaDevicePluginReady")))
+	})
+})
@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package npuclient provides typed clients for the npu.ai API objects, so Go tooling reads and
+// writes them without building schemes or handling unstructured objects. It wraps a
+// controller-runtime client: built from a manager, reads are served from its informer cache.
+package npuclient
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+// Client gives typed access to the npu.ai API objects. The embedded client reaches any
+// other object.
+type Client struct {
+	client.Client
+}
+
+// NewScheme returns a scheme with the built-in Kubernetes types and the npu.ai types.
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(npuv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// New returns a client reading from and writing to the API server of the config.
+func New(config *rest.Config) (*Client, error) {
+	c, err := client.New(config, client.Options{Scheme: NewScheme()})
+	if err != nil {
+		return nil, err
+	}
+	return NewForClient(c), nil
+}
+
+// NewForClient wraps a controller-runtime client whose scheme knows the npu.ai types, e.g. the
+// client of a manager.
+func NewForClient(c client.Client) *Client {
+	return &Client{Client: c}
+}
+
+// Object is an npu.ai API object, as a pointer to its type T.
+type Object[T any] interface {
+	*T
+	client.Object
+}
+
+// ObjectList is a list of npu.ai API objects, as a pointer to its type L.
+type ObjectList[L any] interface {
+	*L
+	client.ObjectList
+}
+
+// Resource reads and writes the objects of a kind. Namespaced kinds are scoped to the
+// namespace the resource was obtained for.
+type Resource[T any, PT Object[T], L any, PL ObjectList[L]] struct {
+	client    client.Client
+	namespace string
+}
+
+// Get returns the object of the name.
+func (r Resource[T, PT, L, PL]) Get(ctx context.Context, name string) (PT, error) {
+	obj := PT(new(T))
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: r.namespace, Name: name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// List returns the objects matching the options, e.g. client.MatchingLabels.
+func (r Resource[T, PT, L, PL]) List(ctx context.Context, opts ...client.ListOption) (PL, error) {
+	list := PL(new(L))
+	if r.namespace != "" {
+		opts = append([]client.ListOption{client.InNamespace(r.namespace)}, opts...)
+	}
+	if err := r.client.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Create creates the object, in the namespace of the resource unless it sets one.
+func (r Resource[T, PT, L, PL]) Create(ctx context.Context, obj PT, opts ...client.CreateOption) error {
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(r.namespace)
+	}
+	return r.client.Create(ctx, obj, opts...)
+}
+
+// Update replaces the spec and metadata of the object.
+func (r Resource[T, PT, L, PL]) Update(ctx context.Context, obj PT, opts ...client.UpdateOption) error {
+	return r.client.Update(ctx, obj, opts...)
+}
+
+// UpdateStatus replaces the status of the object.
+func (r Resource[T, PT, L, PL]) UpdateStatus(ctx context.Context, obj PT, opts ...client.SubResourceUpdateOption) error {
+	return r.client.Status().Update(ctx, obj, opts...)
+}
+
+// Patch patches the object, e.g. with client.MergeFrom of a copy taken before changing it.
+func (r Resource[T, PT, L, PL]) Patch(ctx context.Context, obj PT, patch client.Patch, opts ...client.PatchOption) error {
+	return r.client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete deletes the object of the name.
+func (r Resource[T, PT, L, PL]) Delete(ctx context.Context, name string, opts ...client.DeleteOption) error {
+	obj := PT(new(T))
+	obj.SetName(name)
+	obj.SetNamespace(r.namespace)
+	return r.client.Delete(ctx, obj, opts...)
+}
+
+type (
+	NPUClusterPolicies = Resource[npuv1alpha1.NPUClusterPolicy, *npuv1alpha1.NPUClusterPolicy,
+		npuv1alpha1.NPUClusterPolicyList, *npuv1alpha1.NPUClusterPolicyList]
+	NPUClusterPolicyTemplates = Resource[npuv1alpha1.NPUClusterPolicyTemplate, *npuv1alpha1.NPUClusterPolicyTemplate,
+		npuv1alpha1.NPUClusterPolicyTemplateList, *npuv1alpha1.NPUClusterPolicyTemplateList]
+	NPUPolicyParameterSets = Resource[npuv1alpha1.NPUPolicyParameterSet, *npuv1alpha1.NPUPolicyParameterSet,
+		npuv1alpha1.NPUPolicyParameterSetList, *npuv1alpha1.NPUPolicyParameterSetList]
+	NPUComponentCatalogs = Resource[npuv1alpha1.NPUComponentCatalog, *npuv1alpha1.NPUComponentCatalog,
+		npuv1alpha1.NPUComponentCatalogList, *npuv1alpha1.NPUComponentCatalogList]
+	NPUNodes = Resource[npuv1alpha1.NPUNode, *npuv1alpha1.NPUNode,
+		npuv1alpha1.NPUNodeList, *npuv1alpha1.NPUNodeList]
+	NPUQuotaGrants = Resource[npuv1alpha1.NPUQuotaGrant, *npuv1alpha1.NPUQuotaGrant,
+		npuv1alpha1.NPUQuotaGrantList, *npuv1alpha1.NPUQuotaGrantList]
+	NPUReservations = Resource[npuv1alpha1.NPUReservation, *npuv1alpha1.NPUReservation,
+		npuv1alpha1.NPUReservationList, *npuv1alpha1.NPUReservationList]
+	NPUWorkloadProfiles = Resource[npuv1alpha1.NPUWorkloadProfile, *npuv1alpha1.NPUWorkloadProfile,
+		npuv1alpha1.NPUWorkloadProfileList, *npuv1alpha1.NPUWorkloadProfileList]
+)
+
+// NPUClusterPolicies returns the policies of the namespace, or of every namespace for "".
+func (c *Client) NPUClusterPolicies(namespace string) NPUClusterPolicies {
+	return NPUClusterPolicies{client: c.Client, namespace: namespace}
+}
+
+// NPUClusterPolicyTemplates returns the policy templates.
+func (c *Client) NPUClusterPolicyTemplates() NPUClusterPolicyTemplates {
+	return NPUClusterPolicyTemplates{client: c.Client}
+}
+
+// NPUPolicyParameterSets returns the parameter sets of the namespace, or of every namespace for "".
+func (c *Client) NPUPolicyParameterSets(namespace string) NPUPolicyParameterSets {
+	return NPUPolicyParameterSets{client: c.Client, namespace: namespace}
+}
+
+// NPUComponentCatalogs returns the component catalogs.
+func (c *Client) NPUComponentCatalogs() NPUComponentCatalogs {
+	return NPUComponentCatalogs{client: c.Client}
+}
+
+// NPUNodes returns the NPUNodes, named after their nodes.
+func (c *Client) NPUNodes() NPUNodes {
+	return NPUNodes{client: c.Client}
+}
+
+// NPUQuotaGrants returns the quota grants of the namespace, or of every namespace for "".
+func (c *Client) NPUQuotaGrants(namespace string) NPUQuotaGrants {
+	return NPUQuotaGrants{client: c.Client, namespace: namespace}
+}
+
+// NPUReservations returns the reservations.
+func (c *Client) NPUReservations() NPUReservations {
+	return NPUReservations{client: c.Client}
+}
+
+// NPUWorkloadProfiles returns the workload profiles of the namespace, or of every namespace for "".
+func (c *Client) NPUWorkloadProfiles(namespace string) NPUWorkloadProfiles {
+	return NPUWorkloadProfiles{client: c.Client, namespace: namespace}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package npuclient
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	npuv1alpha1 "npu-operator/api/v1alpha1"
+)
+
+var _ = Describe("Client", func() {
+	ctx := context.Background()
+
+	newClient := func(objs ...client.Object) *Client {
+		return NewForClient(fake.NewClientBuilder().WithScheme(NewScheme()).
+			WithObjects(objs...).WithStatusSubresource(objs...).Build())
+	}
+
+	It("should scope namespaced kinds to their namespace", func() {
+		c := newClient(
+			&npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}},
+			&npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-b"}},
+		)
+
+		policy, err := c.NPUClusterPolicies("team-a").Get(ctx, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Namespace).To(Equal("team-a"))
+		_, err = c.NPUClusterPolicies("team-a").Get(ctx, "b")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		policies, err := c.NPUClusterPolicies("team-b").List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies.Items).To(HaveLen(1))
+		policies, err = c.NPUClusterPolicies("").List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies.Items).To(HaveLen(2))
+
+		created := &npuv1alpha1.NPUClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "c"}}
+		Expect(c.NPUClusterPolicies("team-b").Create(ctx, created)).To(Succeed())
+		Expect(created.Namespace).To(Equal("team-b"))
+		Expect(c.NPUClusterPolicies("team-b").Delete(ctx, "c")).To(Succeed())
+		_, err = c.NPUClusterPolicies("team-b").Get(ctx, "c")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should update the spec, status and labels of cluster-scoped kinds", func() {
+		c := newClient(&npuv1alpha1.NPUNode{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1"}})
+		nodes := c.NPUNodes()
+
+		npuNode, err := nodes.Get(ctx, "gpu-1")
+		Expect(err).NotTo(HaveOccurred())
+		npuNode.Spec.DisabledDevices = []string{"3"}
+		Expect(nodes.Update(ctx, npuNode)).To(Succeed())
+		npuNode.Status.Model = "A100"
+		Expect(nodes.UpdateStatus(ctx, npuNode)).To(Succeed())
+		base := npuNode.DeepCopy()
+		npuNode.Labels = map[string]string{npuv1alpha1.ModelLabel: "A100"}
+		Expect(nodes.Patch(ctx, npuNode, client.MergeFrom(base))).To(Succeed())
+
+		list, err := nodes.List(ctx, client.MatchingLabels{npuv1alpha1.ModelLabel: "A100"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Spec.DisabledDevices).To(ConsistOf("3"))
+		Expect(list.Items[0].Status.Model).To(Equal("A100"))
+	})
+})